@@ -4,12 +4,11 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+
+	"github.com/Clyra-AI/wrkr/core/model"
 )
 
 var aiPrefixes = []string{
-	".claude/",
-	".cursor/",
-	".codex/",
 	".agents/skills/",
 	".github/copilot-",
 	".github/workflows/",
@@ -18,15 +17,11 @@ var aiPrefixes = []string{
 }
 
 var aiExact = map[string]struct{}{
-	"AGENTS.md":          {},
-	"AGENTS.override.md": {},
-	"CLAUDE.md":          {},
-	".mcp.json":          {},
-	".vscode/mcp.json":   {},
-	".cursor/mcp.json":   {},
-	"wrkr-policy.yaml":   {},
-	"gait.yaml":          {},
-	"Jenkinsfile":        {},
+	".mcp.json":        {},
+	".vscode/mcp.json": {},
+	"wrkr-policy.yaml": {},
+	"gait.yaml":        {},
+	"Jenkinsfile":      {},
 }
 
 func HasRelevantChanges(paths []string) bool {
@@ -40,7 +35,7 @@ func RelevantPaths(paths []string) []string {
 		if normalized == "" {
 			continue
 		}
-		if _, ok := aiExact[normalized]; ok {
+		if _, ok := aiExact[normalized]; ok || model.IsCodingAssistantLocation(normalized) {
 			out = append(out, normalized)
 			continue
 		}
@@ -1014,7 +1014,7 @@ func controlSurfaceType(toolType, location string, writeCapable bool, secret boo
 		return ControlSurfaceDependencyAgent
 	case tool == "non_human_identity":
 		return ControlSurfaceNonHumanIdentity
-	case tool == "claude" || tool == "cursor" || tool == "codex" || tool == "copilot" || strings.Contains(loc, ".claude") || strings.Contains(loc, ".cursor") || strings.Contains(loc, ".codex") || strings.Contains(loc, "agents.md"):
+	case model.IsCodingAssistantToolType(tool) || model.IsCodingAssistantLocation(loc):
 		return ControlSurfaceCodingAssistant
 	default:
 		return ControlSurfaceAIAgent
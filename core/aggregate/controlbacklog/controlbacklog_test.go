@@ -213,6 +213,25 @@ func TestWritePathClassifiesPRWriteSecretBearingWorkflow(t *testing.T) {
 	}
 }
 
+func TestControlSurfaceTypeMatchesAssistantPathSegments(t *testing.T) {
+	t.Parallel()
+
+	for location, want := range map[string]string{
+		".roo/mcp.json":                 ControlSurfaceCodingAssistant,
+		".roomodes":                     ControlSurfaceCodingAssistant,
+		"packages/app/.kiro/hooks/x.md": ControlSurfaceCodingAssistant,
+		".windsurf/rules/style.md":      ControlSurfaceCodingAssistant,
+		".rootfs/agent.yaml":            ControlSurfaceAIAgent,
+		"docs/.roomba/notes.md":         ControlSurfaceAIAgent,
+		".kirov/config.json":            ControlSurfaceAIAgent,
+		".windsurfing/agent.py":         ControlSurfaceAIAgent,
+	} {
+		if got := controlSurfaceType("agent_custom_source", location, false, false); got != want {
+			t.Fatalf("controlSurfaceType(%q) = %q, want %q", location, got, want)
+		}
+	}
+}
+
 func TestConflictingOwnersLowerConfidenceAndCreateEvidenceGap(t *testing.T) {
 	t.Parallel()
 
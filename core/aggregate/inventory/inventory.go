@@ -1264,6 +1264,7 @@ func classifyAdoptionPattern(repos []string, locations []ToolLocation) string {
 			if strings.Contains(path, ".github/") || strings.Contains(path, ".gitlab/") || strings.HasSuffix(path, ".gitlab-ci.yml") ||
 				strings.HasSuffix(path, ".gitlab-ci.yaml") || strings.Contains(path, ".azure/pipelines/") ||
 				strings.HasSuffix(path, "azure-pipelines.yml") || strings.HasSuffix(path, "azure-pipelines.yaml") ||
+				strings.HasSuffix(path, "bitbucket-pipelines.yml") || strings.HasSuffix(path, "bitbucket-pipelines.yaml") ||
 				strings.HasSuffix(path, "jenkinsfile") {
 				return "team_level"
 			}
@@ -1487,10 +1488,13 @@ func buildRegulatorySummary(tools []Tool) RegulatorySummary {
 
 func classifyToolCategory(toolType string) string {
 	normalized := strings.ToLower(strings.TrimSpace(toolType))
+	if model.IsCodingAssistantToolType(normalized) {
+		return "assistant"
+	}
 	switch normalized {
-	case "claude", "cursor", "codex", "copilot", "cody", "windsurf":
+	case "cody":
 		return "assistant"
-	case "a2a", "agent", "agent_framework", "ci_agent", "compiled_action", "langchain", "crewai", "autogen", "llamaindex", "openai_agents", "mcp_client", "custom_agent":
+	case "a2a", "agent", "agent_framework", "ci_agent", "compiled_action", "langchain", "langgraph", "crewai", "autogen", "llamaindex", "openai_agents", "google_adk", "pydantic_ai", "smolagents", "dspy", "haystack", "strands", "vercel_ai", "mastra", "spring_ai", "semantic_kernel", "mcp_client", "custom_agent":
 		return "agent_framework"
 	case "agnt_agent":
 		return "agent_framework"
@@ -1498,7 +1502,7 @@ func classifyToolCategory(toolType string) string {
 		return "mcp_integration"
 	case "plugin", "extension", "ide_plugin", "browser_extension":
 		return "plugin_extension"
-	case "openai", "anthropic", "google", "gemini", "model_api", "api_key":
+	case "openai", "anthropic", "google", "model_api", "api_key":
 		return "model_api_integration"
 	default:
 		return "custom_wrapper"
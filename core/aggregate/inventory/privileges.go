@@ -126,6 +126,7 @@ type AgentPrivilegeMapEntry struct {
 	EvalConfigRefs              []string                      `json:"eval_config_refs,omitempty" yaml:"eval_config_refs,omitempty"`
 	DryRunRequired              bool                          `json:"dry_run_required,omitempty" yaml:"dry_run_required,omitempty"`
 	SandboxGates                []string                      `json:"sandbox_gates,omitempty" yaml:"sandbox_gates,omitempty"`
+	DenyRules                   []string                      `json:"deny_rules,omitempty" yaml:"deny_rules,omitempty"`
 	TestGates                   []string                      `json:"test_gates,omitempty" yaml:"test_gates,omitempty"`
 	ValidationRequirements      []string                      `json:"validation_requirements,omitempty" yaml:"validation_requirements,omitempty"`
 	ExecutionRelationships      []model.ExecutionRelationship `json:"execution_relationships,omitempty" yaml:"execution_relationships,omitempty"`
@@ -236,7 +237,8 @@ func ClassifyPathContext(location string) *PathContext {
 		strings.Contains(lower, "dockerfile") || strings.Contains(lower, "jenkinsfile") ||
 		strings.HasSuffix(lower, ".gitlab-ci.yml") || strings.HasSuffix(lower, ".gitlab-ci.yaml") ||
 		strings.Contains(lower, "/.gitlab/ci/") || strings.HasSuffix(lower, "azure-pipelines.yml") ||
-		strings.HasSuffix(lower, "azure-pipelines.yaml") || strings.Contains(lower, "/.azure/pipelines/"):
+		strings.HasSuffix(lower, "azure-pipelines.yaml") || strings.Contains(lower, "/.azure/pipelines/") ||
+		strings.HasSuffix(lower, "bitbucket-pipelines.yml") || strings.HasSuffix(lower, "bitbucket-pipelines.yaml"):
 		return &PathContext{Kind: PathContextDeployableSource, Confidence: "high", Reasons: []string{"deployment_or_ci_path"}}
 	case hasRuntimeExtension(ext):
 		return &PathContext{Kind: PathContextRuntimeSource, Confidence: "medium", Reasons: []string{"runtime_source_extension"}}
@@ -171,6 +171,7 @@ func Build(
 				EvalConfigRefs:           evalConfigRefs(signal, primaryLocation(tool)),
 				DryRunRequired:           dryRunRequired(signal),
 				SandboxGates:             sandboxGates(signal),
+				DenyRules:                denyRules(signal),
 				TestGates:                testGates(signal),
 				ValidationRequirements:   validationRequirements(signal),
 				ExecutionRelationships:   model.NormalizeExecutionRelationships(signal.ExecutionRelationships),
@@ -419,6 +420,7 @@ func buildInstanceEntries(
 			EvalConfigRefs:           evalConfigRefs(signals, strings.TrimSpace(agent.Location)),
 			DryRunRequired:           dryRunRequired(signals),
 			SandboxGates:             sandboxGates(signals),
+			DenyRules:                denyRules(signals),
 			TestGates:                testGates(signals),
 			ValidationRequirements:   validationRequirements(signals),
 			ExecutionRelationships:   model.NormalizeExecutionRelationships(signals.ExecutionRelationships),
@@ -951,6 +953,7 @@ func filteredRepoLocationSignals(signal findingSignals) findingSignals {
 		"eval_config_ref":                    {},
 		"dry_run_required":                   {},
 		"sandbox_gate":                       {},
+		"deny_rule":                          {},
 		"test_gate":                          {},
 		"validation_requirement":             {},
 	}
@@ -1975,6 +1978,8 @@ func inferredCredentialScope(signals findingSignals, authSurfaces []string) stri
 			strings.Contains(lower, "azure-pipelines.yml"),
 			strings.Contains(lower, "azure-pipelines.yaml"),
 			strings.Contains(lower, ".azure/pipelines/"),
+			strings.Contains(lower, "bitbucket-pipelines.yml"),
+			strings.Contains(lower, "bitbucket-pipelines.yaml"),
 			lower == "jenkinsfile":
 			return agginventory.CredentialScopeWorkflow
 		case strings.HasPrefix(lower, ".env"):
@@ -2385,13 +2390,10 @@ func deliveryHarnesses(signals findingSignals, toolType string, location string)
 	if len(values) > 0 {
 		return values
 	}
+	if harness := model.CodingAssistantHarness(normalizeToken(toolType)); harness != "" {
+		return []string{harness}
+	}
 	switch normalizeToken(toolType) {
-	case "codex":
-		return []string{"codex_cli"}
-	case "claude":
-		return []string{"claude_code"}
-	case "cursor":
-		return []string{"cursor_rules"}
 	case "ci_agent":
 		return []string{"ci_workflow"}
 	case "compiled_action":
@@ -2433,6 +2435,10 @@ func sandboxGates(signals findingSignals) []string {
 	return splitNormalizedSignalValues(signals.EvidenceKV["sandbox_gate"])
 }
 
+func denyRules(signals findingSignals) []string {
+	return splitNormalizedSignalValues(signals.EvidenceKV["deny_rule"])
+}
+
 func testGates(signals findingSignals) []string {
 	return splitNormalizedSignalValues(signals.EvidenceKV["test_gate"])
 }
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/Clyra-AI/wrkr/core/detect"
+	"github.com/Clyra-AI/wrkr/core/detect/mcp"
 	"github.com/Clyra-AI/wrkr/core/model"
 )
 
@@ -1149,6 +1150,8 @@ func collectCIAgentScopeMetrics(root string) detectorPathMetrics {
 		"Jenkinsfile",
 		"azure-pipelines.yml",
 		"azure-pipelines.yaml",
+		"bitbucket-pipelines.yml",
+		"bitbucket-pipelines.yaml",
 	}
 	for _, rel := range paths {
 		exists, parseErr := detect.FileExistsWithinRoot("scanquality", root, rel)
@@ -1180,16 +1183,9 @@ func collectDependencyScopeMetrics(root, mode string) detectorPathMetrics {
 }
 
 func collectMCPScopeMetrics(root string) detectorPathMetrics {
-	paths := []string{
-		".mcp.json",
-		".cursor/mcp.json",
-		".vscode/mcp.json",
-		"mcp.json",
-		"managed-mcp.json",
-		".claude/settings.json",
-		".claude/settings.local.json",
-		".codex/config.toml",
-		".codex/config.yaml",
+	paths, err := mcp.ConfigPaths(root)
+	if err != nil {
+		return detectorPathMetrics{}
 	}
 	metrics := detectorPathMetrics{}
 	for _, rel := range paths {
@@ -1291,6 +1287,10 @@ func isDependencyManifestPath(rel string) bool {
 	switch {
 	case base == "go.mod", base == "package.json", base == "pyproject.toml", base == "cargo.toml":
 		return true
+	case base == "pom.xml", base == "build.gradle", base == "build.gradle.kts":
+		return true
+	case strings.HasSuffix(base, ".csproj"), base == "directory.packages.props", base == "packages.config":
+		return true
 	case strings.HasPrefix(base, "requirements") && strings.HasSuffix(base, ".txt"):
 		return true
 	default:
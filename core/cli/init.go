@@ -30,6 +30,12 @@ func runInit(args []string, stdout io.Writer, stderr io.Writer) int {
 	githubAPI := fs.String("github-api", "", "github api base url for hosted repo/org scans")
 	scanToken := fs.String("scan-token", "", "read-only token for scan profile")
 	fixToken := fs.String("fix-token", "", "read-write token for fix profile")
+	gitlabAPI := fs.String("gitlab-api", "", "gitlab api base url for hosted gitlab-group/gitlab-project scans")
+	gitlabToken := fs.String("gitlab-token", "", "read-only token for the gitlab scan profile")
+	bitbucketAPI := fs.String("bitbucket-api", "", "bitbucket api base url for hosted bitbucket-workspace/bitbucket-repo scans")
+	bitbucketToken := fs.String("bitbucket-token", "", "read-only token for the bitbucket scan profile")
+	azdoAPI := fs.String("azdo-api", "", "azure devops api base url for hosted azdo scans")
+	azdoToken := fs.String("azdo-token", "", "read-only token for the azure devops scan profile")
 	configPathFlag := fs.String("config", "", "config file path override")
 
 	if code, handled := parseFlags(fs, args, stderr, jsonRequested || *jsonOut); handled {
@@ -70,6 +76,18 @@ func runInit(args []string, stdout io.Writer, stderr io.Writer) int {
 	cfg.Auth.Fix.Token = strings.TrimSpace(*fixToken)
 	cfg.DefaultTarget = config.Target{Mode: mode, Value: strings.TrimSpace(value)}
 	cfg.GitHubAPIBase = strings.TrimSpace(*githubAPI)
+	cfg.GitLabAPIBase = strings.TrimSpace(*gitlabAPI)
+	if token := strings.TrimSpace(*gitlabToken); token != "" {
+		cfg.Auth.GitLab = &config.AuthProfile{Token: token}
+	}
+	cfg.BitbucketAPIBase = strings.TrimSpace(*bitbucketAPI)
+	if token := strings.TrimSpace(*bitbucketToken); token != "" {
+		cfg.Auth.Bitbucket = &config.AuthProfile{Token: token}
+	}
+	cfg.AzureDevOpsAPIBase = strings.TrimSpace(*azdoAPI)
+	if token := strings.TrimSpace(*azdoToken); token != "" {
+		cfg.Auth.AzureDevOps = &config.AuthProfile{Token: token}
+	}
 
 	if err := config.Save(configPath, cfg); err != nil {
 		return emitError(stderr, jsonRequested || *jsonOut, "runtime_failure", err.Error(), exitRuntime)
@@ -77,6 +95,29 @@ func runInit(args []string, stdout io.Writer, stderr io.Writer) int {
 
 	if *jsonOut {
 		hostedConfigured := cfg.GitHubAPIBase != ""
+		authProfiles := map[string]any{
+			"scan": map[string]any{"token_configured": cfg.Auth.Scan.Token != ""},
+			"fix":  map[string]any{"token_configured": cfg.Auth.Fix.Token != ""},
+		}
+		hostedSource := map[string]any{
+			"github_api_base":       cfg.GitHubAPIBase,
+			"github_api_configured": hostedConfigured,
+		}
+		if cfg.Auth.GitLab != nil || cfg.GitLabAPIBase != "" {
+			authProfiles["gitlab"] = map[string]any{"token_configured": cfg.Auth.GitLab != nil && cfg.Auth.GitLab.Token != ""}
+			hostedSource["gitlab_api_base"] = cfg.GitLabAPIBase
+			hostedSource["gitlab_api_configured"] = cfg.GitLabAPIBase != ""
+		}
+		if cfg.Auth.Bitbucket != nil || cfg.BitbucketAPIBase != "" {
+			authProfiles["bitbucket"] = map[string]any{"token_configured": cfg.Auth.Bitbucket != nil && cfg.Auth.Bitbucket.Token != ""}
+			hostedSource["bitbucket_api_base"] = cfg.BitbucketAPIBase
+			hostedSource["bitbucket_api_configured"] = cfg.BitbucketAPIBase != ""
+		}
+		if cfg.Auth.AzureDevOps != nil || cfg.AzureDevOpsAPIBase != "" {
+			authProfiles["azdo"] = map[string]any{"token_configured": cfg.Auth.AzureDevOps != nil && cfg.Auth.AzureDevOps.Token != ""}
+			hostedSource["azdo_api_base"] = cfg.AzureDevOpsAPIBase
+			hostedSource["azdo_api_configured"] = cfg.AzureDevOpsAPIBase != ""
+		}
 		_ = json.NewEncoder(stdout).Encode(map[string]any{
 			"status":      "ok",
 			"config_path": configPath,
@@ -84,15 +125,9 @@ func runInit(args []string, stdout io.Writer, stderr io.Writer) int {
 				"mode":  cfg.DefaultTarget.Mode,
 				"value": cfg.DefaultTarget.Value,
 			},
-			"auth_profiles": map[string]any{
-				"scan": map[string]any{"token_configured": cfg.Auth.Scan.Token != ""},
-				"fix":  map[string]any{"token_configured": cfg.Auth.Fix.Token != ""},
-			},
-			"hosted_source": map[string]any{
-				"github_api_base":       cfg.GitHubAPIBase,
-				"github_api_configured": hostedConfigured,
-			},
-			"next_step": buildInitNextStep(configPath, cfg.DefaultTarget, hostedConfigured),
+			"auth_profiles": authProfiles,
+			"hosted_source": hostedSource,
+			"next_step":     buildInitNextStep(configPath, cfg.DefaultTarget, hostedConfigured),
 		})
 		return exitSuccess
 	}
@@ -140,7 +175,7 @@ func resolveTarget(repo, org, path string) (config.TargetMode, string, error) {
 func buildInitNextStep(configPath string, target config.Target, hostedConfigured bool) string {
 	scanCommand := fmt.Sprintf("wrkr scan --config %s --json", configPath)
 	switch target.Mode {
-	case config.TargetRepo, config.TargetOrg:
+	case config.TargetRepo, config.TargetOrg, config.TargetEnterprise:
 		if hostedConfigured {
 			return scanCommand
 		}
@@ -78,6 +78,8 @@ func runKnownSubcommand(ctx context.Context, name string, args []string, stdout
 		return runManifest(args, stdout, stderr), true
 	case "regress":
 		return runRegress(args, stdout, stderr), true
+	case "state":
+		return runState(ctx, args, stdout, stderr), true
 	case "score":
 		return runScore(args, stdout, stderr), true
 	case "verify":
@@ -180,6 +182,7 @@ func writeRootUsage(out io.Writer, fs *flag.FlagSet) {
 	_, _ = fmt.Fprintln(out, "  manifest   generate identity manifest baselines")
 	_, _ = fmt.Fprintln(out, "  regress    compare current state to a baseline")
 	_, _ = fmt.Fprintln(out, "  score      compute posture score and breakdown")
+	_, _ = fmt.Fprintln(out, "  state      merge sharded scan state snapshots")
 	_, _ = fmt.Fprintln(out, "  verify     verify proof chain integrity")
 	_, _ = fmt.Fprintln(out, "  evidence   build compliance-ready evidence bundles")
 	_, _ = fmt.Fprintln(out, "  fix        plan deterministic remediations (repo writes require --open-pr)")
@@ -42,7 +42,10 @@ import (
 	"github.com/Clyra-AI/wrkr/core/risk"
 	"github.com/Clyra-AI/wrkr/core/score"
 	"github.com/Clyra-AI/wrkr/core/source"
+	sourceazuredevops "github.com/Clyra-AI/wrkr/core/source/azuredevops"
+	sourcebitbucket "github.com/Clyra-AI/wrkr/core/source/bitbucket"
 	sourcegithub "github.com/Clyra-AI/wrkr/core/source/github"
+	sourcegitlab "github.com/Clyra-AI/wrkr/core/source/gitlab"
 	"github.com/Clyra-AI/wrkr/core/source/localsetup"
 	sourceorg "github.com/Clyra-AI/wrkr/core/source/org"
 	"github.com/Clyra-AI/wrkr/core/sourceprivacy"
@@ -51,10 +54,18 @@ import (
 	"github.com/Clyra-AI/wrkr/internal/statelock"
 )
 
+var scanNow = time.Now
+
 func runScanWithContext(parentCtx context.Context, args []string, stdout io.Writer, stderr io.Writer) int {
 	if len(args) > 0 && args[0] == "status" {
 		return runScanStatus(args[1:], stdout, stderr)
 	}
+	return runScanPipeline(parentCtx, args, stdout, stderr, nil)
+}
+
+// runScanPipeline runs a scan. A non-nil merged input replaces acquisition with
+// the recombined shard manifest for `wrkr state merge`.
+func runScanPipeline(parentCtx context.Context, args []string, stdout io.Writer, stderr io.Writer, merged *scanMergeInput) int {
 	stderr = newSynchronizedScanWriter(stderr)
 	if parentCtx == nil {
 		parentCtx = context.Background()
@@ -73,6 +84,8 @@ func runScanWithContext(parentCtx context.Context, args []string, stdout io.Writ
 	jsonStdoutRaw := fs.String("json-stdout", string(jsonStdoutModeAuto), "stdout JSON mode [auto|full]")
 	jsonPath := fs.String("json-path", "", "write final machine-readable output to a file path")
 	resume := fs.Bool("resume", false, "resume a prior interrupted org scan from checkpoint state")
+	incremental := fs.Bool("incremental", false, "reuse retained repos and findings whose default-branch commit is unchanged since the previous org scan")
+	shardRaw := fs.String("shard", "", "scan one deterministic partition <index>/<count> of the listed org repos")
 	explain := fs.Bool("explain", false, "emit rationale details")
 	quiet := fs.Bool("quiet", false, "suppress non-error output")
 	repo := fs.String("repo", "", "scan one repo owner/repo")
@@ -80,6 +93,7 @@ func runScanWithContext(parentCtx context.Context, args []string, stdout io.Writ
 	githubOrgTarget := fs.String("github-org", "", "scan an organization (alias for --org)")
 	mySetup := fs.Bool("my-setup", false, "scan the local machine setup for AI tool posture")
 	pathTarget := fs.String("path", "", "scan local pre-cloned repositories")
+	pathRef := fs.String("ref", "", "scan a git ref, tag, or commit of the --path repository without checking it out")
 	var explicitTargets repeatedStringFlag
 	fs.Var(&explicitTargets, "target", "repeatable scan target <mode>:<value>")
 	timeout := fs.Duration("timeout", 0, "optional scan timeout (0 disables)")
@@ -102,6 +116,13 @@ func runScanWithContext(parentCtx context.Context, args []string, stdout io.Writ
 	profileName := fs.String("profile", "standard", "posture profile [baseline|standard|strict|assessment]")
 	githubBaseURL := fs.String("github-api", "", "github api base url")
 	githubToken := fs.String("github-token", "", "github token override")
+	githubAPIModeRaw := fs.String("github-api-mode", sourcegithub.APIModeREST, "github acquisition api [rest|graphql]")
+	gitlabBaseURL := fs.String("gitlab-api", "", "gitlab api base url (for example https://gitlab.example.com/api/v4)")
+	gitlabToken := fs.String("gitlab-token", "", "gitlab token override")
+	bitbucketBaseURL := fs.String("bitbucket-api", "", "bitbucket api base url (https://api.bitbucket.org/2.0 or https://bitbucket.example.com/rest/api/1.0)")
+	bitbucketToken := fs.String("bitbucket-token", "", "bitbucket token override (bearer token or username:app-password)")
+	azdoBaseURL := fs.String("azdo-api", "", "azure devops api base url (defaults to https://dev.azure.com)")
+	azdoToken := fs.String("azdo-token", "", "azure devops token override (personal access token or entra access token)")
 	allowPublicOnly := fs.Bool("allow-public-only", false, "acknowledge reduced public-only coverage for unauthenticated assessment org scans")
 	reportMD := fs.Bool("report-md", false, "emit deterministic markdown summary artifact after scan")
 	reportMDPath := fs.String("report-md-path", "wrkr-scan-summary.md", "scan summary markdown output path")
@@ -140,6 +161,14 @@ func runScanWithContext(parentCtx context.Context, args []string, stdout io.Writ
 	if deploymentModeErr != nil {
 		return emitError(stderr, jsonRequested || *jsonOut, "invalid_input", deploymentModeErr.Error(), exitInvalidInput)
 	}
+	githubAPIMode, githubAPIModeErr := sourcegithub.ParseAPIMode(*githubAPIModeRaw)
+	if githubAPIModeErr != nil {
+		return emitError(stderr, jsonRequested || *jsonOut, "invalid_input", githubAPIModeErr.Error(), exitInvalidInput)
+	}
+	shard, shardErr := sourceorg.ParseShard(*shardRaw)
+	if shardErr != nil {
+		return emitError(stderr, jsonRequested || *jsonOut, "invalid_input", shardErr.Error(), exitInvalidInput)
+	}
 	allowHostedSourceMaterialization := *allowSourceMaterialization || scanMode == "deep"
 	productionTargetsFile := strings.TrimSpace(*productionTargetsPath)
 	var executionTopology *executiontopology.Topology
@@ -182,17 +211,47 @@ func runScanWithContext(parentCtx context.Context, args []string, stdout io.Writ
 		}
 		return emitError(stderr, jsonRequested || *jsonOut, "invalid_input", err.Error(), exitInvalidInput)
 	}
-	if *resume && !allTargetsAreOrg(targets) {
-		return emitError(stderr, jsonRequested || *jsonOut, "invalid_input", "--resume is only supported when every requested target is an org target", exitInvalidInput)
+	if strings.TrimSpace(*pathRef) != "" && (len(targets) != 1 || targets[0].Mode != config.TargetPath) {
+		return emitError(stderr, jsonRequested || *jsonOut, "invalid_input", "--ref requires exactly one --path repository target", exitInvalidInput)
+	}
+	if *resume && !allTargetsSupportResume(targets) {
+		return emitError(stderr, jsonRequested || *jsonOut, "invalid_input", "--resume is only supported when every requested target is an org, enterprise, gitlab-group, bitbucket-workspace, or azdo target", exitInvalidInput)
+	}
+	if shard.Enabled() && !allTargetsSupportResume(targets) {
+		return emitError(stderr, jsonRequested || *jsonOut, "invalid_input", "--shard is only supported when every requested target is an org, enterprise, gitlab-group, bitbucket-workspace, or azdo target", exitInvalidInput)
+	}
+	if *incremental {
+		if !allTargetsSupportIncremental(targets) {
+			return emitError(stderr, jsonRequested || *jsonOut, "invalid_input", "--incremental is only supported when every requested target is a GitHub org or enterprise target", exitInvalidInput)
+		}
+		if *resume {
+			return emitError(stderr, jsonRequested || *jsonOut, "invalid_input", "--incremental cannot be combined with --resume", exitInvalidInput)
+		}
+		if sourceRetentionMode != sourceprivacy.RetentionRetain {
+			return emitError(stderr, jsonRequested || *jsonOut, "invalid_input", "--incremental requires --source-retention retain so unchanged repos can be reused", exitInvalidInput)
+		}
 	}
 	if hasLoadedCfg {
 		cfg.Auth = loadedCfg.Auth
 		cfg.GitHubAPIBase = loadedCfg.GitHubAPIBase
+		cfg.GitLabAPIBase = loadedCfg.GitLabAPIBase
+		cfg.BitbucketAPIBase = loadedCfg.BitbucketAPIBase
+		cfg.AzureDevOpsAPIBase = loadedCfg.AzureDevOpsAPIBase
 	}
 	*githubBaseURL = resolveScanGitHubAPIBase(*githubBaseURL, cfg)
 	*githubToken = resolveScanGitHubToken(*githubToken, cfg)
+	*gitlabBaseURL = resolveScanGitLabAPIBase(*gitlabBaseURL, cfg)
+	*gitlabToken = resolveScanGitLabToken(*gitlabToken, cfg)
+	*bitbucketBaseURL = resolveScanBitbucketAPIBase(*bitbucketBaseURL, cfg)
+	*bitbucketToken = resolveScanBitbucketToken(*bitbucketToken, cfg)
+	*azdoBaseURL = resolveScanAzureDevOpsAPIBase(*azdoBaseURL, cfg)
+	*azdoToken = resolveScanAzureDevOpsToken(*azdoToken, cfg)
+	authenticated := strings.TrimSpace(*githubToken) != ""
+	if merged != nil {
+		authenticated = merged.Authenticated
+	}
 	assessmentOrgScan := strings.EqualFold(strings.TrimSpace(*profileName), "assessment") && anyTargetIsOrg(targets)
-	publicOnlyCoverage := assessmentOrgScan && strings.TrimSpace(*githubToken) == ""
+	publicOnlyCoverage := assessmentOrgScan && !authenticated
 	if publicOnlyCoverage && !*allowPublicOnly {
 		return emitError(
 			stderr,
@@ -216,7 +275,7 @@ func runScanWithContext(parentCtx context.Context, args []string, stdout io.Writ
 			stderr,
 			jsonRequested || *jsonOut,
 			"dependency_missing",
-			"--repo and --org scans require --github-api, config github_api_base, or WRKR_GITHUB_API_BASE",
+			"--repo, --org, and enterprise scans require --github-api, config github_api_base, or WRKR_GITHUB_API_BASE",
 			exitDependencyMissing,
 		)
 	}
@@ -225,6 +284,39 @@ func runScanWithContext(parentCtx context.Context, args []string, stdout io.Writ
 			return emitError(stderr, jsonRequested || *jsonOut, "invalid_input", err.Error(), exitInvalidInput)
 		}
 	}
+	if anyTargetNeedsGitLab(targets) {
+		if strings.TrimSpace(*gitlabBaseURL) == "" {
+			return emitError(
+				stderr,
+				jsonRequested || *jsonOut,
+				"dependency_missing",
+				"gitlab-group and gitlab-project scans require --gitlab-api, config gitlab_api_base, or WRKR_GITLAB_API_BASE",
+				exitDependencyMissing,
+			)
+		}
+		if _, err := githubendpoint.Parse(*gitlabBaseURL, githubEndpointOptions()); err != nil {
+			return emitError(stderr, jsonRequested || *jsonOut, "invalid_input", err.Error(), exitInvalidInput)
+		}
+	}
+	if anyTargetNeedsBitbucket(targets) {
+		if strings.TrimSpace(*bitbucketBaseURL) == "" {
+			return emitError(
+				stderr,
+				jsonRequested || *jsonOut,
+				"dependency_missing",
+				"bitbucket-workspace and bitbucket-repo scans require --bitbucket-api, config bitbucket_api_base, or WRKR_BITBUCKET_API_BASE",
+				exitDependencyMissing,
+			)
+		}
+		if _, err := githubendpoint.Parse(*bitbucketBaseURL, githubEndpointOptions()); err != nil {
+			return emitError(stderr, jsonRequested || *jsonOut, "invalid_input", err.Error(), exitInvalidInput)
+		}
+	}
+	if anyTargetNeedsAzureDevOps(targets) {
+		if _, err := githubendpoint.Parse(*azdoBaseURL, githubEndpointOptions()); err != nil {
+			return emitError(stderr, jsonRequested || *jsonOut, "invalid_input", err.Error(), exitInvalidInput)
+		}
+	}
 	artifactPreflight, preflightErr := preflightScanArtifacts(
 		state.ResolvePath(*statePathFlag),
 		*jsonPath,
@@ -261,9 +353,9 @@ func runScanWithContext(parentCtx context.Context, args []string, stdout io.Writ
 		return emitScanRuntimeError(stderr, jsonRequested || *jsonOut, recoveryErr)
 	}
 	materializedRoot := ""
-	if targetsNeedMaterializedRoot(targets) {
+	if merged == nil && (targetsNeedMaterializedRoot(targets) || strings.TrimSpace(*pathRef) != "") {
 		var rootErr error
-		if *resume {
+		if *resume || *incremental {
 			materializedRoot, rootErr = prepareMaterializedRootForResume(statePath)
 		} else {
 			materializedRoot, rootErr = prepareMaterializedRoot(statePath)
@@ -275,7 +367,11 @@ func runScanWithContext(parentCtx context.Context, args []string, stdout io.Writ
 	sourcePrivacy := sourceprivacy.InitialContract(sourceRetentionMode, materializedRoot != "", allowHostedSourceMaterialization, deploymentMode)
 	sourceCleanupFinalized := false
 
-	scanStartedAt := time.Now().UTC().Truncate(time.Second)
+	scanStartedAt := scanNow().UTC().Truncate(time.Second)
+	if merged != nil {
+		sourcePrivacy = merged.SourcePrivacy
+		scanStartedAt = merged.StartedAt
+	}
 	progressTargetMode, progressTargetValue := scanProgressTargetLabel(targets)
 	artifactPaths := map[string]string{
 		"state":     statePath,
@@ -373,21 +469,40 @@ func runScanWithContext(parentCtx context.Context, args []string, stdout io.Writ
 	if err := statusTracker.Phase("source_acquire_start"); err != nil {
 		return emitScanFailure(err)
 	}
-	manifestOut, findings, err := acquireSources(ctx, targets, *githubBaseURL, *githubToken, acquireOptions{
-		StatePath:                  statePath,
-		Progress:                   progress,
-		Resume:                     *resume,
-		MaterializedRoot:           materializedRoot,
-		AllowSourceMaterialization: allowHostedSourceMaterialization,
-	})
-	if err != nil {
-		if source.IsPublicSurfaceInputError(err) {
-			return emitScanError("invalid_input", err.Error(), exitInvalidInput)
-		}
-		if source.IsPublicSurfaceSafetyError(err) {
-			return emitScanError("unsafe_operation_blocked", err.Error(), exitUnsafeBlocked)
+	var manifestOut source.Manifest
+	var findings []source.Finding
+	if merged != nil {
+		manifestOut = merged.Manifest
+		findings = buildSourceFindings(manifestOut.Repos)
+		source.SortFindings(findings)
+	} else {
+		manifestOut, findings, err = acquireSources(ctx, targets, *githubBaseURL, *githubToken, acquireOptions{
+			StatePath:                  statePath,
+			Progress:                   progress,
+			Resume:                     *resume,
+			Incremental:                *incremental,
+			MaterializedRoot:           materializedRoot,
+			AllowSourceMaterialization: allowHostedSourceMaterialization,
+			GitHubResponseCache:        sourceRetentionMode != sourceprivacy.RetentionEphemeral,
+			GitHubAPIMode:              githubAPIMode,
+			Shard:                      shard,
+			PathRef:                    strings.TrimSpace(*pathRef),
+			GitLabBaseURL:              *gitlabBaseURL,
+			GitLabToken:                *gitlabToken,
+			BitbucketBaseURL:           *bitbucketBaseURL,
+			BitbucketToken:             *bitbucketToken,
+			AzureDevOpsBaseURL:         *azdoBaseURL,
+			AzureDevOpsToken:           *azdoToken,
+		})
+		if err != nil {
+			if source.IsPublicSurfaceInputError(err) {
+				return emitScanError("invalid_input", err.Error(), exitInvalidInput)
+			}
+			if source.IsPublicSurfaceSafetyError(err) {
+				return emitScanError("unsafe_operation_blocked", err.Error(), exitUnsafeBlocked)
+			}
+			return emitScanFailure(err)
 		}
-		return emitScanFailure(err)
 	}
 	if err := checkScanContext(); err != nil {
 		return emitScanFailure(err)
@@ -429,10 +544,23 @@ func runScanWithContext(parentCtx context.Context, args []string, stdout io.Writ
 			}
 			catalogScopes = append(catalogScopes, workflowcap.CatalogScope{Repo: scope.Repo, Root: scope.Root, Catalog: catalog})
 		}
-		for root, catalog := range workflowcap.ResolveCatalogs(catalogScopes) {
+		resolvedCatalogs := workflowcap.ResolveCatalogs(catalogScopes)
+		for root, catalog := range resolvedCatalogs {
 			workflowCatalogs[root] = catalog
 		}
-		detected, runErr := registry.Run(ctx, scopes, detect.Options{
+		// Enrichment and execution topology depend on inputs outside the repo
+		// commit, so only plain incremental scans reuse cached detector output.
+		var reusable *incrementalDetections
+		detectScopes := scopes
+		if *incremental && !*enrich && executionTopology == nil {
+			loaded, loadErr := loadIncrementalDetections(materializedRoot, scanMode, allowHostedSourceMaterialization, manifestOut, scopes, resolvedCatalogs)
+			if loadErr != nil {
+				return emitScanFailure(loadErr)
+			}
+			reusable = loaded
+			detectScopes = loaded.pending
+		}
+		detected, runErr := registry.Run(ctx, detectScopes, detect.Options{
 			Enrich:            *enrich,
 			ScanMode:          scanMode,
 			Progress:          detectorProgress,
@@ -445,6 +573,12 @@ func runScanWithContext(parentCtx context.Context, args []string, stdout io.Writ
 		if err := checkScanContext(); err != nil {
 			return emitScanFailure(err)
 		}
+		if reusable != nil {
+			detected, runErr = reusable.save(detected)
+			if runErr != nil {
+				return emitScanFailure(runErr)
+			}
+		}
 		findings = append(findings, detected.Findings...)
 		findings = append(findings, agnt.SynthesizeDrift(findings)...)
 		detectorErrors = append(detectorErrors, detected.DetectorErrors...)
@@ -463,6 +597,42 @@ func runScanWithContext(parentCtx context.Context, args []string, stdout io.Writ
 			return emitScanFailure(err)
 		}
 	}
+	var shardRecord *state.ShardRecord
+	if shard.Enabled() {
+		record, recordErr := buildShardRecord(shardRecordInput{
+			Shard: shard,
+			Config: state.ShardScanConfig{
+				WrkrVersion:                wrkrVersion(),
+				Targets:                    shardTargetValues(targets),
+				Mode:                       scanMode,
+				Profile:                    strings.TrimSpace(*profileName),
+				GitHubAPI:                  strings.TrimSpace(*githubBaseURL),
+				GitHubAPIMode:              githubAPIMode,
+				GitLabAPI:                  strings.TrimSpace(*gitlabBaseURL),
+				BitbucketAPI:               strings.TrimSpace(*bitbucketBaseURL),
+				AzureDevOpsAPI:             strings.TrimSpace(*azdoBaseURL),
+				Authenticated:              authenticated,
+				AllowPublicOnly:            *allowPublicOnly,
+				AllowSourceMaterialization: *allowSourceMaterialization,
+				Enrich:                     *enrich,
+				SourceRetention:            sourceRetentionMode,
+				DeploymentMode:             deploymentMode,
+				ProductionTargetsStrict:    *productionTargetsStrict,
+				PolicyPath:                 strings.TrimSpace(*policyPath),
+				ApprovedToolsPath:          strings.TrimSpace(*approvedToolsPath),
+				ProductionTargetsPath:      productionTargetsFile,
+				ExecutionTopologyPath:      strings.TrimSpace(*executionTopologyPath),
+			},
+			PolicyPath:     strings.TrimSpace(*policyPath),
+			TopologyDigest: executionTopologyDigest(executionTopology),
+			StartedAt:      scanStartedAt,
+			Manifest:       manifestOut,
+		})
+		if recordErr != nil {
+			return emitScanFailure(recordErr)
+		}
+		shardRecord = record
+	}
 	if executionTopology != nil {
 		findings = append(findings, model.Finding{
 			FindingType: "execution_topology",
@@ -495,7 +665,7 @@ func runScanWithContext(parentCtx context.Context, args []string, stdout io.Writ
 		return emitScanError("invalid_input", fmt.Sprintf("--diff requires matching scan modes: previous=%s current=%s", previousSnapshot.ScanMode, scanMode), exitInvalidInput)
 	}
 
-	now := time.Now().UTC().Truncate(time.Second)
+	now := scanNow().UTC().Truncate(time.Second)
 	if err := validateRepoControlDeclarations(manifestOut); err != nil {
 		return emitScanError("policy_schema_violation", err.Error(), exitPolicyViolation)
 	}
@@ -696,7 +866,7 @@ func runScanWithContext(parentCtx context.Context, args []string, stdout io.Writ
 	scanQuality.HostedCoverage = scanquality.BuildHostedCoverage(scanquality.HostedCoverageInput{
 		HostedTarget:       anyTargetNeedsGitHub(targets),
 		OrgTarget:          anyTargetIsOrg(targets),
-		Authenticated:      strings.TrimSpace(*githubToken) != "",
+		Authenticated:      authenticated,
 		PublicOnlyOptIn:    *allowPublicOnly,
 		RequestedRepos:     len(manifestOut.Repos) + len(manifestOut.Failures),
 		CompletedRepos:     len(manifestOut.Repos),
@@ -821,6 +991,7 @@ func runScanWithContext(parentCtx context.Context, args []string, stdout io.Writ
 		SourcePrivacy:              &sourcePrivacy,
 		PublicEvidenceManifestName: manifestOut.PublicEvidenceManifestName,
 		PublicEvidence:             manifestOut.PublicEvidence,
+		Shard:                      shardRecord,
 	}
 	chainPath := artifactPreflight.LifecyclePath
 	proofChainPath := artifactPreflight.ProofChainPath
@@ -1386,6 +1557,12 @@ func emitScanRuntimeError(stderr io.Writer, jsonOut bool, err error) int {
 		return emitError(stderr, jsonOut, "invalid_input", err.Error(), exitInvalidInput)
 	case sourcegithub.IsRateLimitedError(err):
 		return emitError(stderr, jsonOut, "rate_limited", scanRateLimitedMessage(err), exitRuntime)
+	case sourcegitlab.IsRateLimitedError(err):
+		return emitError(stderr, jsonOut, "rate_limited", err.Error()+"; authenticate GitLab scans with --gitlab-token, config auth.gitlab.token, WRKR_GITLAB_TOKEN, or GITLAB_TOKEN; wait for the reported GitLab reset window before retrying", exitRuntime)
+	case sourceazuredevops.IsRateLimitedError(err):
+		return emitError(stderr, jsonOut, "rate_limited", err.Error()+"; authenticate Azure DevOps scans with --azdo-token, config auth.azdo.token, WRKR_AZDO_TOKEN, or AZURE_DEVOPS_EXT_PAT; wait for the reported Retry-After window before retrying", exitRuntime)
+	case sourcebitbucket.IsRateLimitedError(err):
+		return emitError(stderr, jsonOut, "rate_limited", err.Error()+"; authenticate Bitbucket scans with --bitbucket-token, config auth.bitbucket.token, or WRKR_BITBUCKET_TOKEN; wait for the Bitbucket rate-limit window to pass before retrying", exitRuntime)
 	default:
 		return emitError(stderr, jsonOut, "runtime_failure", scanRuntimeErrorMessage(err), exitRuntime)
 	}
@@ -1418,6 +1595,100 @@ func resolveScanGitHubAPIBase(explicit string, cfg config.Config) string {
 	return ""
 }
 
+func resolveScanGitLabToken(explicit string, cfg config.Config) string {
+	configured := ""
+	if cfg.Auth.GitLab != nil {
+		configured = cfg.Auth.GitLab.Token
+	}
+	for _, candidate := range []string{
+		strings.TrimSpace(explicit),
+		strings.TrimSpace(configured),
+		strings.TrimSpace(os.Getenv("WRKR_GITLAB_TOKEN")),
+		strings.TrimSpace(os.Getenv("GITLAB_TOKEN")),
+	} {
+		if candidate != "" {
+			return candidate
+		}
+	}
+	return ""
+}
+
+func resolveScanGitLabAPIBase(explicit string, cfg config.Config) string {
+	for _, candidate := range []string{
+		strings.TrimSpace(explicit),
+		strings.TrimSpace(cfg.GitLabAPIBase),
+		strings.TrimSpace(os.Getenv("WRKR_GITLAB_API_BASE")),
+	} {
+		if candidate != "" {
+			return candidate
+		}
+	}
+	return ""
+}
+
+func resolveScanBitbucketToken(explicit string, cfg config.Config) string {
+	configured := ""
+	if cfg.Auth.Bitbucket != nil {
+		configured = cfg.Auth.Bitbucket.Token
+	}
+	for _, candidate := range []string{
+		strings.TrimSpace(explicit),
+		strings.TrimSpace(configured),
+		strings.TrimSpace(os.Getenv("WRKR_BITBUCKET_TOKEN")),
+	} {
+		if candidate != "" {
+			return candidate
+		}
+	}
+	return ""
+}
+
+func resolveScanBitbucketAPIBase(explicit string, cfg config.Config) string {
+	for _, candidate := range []string{
+		strings.TrimSpace(explicit),
+		strings.TrimSpace(cfg.BitbucketAPIBase),
+		strings.TrimSpace(os.Getenv("WRKR_BITBUCKET_API_BASE")),
+	} {
+		if candidate != "" {
+			return candidate
+		}
+	}
+	return ""
+}
+
+func resolveScanAzureDevOpsToken(explicit string, cfg config.Config) string {
+	configured := ""
+	if cfg.Auth.AzureDevOps != nil {
+		configured = cfg.Auth.AzureDevOps.Token
+	}
+	for _, candidate := range []string{
+		strings.TrimSpace(explicit),
+		strings.TrimSpace(configured),
+		strings.TrimSpace(os.Getenv("WRKR_AZDO_TOKEN")),
+		strings.TrimSpace(os.Getenv("AZURE_DEVOPS_EXT_PAT")),
+	} {
+		if candidate != "" {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// resolveScanAzureDevOpsAPIBase falls back to Azure DevOps Services because,
+// unlike GitLab and Bitbucket, its API root is the same for every tenant.
+func resolveScanAzureDevOpsAPIBase(explicit string, cfg config.Config) string {
+	for _, candidate := range []string{
+		strings.TrimSpace(explicit),
+		strings.TrimSpace(cfg.AzureDevOpsAPIBase),
+		strings.TrimSpace(os.Getenv("WRKR_AZDO_API_BASE")),
+	} {
+		if candidate != "" {
+			return candidate
+		}
+	}
+	return sourceazuredevops.DefaultBaseURL
+}
+
 func loadOptionalScanConfig(configPath string, hasExplicitTarget bool) (config.Config, bool, error) {
 	resolvedPath, err := config.ResolvePath(configPath)
 	if err != nil {
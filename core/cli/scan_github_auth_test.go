@@ -72,6 +72,8 @@ func TestAssessmentOrgScanPublicOnlyOptInRecordsReducedCoverage(t *testing.T) {
 			_, _ = fmt.Fprint(w, `[{"full_name":"acme/backend"}]`)
 		case "/repos/acme/backend":
 			_, _ = fmt.Fprint(w, `{"full_name":"acme/backend","default_branch":"main"}`)
+		case "/repos/acme/backend/git/ref/heads/main":
+			_, _ = fmt.Fprint(w, `{"object":{"sha":"0123456789abcdef0123456789abcdef01234567"}}`)
 		case "/repos/acme/backend/git/trees/main":
 			_, _ = fmt.Fprint(w, `{"tree":[]}`)
 		default:
@@ -301,6 +303,8 @@ func newHostedScanAuthServer(t *testing.T, wantAuth string) *httptest.Server {
 		switch r.URL.Path {
 		case "/repos/acme/backend":
 			_, _ = fmt.Fprint(w, `{"full_name":"acme/backend","default_branch":"main"}`)
+		case "/repos/acme/backend/git/ref/heads/main":
+			_, _ = fmt.Fprint(w, `{"object":{"sha":"0123456789abcdef0123456789abcdef01234567"}}`)
 		case "/repos/acme/backend/git/trees/main":
 			_, _ = fmt.Fprint(w, `{"tree":[]}`)
 		default:
@@ -329,6 +333,8 @@ func newHostedScanBaseServer(t *testing.T) *httptest.Server {
 		switch r.URL.Path {
 		case "/repos/acme/backend":
 			_, _ = fmt.Fprint(w, `{"full_name":"acme/backend","default_branch":"main"}`)
+		case "/repos/acme/backend/git/ref/heads/main":
+			_, _ = fmt.Fprint(w, `{"object":{"sha":"0123456789abcdef0123456789abcdef01234567"}}`)
 		case "/repos/acme/backend/git/trees/main":
 			_, _ = fmt.Fprint(w, `{"tree":[]}`)
 		default:
@@ -22,7 +22,11 @@ import (
 	policyeval "github.com/Clyra-AI/wrkr/core/policy/eval"
 	"github.com/Clyra-AI/wrkr/core/risk"
 	"github.com/Clyra-AI/wrkr/core/source"
+	"github.com/Clyra-AI/wrkr/core/source/archive"
+	"github.com/Clyra-AI/wrkr/core/source/azuredevops"
+	"github.com/Clyra-AI/wrkr/core/source/bitbucket"
 	"github.com/Clyra-AI/wrkr/core/source/github"
+	"github.com/Clyra-AI/wrkr/core/source/gitlab"
 	"github.com/Clyra-AI/wrkr/core/source/local"
 	"github.com/Clyra-AI/wrkr/core/source/localsetup"
 	"github.com/Clyra-AI/wrkr/core/source/org"
@@ -42,9 +46,55 @@ type acquireOptions struct {
 	StatePath                  string
 	Progress                   *scanProgressReporter
 	Resume                     bool
+	Incremental                bool
 	MaterializedRoot           string
 	AllowSourceMaterialization bool
-}
+	PathRef                    string
+	GitLabBaseURL              string
+	GitLabToken                string
+	BitbucketBaseURL           string
+	BitbucketToken             string
+	AzureDevOpsBaseURL         string
+	AzureDevOpsToken           string
+	// GitHubResponseCache keeps GitHub API responses under the state directory
+	// for conditional requests. Responses include source blobs, so it is only
+	// enabled when the operator already retains materialized source.
+	GitHubResponseCache bool
+	GitHubAPIMode       string
+	// Shard restricts checkpointed org-style targets to one partition of
+	// their listed repos.
+	Shard org.Shard
+}
+
+// hostedConnectors carries the per-scan hosted source connectors so request
+// telemetry and retry budgets stay shared across targets on the same host.
+type hostedConnectors struct {
+	github      *github.Connector
+	gitlab      *gitlab.Connector
+	bitbucket   *bitbucket.Connector
+	azuredevops *azuredevops.Connector
+}
+
+// hostedProviderConnector is the acquisition surface shared by the non-GitHub
+// hosted connectors.
+type hostedProviderConnector interface {
+	org.RepoLister
+	org.RepoMaterializer
+	AcquireRepo(ctx context.Context, repo string) (source.RepoManifest, error)
+	SetRetryHandler(fn func(github.RetryEvent))
+}
+
+// Non-GitHub hosted repos, local git ref snapshots, and extracted archives
+// materialize under reserved subdirectories so they never collide with
+// GitHub owner/repo directories; GitHub owners cannot start with an
+// underscore.
+const (
+	gitlabMaterializedDir    = "_gitlab"
+	bitbucketMaterializedDir = "_bitbucket"
+	azdoMaterializedDir      = "_azdo"
+	gitRefMaterializedDir    = "_gitref"
+	archiveMaterializedDir   = "_archive"
+)
 
 type repeatedStringFlag []string
 
@@ -244,6 +294,27 @@ func acquireSources(ctx context.Context, targets []config.Target, githubBaseURL,
 
 	connector := github.NewConnectorWithOptions(githubBaseURL, githubToken, nil, github.ConnectorOptions{AllowInsecureLoopback: githubEndpointOptions().AllowInsecureLoopback})
 	connector.SetAllowSourceMaterialization(opts.AllowSourceMaterialization)
+	connector.SetAPIMode(opts.GitHubAPIMode)
+	if opts.GitHubResponseCache && anyTargetNeedsGitHub(targets) {
+		cache, cacheErr := github.NewResponseCache(opts.StatePath)
+		if cacheErr != nil {
+			return source.Manifest{}, nil, cacheErr
+		}
+		connector.SetResponseCache(cache)
+	}
+	connectors := hostedConnectors{github: connector}
+	if anyTargetNeedsGitLab(targets) {
+		connectors.gitlab = gitlab.NewConnectorWithOptions(opts.GitLabBaseURL, opts.GitLabToken, nil, gitlab.ConnectorOptions{AllowInsecureLoopback: githubEndpointOptions().AllowInsecureLoopback})
+		connectors.gitlab.SetAllowSourceMaterialization(opts.AllowSourceMaterialization)
+	}
+	if anyTargetNeedsBitbucket(targets) {
+		connectors.bitbucket = bitbucket.NewConnectorWithOptions(opts.BitbucketBaseURL, opts.BitbucketToken, nil, bitbucket.ConnectorOptions{AllowInsecureLoopback: githubEndpointOptions().AllowInsecureLoopback})
+		connectors.bitbucket.SetAllowSourceMaterialization(opts.AllowSourceMaterialization)
+	}
+	if anyTargetNeedsAzureDevOps(targets) {
+		connectors.azuredevops = azuredevops.NewConnectorWithOptions(opts.AzureDevOpsBaseURL, opts.AzureDevOpsToken, nil, azuredevops.ConnectorOptions{AllowInsecureLoopback: githubEndpointOptions().AllowInsecureLoopback})
+		connectors.azuredevops.SetAllowSourceMaterialization(opts.AllowSourceMaterialization)
+	}
 	manifestOut := source.Manifest{
 		Target:           manifestTargetFromTargets(targets),
 		Targets:          manifestTargets(targets),
@@ -256,34 +327,27 @@ func acquireSources(ctx context.Context, targets []config.Target, githubBaseURL,
 		}
 	}
 	if opts.Resume {
-		if !allTargetsAreOrg(targets) {
-			return source.Manifest{}, nil, fmt.Errorf("--resume is only supported when every requested target is an org target")
-		}
-		targetSet := make([]string, 0, len(targets))
-		for _, target := range targets {
-			targetSet = append(targetSet, target.Value)
+		if !allTargetsSupportResume(targets) {
+			return source.Manifest{}, nil, fmt.Errorf("--resume is only supported when every requested target is an org, enterprise, gitlab-group, bitbucket-workspace, or azdo target")
 		}
-		if err := org.ValidateTargetSet(opts.StatePath, targetSet, materializeRoot); err != nil {
+		if err := org.ValidateTargetSet(opts.StatePath, resumeTargetSet(targets), materializeRoot); err != nil {
 			return source.Manifest{}, nil, err
 		}
-	} else if allTargetsAreOrg(targets) {
-		targetSet := make([]string, 0, len(targets))
-		for _, target := range targets {
-			targetSet = append(targetSet, target.Value)
-		}
-		if err := org.SaveTargetSet(opts.StatePath, targetSet, materializeRoot); err != nil {
+	} else if allTargetsSupportResume(targets) {
+		if err := org.SaveTargetSet(opts.StatePath, resumeTargetSet(targets), materializeRoot); err != nil {
 			return source.Manifest{}, nil, err
 		}
 	}
 
 	seenRepos := map[string]struct{}{}
+	expandedTargets := []source.Target{}
 	for _, target := range targets {
-		targetManifest, err := acquireTarget(ctx, connector, target, githubBaseURL, githubToken, materializeRoot, opts)
+		targetManifest, err := acquireTarget(ctx, connectors, target, githubBaseURL, githubToken, materializeRoot, opts)
 		if err != nil {
 			if shouldRetryOrgTargetWithoutResume(targets, target, opts.Resume, err) {
 				retryOpts := opts
 				retryOpts.Resume = false
-				targetManifest, err = acquireTarget(ctx, connector, target, githubBaseURL, githubToken, materializeRoot, retryOpts)
+				targetManifest, err = acquireTarget(ctx, connectors, target, githubBaseURL, githubToken, materializeRoot, retryOpts)
 			}
 			if err != nil {
 				if len(targets) == 1 || isTargetAcquisitionFatal(err) {
@@ -297,6 +361,7 @@ func acquireSources(ctx context.Context, targets []config.Target, githubBaseURL,
 			}
 		}
 		manifestOut.Failures = append(manifestOut.Failures, targetManifest.Failures...)
+		expandedTargets = append(expandedTargets, targetManifest.Targets...)
 		if manifestOut.PublicEvidenceManifestName == "" {
 			manifestOut.PublicEvidenceManifestName = strings.TrimSpace(targetManifest.PublicEvidenceManifestName)
 		}
@@ -310,10 +375,11 @@ func acquireSources(ctx context.Context, targets []config.Target, githubBaseURL,
 			manifestOut.Repos = append(manifestOut.Repos, repoManifest)
 		}
 	}
-	if anyTargetNeedsGitHub(targets) {
-		telemetry := connector.AcquisitionTelemetry()
-		manifestOut.Acquisition = &telemetry
+	if len(expandedTargets) > 0 {
+		manifestOut.Target = source.Target{Mode: source.TargetModeMulti}
+		manifestOut.Targets = expandedScanTargets(targets, expandedTargets)
 	}
+	manifestOut.Acquisition = combinedAcquisitionTelemetry(targets, connectors)
 
 	manifestOut = source.SortManifest(manifestOut)
 	findings := buildSourceFindings(manifestOut.Repos)
@@ -321,10 +387,11 @@ func acquireSources(ctx context.Context, targets []config.Target, githubBaseURL,
 	return manifestOut, findings, nil
 }
 
-func acquireTarget(ctx context.Context, connector *github.Connector, target config.Target, githubBaseURL, githubToken, materializeRoot string, opts acquireOptions) (source.Manifest, error) {
+func acquireTarget(ctx context.Context, connectors hostedConnectors, target config.Target, githubBaseURL, githubToken, materializeRoot string, opts acquireOptions) (source.Manifest, error) {
 	if ctxErr := ctx.Err(); ctxErr != nil {
 		return source.Manifest{}, ctxErr
 	}
+	connector := connectors.github
 	manifestOut := source.Manifest{Target: source.Target{Mode: string(target.Mode), Value: target.Value}}
 	switch target.Mode {
 	case config.TargetRepo:
@@ -355,6 +422,84 @@ func acquireTarget(ctx context.Context, connector *github.Connector, target conf
 			MaterializedRoot: materializeRoot,
 			Resume:           opts.Resume,
 			Progress:         opts.Progress,
+			Incremental:      opts.Incremental,
+			Shard:            opts.Shard,
+		})
+		if err != nil {
+			return source.Manifest{}, err
+		}
+		manifestOut.Repos = repos
+		manifestOut.Failures = failures
+	case config.TargetEnterprise:
+		connector.SetRetryHandler(func(event github.RetryEvent) {
+			if opts.Progress == nil {
+				return
+			}
+			opts.Progress.Retry(target.Value, event.Attempt, event.Delay, event.StatusCode)
+		})
+		connector.SetCooldownHandler(func(event github.CooldownEvent) {
+			if opts.Progress == nil {
+				return
+			}
+			opts.Progress.Cooldown(target.Value, event.Delay, event.Until)
+		})
+		results, err := org.AcquireEnterpriseMaterialized(ctx, target.Value, connector, connector, connector, org.AcquireEnterpriseOptions{
+			AcquireMaterializedOptions: org.AcquireMaterializedOptions{
+				StatePath:        opts.StatePath,
+				MaterializedRoot: materializeRoot,
+				Resume:           opts.Resume,
+				Progress:         opts.Progress,
+				Incremental:      opts.Incremental,
+				Shard:            opts.Shard,
+			},
+			IsFatal: isEnterpriseOrgListingFatal,
+		})
+		if err != nil {
+			return source.Manifest{}, err
+		}
+		for _, result := range results {
+			manifestOut.Targets = append(manifestOut.Targets, source.Target{Mode: string(config.TargetOrg), Value: result.Org})
+			manifestOut.Repos = append(manifestOut.Repos, result.Repos...)
+			manifestOut.Failures = append(manifestOut.Failures, result.Failures...)
+			if result.Err != nil {
+				manifestOut.Failures = append(manifestOut.Failures, source.RepoFailure{
+					Repo:   renderScanTarget(config.Target{Mode: config.TargetOrg, Value: result.Org}),
+					Reason: result.Err.Error(),
+				})
+			}
+		}
+	case config.TargetGitLabProject, config.TargetBitbucketRepo:
+		connector, provider, dir, err := hostedProviderForTarget(connectors, target)
+		if err != nil {
+			return source.Manifest{}, err
+		}
+		repoManifest, err := connector.AcquireRepo(ctx, target.Value)
+		if err != nil {
+			return source.Manifest{}, err
+		}
+		materialized, materializeErr := connector.MaterializeRepo(ctx, repoManifest.Repo, filepath.Join(materializeRoot, dir))
+		if materializeErr != nil {
+			return source.Manifest{}, fmt.Errorf("materialize %s repo %s: %w", provider, repoManifest.Repo, materializeErr)
+		}
+		manifestOut.Repos = []source.RepoManifest{materialized}
+	case config.TargetGitLabGroup, config.TargetBitbucketWorkspace, config.TargetAzureDevOps:
+		connector, provider, dir, err := hostedProviderForTarget(connectors, target)
+		if err != nil {
+			return source.Manifest{}, err
+		}
+		connector.SetRetryHandler(func(event github.RetryEvent) {
+			if opts.Progress == nil {
+				return
+			}
+			opts.Progress.Retry(target.Value, event.Attempt, event.Delay, event.StatusCode)
+		})
+		repos, failures, err := org.AcquireMaterialized(ctx, target.Value, connector, connector, org.AcquireMaterializedOptions{
+			StatePath:        opts.StatePath,
+			MaterializedRoot: filepath.Join(materializeRoot, dir),
+			Resume:           opts.Resume,
+			Progress:         opts.Progress,
+			Provider:         provider,
+			Shard:            opts.Shard,
 		})
 		if err != nil {
 			return source.Manifest{}, err
@@ -362,11 +507,32 @@ func acquireTarget(ctx context.Context, connector *github.Connector, target conf
 		manifestOut.Repos = repos
 		manifestOut.Failures = failures
 	case config.TargetPath:
+		if opts.PathRef != "" {
+			repoManifest, err := local.AcquireRef(ctx, target.Value, opts.PathRef, local.RefOptions{
+				MaterializedRoot:           filepath.Join(materializeRoot, gitRefMaterializedDir),
+				AllowSourceMaterialization: opts.AllowSourceMaterialization,
+				Progress:                   opts.Progress,
+			})
+			if err != nil {
+				return source.Manifest{}, err
+			}
+			manifestOut.Repos = []source.RepoManifest{repoManifest}
+			break
+		}
 		repos, err := local.AcquireWithOptions(ctx, target.Value, local.AcquireOptions{Progress: opts.Progress})
 		if err != nil {
 			return source.Manifest{}, err
 		}
 		manifestOut.Repos = repos
+	case config.TargetArchive:
+		repos, err := archive.Acquire(ctx, target.Value, archive.Options{
+			MaterializedRoot:           filepath.Join(materializeRoot, archiveMaterializedDir),
+			AllowSourceMaterialization: opts.AllowSourceMaterialization,
+		})
+		if err != nil {
+			return source.Manifest{}, err
+		}
+		manifestOut.Repos = repos
 	case config.TargetMySetup:
 		repos, err := localsetup.Acquire()
 		if err != nil {
@@ -404,30 +570,86 @@ func manifestTargets(targets []config.Target) []source.Target {
 	return source.SortTargets(out)
 }
 
+// expandedScanTargets replaces enterprise targets with the member orgs they
+// enumerated so the state records one target per scanned org.
+func expandedScanTargets(targets []config.Target, expanded []source.Target) []source.Target {
+	out := make([]source.Target, 0, len(targets)+len(expanded))
+	for _, target := range targets {
+		if target.Mode == config.TargetEnterprise {
+			continue
+		}
+		out = append(out, source.Target{Mode: string(target.Mode), Value: target.Value})
+	}
+	out = append(out, expanded...)
+	sorted := source.SortTargets(out)
+	deduped := make([]source.Target, 0, len(sorted))
+	for _, target := range sorted {
+		if len(deduped) > 0 && deduped[len(deduped)-1] == target {
+			continue
+		}
+		deduped = append(deduped, target)
+	}
+	return deduped
+}
+
 func targetsNeedMaterializedRoot(targets []config.Target) bool {
+	return anyTargetNeedsGitHub(targets) || anyTargetNeedsGitLab(targets) || anyTargetNeedsBitbucket(targets) || anyTargetNeedsAzureDevOps(targets) || anyTargetIsArchive(targets)
+}
+
+// allTargetsSupportResume reports whether every target enumerates repos
+// through org checkpointing.
+func allTargetsSupportResume(targets []config.Target) bool {
+	if len(targets) == 0 {
+		return false
+	}
 	for _, target := range targets {
-		if target.Mode == config.TargetRepo || target.Mode == config.TargetOrg {
-			return true
+		if !isCheckpointedTargetMode(target.Mode) {
+			return false
 		}
 	}
-	return false
+	return true
 }
 
-func allTargetsAreOrg(targets []config.Target) bool {
+// allTargetsSupportIncremental reports whether every target is a GitHub org
+// or enterprise whose repos can be pinned to a default-branch commit.
+func allTargetsSupportIncremental(targets []config.Target) bool {
 	if len(targets) == 0 {
 		return false
 	}
 	for _, target := range targets {
-		if target.Mode != config.TargetOrg {
+		if target.Mode != config.TargetOrg && target.Mode != config.TargetEnterprise {
 			return false
 		}
 	}
 	return true
 }
 
-func anyTargetIsOrg(targets []config.Target) bool {
+func isCheckpointedTargetMode(mode config.TargetMode) bool {
+	switch mode {
+	case config.TargetOrg, config.TargetEnterprise, config.TargetGitLabGroup, config.TargetBitbucketWorkspace, config.TargetAzureDevOps:
+		return true
+	default:
+		return false
+	}
+}
+
+// resumeTargetSet keeps bare org values so existing GitHub target-set
+// checkpoints stay valid, and prefixes other hosts with their target mode.
+func resumeTargetSet(targets []config.Target) []string {
+	targetSet := make([]string, 0, len(targets))
 	for _, target := range targets {
 		if target.Mode == config.TargetOrg {
+			targetSet = append(targetSet, target.Value)
+			continue
+		}
+		targetSet = append(targetSet, renderScanTarget(target))
+	}
+	return targetSet
+}
+
+func anyTargetIsOrg(targets []config.Target) bool {
+	for _, target := range targets {
+		if target.Mode == config.TargetOrg || target.Mode == config.TargetEnterprise {
 			return true
 		}
 	}
@@ -444,7 +666,12 @@ func anyTargetIsPath(targets []config.Target) bool {
 }
 
 func anyTargetUsesProgress(targets []config.Target) bool {
-	return anyTargetIsOrg(targets) || anyTargetIsPath(targets)
+	for _, target := range targets {
+		if isCheckpointedTargetMode(target.Mode) {
+			return true
+		}
+	}
+	return anyTargetIsPath(targets)
 }
 
 func anyTargetIsMySetup(targets []config.Target) bool {
@@ -458,13 +685,103 @@ func anyTargetIsMySetup(targets []config.Target) bool {
 
 func anyTargetNeedsGitHub(targets []config.Target) bool {
 	for _, target := range targets {
-		if target.Mode == config.TargetRepo || target.Mode == config.TargetOrg {
+		if target.Mode == config.TargetRepo || target.Mode == config.TargetOrg || target.Mode == config.TargetEnterprise {
+			return true
+		}
+	}
+	return false
+}
+
+func anyTargetNeedsGitLab(targets []config.Target) bool {
+	for _, target := range targets {
+		if target.Mode == config.TargetGitLabGroup || target.Mode == config.TargetGitLabProject {
+			return true
+		}
+	}
+	return false
+}
+
+func anyTargetNeedsBitbucket(targets []config.Target) bool {
+	for _, target := range targets {
+		if target.Mode == config.TargetBitbucketWorkspace || target.Mode == config.TargetBitbucketRepo {
+			return true
+		}
+	}
+	return false
+}
+
+func anyTargetNeedsAzureDevOps(targets []config.Target) bool {
+	for _, target := range targets {
+		if target.Mode == config.TargetAzureDevOps {
+			return true
+		}
+	}
+	return false
+}
+
+func anyTargetIsArchive(targets []config.Target) bool {
+	for _, target := range targets {
+		if target.Mode == config.TargetArchive {
 			return true
 		}
 	}
 	return false
 }
 
+// hostedProviderForTarget resolves the non-GitHub connector, provider name,
+// and materialized subdirectory for a hosted target.
+func hostedProviderForTarget(connectors hostedConnectors, target config.Target) (hostedProviderConnector, string, string, error) {
+	switch target.Mode {
+	case config.TargetGitLabGroup, config.TargetGitLabProject:
+		if connectors.gitlab != nil {
+			return connectors.gitlab, gitlab.Provider, gitlabMaterializedDir, nil
+		}
+	case config.TargetBitbucketWorkspace, config.TargetBitbucketRepo:
+		if connectors.bitbucket != nil {
+			return connectors.bitbucket, bitbucket.Provider, bitbucketMaterializedDir, nil
+		}
+	case config.TargetAzureDevOps:
+		if connectors.azuredevops != nil {
+			return connectors.azuredevops, azuredevops.Provider, azdoMaterializedDir, nil
+		}
+	}
+	return nil, "", "", fmt.Errorf("hosted connector is required for %s targets", target.Mode)
+}
+
+// combinedAcquisitionTelemetry reports hosted request counters. Mode and
+// rate-limit windows come from the first scanned provider in GitHub, GitLab,
+// Bitbucket, Azure DevOps order because they are not comparable across providers; request
+// counts and warnings add up.
+func combinedAcquisitionTelemetry(targets []config.Target, connectors hostedConnectors) *source.AcquisitionTelemetry {
+	parts := []source.AcquisitionTelemetry{}
+	if anyTargetNeedsGitHub(targets) {
+		parts = append(parts, connectors.github.AcquisitionTelemetry())
+	}
+	if anyTargetNeedsGitLab(targets) && connectors.gitlab != nil {
+		parts = append(parts, connectors.gitlab.AcquisitionTelemetry())
+	}
+	if anyTargetNeedsBitbucket(targets) && connectors.bitbucket != nil {
+		parts = append(parts, connectors.bitbucket.AcquisitionTelemetry())
+	}
+	if anyTargetNeedsAzureDevOps(targets) && connectors.azuredevops != nil {
+		parts = append(parts, connectors.azuredevops.AcquisitionTelemetry())
+	}
+	if len(parts) == 0 {
+		return nil
+	}
+	telemetry := parts[0]
+	for _, part := range parts[1:] {
+		telemetry.Requests += part.Requests
+		telemetry.EstimatedRequests += part.EstimatedRequests
+		telemetry.NotModified += part.NotModified
+		telemetry.CacheHits += part.CacheHits
+		telemetry.GraphQLPoints += part.GraphQLPoints
+		telemetry.EstimatedGraphQLPoints += part.EstimatedGraphQLPoints
+		telemetry.Warnings = append(telemetry.Warnings, part.Warnings...)
+	}
+	return &telemetry
+}
+
 func scanProgressTargetLabel(targets []config.Target) (string, string) {
 	valuesByMode := map[config.TargetMode][]string{}
 	for _, target := range targets {
@@ -477,15 +794,36 @@ func scanProgressTargetLabel(targets []config.Target) (string, string) {
 	if orgs := valuesByMode[config.TargetOrg]; len(orgs) == 1 && len(valuesByMode) == 1 {
 		return "org", orgs[0]
 	}
+	if enterprises := valuesByMode[config.TargetEnterprise]; len(enterprises) == 1 && len(valuesByMode) == 1 {
+		return string(config.TargetEnterprise), enterprises[0]
+	}
 	if paths := valuesByMode[config.TargetPath]; len(paths) == 1 && len(valuesByMode) == 1 {
 		return "path", paths[0]
 	}
+	if archives := valuesByMode[config.TargetArchive]; len(archives) == 1 && len(valuesByMode) == 1 {
+		return string(config.TargetArchive), archives[0]
+	}
 	if manifests := valuesByMode[config.TargetPublicSurface]; len(manifests) == 1 && len(valuesByMode) == 1 {
 		return source.TargetModePublicSurface, manifests[0]
 	}
 	if repos := valuesByMode[config.TargetRepo]; len(repos) == 1 && len(valuesByMode) == 1 {
 		return "repo", repos[0]
 	}
+	if groups := valuesByMode[config.TargetGitLabGroup]; len(groups) == 1 && len(valuesByMode) == 1 {
+		return string(config.TargetGitLabGroup), groups[0]
+	}
+	if projects := valuesByMode[config.TargetGitLabProject]; len(projects) == 1 && len(valuesByMode) == 1 {
+		return string(config.TargetGitLabProject), projects[0]
+	}
+	if workspaces := valuesByMode[config.TargetBitbucketWorkspace]; len(workspaces) == 1 && len(valuesByMode) == 1 {
+		return string(config.TargetBitbucketWorkspace), workspaces[0]
+	}
+	if repos := valuesByMode[config.TargetBitbucketRepo]; len(repos) == 1 && len(valuesByMode) == 1 {
+		return string(config.TargetBitbucketRepo), repos[0]
+	}
+	if scopes := valuesByMode[config.TargetAzureDevOps]; len(scopes) == 1 && len(valuesByMode) == 1 {
+		return string(config.TargetAzureDevOps), scopes[0]
+	}
 	if setups := valuesByMode[config.TargetMySetup]; len(setups) == 1 && len(valuesByMode) == 1 {
 		return "my_setup", setups[0]
 	}
@@ -516,7 +854,13 @@ func renderScanTargetSet(targets []config.Target) string {
 }
 
 func shouldRetryOrgTargetWithoutResume(targets []config.Target, target config.Target, resume bool, err error) bool {
-	return resume && len(targets) > 1 && target.Mode == config.TargetOrg && org.IsCheckpointMissingError(err)
+	return resume && len(targets) > 1 && isCheckpointedTargetMode(target.Mode) && org.IsCheckpointMissingError(err)
+}
+
+// isEnterpriseOrgListingFatal stops an enterprise scan when an org listing
+// failure would repeat for every remaining member org.
+func isEnterpriseOrgListingFatal(err error) bool {
+	return isTargetAcquisitionFatal(err) || github.IsRateLimitedError(err) || github.IsDegradedError(err)
 }
 
 func isTargetAcquisitionFatal(err error) bool {
@@ -534,9 +878,21 @@ func isTargetAcquisitionFatal(err error) bool {
 	}
 }
 
+// hostedSourceProvider returns the hosting provider for API-acquired repos and
+// an empty string for local sources.
+func hostedSourceProvider(sourceName string) string {
+	sourceName = strings.TrimSpace(sourceName)
+	for _, provider := range []string{"github", gitlab.Provider, bitbucket.Provider, azuredevops.Provider} {
+		if strings.HasPrefix(sourceName, provider+"_") {
+			return provider
+		}
+	}
+	return ""
+}
+
 func repoIdentityKey(repo source.RepoManifest) string {
-	if strings.HasPrefix(strings.TrimSpace(repo.Source), "github_") {
-		return "github:" + strings.ToLower(strings.TrimSpace(repo.Repo))
+	if provider := hostedSourceProvider(repo.Source); provider != "" {
+		return provider + ":" + strings.ToLower(strings.TrimSpace(repo.Repo))
 	}
 	location := filepath.Clean(filepath.FromSlash(strings.TrimSpace(repo.Location)))
 	if abs, err := filepath.Abs(location); err == nil {
@@ -553,7 +909,7 @@ func buildSourceFindings(repos []source.RepoManifest) []source.Finding {
 	for _, repoManifest := range repos {
 		orgName := "local"
 		permission := "filesystem.read"
-		if strings.HasPrefix(strings.TrimSpace(repoManifest.Source), "github_") {
+		if hostedSourceProvider(repoManifest.Source) != "" {
 			orgName = repoOwner(repoManifest.Repo)
 			permission = "repo.contents.read"
 		}
@@ -763,7 +1119,7 @@ func detectorScopes(manifestOut source.Manifest) []detect.Scope {
 }
 
 func deriveOrg(repo source.RepoManifest) string {
-	if strings.HasPrefix(strings.TrimSpace(repo.Source), "github_") {
+	if hostedSourceProvider(repo.Source) != "" {
 		return repoOwner(repo.Repo)
 	}
 	return "local"
@@ -0,0 +1,212 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Clyra-AI/wrkr/core/detect"
+	"github.com/Clyra-AI/wrkr/core/detect/workflowcap"
+	"github.com/Clyra-AI/wrkr/core/source"
+	"github.com/Clyra-AI/wrkr/internal/atomicwrite"
+)
+
+// The incremental detection cache lives inside the retained materialized root
+// so cached detector output never outlives the source trees it was computed
+// from; a non-incremental scan resets the root and drops the cache with it.
+const (
+	incrementalDetectionCacheFile    = ".wrkr-incremental-detections.json"
+	incrementalDetectionCacheVersion = "v1"
+)
+
+type incrementalDetectionCache struct {
+	Version                    string                      `json:"version"`
+	WrkrVersion                string                      `json:"wrkr_version"`
+	ScanMode                   string                      `json:"scan_mode"`
+	AllowSourceMaterialization bool                        `json:"allow_source_materialization"`
+	Repos                      []incrementalDetectionEntry `json:"repos"`
+}
+
+// incrementalDetectionEntry is the registry output for one repo scope. It is
+// reused only when the repo commit and its resolved workflow catalog are both
+// unchanged, which keeps incremental output identical to a full scan.
+type incrementalDetectionEntry struct {
+	Org           string           `json:"org"`
+	Repo          string           `json:"repo"`
+	Root          string           `json:"root"`
+	Commit        string           `json:"commit"`
+	CatalogDigest string           `json:"catalog_digest"`
+	Result        detect.RunResult `json:"result"`
+}
+
+type incrementalDetections struct {
+	path    string
+	header  incrementalDetectionCache
+	cached  []detect.RunResult
+	reused  []incrementalDetectionEntry
+	pending []detect.Scope
+	keys    map[string]incrementalDetectionEntry
+}
+
+func incrementalDetectionCachePath(materializedRoot string) string {
+	return filepath.Join(filepath.Clean(strings.TrimSpace(materializedRoot)), incrementalDetectionCacheFile)
+}
+
+// loadIncrementalDetections splits scopes into those whose cached registry
+// output can be reused and those that must be detected again.
+func loadIncrementalDetections(
+	materializedRoot string,
+	scanMode string,
+	allowSourceMaterialization bool,
+	manifestOut source.Manifest,
+	scopes []detect.Scope,
+	catalogs map[string]*workflowcap.Catalog,
+) (*incrementalDetections, error) {
+	out := &incrementalDetections{
+		path: incrementalDetectionCachePath(materializedRoot),
+		header: incrementalDetectionCache{
+			Version:                    incrementalDetectionCacheVersion,
+			WrkrVersion:                wrkrVersion(),
+			ScanMode:                   strings.TrimSpace(scanMode),
+			AllowSourceMaterialization: allowSourceMaterialization,
+		},
+		keys: map[string]incrementalDetectionEntry{},
+	}
+	commits := map[string]string{}
+	for _, repo := range manifestOut.Repos {
+		if commit := strings.TrimSpace(repo.Commit); commit != "" {
+			commits[strings.TrimSpace(repo.ScanRoot)] = commit
+		}
+	}
+	for _, scope := range scopes {
+		commit := commits[strings.TrimSpace(scope.Root)]
+		catalog, ok := catalogs[scope.Root]
+		if commit == "" || !ok {
+			continue
+		}
+		digest, err := catalog.Digest()
+		if err != nil {
+			return nil, err
+		}
+		out.keys[scope.Root] = incrementalDetectionEntry{
+			Org:           strings.TrimSpace(scope.Org),
+			Repo:          strings.TrimSpace(scope.Repo),
+			Root:          scope.Root,
+			Commit:        commit,
+			CatalogDigest: digest,
+		}
+	}
+
+	previous, err := readIncrementalDetectionCache(out.path)
+	if err != nil {
+		return nil, err
+	}
+	byRoot := map[string]incrementalDetectionEntry{}
+	if previous.Version == out.header.Version &&
+		previous.WrkrVersion == out.header.WrkrVersion &&
+		previous.ScanMode == out.header.ScanMode &&
+		previous.AllowSourceMaterialization == out.header.AllowSourceMaterialization {
+		for _, entry := range previous.Repos {
+			byRoot[entry.Root] = entry
+		}
+	}
+	for _, scope := range scopes {
+		key, ok := out.keys[scope.Root]
+		entry, cached := byRoot[scope.Root]
+		if !ok || !cached || entry.Org != key.Org || entry.Repo != key.Repo || entry.Commit != key.Commit || entry.CatalogDigest != key.CatalogDigest {
+			out.pending = append(out.pending, scope)
+			continue
+		}
+		out.cached = append(out.cached, entry.Result)
+		out.reused = append(out.reused, entry)
+	}
+	return out, nil
+}
+
+func readIncrementalDetectionCache(path string) (incrementalDetectionCache, error) {
+	info, err := os.Lstat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return incrementalDetectionCache{}, nil
+		}
+		return incrementalDetectionCache{}, fmt.Errorf("lstat incremental detection cache: %w", err)
+	}
+	if !info.Mode().IsRegular() {
+		return incrementalDetectionCache{}, newMaterializedRootSafetyError("incremental detection cache is not a regular file: %s", path)
+	}
+	payload, err := os.ReadFile(path) // #nosec G304 -- cache path is deterministic under the managed materialized source root.
+	if err != nil {
+		return incrementalDetectionCache{}, fmt.Errorf("read incremental detection cache: %w", err)
+	}
+	var cache incrementalDetectionCache
+	if err := json.Unmarshal(payload, &cache); err != nil {
+		// A corrupt cache only costs a full re-detection.
+		return incrementalDetectionCache{}, nil
+	}
+	return cache, nil
+}
+
+// save records the reused entries plus the fresh registry output for every
+// cacheable pending scope, then returns the merged result for all scopes.
+func (d *incrementalDetections) save(detected detect.RunResult) (detect.RunResult, error) {
+	fresh := splitRunResultByRepo(detected)
+	pendingRepos := map[string]struct{}{}
+	for _, scope := range d.pending {
+		pendingRepos[strings.TrimSpace(scope.Org)+"/"+strings.TrimSpace(scope.Repo)] = struct{}{}
+	}
+	attributable := true
+	for repo := range fresh {
+		if _, ok := pendingRepos[repo]; !ok {
+			attributable = false
+			break
+		}
+	}
+	entries := append([]incrementalDetectionEntry(nil), d.reused...)
+	for _, scope := range d.pending {
+		key, ok := d.keys[scope.Root]
+		if !ok || !attributable {
+			// Output that cannot be attributed to exactly one repo is never
+			// cached, so a later reuse cannot silently drop it.
+			continue
+		}
+		key.Result = fresh[key.Org+"/"+key.Repo]
+		entries = append(entries, key)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Root < entries[j].Root })
+	cache := d.header
+	cache.Repos = entries
+	payload, err := json.Marshal(cache)
+	if err != nil {
+		return detect.RunResult{}, fmt.Errorf("marshal incremental detection cache: %w", err)
+	}
+	if err := atomicwrite.WriteFile(d.path, append(payload, '\n'), 0o600); err != nil {
+		return detect.RunResult{}, fmt.Errorf("write incremental detection cache: %w", err)
+	}
+	return detect.MergeRunResults(append(append([]detect.RunResult(nil), d.cached...), detected)...), nil
+}
+
+func splitRunResultByRepo(result detect.RunResult) map[string]detect.RunResult {
+	out := map[string]detect.RunResult{}
+	for _, finding := range result.Findings {
+		key := strings.TrimSpace(finding.Org) + "/" + strings.TrimSpace(finding.Repo)
+		item := out[key]
+		item.Findings = append(item.Findings, finding)
+		out[key] = item
+	}
+	for _, detectorErr := range result.DetectorErrors {
+		key := detectorErr.Org + "/" + detectorErr.Repo
+		item := out[key]
+		item.DetectorErrors = append(item.DetectorErrors, detectorErr)
+		out[key] = item
+	}
+	for _, coverage := range result.SurfaceCoverage {
+		key := coverage.Org + "/" + coverage.Repo
+		item := out[key]
+		item.SurfaceCoverage = append(item.SurfaceCoverage, coverage)
+		out[key] = item
+	}
+	return out
+}
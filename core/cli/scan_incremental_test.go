@@ -0,0 +1,179 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Clyra-AI/wrkr/core/detect"
+	"github.com/Clyra-AI/wrkr/core/detect/workflowcap"
+	"github.com/Clyra-AI/wrkr/core/model"
+	"github.com/Clyra-AI/wrkr/core/source"
+)
+
+func TestScanIncrementalRejectsUnsupportedInvocations(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string][]string{
+		"path target":      {"--path", t.TempDir(), "--source-retention", "retain"},
+		"with resume":      {"--org", "acme", "--github-api", "https://api.github.com", "--source-retention", "retain", "--resume"},
+		"without retained": {"--org", "acme", "--github-api", "https://api.github.com"},
+	}
+	for name, extra := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var out bytes.Buffer
+			var errOut bytes.Buffer
+			args := append([]string{"scan", "--incremental", "--state", filepath.Join(t.TempDir(), "state.json"), "--json"}, extra...)
+			code := Run(args, &out, &errOut)
+			if code != exitInvalidInput {
+				t.Fatalf("expected exit %d, got %d (%s)", exitInvalidInput, code, errOut.String())
+			}
+			assertErrorEnvelopeCode(t, errOut.Bytes(), "invalid_input", exitInvalidInput)
+		})
+	}
+}
+
+func TestIncrementalDetectionsReuseOnlyUnchangedRepos(t *testing.T) {
+	t.Parallel()
+
+	materializedRoot := t.TempDir()
+	webRoot := filepath.ToSlash(filepath.Join(materializedRoot, "acme", "web"))
+	apiRoot := filepath.ToSlash(filepath.Join(materializedRoot, "acme", "api"))
+	scopes := []detect.Scope{
+		{Org: "acme", Repo: "acme/api", Root: apiRoot, TargetMode: "org"},
+		{Org: "acme", Repo: "acme/web", Root: webRoot, TargetMode: "org"},
+	}
+	catalogs := map[string]*workflowcap.Catalog{}
+	for _, scope := range scopes {
+		catalog, err := workflowcap.BuildCatalog(t.TempDir(), detect.Options{})
+		if err != nil {
+			t.Fatalf("build catalog: %v", err)
+		}
+		catalogs[scope.Root] = catalog
+	}
+	manifestFor := func(apiCommit, webCommit string) source.Manifest {
+		return source.Manifest{Repos: []source.RepoManifest{
+			{Repo: "acme/api", ScanRoot: apiRoot, Source: "github_repo_materialized", Commit: apiCommit},
+			{Repo: "acme/web", ScanRoot: webRoot, Source: "github_repo_materialized", Commit: webCommit},
+		}}
+	}
+	detected := detect.RunResult{Findings: []model.Finding{
+		{FindingType: "tool_config", Severity: model.SeverityLow, ToolType: "codex", Location: "a", Org: "acme", Repo: "acme/api"},
+		{FindingType: "tool_config", Severity: model.SeverityHigh, ToolType: "codex", Location: "b", Org: "acme", Repo: "acme/web"},
+	}}
+
+	first, err := loadIncrementalDetections(materializedRoot, "governance", false, manifestFor("aaa", "bbb"), scopes, catalogs)
+	if err != nil {
+		t.Fatalf("load empty cache: %v", err)
+	}
+	if len(first.pending) != 2 {
+		t.Fatalf("expected every scope to be detected without a cache, got %+v", first.pending)
+	}
+	firstMerged, err := first.save(detected)
+	if err != nil {
+		t.Fatalf("save cache: %v", err)
+	}
+
+	second, err := loadIncrementalDetections(materializedRoot, "governance", false, manifestFor("aaa", "ccc"), scopes, catalogs)
+	if err != nil {
+		t.Fatalf("load cache: %v", err)
+	}
+	if len(second.pending) != 1 || second.pending[0].Repo != "acme/web" {
+		t.Fatalf("expected only the changed repo to be detected again, got %+v", second.pending)
+	}
+	secondMerged, err := second.save(detect.RunResult{Findings: []model.Finding{detected.Findings[1]}})
+	if err != nil {
+		t.Fatalf("save cache again: %v", err)
+	}
+	if !reflect.DeepEqual(firstMerged.Findings, secondMerged.Findings) {
+		t.Fatalf("expected reused findings to match a full detection\nfirst=%+v\nsecond=%+v", firstMerged.Findings, secondMerged.Findings)
+	}
+
+	deep, err := loadIncrementalDetections(materializedRoot, "deep", false, manifestFor("aaa", "ccc"), scopes, catalogs)
+	if err != nil {
+		t.Fatalf("load cache for other mode: %v", err)
+	}
+	if len(deep.pending) != 2 {
+		t.Fatalf("expected a scan mode change to invalidate the cache, got %+v", deep.pending)
+	}
+}
+
+func TestScanIncrementalStateMatchesFullScan(t *testing.T) {
+	// Not parallel: pins the package scan clock so both states share timestamps.
+	previousNow := scanNow
+	scanNow = func() time.Time { return time.Date(2026, time.January, 2, 3, 4, 5, 0, time.UTC) }
+	t.Cleanup(func() { scanNow = previousNow })
+
+	commit := "0123456789abcdef0123456789abcdef01234567"
+	mcpConfig := base64.StdEncoding.EncodeToString([]byte(`{"mcpServers":{"fs":{"command":"npx","args":["-y","@modelcontextprotocol/server-filesystem","."]}}}`))
+	agents := base64.StdEncoding.EncodeToString([]byte("# agents\nRun tests before pushing.\n"))
+	var contentRequests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/orgs/acme/repos":
+			_, _ = fmt.Fprint(w, `[{"full_name":"acme/api"},{"full_name":"acme/web"}]`)
+		case "/repos/acme/api", "/repos/acme/web":
+			_, _ = fmt.Fprintf(w, `{"full_name":%q,"default_branch":"main"}`, r.URL.Path[len("/repos/"):])
+		case "/repos/acme/api/git/ref/heads/main", "/repos/acme/web/git/ref/heads/main":
+			_, _ = fmt.Fprintf(w, `{"object":{"sha":%q}}`, commit)
+		case "/repos/acme/api/git/trees/main", "/repos/acme/web/git/trees/main":
+			t.Fatalf("expected org scans to read trees at the recorded commit, got %s", r.URL.Path)
+		case "/repos/acme/api/git/trees/" + commit, "/repos/acme/web/git/trees/" + commit:
+			contentRequests.Add(1)
+			_, _ = fmt.Fprint(w, `{"tree":[{"path":".mcp.json","type":"blob","sha":"blob-mcp"},{"path":"AGENTS.md","type":"blob","sha":"blob-agents"}]}`)
+		case "/repos/acme/api/git/blobs/blob-mcp", "/repos/acme/web/git/blobs/blob-mcp":
+			contentRequests.Add(1)
+			_, _ = fmt.Fprintf(w, `{"content":%q,"encoding":"base64"}`, mcpConfig)
+		case "/repos/acme/api/git/blobs/blob-agents", "/repos/acme/web/git/blobs/blob-agents":
+			contentRequests.Add(1)
+			_, _ = fmt.Fprintf(w, `{"content":%q,"encoding":"base64"}`, agents)
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	statePath := filepath.Join(t.TempDir(), "state.json")
+	scan := func(extra ...string) []byte {
+		t.Helper()
+		var out, errOut bytes.Buffer
+		args := append([]string{"scan", "--org", "acme", "--github-api", server.URL, "--state", statePath, "--source-retention", "retain", "--json"}, extra...)
+		if code := Run(args, &out, &errOut); code != exitSuccess {
+			t.Fatalf("scan %v failed: %d (%s)", extra, code, errOut.String())
+		}
+		payload, err := os.ReadFile(statePath)
+		if err != nil {
+			t.Fatalf("read state: %v", err)
+		}
+		return payload
+	}
+
+	// The first scan creates the identities both compared scans see as prior state.
+	scan()
+	full := scan()
+	fetched := contentRequests.Load()
+	incremental := scan("--incremental")
+	if contentRequests.Load() != fetched {
+		t.Fatalf("expected unchanged repos to be reused, got %d content requests after %d", contentRequests.Load(), fetched)
+	}
+
+	// Hosted coverage reports the requests each scan actually made, which is
+	// the one value an incremental scan is expected to change.
+	requests := regexp.MustCompile(`"requests":[0-9]+`)
+	full = requests.ReplaceAll(full, []byte(`"requests":0`))
+	incremental = requests.ReplaceAll(incremental, []byte(`"requests":0`))
+	if !bytes.Equal(full, incremental) {
+		t.Fatalf("expected incremental state to be byte-identical to a full scan\nfull=%s\nincremental=%s", full, incremental)
+	}
+}
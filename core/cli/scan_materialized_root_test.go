@@ -254,6 +254,8 @@ func TestScanHostedDefaultRemovesMaterializedRootAndSerializesLogicalLocation(t
 		switch r.URL.Path {
 		case "/repos/acme/backend":
 			_, _ = fmt.Fprint(w, `{"full_name":"acme/backend","default_branch":"main"}`)
+		case "/repos/acme/backend/git/ref/heads/main":
+			_, _ = fmt.Fprint(w, `{"object":{"sha":"0123456789abcdef0123456789abcdef01234567"}}`)
 		case "/repos/acme/backend/git/trees/main":
 			_, _ = fmt.Fprint(w, `{"tree":[{"path":"AGENTS.md","type":"blob","sha":"sha-agents"},{"path":"src/private.py","type":"blob","sha":"sha-source"}]}`)
 		case "/repos/acme/backend/git/blobs/sha-agents":
@@ -339,6 +341,8 @@ func TestHostedShareableArtifactsDoNotSerializeMaterializedSource(t *testing.T)
 		switch r.URL.Path {
 		case "/repos/acme/backend":
 			_, _ = fmt.Fprint(w, `{"full_name":"acme/backend","default_branch":"main"}`)
+		case "/repos/acme/backend/git/ref/heads/main":
+			_, _ = fmt.Fprint(w, `{"object":{"sha":"0123456789abcdef0123456789abcdef01234567"}}`)
 		case "/repos/acme/backend/git/trees/main":
 			_, _ = fmt.Fprint(w, `{"tree":[{"path":"AGENTS.md","type":"blob","sha":"sha-agents"},{"path":"src/private.py","type":"blob","sha":"sha-source"}]}`)
 		case "/repos/acme/backend/git/blobs/sha-agents":
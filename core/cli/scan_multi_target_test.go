@@ -27,6 +27,8 @@ func TestScanTargetAcceptsMixedOrgAndPathTargets(t *testing.T) {
 			_, _ = fmt.Fprint(w, `[{"full_name":"acme/api"}]`)
 		case "/repos/acme/api":
 			_, _ = fmt.Fprint(w, `{"full_name":"acme/api","default_branch":"main"}`)
+		case "/repos/acme/api/git/ref/heads/main":
+			_, _ = fmt.Fprint(w, `{"object":{"sha":"0123456789abcdef0123456789abcdef01234567"}}`)
 		case "/repos/acme/api/git/trees/main":
 			_, _ = fmt.Fprint(w, `{"tree":[]}`)
 		default:
@@ -90,6 +92,59 @@ func TestScanTargetAcceptsMixedOrgAndPathTargets(t *testing.T) {
 	}
 }
 
+func TestScanEnterpriseTargetRecordsPerOrgTargetsAndErrors(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/graphql":
+			_, _ = fmt.Fprint(w, `{"data":{"enterprise":{"organizations":{"nodes":[{"login":"acme"},{"login":"locked"}],"pageInfo":{"hasNextPage":false}}}}}`)
+		case "/orgs/acme/repos":
+			_, _ = fmt.Fprint(w, `[{"full_name":"acme/api"}]`)
+		case "/orgs/locked/repos":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = fmt.Fprint(w, `{"message":"Not Found"}`)
+		case "/repos/acme/api":
+			_, _ = fmt.Fprint(w, `{"full_name":"acme/api","default_branch":"main"}`)
+		case "/repos/acme/api/git/ref/heads/main":
+			_, _ = fmt.Fprint(w, `{"object":{"sha":"0123456789abcdef0123456789abcdef01234567"}}`)
+		case "/repos/acme/api/git/trees/main":
+			_, _ = fmt.Fprint(w, `{"tree":[]}`)
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	statePath := filepath.Join(t.TempDir(), "state.json")
+	var out bytes.Buffer
+	var errOut bytes.Buffer
+	code := Run([]string{
+		"scan",
+		"--target", "enterprise:big-co",
+		"--github-api", server.URL,
+		"--state", statePath,
+		"--json",
+	}, &out, &errOut)
+	if code != exitSuccess {
+		t.Fatalf("scan failed: code=%d stderr=%s", code, errOut.String())
+	}
+
+	snapshot, err := state.Load(statePath)
+	if err != nil {
+		t.Fatalf("load saved state: %v", err)
+	}
+	if snapshot.Target.Mode != "multi" {
+		t.Fatalf("expected saved multi target mode, got %+v", snapshot.Target)
+	}
+	if len(snapshot.Targets) != 2 || snapshot.Targets[0].Mode != "org" || snapshot.Targets[0].Value != "acme" || snapshot.Targets[1].Value != "locked" {
+		t.Fatalf("expected per-org targets, got %+v", snapshot.Targets)
+	}
+	if len(snapshot.SourceErrors) != 1 || snapshot.SourceErrors[0].Repo != "org:locked" {
+		t.Fatalf("expected locked org listing error only, got %+v", snapshot.SourceErrors)
+	}
+}
+
 func TestScanRejectsMixedLegacyAndTargetFlags(t *testing.T) {
 	t.Parallel()
 
@@ -143,6 +198,8 @@ func TestScanMultiOrgResumeRejectsTargetSetMismatch(t *testing.T) {
 			_, _ = fmt.Fprint(w, `{"full_name":"beta/b","default_branch":"main"}`)
 		case "/repos/gamma/c":
 			_, _ = fmt.Fprint(w, `{"full_name":"gamma/c","default_branch":"main"}`)
+		case "/repos/acme/a/git/ref/heads/main", "/repos/beta/b/git/ref/heads/main", "/repos/gamma/c/git/ref/heads/main":
+			_, _ = fmt.Fprint(w, `{"object":{"sha":"0123456789abcdef0123456789abcdef01234567"}}`)
 		case "/repos/acme/a/git/trees/main", "/repos/beta/b/git/trees/main", "/repos/gamma/c/git/trees/main":
 			_, _ = fmt.Fprint(w, `{"tree":[]}`)
 		default:
@@ -196,6 +253,8 @@ func TestReportIncludesTargetsForMultiTargetSnapshots(t *testing.T) {
 			_, _ = fmt.Fprint(w, `[{"full_name":"acme/api"}]`)
 		case "/repos/acme/api":
 			_, _ = fmt.Fprint(w, `{"full_name":"acme/api","default_branch":"main"}`)
+		case "/repos/acme/api/git/ref/heads/main":
+			_, _ = fmt.Fprint(w, `{"object":{"sha":"0123456789abcdef0123456789abcdef01234567"}}`)
 		case "/repos/acme/api/git/trees/main":
 			_, _ = fmt.Fprint(w, `{"tree":[]}`)
 		default:
@@ -7,6 +7,7 @@ import (
 	"github.com/Clyra-AI/wrkr/core/aggregate/controlbacklog"
 	agginventory "github.com/Clyra-AI/wrkr/core/aggregate/inventory"
 	"github.com/Clyra-AI/wrkr/core/compliance"
+	"github.com/Clyra-AI/wrkr/core/model"
 	"github.com/Clyra-AI/wrkr/core/outputsignal"
 	profileeval "github.com/Clyra-AI/wrkr/core/policy/profileeval"
 	reportcore "github.com/Clyra-AI/wrkr/core/report"
@@ -333,9 +334,9 @@ func retainArtifactFinding(finding source.Finding) bool {
 	switch {
 	case kind == "unsafe_path", kind == "schema_validation_error":
 		return true
-	case detector == "gaitpolicy", detector == "mcp", detector == "mcpgateway", detector == "webmcp", detector == "codex", detector == "cursor", detector == "claude", detector == "copilot", detector == "ciagent", detector == "a2a", detector == "secret":
+	case detector == "gaitpolicy", detector == "mcp", detector == "mcpgateway", detector == "webmcp", model.IsCodingAssistantDetector(detector), detector == "ciagent", detector == "a2a", detector == "secret":
 		return true
-	case toolType == "gait_policy", toolType == "mcp", toolType == "mcp_gateway", toolType == "webmcp", toolType == "codex", toolType == "cursor", toolType == "claude", toolType == "copilot", toolType == "ci_agent", toolType == "a2a", toolType == "secret":
+	case toolType == "gait_policy", toolType == "mcp", toolType == "mcp_gateway", toolType == "webmcp", model.IsCodingAssistantToolType(toolType), toolType == "ci_agent", toolType == "a2a", toolType == "secret":
 		return true
 	default:
 		return false
@@ -424,8 +424,12 @@ func newMaterializationFailureServer(t *testing.T) *httptest.Server {
 			_, _ = fmt.Fprint(w, `{"full_name":"acme/a","default_branch":"main"}`)
 		case "/repos/acme/b":
 			_, _ = fmt.Fprint(w, `{"full_name":"acme/b","default_branch":"main"}`)
+		case "/repos/acme/a/git/ref/heads/main":
+			_, _ = fmt.Fprint(w, `{"object":{"sha":"0123456789abcdef0123456789abcdef01234567"}}`)
 		case "/repos/acme/a/git/trees/main":
 			_, _ = fmt.Fprint(w, `{"tree":[]}`)
+		case "/repos/acme/b/git/ref/heads/main":
+			_, _ = fmt.Fprint(w, `{"object":{"sha":"0123456789abcdef0123456789abcdef01234567"}}`)
 		case "/repos/acme/b/git/trees/main":
 			w.WriteHeader(http.StatusBadGateway)
 			_, _ = fmt.Fprint(w, `{"message":"upstream unavailable"}`)
@@ -893,6 +893,9 @@ func repoMaterializeDoneMessage(repo, status string, completed, total int) strin
 	if status == "failed" {
 		return fmt.Sprintf("materialization failed for %s (%d/%d)", repo, completed, total)
 	}
+	if status == "unchanged" {
+		return fmt.Sprintf("reused unchanged %s (%d/%d)", repo, completed, total)
+	}
 	return fmt.Sprintf("materialized %s (%d/%d)", repo, completed, total)
 }
 
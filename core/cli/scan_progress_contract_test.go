@@ -130,6 +130,8 @@ func TestScanStatusIncludesProgressFieldsDuringRun(t *testing.T) {
 		case "/repos/acme/a":
 			<-releaseRepo
 			_, _ = fmt.Fprint(w, `{"full_name":"acme/a","default_branch":"main"}`)
+		case "/repos/acme/a/git/ref/heads/main":
+			_, _ = fmt.Fprint(w, `{"object":{"sha":"0123456789abcdef0123456789abcdef01234567"}}`)
 		case "/repos/acme/a/git/trees/main":
 			_, _ = fmt.Fprint(w, `{"tree":[]}`)
 		default:
@@ -401,6 +403,8 @@ func TestScanProgressHeartbeatVisibleBeforeLongSourceCompletion(t *testing.T) {
 		case "/repos/acme/a":
 			<-releaseRepo
 			_, _ = fmt.Fprint(w, `{"full_name":"acme/a","default_branch":"main"}`)
+		case "/repos/acme/a/git/ref/heads/main":
+			_, _ = fmt.Fprint(w, `{"object":{"sha":"0123456789abcdef0123456789abcdef01234567"}}`)
 		case "/repos/acme/a/git/trees/main":
 			_, _ = fmt.Fprint(w, `{"tree":[]}`)
 		default:
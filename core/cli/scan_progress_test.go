@@ -34,8 +34,12 @@ func TestScanJSONOrgProgressEmitsToStderrOnly(t *testing.T) {
 				return
 			}
 			_, _ = fmt.Fprint(w, `{"full_name":"acme/b","default_branch":"main"}`)
+		case "/repos/acme/a/git/ref/heads/main":
+			_, _ = fmt.Fprint(w, `{"object":{"sha":"0123456789abcdef0123456789abcdef01234567"}}`)
 		case "/repos/acme/a/git/trees/main":
 			_, _ = fmt.Fprint(w, `{"tree":[]}`)
+		case "/repos/acme/b/git/ref/heads/main":
+			_, _ = fmt.Fprint(w, `{"object":{"sha":"0123456789abcdef0123456789abcdef01234567"}}`)
 		case "/repos/acme/b/git/trees/main":
 			_, _ = fmt.Fprint(w, `{"tree":[]}`)
 		default:
@@ -124,8 +128,12 @@ func TestHostedProgressPendingAfterFailedMaterialization(t *testing.T) {
 			_, _ = fmt.Fprint(w, `{"full_name":"acme/a","default_branch":"main"}`)
 		case "/repos/acme/b":
 			_, _ = fmt.Fprint(w, `{"full_name":"acme/b","default_branch":"main"}`)
+		case "/repos/acme/a/git/ref/heads/main":
+			_, _ = fmt.Fprint(w, `{"object":{"sha":"0123456789abcdef0123456789abcdef01234567"}}`)
 		case "/repos/acme/a/git/trees/main":
 			_, _ = fmt.Fprint(w, `{"tree":[]}`)
+		case "/repos/acme/b/git/ref/heads/main":
+			_, _ = fmt.Fprint(w, `{"object":{"sha":"0123456789abcdef0123456789abcdef01234567"}}`)
 		case "/repos/acme/b/git/trees/main":
 			w.WriteHeader(http.StatusBadGateway)
 			_, _ = fmt.Fprint(w, `{"message":"upstream unavailable"}`)
@@ -181,8 +189,12 @@ func TestScanProgressFooterCountsFailedReposOnce(t *testing.T) {
 			_, _ = fmt.Fprint(w, `{"full_name":"acme/a","default_branch":"main"}`)
 		case "/repos/acme/b":
 			_, _ = fmt.Fprint(w, `{"full_name":"acme/b","default_branch":"main"}`)
+		case "/repos/acme/a/git/ref/heads/main":
+			_, _ = fmt.Fprint(w, `{"object":{"sha":"0123456789abcdef0123456789abcdef01234567"}}`)
 		case "/repos/acme/a/git/trees/main":
 			_, _ = fmt.Fprint(w, `{"tree":[]}`)
+		case "/repos/acme/b/git/ref/heads/main":
+			_, _ = fmt.Fprint(w, `{"object":{"sha":"0123456789abcdef0123456789abcdef01234567"}}`)
 		case "/repos/acme/b/git/trees/main":
 			w.WriteHeader(http.StatusBadGateway)
 			_, _ = fmt.Fprint(w, `{"message":"upstream unavailable"}`)
@@ -253,6 +265,8 @@ func TestScanJSONOrgProgressEmitsRecognized403RateLimitRetries(t *testing.T) {
 				return
 			}
 			_, _ = fmt.Fprint(w, `{"full_name":"acme/a","default_branch":"main"}`)
+		case "/repos/acme/a/git/ref/heads/main":
+			_, _ = fmt.Fprint(w, `{"object":{"sha":"0123456789abcdef0123456789abcdef01234567"}}`)
 		case "/repos/acme/a/git/trees/main":
 			_, _ = fmt.Fprint(w, `{"tree":[]}`)
 		default:
@@ -288,6 +302,8 @@ func TestScanJSONQuietSuppressesProgressLines(t *testing.T) {
 			_, _ = fmt.Fprint(w, `[{"full_name":"acme/a"}]`)
 		case "/repos/acme/a":
 			_, _ = fmt.Fprint(w, `{"full_name":"acme/a","default_branch":"main"}`)
+		case "/repos/acme/a/git/ref/heads/main":
+			_, _ = fmt.Fprint(w, `{"object":{"sha":"0123456789abcdef0123456789abcdef01234567"}}`)
 		case "/repos/acme/a/git/trees/main":
 			_, _ = fmt.Fprint(w, `{"tree":[]}`)
 		default:
@@ -372,6 +388,8 @@ func TestScanJSONOrgProgressIsVisibleBeforeCommandCompletion(t *testing.T) {
 		case "/repos/acme/a":
 			<-releaseRepo
 			_, _ = fmt.Fprint(w, `{"full_name":"acme/a","default_branch":"main"}`)
+		case "/repos/acme/a/git/ref/heads/main":
+			_, _ = fmt.Fprint(w, `{"object":{"sha":"0123456789abcdef0123456789abcdef01234567"}}`)
 		case "/repos/acme/a/git/trees/main":
 			_, _ = fmt.Fprint(w, `{"tree":[]}`)
 		default:
@@ -434,6 +452,8 @@ func TestScanJSONPathAndProgressRemainCompatible(t *testing.T) {
 			_, _ = fmt.Fprint(w, `[{"full_name":"acme/a"}]`)
 		case "/repos/acme/a":
 			_, _ = fmt.Fprint(w, `{"full_name":"acme/a","default_branch":"main"}`)
+		case "/repos/acme/a/git/ref/heads/main":
+			_, _ = fmt.Fprint(w, `{"object":{"sha":"0123456789abcdef0123456789abcdef01234567"}}`)
 		case "/repos/acme/a/git/trees/main":
 			_, _ = fmt.Fprint(w, `{"tree":[]}`)
 		default:
@@ -480,6 +500,8 @@ func TestScanJSONProgressFlushesOnErrorExit(t *testing.T) {
 			_, _ = fmt.Fprint(w, `[{"full_name":"acme/a"}]`)
 		case "/repos/acme/a":
 			_, _ = fmt.Fprint(w, `{"full_name":"acme/a","default_branch":"main"}`)
+		case "/repos/acme/a/git/ref/heads/main":
+			_, _ = fmt.Fprint(w, `{"object":{"sha":"0123456789abcdef0123456789abcdef01234567"}}`)
 		case "/repos/acme/a/git/trees/main":
 			_, _ = fmt.Fprint(w, `{"tree":[]}`)
 		default:
@@ -76,6 +76,8 @@ func TestScanResumeMismatchReturnsInvalidInput(t *testing.T) {
 			_, _ = fmt.Fprint(w, repoList)
 		case "/repos/acme/a":
 			_, _ = fmt.Fprint(w, `{"full_name":"acme/a","default_branch":"main"}`)
+		case "/repos/acme/a/git/ref/heads/main":
+			_, _ = fmt.Fprint(w, `{"object":{"sha":"0123456789abcdef0123456789abcdef01234567"}}`)
 		case "/repos/acme/a/git/trees/main":
 			_, _ = fmt.Fprint(w, `{"tree":[]}`)
 		case "/orgs/acme/repos?page=2&per_page=100":
@@ -112,6 +114,8 @@ func TestScanResumeSuccessEmitsResumeProgress(t *testing.T) {
 			_, _ = fmt.Fprint(w, `[{"full_name":"acme/a"}]`)
 		case "/repos/acme/a":
 			_, _ = fmt.Fprint(w, `{"full_name":"acme/a","default_branch":"main"}`)
+		case "/repos/acme/a/git/ref/heads/main":
+			_, _ = fmt.Fprint(w, `{"object":{"sha":"0123456789abcdef0123456789abcdef01234567"}}`)
 		case "/repos/acme/a/git/trees/main":
 			_, _ = fmt.Fprint(w, `{"tree":[]}`)
 		default:
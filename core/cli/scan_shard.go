@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Clyra-AI/wrkr/core/config"
+	"github.com/Clyra-AI/wrkr/core/source"
+	sourceorg "github.com/Clyra-AI/wrkr/core/source/org"
+	"github.com/Clyra-AI/wrkr/core/sourceprivacy"
+	"github.com/Clyra-AI/wrkr/core/state"
+)
+
+// scanMergeInput carries the recombined shard manifest into the scan
+// pipeline. When set, the pipeline skips acquisition and runs detection,
+// analysis, lifecycle and proof emission over the shards' retained
+// materialized trees, exactly as an unsharded scan of the same repos would.
+type scanMergeInput struct {
+	StartedAt     time.Time
+	Manifest      source.Manifest
+	SourcePrivacy sourceprivacy.Contract
+	Authenticated bool
+}
+
+type shardRecordInput struct {
+	Shard          sourceorg.Shard
+	Config         state.ShardScanConfig
+	PolicyPath     string
+	TopologyDigest string
+	StartedAt      time.Time
+	Manifest       source.Manifest
+}
+
+func buildShardRecord(in shardRecordInput) (*state.ShardRecord, error) {
+	policyDigest, err := shardFileDigest(in.PolicyPath)
+	if err != nil {
+		return nil, err
+	}
+	cfg := in.Config
+	if cfg.ApprovedToolsDigest, err = shardFileDigest(cfg.ApprovedToolsPath); err != nil {
+		return nil, err
+	}
+	if cfg.ProductionTargetsDigest, err = shardFileDigest(cfg.ProductionTargetsPath); err != nil {
+		return nil, err
+	}
+	configDigest, err := shardConfigDigest(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &state.ShardRecord{
+		Index:          in.Shard.Index,
+		Count:          in.Shard.Count,
+		ConfigDigest:   configDigest,
+		PolicyDigest:   policyDigest,
+		TopologyDigest: in.TopologyDigest,
+		Scan:           cfg,
+		StartedAt:      in.StartedAt.UTC().Format(time.RFC3339),
+		Manifest:       in.Manifest,
+	}, nil
+}
+
+func shardTargetValues(targets []config.Target) []string {
+	out := make([]string, 0, len(targets))
+	for _, target := range normalizeScanTargets(targets) {
+		out = append(out, string(target.Mode)+":"+strings.TrimSpace(target.Value))
+	}
+	return out
+}
+
+func shardConfigDigest(cfg state.ShardScanConfig) (string, error) {
+	payload, err := json.Marshal(cfg.Digestable())
+	if err != nil {
+		return "", fmt.Errorf("encode shard scan config: %w", err)
+	}
+	sum := sha256.Sum256(payload)
+	return "sha256:" + hex.EncodeToString(sum[:]), nil
+}
+
+// shardFileDigest hashes an optional scan input file. An empty path yields an
+// empty digest so shards without the input still agree.
+func shardFileDigest(path string) (string, error) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return "", nil
+	}
+	payload, err := os.ReadFile(path) // #nosec G304 -- operator-supplied scan input path.
+	if err != nil {
+		return "", fmt.Errorf("read shard scan input %s: %w", path, err)
+	}
+	sum := sha256.Sum256(payload)
+	return "sha256:" + hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,365 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Clyra-AI/wrkr/core/executiontopology"
+	"github.com/Clyra-AI/wrkr/core/source"
+	"github.com/Clyra-AI/wrkr/core/sourceprivacy"
+	"github.com/Clyra-AI/wrkr/core/state"
+)
+
+func runState(ctx context.Context, args []string, stdout io.Writer, stderr io.Writer) int {
+	if len(args) == 0 {
+		return emitError(stderr, wantsJSONOutput(args), "invalid_input", "state subcommand is required", exitInvalidInput)
+	}
+	if isHelpFlag(args[0]) {
+		_, _ = fmt.Fprintln(stderr, "Usage of wrkr state: state <merge> [flags]")
+		return exitSuccess
+	}
+	switch args[0] {
+	case "merge":
+		return runStateMerge(ctx, args[1:], stdout, stderr)
+	default:
+		return emitError(stderr, wantsJSONOutput(args[1:]), "invalid_input", "unsupported state subcommand", exitInvalidInput)
+	}
+}
+
+// runStateMerge recombines `wrkr scan --shard i/n` snapshots and reruns
+// detection and analysis over the shards' retained materialized trees, so
+// findings, inventory, risk, identities, lifecycle and proof records match an
+// unsharded scan of the same repos.
+func runStateMerge(ctx context.Context, args []string, stdout io.Writer, stderr io.Writer) int {
+	jsonRequested := wantsJSONOutput(args)
+	fs := flag.NewFlagSet("state merge", flag.ContinueOnError)
+	if jsonRequested {
+		fs.SetOutput(io.Discard)
+	} else {
+		fs.SetOutput(stderr)
+	}
+	jsonOut := fs.Bool("json", false, "emit machine-readable output")
+	var inputs repeatedStringFlag
+	fs.Var(&inputs, "input", "repeatable shard state file path or glob")
+	outputPath := fs.String("output", "", "merged state file path")
+	if code, handled := parseFlags(fs, args, stderr, jsonRequested || *jsonOut); handled {
+		return code
+	}
+	jsonMode := jsonRequested || *jsonOut
+	// Unquoted globs are expanded by the shell into positional arguments.
+	patterns := append(append([]string(nil), inputs...), fs.Args()...)
+	if len(patterns) == 0 {
+		return emitError(stderr, jsonMode, "invalid_input", "--input is required", exitInvalidInput)
+	}
+	output := strings.TrimSpace(*outputPath)
+	if output == "" {
+		return emitError(stderr, jsonMode, "invalid_input", "--output is required", exitInvalidInput)
+	}
+	paths, err := expandShardInputs(patterns)
+	if err != nil {
+		return emitError(stderr, jsonMode, "invalid_input", err.Error(), exitInvalidInput)
+	}
+	for _, path := range paths {
+		if filepath.Clean(path) == filepath.Clean(output) {
+			return emitError(stderr, jsonMode, "invalid_input", "--output must not overwrite a shard input", exitInvalidInput)
+		}
+	}
+	snapshots := make([]state.Snapshot, 0, len(paths))
+	for _, path := range paths {
+		if err := preflightManagedArtifactRead(path); err != nil {
+			return emitManagedArtifactReadError(stderr, jsonMode, err)
+		}
+		snapshot, loadErr := state.Load(path)
+		if loadErr != nil {
+			return emitError(stderr, jsonMode, "runtime_failure", loadErr.Error(), exitRuntime)
+		}
+		if snapshot.Shard == nil {
+			return emitError(stderr, jsonMode, "invalid_input", fmt.Sprintf("%s is not a shard snapshot; produce shards with wrkr scan --shard <index>/<count>", path), exitInvalidInput)
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	merged, scanCfg, err := mergeShardSnapshots(snapshots)
+	if err != nil {
+		return emitError(stderr, jsonMode, "invalid_input", err.Error(), exitInvalidInput)
+	}
+	if err := verifyShardScanInputs(*snapshots[0].Shard); err != nil {
+		return emitError(stderr, jsonMode, "invalid_input", err.Error(), exitInvalidInput)
+	}
+	if err := verifyShardSourceTrees(merged.Manifest); err != nil {
+		return emitError(stderr, jsonMode, "invalid_input", err.Error(), exitInvalidInput)
+	}
+
+	scanArgs := append(shardReplayArgs(scanCfg), "--state", output)
+	scanStdout := io.Discard
+	if jsonMode {
+		scanArgs = append(scanArgs, "--json")
+		scanStdout = stdout
+	} else {
+		scanArgs = append(scanArgs, "--quiet")
+	}
+	if code := runScanPipeline(ctx, scanArgs, scanStdout, stderr, merged); code != exitSuccess {
+		return code
+	}
+	if !jsonMode {
+		_, _ = fmt.Fprintf(stdout, "wrkr state merge shards=%d repos=%d output=%s\n", len(snapshots), len(merged.Manifest.Repos), output)
+	}
+	return exitSuccess
+}
+
+func expandShardInputs(patterns []string) ([]string, error) {
+	set := map[string]struct{}{}
+	for _, pattern := range patterns {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --input pattern %q: %w", pattern, err)
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("no shard state files match %s", pattern)
+		}
+		for _, match := range matches {
+			set[filepath.Clean(match)] = struct{}{}
+		}
+	}
+	if len(set) == 0 {
+		return nil, fmt.Errorf("--input is required")
+	}
+	out := make([]string, 0, len(set))
+	for path := range set {
+		out = append(out, path)
+	}
+	sort.Strings(out)
+	return out, nil
+}
+
+// mergeShardSnapshots validates that snapshots form one complete shard set
+// with matching digests and recombines their source manifests.
+func mergeShardSnapshots(snapshots []state.Snapshot) (*scanMergeInput, state.ShardScanConfig, error) {
+	if len(snapshots) == 0 {
+		return nil, state.ShardScanConfig{}, fmt.Errorf("at least one shard snapshot is required")
+	}
+	sorted := append([]state.Snapshot(nil), snapshots...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Shard.Index < sorted[j].Shard.Index })
+	first := sorted[0].Shard
+	if first.Count < 1 {
+		return nil, state.ShardScanConfig{}, fmt.Errorf("shard record has an invalid count %d", first.Count)
+	}
+	if version := wrkrVersion(); first.Scan.WrkrVersion != version {
+		return nil, state.ShardScanConfig{}, fmt.Errorf("shards were produced by wrkr %s; merge them with the same version (running %s)", first.Scan.WrkrVersion, version)
+	}
+	if first.Scan.SourceRetention != sourceprivacy.RetentionRetain {
+		return nil, state.ShardScanConfig{}, fmt.Errorf("shards ran with --source-retention %s; merging reruns detection over the shards' materialized trees, so run shards with --source-retention %s", first.Scan.SourceRetention, sourceprivacy.RetentionRetain)
+	}
+	seen := map[int]struct{}{}
+	for _, snapshot := range sorted {
+		record := snapshot.Shard
+		label := fmt.Sprintf("%d/%d", record.Index, record.Count)
+		if record.Count != first.Count {
+			return nil, state.ShardScanConfig{}, fmt.Errorf("shard %s does not match shard count %d", label, first.Count)
+		}
+		if record.Index < 1 || record.Index > record.Count {
+			return nil, state.ShardScanConfig{}, fmt.Errorf("shard %s has an index outside the shard count", label)
+		}
+		if _, dup := seen[record.Index]; dup {
+			return nil, state.ShardScanConfig{}, fmt.Errorf("shard %s was provided more than once", label)
+		}
+		seen[record.Index] = struct{}{}
+		if digest, err := shardConfigDigest(record.Scan); err != nil || digest != record.ConfigDigest {
+			return nil, state.ShardScanConfig{}, fmt.Errorf("shard %s config digest does not match its recorded scan config", label)
+		}
+		if record.ConfigDigest != first.ConfigDigest {
+			return nil, state.ShardScanConfig{}, fmt.Errorf("shard %s config digest %s does not match shard %d/%d config digest %s", label, record.ConfigDigest, first.Index, first.Count, first.ConfigDigest)
+		}
+		if record.PolicyDigest != first.PolicyDigest {
+			return nil, state.ShardScanConfig{}, fmt.Errorf("shard %s policy digest %q does not match shard %d/%d policy digest %q", label, record.PolicyDigest, first.Index, first.Count, first.PolicyDigest)
+		}
+		if record.TopologyDigest != first.TopologyDigest {
+			return nil, state.ShardScanConfig{}, fmt.Errorf("shard %s topology digest %q does not match shard %d/%d topology digest %q", label, record.TopologyDigest, first.Index, first.Count, first.TopologyDigest)
+		}
+	}
+	missing := []string{}
+	for index := 1; index <= first.Count; index++ {
+		if _, ok := seen[index]; !ok {
+			missing = append(missing, fmt.Sprintf("%d/%d", index, first.Count))
+		}
+	}
+	if len(missing) > 0 {
+		return nil, state.ShardScanConfig{}, fmt.Errorf("missing shard snapshots: %s", strings.Join(missing, ", "))
+	}
+
+	merged := &scanMergeInput{Authenticated: first.Scan.Authenticated}
+	if sorted[0].SourcePrivacy != nil {
+		merged.SourcePrivacy = sourceprivacy.Normalize(*sorted[0].SourcePrivacy)
+	}
+	telemetry := []*source.AcquisitionTelemetry{}
+	targets := map[source.Target]struct{}{}
+	repoShard := map[string]int{}
+	for _, snapshot := range sorted {
+		record := snapshot.Shard
+		startedAt, err := time.Parse(time.RFC3339, record.StartedAt)
+		if err != nil {
+			return nil, state.ShardScanConfig{}, fmt.Errorf("shard %d/%d has an invalid started_at: %w", record.Index, record.Count, err)
+		}
+		if merged.StartedAt.IsZero() || startedAt.Before(merged.StartedAt) {
+			merged.StartedAt = startedAt
+		}
+		manifest := record.Manifest
+		if merged.Manifest.Target == (source.Target{}) {
+			merged.Manifest.Target = manifest.Target
+		}
+		if merged.Manifest.PublicEvidenceManifestName == "" {
+			merged.Manifest.PublicEvidenceManifestName = manifest.PublicEvidenceManifestName
+		}
+		for _, target := range manifest.Targets {
+			targets[target] = struct{}{}
+		}
+		for _, repo := range manifest.Repos {
+			if other, dup := repoShard[repo.Repo]; dup {
+				return nil, state.ShardScanConfig{}, fmt.Errorf("repo %s appears in shards %d/%d and %d/%d", repo.Repo, other, first.Count, record.Index, first.Count)
+			}
+			repoShard[repo.Repo] = record.Index
+		}
+		merged.Manifest.Repos = append(merged.Manifest.Repos, manifest.Repos...)
+		merged.Manifest.Failures = append(merged.Manifest.Failures, manifest.Failures...)
+		merged.Manifest.PublicEvidence = append(merged.Manifest.PublicEvidence, manifest.PublicEvidence...)
+		if manifest.Acquisition != nil {
+			telemetry = append(telemetry, manifest.Acquisition)
+		}
+	}
+	for target := range targets {
+		merged.Manifest.Targets = append(merged.Manifest.Targets, target)
+	}
+	merged.Manifest.Acquisition = mergeShardAcquisitionTelemetry(telemetry)
+	merged.Manifest = source.SortManifest(merged.Manifest)
+	return merged, first.Scan, nil
+}
+
+func mergeShardAcquisitionTelemetry(parts []*source.AcquisitionTelemetry) *source.AcquisitionTelemetry {
+	if len(parts) == 0 {
+		return nil
+	}
+	telemetry := *parts[0]
+	telemetry.Warnings = append([]string(nil), parts[0].Warnings...)
+	for _, part := range parts[1:] {
+		telemetry.Requests += part.Requests
+		telemetry.EstimatedRequests += part.EstimatedRequests
+		telemetry.NotModified += part.NotModified
+		telemetry.CacheHits += part.CacheHits
+		telemetry.GraphQLPoints += part.GraphQLPoints
+		telemetry.EstimatedGraphQLPoints += part.EstimatedGraphQLPoints
+		telemetry.Warnings = append(telemetry.Warnings, part.Warnings...)
+		if part.RateLimitRemaining > 0 && (telemetry.RateLimitRemaining == 0 || part.RateLimitRemaining < telemetry.RateLimitRemaining) {
+			telemetry.RateLimitRemaining = part.RateLimitRemaining
+			telemetry.RateLimitReset = part.RateLimitReset
+		}
+	}
+	return &telemetry
+}
+
+// verifyShardScanInputs checks that the policy, approved-tools,
+// production-targets and topology files the merge will reload still match
+// the digests the shards recorded.
+func verifyShardScanInputs(record state.ShardRecord) error {
+	checks := []struct {
+		flag   string
+		path   string
+		digest string
+	}{
+		{flag: "--policy", path: record.Scan.PolicyPath, digest: record.PolicyDigest},
+		{flag: "--approved-tools", path: record.Scan.ApprovedToolsPath, digest: record.Scan.ApprovedToolsDigest},
+		{flag: "--production-targets", path: record.Scan.ProductionTargetsPath, digest: record.Scan.ProductionTargetsDigest},
+	}
+	for _, check := range checks {
+		digest, err := shardFileDigest(check.path)
+		if err != nil {
+			return fmt.Errorf("%s input for merge: %w", check.flag, err)
+		}
+		if digest != check.digest {
+			return fmt.Errorf("%s file %s no longer matches the digest recorded by the shards", check.flag, check.path)
+		}
+	}
+	if path := strings.TrimSpace(record.Scan.ExecutionTopologyPath); path != "" {
+		topology, err := executiontopology.Load(path)
+		if err != nil {
+			return fmt.Errorf("--execution-topology input for merge: %w", err)
+		}
+		if executionTopologyDigest(topology) != record.TopologyDigest {
+			return fmt.Errorf("--execution-topology file %s no longer matches the digest recorded by the shards", path)
+		}
+	}
+	return nil
+}
+
+// verifyShardSourceTrees checks that every merged repo's materialized tree is
+// still on disk. Detection, commit attribution, config fingerprints and
+// cross-repo workflow resolution all read these trees, so a merge without them
+// could not match an unsharded scan.
+func verifyShardSourceTrees(manifest source.Manifest) error {
+	for _, repo := range manifest.Repos {
+		root := strings.TrimSpace(repo.ScanRoot)
+		if root == "" {
+			root = strings.TrimSpace(repo.Location)
+		}
+		if root == "" {
+			return fmt.Errorf("shard manifest records no materialized tree for repo %s", repo.Repo)
+		}
+		info, err := os.Stat(root)
+		if err != nil || !info.IsDir() {
+			return fmt.Errorf("materialized tree for repo %s is missing at %s; keep each shard's retained source on storage the merge can read", repo.Repo, root)
+		}
+	}
+	return nil
+}
+
+// shardReplayArgs rebuilds the scan flags recorded by a shard. Tokens are not
+// recorded; the merged scan reuses the shards' authentication status instead.
+func shardReplayArgs(cfg state.ShardScanConfig) []string {
+	args := []string{
+		"--mode", cfg.Mode,
+		"--profile", cfg.Profile,
+		"--source-retention", cfg.SourceRetention,
+		"--deployment-mode", cfg.DeploymentMode,
+	}
+	for _, target := range cfg.Targets {
+		args = append(args, "--target", target)
+	}
+	for _, flagValue := range []struct{ name, value string }{
+		{name: "--github-api", value: cfg.GitHubAPI},
+		{name: "--github-api-mode", value: cfg.GitHubAPIMode},
+		{name: "--gitlab-api", value: cfg.GitLabAPI},
+		{name: "--bitbucket-api", value: cfg.BitbucketAPI},
+		{name: "--azdo-api", value: cfg.AzureDevOpsAPI},
+		{name: "--policy", value: cfg.PolicyPath},
+		{name: "--approved-tools", value: cfg.ApprovedToolsPath},
+		{name: "--production-targets", value: cfg.ProductionTargetsPath},
+		{name: "--execution-topology", value: cfg.ExecutionTopologyPath},
+	} {
+		if strings.TrimSpace(flagValue.value) != "" {
+			args = append(args, flagValue.name, flagValue.value)
+		}
+	}
+	for _, flagValue := range []struct {
+		name string
+		set  bool
+	}{
+		{name: "--allow-public-only", set: cfg.AllowPublicOnly},
+		{name: "--allow-source-materialization", set: cfg.AllowSourceMaterialization},
+		{name: "--enrich", set: cfg.Enrich},
+		{name: "--production-targets-strict", set: cfg.ProductionTargetsStrict},
+	} {
+		if flagValue.set {
+			args = append(args, flagValue.name)
+		}
+	}
+	return args
+}
@@ -0,0 +1,160 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Clyra-AI/wrkr/core/state"
+)
+
+func TestStateMergeMatchesUnshardedScan(t *testing.T) {
+	t.Parallel()
+
+	repos := []string{"acme/api", "acme/billing", "acme/docs", "acme/web"}
+	mcpConfig := base64.StdEncoding.EncodeToString([]byte(`{"mcpServers":{"fs":{"command":"npx","args":["-y","@modelcontextprotocol/server-filesystem","."]}}}`))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/orgs/acme/repos" {
+			names := make([]string, 0, len(repos))
+			for _, repo := range repos {
+				names = append(names, fmt.Sprintf(`{"full_name":%q}`, repo))
+			}
+			_, _ = fmt.Fprintf(w, "[%s]", strings.Join(names, ","))
+			return
+		}
+		for _, repo := range repos {
+			switch r.URL.Path {
+			case "/repos/" + repo:
+				_, _ = fmt.Fprintf(w, `{"full_name":%q,"default_branch":"main"}`, repo)
+				return
+			case "/repos/" + repo + "/git/ref/heads/main":
+				_, _ = fmt.Fprint(w, `{"object":{"sha":"0123456789abcdef0123456789abcdef01234567"}}`)
+				return
+			case "/repos/" + repo + "/git/trees/main":
+				_, _ = fmt.Fprint(w, `{"tree":[{"path":".mcp.json","type":"blob","sha":"0123456789abcdef0123456789abcdef01234567"}]}`)
+				return
+			case "/repos/" + repo + "/git/blobs/0123456789abcdef0123456789abcdef01234567":
+				_, _ = fmt.Fprintf(w, `{"content":%q,"encoding":"base64"}`, mcpConfig)
+				return
+			}
+		}
+		t.Fatalf("unexpected path: %s", r.URL.Path)
+	}))
+	defer server.Close()
+
+	tmp := t.TempDir()
+	scan := func(statePath string, extra ...string) {
+		t.Helper()
+		var out, errOut bytes.Buffer
+		args := append([]string{"scan", "--org", "acme", "--github-api", server.URL, "--state", statePath, "--json"}, extra...)
+		if code := Run(args, &out, &errOut); code != exitSuccess {
+			t.Fatalf("scan %v failed: %d (%s)", extra, code, errOut.String())
+		}
+	}
+	unshardedPath := filepath.Join(tmp, "unsharded", "state.json")
+	scan(unshardedPath)
+	for index := 1; index <= 2; index++ {
+		scan(filepath.Join(tmp, fmt.Sprintf("shard-%d", index), "state.json"), "--shard", fmt.Sprintf("%d/2", index), "--source-retention", "retain")
+	}
+
+	mergedPath := filepath.Join(tmp, "merged", "state.json")
+	var out, errOut bytes.Buffer
+	if code := Run([]string{"state", "merge", "--input", filepath.Join(tmp, "shard-*", "state.json"), "--output", mergedPath, "--json"}, &out, &errOut); code != exitSuccess {
+		t.Fatalf("state merge failed: %d (%s)", code, errOut.String())
+	}
+
+	unsharded, err := state.Load(unshardedPath)
+	if err != nil {
+		t.Fatalf("load unsharded state: %v", err)
+	}
+	merged, err := state.Load(mergedPath)
+	if err != nil {
+		t.Fatalf("load merged state: %v", err)
+	}
+	if merged.Shard != nil {
+		t.Fatalf("expected merged state to drop the shard record, got %+v", merged.Shard)
+	}
+	if !reflect.DeepEqual(merged.Findings, unsharded.Findings) {
+		t.Fatalf("expected merged findings to match the unsharded scan\nmerged=%+v\nunsharded=%+v", merged.Findings, unsharded.Findings)
+	}
+	agentIDs := func(snapshot state.Snapshot) []string {
+		ids := []string{}
+		for _, identity := range snapshot.Identities {
+			ids = append(ids, identity.AgentID)
+		}
+		return ids
+	}
+	if !reflect.DeepEqual(agentIDs(merged), agentIDs(unsharded)) || len(merged.Identities) == 0 {
+		t.Fatalf("expected merged identities to match, merged=%v unsharded=%v", agentIDs(merged), agentIDs(unsharded))
+	}
+	if merged.Inventory == nil || unsharded.Inventory == nil || len(merged.Inventory.Tools) != len(unsharded.Inventory.Tools) {
+		t.Fatalf("expected merged inventory to match the unsharded scan")
+	}
+	if merged.RiskReport == nil || unsharded.RiskReport == nil || !reflect.DeepEqual(merged.RiskReport.Repos, unsharded.RiskReport.Repos) {
+		t.Fatalf("expected merged repo risk to match the unsharded scan")
+	}
+
+	if err := os.RemoveAll(filepath.Join(tmp, "shard-2", "materialized-sources")); err != nil {
+		t.Fatalf("remove shard tree: %v", err)
+	}
+	out.Reset()
+	errOut.Reset()
+	code := Run([]string{"state", "merge", "--input", filepath.Join(tmp, "shard-*", "state.json"), "--output", filepath.Join(tmp, "remerged", "state.json"), "--json"}, &out, &errOut)
+	if code != exitInvalidInput || !strings.Contains(errOut.String(), "materialized tree for repo") {
+		t.Fatalf("expected merge without retained shard trees to fail closed, got %d (%s)", code, errOut.String())
+	}
+}
+
+func TestMergeShardSnapshotsRejectsMismatchedSets(t *testing.T) {
+	t.Parallel()
+
+	shard := func(index int, mutate func(*state.ShardRecord)) state.Snapshot {
+		record := state.ShardRecord{
+			Index:     index,
+			Count:     2,
+			Scan:      state.ShardScanConfig{WrkrVersion: wrkrVersion(), Targets: []string{"org:acme"}, Mode: "governance", Profile: "standard", SourceRetention: "retain"},
+			StartedAt: "2026-01-02T03:04:05Z",
+		}
+		if mutate != nil {
+			mutate(&record)
+		}
+		digest, err := shardConfigDigest(record.Scan)
+		if err != nil {
+			t.Fatalf("digest shard config: %v", err)
+		}
+		record.ConfigDigest = digest
+		return state.Snapshot{Shard: &record}
+	}
+
+	if _, _, err := mergeShardSnapshots([]state.Snapshot{shard(1, nil), shard(2, nil)}); err != nil {
+		t.Fatalf("expected matching shards to merge, got %v", err)
+	}
+	cases := map[string]struct {
+		snapshots []state.Snapshot
+		want      string
+	}{
+		"config":    {snapshots: []state.Snapshot{shard(1, nil), shard(2, func(r *state.ShardRecord) { r.Scan.Profile = "strict" })}, want: "config digest"},
+		"policy":    {snapshots: []state.Snapshot{shard(1, nil), shard(2, func(r *state.ShardRecord) { r.PolicyDigest = "sha256:other" })}, want: "policy digest"},
+		"topology":  {snapshots: []state.Snapshot{shard(1, nil), shard(2, func(r *state.ShardRecord) { r.TopologyDigest = "sha256:other" })}, want: "topology digest"},
+		"missing":   {snapshots: []state.Snapshot{shard(1, nil)}, want: "missing shard snapshots: 2/2"},
+		"duplicate": {snapshots: []state.Snapshot{shard(1, nil), shard(1, nil)}, want: "more than once"},
+		"count":     {snapshots: []state.Snapshot{shard(1, nil), shard(2, func(r *state.ShardRecord) { r.Count = 3 })}, want: "shard count"},
+		"retention": {snapshots: []state.Snapshot{shard(1, func(r *state.ShardRecord) { r.Scan.SourceRetention = "ephemeral" }), shard(2, func(r *state.ShardRecord) { r.Scan.SourceRetention = "ephemeral" })}, want: "--source-retention retain"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if _, _, err := mergeShardSnapshots(tc.snapshots); err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("expected %q error, got %v", tc.want, err)
+			}
+		})
+	}
+}
@@ -17,11 +17,18 @@ const CurrentVersion = "v1"
 type TargetMode string
 
 const (
-	TargetRepo          TargetMode = "repo"
-	TargetOrg           TargetMode = "org"
-	TargetPath          TargetMode = "path"
-	TargetMySetup       TargetMode = "my_setup"
-	TargetPublicSurface TargetMode = "public-surface"
+	TargetRepo               TargetMode = "repo"
+	TargetOrg                TargetMode = "org"
+	TargetEnterprise         TargetMode = "enterprise"
+	TargetPath               TargetMode = "path"
+	TargetMySetup            TargetMode = "my_setup"
+	TargetPublicSurface      TargetMode = "public-surface"
+	TargetGitLabGroup        TargetMode = "gitlab-group"
+	TargetGitLabProject      TargetMode = "gitlab-project"
+	TargetBitbucketWorkspace TargetMode = "bitbucket-workspace"
+	TargetBitbucketRepo      TargetMode = "bitbucket-repo"
+	TargetAzureDevOps        TargetMode = "azdo"
+	TargetArchive            TargetMode = "archive"
 )
 
 // Target identifies a scan source target.
@@ -35,18 +42,25 @@ type AuthProfile struct {
 	Token string `json:"token,omitempty"`
 }
 
-// AuthProfiles stores split privileges for scan and fix paths.
+// AuthProfiles stores split privileges for scan and fix paths plus optional
+// read-only scan tokens for non-GitHub hosted sources.
 type AuthProfiles struct {
-	Scan AuthProfile `json:"scan"`
-	Fix  AuthProfile `json:"fix"`
+	Scan        AuthProfile  `json:"scan"`
+	Fix         AuthProfile  `json:"fix"`
+	GitLab      *AuthProfile `json:"gitlab,omitempty"`
+	Bitbucket   *AuthProfile `json:"bitbucket,omitempty"`
+	AzureDevOps *AuthProfile `json:"azdo,omitempty"`
 }
 
 // Config is the persisted wrkr init configuration.
 type Config struct {
-	Version       string       `json:"version"`
-	Auth          AuthProfiles `json:"auth"`
-	DefaultTarget Target       `json:"default_target"`
-	GitHubAPIBase string       `json:"github_api_base,omitempty"`
+	Version            string       `json:"version"`
+	Auth               AuthProfiles `json:"auth"`
+	DefaultTarget      Target       `json:"default_target"`
+	GitHubAPIBase      string       `json:"github_api_base,omitempty"`
+	GitLabAPIBase      string       `json:"gitlab_api_base,omitempty"`
+	BitbucketAPIBase   string       `json:"bitbucket_api_base,omitempty"`
+	AzureDevOpsAPIBase string       `json:"azdo_api_base,omitempty"`
 }
 
 func Default() Config {
@@ -61,6 +75,9 @@ func Default() Config {
 
 func Validate(cfg Config) error {
 	cfg.GitHubAPIBase = strings.TrimSpace(cfg.GitHubAPIBase)
+	cfg.GitLabAPIBase = strings.TrimSpace(cfg.GitLabAPIBase)
+	cfg.BitbucketAPIBase = strings.TrimSpace(cfg.BitbucketAPIBase)
+	cfg.AzureDevOpsAPIBase = strings.TrimSpace(cfg.AzureDevOpsAPIBase)
 	if cfg.Version == "" {
 		return errors.New("config version is required")
 	}
@@ -86,6 +103,10 @@ func ValidateTarget(mode TargetMode, value string) error {
 		if err := reponame.ValidateOrg(value); err != nil {
 			return err
 		}
+	case TargetEnterprise:
+		if _, err := reponame.NormalizeOrg(value); err != nil {
+			return fmt.Errorf("enterprise %w", err)
+		}
 	case TargetPath:
 		if strings.TrimSpace(value) == "" {
 			return errors.New("path target must be non-empty")
@@ -98,6 +119,34 @@ func ValidateTarget(mode TargetMode, value string) error {
 		if strings.TrimSpace(value) == "" {
 			return errors.New("public-surface target must point to a readable manifest path")
 		}
+	case TargetArchive:
+		if strings.TrimSpace(value) == "" {
+			return errors.New("archive target must point to a tar.gz, tar, zip, or git bundle file")
+		}
+	case TargetGitLabGroup:
+		if _, err := reponame.NormalizeNamespacePath(value, "gitlab group", 1); err != nil {
+			return err
+		}
+	case TargetGitLabProject:
+		if _, err := reponame.NormalizeNamespacePath(value, "gitlab project", 2); err != nil {
+			return err
+		}
+	case TargetBitbucketWorkspace:
+		if _, err := reponame.NormalizeOrg(value); err != nil {
+			return err
+		}
+	case TargetBitbucketRepo:
+		if _, err := reponame.NormalizeRepo(value); err != nil {
+			return err
+		}
+	case TargetAzureDevOps:
+		scope, err := reponame.NormalizeNamespacePath(value, "azure devops scope", 1)
+		if err != nil {
+			return err
+		}
+		if strings.Count(scope, "/") > 1 {
+			return fmt.Errorf("azure devops target must be org or org/project, got %q", value)
+		}
 	default:
 		return fmt.Errorf("unsupported target mode %q", mode)
 	}
@@ -122,6 +171,9 @@ func ResolvePath(explicit string) (string, error) {
 // Save writes config deterministically.
 func Save(path string, cfg Config) error {
 	cfg.GitHubAPIBase = strings.TrimSpace(cfg.GitHubAPIBase)
+	cfg.GitLabAPIBase = strings.TrimSpace(cfg.GitLabAPIBase)
+	cfg.BitbucketAPIBase = strings.TrimSpace(cfg.BitbucketAPIBase)
+	cfg.AzureDevOpsAPIBase = strings.TrimSpace(cfg.AzureDevOpsAPIBase)
 	if err := Validate(cfg); err != nil {
 		return err
 	}
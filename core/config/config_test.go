@@ -15,6 +15,12 @@ func TestValidateTarget(t *testing.T) {
 	if err := ValidateTarget(TargetOrg, "acme"); err != nil {
 		t.Fatalf("expected org target to validate: %v", err)
 	}
+	if err := ValidateTarget(TargetEnterprise, "big-co"); err != nil {
+		t.Fatalf("expected enterprise target to validate: %v", err)
+	}
+	if err := ValidateTarget(TargetEnterprise, "big-co/acme"); err == nil {
+		t.Fatal("expected enterprise target with org path to fail")
+	}
 	if err := ValidateTarget(TargetPath, "./repos"); err != nil {
 		t.Fatalf("expected path target to validate: %v", err)
 	}
@@ -33,6 +39,42 @@ func TestValidateTarget(t *testing.T) {
 	if err := ValidateTarget(TargetOrg, ".."); err == nil {
 		t.Fatal("expected traversal-style org target to fail")
 	}
+	if err := ValidateTarget(TargetGitLabGroup, "acme/platform"); err != nil {
+		t.Fatalf("expected nested gitlab group target to be valid: %v", err)
+	}
+	if err := ValidateTarget(TargetGitLabProject, "acme/platform/backend"); err != nil {
+		t.Fatalf("expected nested gitlab project target to be valid: %v", err)
+	}
+	if err := ValidateTarget(TargetGitLabProject, "backend"); err == nil {
+		t.Fatal("expected gitlab project without namespace to fail")
+	}
+	if err := ValidateTarget(TargetGitLabGroup, "acme/../platform"); err == nil {
+		t.Fatal("expected traversal-style gitlab group target to fail")
+	}
+	if err := ValidateTarget(TargetBitbucketWorkspace, "payments"); err != nil {
+		t.Fatalf("expected bitbucket workspace target to be valid: %v", err)
+	}
+	if err := ValidateTarget(TargetBitbucketRepo, "payments/ledger"); err != nil {
+		t.Fatalf("expected bitbucket repo target to be valid: %v", err)
+	}
+	if err := ValidateTarget(TargetBitbucketWorkspace, "payments/ledger"); err == nil {
+		t.Fatal("expected bitbucket workspace with repo path to fail")
+	}
+	if err := ValidateTarget(TargetAzureDevOps, "contoso/Payments Platform"); err != nil {
+		t.Fatalf("expected azure devops project target to be valid: %v", err)
+	}
+	if err := ValidateTarget(TargetAzureDevOps, "contoso"); err != nil {
+		t.Fatalf("expected azure devops org target to be valid: %v", err)
+	}
+	if err := ValidateTarget(TargetAzureDevOps, "contoso/payments/ledger"); err == nil {
+		t.Fatal("expected azure devops target with repo path to fail")
+	}
+	if err := ValidateTarget(TargetArchive, "./review/backend.tar.gz"); err != nil {
+		t.Fatalf("expected archive target to be valid: %v", err)
+	}
+	if err := ValidateTarget(TargetArchive, " "); err == nil {
+		t.Fatal("expected empty archive target to fail")
+	}
 }
 
 func TestSaveLoadDeterministicRoundTrip(t *testing.T) {
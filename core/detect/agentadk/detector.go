@@ -0,0 +1,47 @@
+package agentadk
+
+import (
+	"context"
+
+	"github.com/Clyra-AI/wrkr/core/detect"
+	"github.com/Clyra-AI/wrkr/core/detect/agentframework"
+	"github.com/Clyra-AI/wrkr/core/model"
+)
+
+const detectorID = "agentadk"
+
+type Detector struct{}
+
+func New() Detector { return Detector{} }
+
+func (Detector) ID() string { return detectorID }
+
+func (Detector) Detect(ctx context.Context, scope detect.Scope, options detect.Options) ([]model.Finding, error) {
+	_ = ctx
+	return agentframework.DetectManyWithOptions(scope, []agentframework.DetectorConfig{
+		{
+			DetectorID: detectorID,
+			Framework:  "google_adk",
+			ConfigPath: ".wrkr/agents/google-adk.yaml",
+			Format:     "yaml",
+		},
+		{
+			DetectorID: detectorID,
+			Framework:  "google_adk",
+			ConfigPath: ".wrkr/agents/google-adk.yml",
+			Format:     "yaml",
+		},
+		{
+			DetectorID: detectorID,
+			Framework:  "google_adk",
+			ConfigPath: ".wrkr/agents/google-adk.json",
+			Format:     "json",
+		},
+		{
+			DetectorID: detectorID,
+			Framework:  "google_adk",
+			ConfigPath: ".wrkr/agents/google-adk.toml",
+			Format:     "toml",
+		},
+	}, options)
+}
@@ -0,0 +1,164 @@
+package agentadk
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Clyra-AI/wrkr/core/detect"
+	"github.com/Clyra-AI/wrkr/core/model"
+)
+
+func TestADKDetector_DeclarationBaseline(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeFile(t, root, ".wrkr/agents/google-adk.yaml", `agents:
+  - name: weather_agent
+    file: agents/weather/main.go
+    tools: [get_weather]
+`)
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Org: "acme", Repo: "weather", Root: root}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect: %v", err)
+	}
+	if len(findings) != 1 {
+		t.Fatalf("expected one finding, got %d", len(findings))
+	}
+	if findings[0].ToolType != "google_adk" || findings[0].Detector != detectorID {
+		t.Fatalf("unexpected finding %+v", findings[0])
+	}
+}
+
+func TestADKDetector_GoSourceOnlyRepo(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeFile(t, root, "agents/weather/main.go", `package main
+
+import (
+	"context"
+	"os"
+
+	"google.golang.org/adk/agent/llmagent"
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/geminitool"
+)
+
+func main() {
+	ctx := context.Background()
+	model := newModel(ctx, os.Getenv("GOOGLE_API_KEY"))
+	weather, err := llmagent.New(llmagent.Config{
+		Name:        "weather_time_agent",
+		Model:       model,
+		Instruction: "Answer questions about the weather.",
+		Tools:       []tool.Tool{geminitool.GoogleSearch{}, weatherTool},
+		APIKey:      os.Getenv("GOOGLE_API_KEY"),
+	})
+	if err != nil {
+		panic(err)
+	}
+	_ = weather
+}
+`)
+	writeFile(t, root, "agents/weather/main_test.go", `package main
+
+import "google.golang.org/adk/agent/llmagent"
+
+func TestAgent(t *testing.T) {
+	agent, _ := llmagent.New(llmagent.Config{Name: "test_agent"})
+	_ = agent
+}
+`)
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Org: "acme", Repo: "weather", Root: root}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect: %v", err)
+	}
+	if len(findings) != 1 {
+		t.Fatalf("expected one source finding outside test files, got %+v", findings)
+	}
+	finding := findings[0]
+	for key, want := range map[string]string{
+		"symbol":               "weather_time_agent",
+		"source_language":      "go",
+		"source_call":          "llmagent.New",
+		"bound_tools":          "geminitool.GoogleSearch,weatherTool",
+		"auth_surfaces":        "GOOGLE_API_KEY",
+		"deployment_artifacts": "agents/weather/main.go",
+	} {
+		if got := evidenceValue(finding.Evidence, key); got != want {
+			t.Fatalf("expected %s=%q, got %q", key, want, got)
+		}
+	}
+	if finding.LocationRange == nil || finding.LocationRange.StartLine != 15 {
+		t.Fatalf("expected constructor line range, got %+v", finding.LocationRange)
+	}
+}
+
+func TestADKDetector_PythonSourceOnlyRepo(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeFile(t, root, "agents/weather/agent.py", `from google.adk.agents import Agent, LlmAgent
+from google.adk.tools import google_search
+
+greeter = LlmAgent(name="greeter", model="gemini-2.0-flash", instruction="Greet the user.")
+
+root_agent = Agent(
+    name="weather_coordinator",
+    model="gemini-2.0-flash",
+    tools=[google_search, get_weather],
+    sub_agents=[greeter],
+)
+`)
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Org: "acme", Repo: "weather", Root: root}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect: %v", err)
+	}
+	bySymbol := map[string]model.Finding{}
+	for _, finding := range findings {
+		bySymbol[evidenceValue(finding.Evidence, "symbol")] = finding
+	}
+	if len(bySymbol) != 2 {
+		t.Fatalf("expected coordinator and sub-agent findings, got %+v", findings)
+	}
+	coordinator := bySymbol["weather_coordinator"]
+	for key, want := range map[string]string{
+		"source_language":   "python",
+		"source_call":       "Agent",
+		"bound_tools":       "get_weather,google_search,greeter",
+		"dynamic_discovery": "true",
+		"model_providers":   "google_genai",
+	} {
+		if got := evidenceValue(coordinator.Evidence, key); got != want {
+			t.Fatalf("expected %s=%q, got %q", key, want, got)
+		}
+	}
+	if got := evidenceValue(bySymbol["greeter"].Evidence, "source_call"); got != "LlmAgent" {
+		t.Fatalf("expected LlmAgent sub-agent, got %q", got)
+	}
+}
+
+func writeFile(t *testing.T, root, rel, content string) {
+	t.Helper()
+	path := filepath.Join(root, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir %s: %v", rel, err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write %s: %v", rel, err)
+	}
+}
+
+func evidenceValue(evidence []model.Evidence, key string) string {
+	for _, item := range evidence {
+		if item.Key == key {
+			return item.Value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,47 @@
+package agentdspy
+
+import (
+	"context"
+
+	"github.com/Clyra-AI/wrkr/core/detect"
+	"github.com/Clyra-AI/wrkr/core/detect/agentframework"
+	"github.com/Clyra-AI/wrkr/core/model"
+)
+
+const detectorID = "agentdspy"
+
+type Detector struct{}
+
+func New() Detector { return Detector{} }
+
+func (Detector) ID() string { return detectorID }
+
+func (Detector) Detect(ctx context.Context, scope detect.Scope, options detect.Options) ([]model.Finding, error) {
+	_ = ctx
+	return agentframework.DetectManyWithOptions(scope, []agentframework.DetectorConfig{
+		{
+			DetectorID: detectorID,
+			Framework:  "dspy",
+			ConfigPath: ".wrkr/agents/dspy.yaml",
+			Format:     "yaml",
+		},
+		{
+			DetectorID: detectorID,
+			Framework:  "dspy",
+			ConfigPath: ".wrkr/agents/dspy.yml",
+			Format:     "yaml",
+		},
+		{
+			DetectorID: detectorID,
+			Framework:  "dspy",
+			ConfigPath: ".wrkr/agents/dspy.json",
+			Format:     "json",
+		},
+		{
+			DetectorID: detectorID,
+			Framework:  "dspy",
+			ConfigPath: ".wrkr/agents/dspy.toml",
+			Format:     "toml",
+		},
+	}, options)
+}
@@ -0,0 +1,94 @@
+package agentdspy
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Clyra-AI/wrkr/core/detect"
+	"github.com/Clyra-AI/wrkr/core/model"
+)
+
+func TestDSPyDetector_DeclarationBaseline(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeFile(t, root, ".wrkr/agents/dspy.yaml", `agents:
+  - name: react
+    file: agents/react_agent.py
+    tools: [search_wikipedia]
+`)
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Org: "acme", Repo: "qa", Root: root}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect: %v", err)
+	}
+	if len(findings) != 1 {
+		t.Fatalf("expected one finding, got %d", len(findings))
+	}
+	if findings[0].ToolType != "dspy" || findings[0].Detector != detectorID {
+		t.Fatalf("unexpected finding %+v", findings[0])
+	}
+}
+
+func TestDSPyDetector_ReActReadsModuleConfiguration(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeFile(t, root, "agents/react_agent.py", `import os
+
+import dspy
+
+lm = dspy.LM("openai/gpt-4o-mini", api_key=os.getenv("OPENAI_API_KEY"))
+colbert = dspy.ColBERTv2(url="http://20.102.90.50:2017/wiki17_abstracts")
+dspy.configure(lm=lm, rm=colbert)
+
+
+def search_wikipedia(query: str) -> list[str]:
+    return [hit["text"] for hit in colbert(query, k=3)]
+
+
+react = dspy.ReAct("question -> answer", tools=[search_wikipedia, evaluate_math])
+`)
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Org: "acme", Repo: "qa", Root: root}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect: %v", err)
+	}
+	if len(findings) != 1 {
+		t.Fatalf("expected one source finding, got %+v", findings)
+	}
+	for key, want := range map[string]string{
+		"symbol":          "react",
+		"source_call":     "ReAct",
+		"bound_tools":     "evaluate_math,search_wikipedia",
+		"data_sources":    "colbert",
+		"auth_surfaces":   "OPENAI_API_KEY",
+		"model_providers": "openai",
+	} {
+		if got := evidenceValue(findings[0].Evidence, key); got != want {
+			t.Fatalf("expected %s=%q, got %q", key, want, got)
+		}
+	}
+}
+
+func writeFile(t *testing.T, root, rel, content string) {
+	t.Helper()
+	path := filepath.Join(root, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir %s: %v", rel, err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write %s: %v", rel, err)
+	}
+}
+
+func evidenceValue(evidence []model.Evidence, key string) string {
+	for _, item := range evidence {
+		if item.Key == key {
+			return item.Value
+		}
+	}
+	return ""
+}
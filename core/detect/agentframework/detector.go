@@ -7,7 +7,9 @@ import (
 	"strings"
 
 	"github.com/Clyra-AI/wrkr/core/detect"
+	"github.com/Clyra-AI/wrkr/core/detect/openapi"
 	"github.com/Clyra-AI/wrkr/core/model"
+	"github.com/Clyra-AI/wrkr/core/risk/autonomy"
 )
 
 type AgentSpec struct {
@@ -19,6 +21,7 @@ type AgentSpec struct {
 	DataSources      []string `json:"data_sources" yaml:"data_sources" toml:"data_sources"`
 	AuthSurfaces     []string `json:"auth_surfaces" yaml:"auth_surfaces" toml:"auth_surfaces"`
 	Deployment       []string `json:"deployment_artifacts" yaml:"deployment_artifacts" toml:"deployment_artifacts"`
+	APISpecs         []string `json:"api_specs,omitempty" yaml:"api_specs,omitempty" toml:"api_specs,omitempty"`
 	DataClass        string   `json:"data_class" yaml:"data_class" toml:"data_class"`
 	ApprovalStatus   string   `json:"approval_status" yaml:"approval_status" toml:"approval_status"`
 	ApprovalSource   string   `json:"approval_source" yaml:"approval_source" toml:"approval_source"`
@@ -28,6 +31,12 @@ type AgentSpec struct {
 	AutoDeploy       bool     `json:"auto_deploy" yaml:"auto_deploy" toml:"auto_deploy"`
 	HumanGate        bool     `json:"human_gate" yaml:"human_gate" toml:"human_gate"`
 	DeploymentGate   string   `json:"deployment_gate" yaml:"deployment_gate" toml:"deployment_gate"`
+	// CodeExecution marks agents that write and run code, such as
+	// smolagents `CodeAgent`, rather than only calling declared tools.
+	CodeExecution bool `json:"code_execution,omitempty" yaml:"code_execution,omitempty" toml:"code_execution,omitempty"`
+	// StepLimit records the bound on an agent's model and tool loop, such
+	// as `maxSteps=5`, so a bounded run is distinguishable from an open one.
+	StepLimit string `json:"step_limit,omitempty" yaml:"step_limit,omitempty" toml:"step_limit,omitempty"`
 }
 
 type declaration struct {
@@ -219,6 +228,16 @@ func frameworkFinding(scope detect.Scope, cfg DetectorConfig, agent AgentSpec) m
 		{Key: "human_gate", Value: fmt.Sprintf("%t", agent.HumanGate)},
 		{Key: "deployment_gate", Value: deriveDeploymentGate(agent)},
 	}
+	_, _, relationships := apiSpecReach(agent)
+	if specs := uniqueSorted(agent.APISpecs); len(specs) > 0 {
+		evidence = append(evidence, model.Evidence{Key: "api_specs", Value: strings.Join(specs, ",")})
+	}
+	if agent.CodeExecution {
+		evidence = append(evidence, model.Evidence{Key: "code_execution", Value: "true"})
+	}
+	if stepLimit := strings.TrimSpace(agent.StepLimit); stepLimit != "" {
+		evidence = append(evidence, model.Evidence{Key: "step_limit", Value: stepLimit})
+	}
 
 	severity := model.SeverityLow
 	if agent.AutoDeploy {
@@ -227,6 +246,10 @@ func frameworkFinding(scope detect.Scope, cfg DetectorConfig, agent AgentSpec) m
 	if agent.AutoDeploy && !agent.HumanGate {
 		severity = model.SeverityHigh
 	}
+	level := agentAutonomy(cfg.Framework, agent)
+	if level == autonomy.LevelHeadlessAuto && severity == model.SeverityLow {
+		severity = model.SeverityMedium
+	}
 
 	var locationRange *model.LocationRange
 	if agent.StartLine > 0 || agent.EndLine > 0 {
@@ -234,20 +257,52 @@ func frameworkFinding(scope detect.Scope, cfg DetectorConfig, agent AgentSpec) m
 	}
 
 	return model.Finding{
-		FindingType:   "agent_framework",
-		Severity:      severity,
-		ToolType:      strings.TrimSpace(cfg.Framework),
-		Location:      strings.TrimSpace(agent.File),
-		LocationRange: locationRange,
-		Repo:          strings.TrimSpace(scope.Repo),
-		Org:           fallbackOrg(scope.Org),
-		Detector:      strings.TrimSpace(cfg.DetectorID),
-		Permissions:   permissions,
-		Evidence:      evidence,
-		Remediation:   "Declare deterministic agent bindings, deployment context, and governance controls.",
+		FindingType:            "agent_framework",
+		Severity:               severity,
+		ToolType:               strings.TrimSpace(cfg.Framework),
+		Location:               strings.TrimSpace(agent.File),
+		LocationRange:          locationRange,
+		Repo:                   strings.TrimSpace(scope.Repo),
+		Org:                    fallbackOrg(scope.Org),
+		Detector:               strings.TrimSpace(cfg.DetectorID),
+		Permissions:            permissions,
+		Autonomy:               level,
+		Evidence:               evidence,
+		ExecutionRelationships: relationships,
+		Remediation:            "Declare deterministic agent bindings, deployment context, and governance controls.",
 	}
 }
 
+// apiSpecReach splits an agent's API specs into local spec files and remote
+// spec URLs. Local specs are linked with the relationship the openapi
+// detector records for their consumers.
+func apiSpecReach(agent AgentSpec) ([]string, []string, []model.ExecutionRelationship) {
+	local := []string{}
+	remote := []string{}
+	relationships := []model.ExecutionRelationship{}
+	for _, spec := range uniqueSorted(agent.APISpecs) {
+		if urlPattern.MatchString(spec) {
+			remote = append(remote, spec)
+			continue
+		}
+		local = append(local, spec)
+		if file := strings.TrimSpace(agent.File); file != "" {
+			relationships = append(relationships, openapi.ConsumerRelationship(spec, file))
+		}
+	}
+	return local, remote, model.NormalizeExecutionRelationships(relationships)
+}
+
+// agentAutonomy treats an agent that runs the code it writes as
+// auto-approved exec, which is headless-equivalent unless a human gate
+// reviews its actions.
+func agentAutonomy(framework string, agent AgentSpec) string {
+	if !agent.CodeExecution {
+		return ""
+	}
+	return autonomy.Classify(autonomy.Signals{Tool: framework, AutoApprovedWrite: true, HasApprovalGate: agent.HumanGate})
+}
+
 func parseErrorFinding(scope detect.Scope, cfg DetectorConfig, parseErr model.ParseError) model.Finding {
 	parseErr.Detector = strings.TrimSpace(cfg.DetectorID)
 	if strings.TrimSpace(parseErr.Path) == "" {
@@ -298,6 +353,9 @@ func derivePermissions(agent AgentSpec) []string {
 	if agent.AutoDeploy {
 		permissions = append(permissions, "deploy.write")
 	}
+	if agent.CodeExecution {
+		permissions = append(permissions, "proc.exec")
+	}
 	return uniqueSorted(permissions)
 }
 
@@ -420,6 +478,10 @@ func mergeFrameworkFinding(current, incoming model.Finding) model.Finding {
 	}
 	current.Permissions = uniqueSorted(append(append([]string(nil), current.Permissions...), incoming.Permissions...))
 	current.Evidence = mergeEvidence(current.Evidence, incoming.Evidence)
+	if strings.TrimSpace(current.Autonomy) == "" || incoming.Autonomy == autonomy.LevelHeadlessAuto {
+		current.Autonomy = fallback(incoming.Autonomy, current.Autonomy)
+	}
+	current.ExecutionRelationships = model.NormalizeExecutionRelationships(append(append([]model.ExecutionRelationship(nil), current.ExecutionRelationships...), incoming.ExecutionRelationships...))
 	if current.LocationRange == nil {
 		current.LocationRange = incoming.LocationRange
 	}
@@ -464,13 +526,13 @@ func mergeEvidence(current, incoming []model.Evidence) []model.Evidence {
 func mergeEvidenceValue(key string, values []string) string {
 	normalizedKey := strings.ToLower(strings.TrimSpace(key))
 	switch normalizedKey {
-	case "bound_tools", "data_sources", "auth_surfaces", "deployment_artifacts":
+	case "bound_tools", "data_sources", "auth_surfaces", "deployment_artifacts", "api_specs":
 		items := []string{}
 		for _, value := range values {
 			items = append(items, strings.Split(value, ",")...)
 		}
 		return strings.Join(uniqueSorted(items), ",")
-	case "dynamic_discovery", "kill_switch", "auto_deploy", "human_gate":
+	case "dynamic_discovery", "kill_switch", "auto_deploy", "human_gate", "code_execution":
 		for _, value := range values {
 			if strings.EqualFold(strings.TrimSpace(value), "true") {
 				return "true"
@@ -393,6 +393,307 @@ const release_agent = new Agent({
 	}
 }
 
+func TestDetectMany_GoLangchaingoSourceResolvesToolSlice(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeFile(t, root, "agents/research/agent.go", `package research
+
+import (
+	"os"
+
+	"github.com/tmc/langchaingo/agents"
+	"github.com/tmc/langchaingo/tools"
+	"github.com/tmc/langchaingo/tools/serpapi"
+)
+
+func Build(llm Model) (*agents.Executor, error) {
+	search, err := serpapi.New(serpapi.WithAPIKey(os.Getenv("SERPAPI_API_KEY")))
+	if err != nil {
+		return nil, err
+	}
+	agentTools := []tools.Tool{
+		tools.Calculator{},
+		search,
+	}
+	researcher := agents.NewOneShotAgent(llm, agentTools, agents.WithMaxIterations(3))
+	return agents.NewExecutor(researcher), nil
+}
+`)
+
+	findings, err := DetectMany(detect.Scope{Org: "acme", Repo: "research", Root: root}, []DetectorConfig{
+		{DetectorID: "agentlangchain", Framework: "langchain", ConfigPath: ".wrkr/agents/langchain.json", Format: "json"},
+	})
+	if err != nil {
+		t.Fatalf("detect many: %v", err)
+	}
+	if len(findings) != 1 {
+		t.Fatalf("expected only the assigned constructor, got %+v", findings)
+	}
+	if got := evidenceValue(findings[0], "source_language"); got != "go" {
+		t.Fatalf("expected go source language, got %q", got)
+	}
+	if got := evidenceValue(findings[0], "symbol"); got != "researcher" {
+		t.Fatalf("expected variable symbol, got %q", got)
+	}
+	if got := evidenceValue(findings[0], "bound_tools"); got != "search,tools.Calculator" {
+		t.Fatalf("expected positional tool slice to resolve, got %q", got)
+	}
+}
+
+func TestDetectMany_GoMCPServersCaptureRegisteredTools(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeFile(t, root, "cmd/greeter-mcp/main.go", `package main
+
+import (
+	"context"
+	"os"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func main() {
+	server := mcp.NewServer(&mcp.Implementation{Name: "greeter", Version: "v1.0.0"}, nil)
+	mcp.AddTool(server, &mcp.Tool{Name: "greet", Description: "say hi"}, SayHi)
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "deploy",
+		Description: "deploy the service",
+	}, Deploy)
+	_ = os.Getenv("DEPLOY_TOKEN")
+	_ = server.Run(context.Background(), &mcp.StdioTransport{})
+}
+`)
+	writeFile(t, root, "cmd/demo-mcp/main.go", `package main
+
+import (
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+func main() {
+	s := server.NewMCPServer(
+		"Demo",
+		"1.0.0",
+		server.WithToolCapabilities(false),
+	)
+	calculator := mcp.NewTool("calculate", mcp.WithDescription("arithmetic"))
+	s.AddTool(calculator, calculateHandler)
+	s.AddTool(mcp.NewTool("hello_world", mcp.WithDescription("greet")), helloHandler)
+	server.ServeStdio(s)
+}
+`)
+
+	findings, err := DetectMany(detect.Scope{Org: "acme", Repo: "tools", Root: root}, []DetectorConfig{
+		{DetectorID: "agentmcpclient", Framework: "mcp_client", ConfigPath: ".wrkr/agents/mcp-client.yaml", Format: "yaml"},
+	})
+	if err != nil {
+		t.Fatalf("detect many: %v", err)
+	}
+	if len(findings) != 2 {
+		t.Fatalf("expected one finding per Go MCP server, got %+v", findings)
+	}
+	byLocation := map[string]model.Finding{}
+	for _, finding := range findings {
+		byLocation[finding.Location] = finding
+	}
+
+	official := byLocation["cmd/greeter-mcp/main.go"]
+	if got := evidenceValue(official, "symbol"); got != "greeter" {
+		t.Fatalf("expected implementation name, got %q", got)
+	}
+	if got := evidenceValue(official, "bound_tools"); got != "deploy,greet" {
+		t.Fatalf("expected mcp.AddTool registrations, got %q", got)
+	}
+
+	community := byLocation["cmd/demo-mcp/main.go"]
+	if got := evidenceValue(community, "symbol"); got != "Demo" {
+		t.Fatalf("expected positional server name, got %q", got)
+	}
+	if got := evidenceValue(community, "source_call"); got != "server.NewMCPServer" {
+		t.Fatalf("unexpected source call %q", got)
+	}
+	if got := evidenceValue(community, "bound_tools"); got != "calculate,hello_world" {
+		t.Fatalf("expected AddTool registrations, got %q", got)
+	}
+	if got := evidenceValue(community, "deployment_artifacts"); got != "cmd/demo-mcp/main.go" {
+		t.Fatalf("expected ServeStdio deployment hint, got %q", got)
+	}
+}
+
+func TestParseGoImportSummaryReadsBlocksAndAliases(t *testing.T) {
+	t.Parallel()
+
+	summary := parseGoImportSummary(`package main
+
+import "fmt"
+
+import (
+	// MCP server SDK.
+	mcpserver "github.com/mark3labs/mcp-go/server"
+	_ "embed"
+)
+
+func main() {
+	fmt.Println("import \"not/a/module\"")
+}
+`)
+	want := []string{"embed", "fmt", "github.com/mark3labs/mcp-go/server"}
+	if !reflect.DeepEqual(summary.Modules, want) {
+		t.Fatalf("expected modules %v, got %v", want, summary.Modules)
+	}
+}
+
+func TestDetectMany_SpringAIChatClientExpandsToolMethods(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeFile(t, root, "src/main/java/com/acme/ChatConfig.java", `package com.acme;
+
+import org.springframework.ai.chat.client.ChatClient;
+import org.springframework.ai.chat.model.ChatModel;
+import org.springframework.context.annotation.Bean;
+
+@Configuration
+public class ChatConfig {
+
+    @Bean
+    public ChatClient supportClient(ChatModel chatModel, WeatherTools weatherTools) {
+        return ChatClient.builder(chatModel)
+                .defaultSystem("You are a support agent.")
+                .defaultTools(weatherTools, new TicketTools())
+                .defaultAdvisors(MessageChatMemoryAdvisor.builder(chatMemory).build())
+                .build();
+    }
+}
+`)
+	writeFile(t, root, "src/main/java/com/acme/WeatherTools.java", `package com.acme;
+
+import org.springframework.ai.tool.annotation.Tool;
+import org.springframework.ai.tool.annotation.ToolParam;
+
+class WeatherTools {
+
+    @Tool(description = "Current weather (celsius) for a city")
+    String currentWeather(@ToolParam(description = "city") String city) {
+        return "sunny";
+    }
+
+    String helper() {
+        return "not a tool";
+    }
+}
+`)
+	writeFile(t, root, "src/main/java/com/acme/TicketTools.java", `package com.acme;
+
+import org.springframework.ai.tool.annotation.Tool;
+
+public class TicketTools {
+
+    @Tool(name = "close_ticket", description = "Close a ticket")
+    @Transactional
+    public void close(String id) {}
+
+    @Tool(description = "Open a ticket")
+    public String openTicket(String summary) {
+        return "T-1";
+    }
+}
+`)
+
+	findings, err := DetectMany(detect.Scope{Org: "acme", Repo: "support", Root: root}, []DetectorConfig{
+		{DetectorID: "agentspringai", Framework: "spring_ai", ConfigPath: ".wrkr/agents/spring-ai.yaml", Format: "yaml"},
+	})
+	if err != nil {
+		t.Fatalf("detect many: %v", err)
+	}
+	if len(findings) != 1 {
+		t.Fatalf("expected one ChatClient finding, got %+v", findings)
+	}
+	finding := findings[0]
+	for key, want := range map[string]string{
+		"source_language": "java",
+		"source_call":     "ChatClient.builder",
+		"symbol":          "supportClient",
+		"bound_tools":     "close_ticket,currentWeather,openTicket",
+		"data_sources":    "MessageChatMemoryAdvisor",
+	} {
+		if got := evidenceValue(finding, key); got != want {
+			t.Fatalf("expected %s=%q, got %q", key, want, got)
+		}
+	}
+	if finding.LocationRange == nil || finding.LocationRange.StartLine != 12 || finding.LocationRange.EndLine != 16 {
+		t.Fatalf("expected builder chain range 12-16, got %+v", finding.LocationRange)
+	}
+}
+
+func TestDetectMany_LangChain4jKotlinAiServices(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeFile(t, root, "app/src/main/kotlin/acme/Assistants.kt", `package acme
+
+import dev.langchain4j.memory.chat.MessageWindowChatMemory
+import dev.langchain4j.service.AiServices
+
+fun buildAssistant(model: ChatModel, retriever: ContentRetriever): Assistant {
+    val calculator = Calculator()
+    val assistant: Assistant = AiServices.builder(Assistant::class.java)
+        .chatModel(model)
+        .tools(listOf(calculator, SearchTools(System.getenv("SEARCH_API_KEY"))))
+        .contentRetriever(retriever)
+        .chatMemory(MessageWindowChatMemory.withMaxMessages(10))
+        .build()
+    return assistant
+}
+
+class Calculator {
+    @Tool("Adds two numbers")
+    fun add(a: Int, b: Int): Int = a + b
+}
+`)
+
+	findings, err := DetectMany(detect.Scope{Org: "acme", Repo: "assistants", Root: root}, []DetectorConfig{
+		{DetectorID: "agentlangchain", Framework: "langchain", ConfigPath: ".wrkr/agents/langchain.json", Format: "json"},
+	})
+	if err != nil {
+		t.Fatalf("detect many: %v", err)
+	}
+	if len(findings) != 1 {
+		t.Fatalf("expected one AiServices finding, got %+v", findings)
+	}
+	for key, want := range map[string]string{
+		"source_language": "kotlin",
+		"symbol":          "assistant",
+		"bound_tools":     "SearchTools,add",
+		"data_sources":    "MessageWindowChatMemory,retriever",
+		"auth_surfaces":   "SEARCH_API_KEY",
+	} {
+		if got := evidenceValue(findings[0], key); got != want {
+			t.Fatalf("expected %s=%q, got %q", key, want, got)
+		}
+	}
+}
+
+func TestParseJVMImportSummaryReadsJavaAndKotlinImports(t *testing.T) {
+	t.Parallel()
+
+	summary := parseJVMImportSummary(`package acme;
+
+import static org.springframework.ai.chat.client.ChatClient.create;
+import dev.langchain4j.service.*;
+import dev.langchain4j.agent.tool.Tool as LcTool
+`)
+	wantModules := []string{"dev.langchain4j.agent.tool.tool", "dev.langchain4j.service", "org.springframework.ai.chat.client.chatclient.create"}
+	if !reflect.DeepEqual(summary.Modules, wantModules) {
+		t.Fatalf("expected modules %v, got %v", wantModules, summary.Modules)
+	}
+	if !reflect.DeepEqual(summary.Names, []string{"Tool", "create"}) {
+		t.Fatalf("unexpected imported names %v", summary.Names)
+	}
+}
+
 func evidenceValue(finding model.Finding, key string) string {
 	target := strings.ToLower(strings.TrimSpace(key))
 	for _, evidence := range finding.Evidence {
@@ -413,3 +714,179 @@ func writeFile(t *testing.T, root, rel, content string) {
 		t.Fatalf("write %s: %v", rel, err)
 	}
 }
+
+func TestDetectMany_AgentFrameworkAndMCPClientInCSharp(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeFile(t, root, "src/Triage/Program.cs", `using Azure.AI.OpenAI;
+using Microsoft.Agents.AI;
+using ModelContextProtocol.Client;
+
+await using var mcpClient = await McpClientFactory.CreateAsync(new StdioClientTransport(new()
+{
+    Name = "GitHub",
+    Command = "npx",
+    Arguments = ["-y", "@modelcontextprotocol/server-github"],
+}));
+
+AIAgent triage = new AzureOpenAIClient(new Uri(endpoint), new AzureCliCredential())
+    .GetChatClient("gpt-4o-mini")
+    .CreateAIAgent(
+        instructions: "Triage incoming issues.",
+        name: "Triage", // routed from the webhook
+        tools: [AIFunctionFactory.Create(LabelIssue), .. tools]);
+`)
+
+	findings, err := DetectMany(detect.Scope{Org: "acme", Repo: "triage", Root: root}, []DetectorConfig{
+		{DetectorID: "agentsemantickernel", Framework: "semantic_kernel", ConfigPath: ".wrkr/agents/semantic-kernel.yaml", Format: "yaml"},
+		{DetectorID: "agentmcpclient", Framework: "mcp_client", ConfigPath: ".wrkr/agents/mcp-client.yaml", Format: "yaml"},
+	})
+	if err != nil {
+		t.Fatalf("detect many: %v", err)
+	}
+	byFramework := map[string]model.Finding{}
+	for _, finding := range findings {
+		byFramework[finding.ToolType] = finding
+	}
+	if len(findings) != 2 {
+		t.Fatalf("expected agent and MCP client findings, got %+v", findings)
+	}
+	for key, want := range map[string]string{
+		"symbol":          "Triage",
+		"source_call":     "CreateAIAgent",
+		"bound_tools":     "LabelIssue,tools",
+		"model_providers": "azure_openai",
+	} {
+		if got := evidenceValue(byFramework["semantic_kernel"], key); got != want {
+			t.Fatalf("expected %s=%q, got %q", key, want, got)
+		}
+	}
+	for key, want := range map[string]string{
+		"symbol":      "GitHub",
+		"source_call": "McpClientFactory.CreateAsync",
+	} {
+		if got := evidenceValue(byFramework["mcp_client"], key); got != want {
+			t.Fatalf("expected %s=%q, got %q", key, want, got)
+		}
+	}
+}
+
+func TestDotnetSpecLocationReadsLiteralsUrisAndPathCombine(t *testing.T) {
+	t.Parallel()
+
+	for expr, want := range map[string]string{
+		`"Plugins/github.yaml"`:                                                "Plugins/github.yaml",
+		`new Uri("https://api.example.com/openapi.json")`:                      "https://api.example.com/openapi.json",
+		`Path.Combine(AppContext.BaseDirectory, "Plugins", "github.yaml")`:     "Plugins/github.yaml",
+		`Path.Combine(Directory.GetCurrentDirectory(), @"specs", "jira.json")`: "specs/jira.json",
+		`specPath`: "",
+	} {
+		if got := dotnetSpecLocation(expr); got != want {
+			t.Fatalf("dotnetSpecLocation(%s) = %q, want %q", expr, got, want)
+		}
+	}
+}
+
+func TestParseDotnetImportSummaryReadsUsingDirectives(t *testing.T) {
+	t.Parallel()
+
+	summary := parseDotnetImportSummary(`global using Microsoft.SemanticKernel;
+using static System.Console;
+using SK = Microsoft.SemanticKernel.Agents;
+namespace Acme.Support
+{
+    using ModelContextProtocol.Client;
+    using var stream = File.OpenRead(path);
+}
+`)
+	wantModules := []string{"microsoft.semantickernel", "microsoft.semantickernel.agents", "modelcontextprotocol.client", "system.console"}
+	if !reflect.DeepEqual(summary.Modules, wantModules) {
+		t.Fatalf("expected modules %v, got %v", wantModules, summary.Modules)
+	}
+}
+
+func TestMatchesSourceImportsKeepsOpenAIAgentsMarkerExact(t *testing.T) {
+	t.Parallel()
+
+	profile, ok := sourceProfileForFramework("openai_agents")
+	if !ok {
+		t.Fatal("expected openai_agents source profile")
+	}
+	for _, tc := range []struct {
+		source string
+		want   bool
+	}{
+		{source: "from agents import Agent, Runner\n", want: true},
+		{source: "from agents.extensions.models import Agent\n", want: true},
+		{source: "from google.adk.agents import Agent\n", want: false},
+		{source: "from smolagents import Agent\n", want: false},
+	} {
+		if got := matchesSourceImports("python", parseImportSummary("python", tc.source), profile); got != tc.want {
+			t.Fatalf("expected %q match=%t, got %t", tc.source, tc.want, got)
+		}
+	}
+}
+
+func TestPythonAssignedExpressionSkipsKeywordArguments(t *testing.T) {
+	t.Parallel()
+
+	content := `agent = Agent(
+    tools=tools,
+)
+tools: list[Tool] = [
+    search,
+    fetch,
+]
+`
+	if got := pythonAssignedExpression(content, "tools"); got != "[\n    search,\n    fetch,\n]" {
+		t.Fatalf("expected multi-line list assignment, got %q", got)
+	}
+	if got := pythonAssignedExpression(content, "missing"); got != "" {
+		t.Fatalf("expected no assignment, got %q", got)
+	}
+}
+
+func TestDetectMany_LangGraphImportsStayOutOfLangChain(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeFile(t, root, "agents/researcher.ts", `import { ChatOpenAI } from "@langchain/openai";
+import { createReactAgent } from "@langchain/langgraph/prebuilt";
+
+export const researcher = createReactAgent({ llm: new ChatOpenAI({ model: "gpt-4o" }), tools: [searchTool] });
+`)
+
+	findings, err := DetectMany(detect.Scope{Org: "acme", Repo: "support", Root: root}, []DetectorConfig{
+		{DetectorID: "agentlangchain", Framework: "langchain", ConfigPath: ".wrkr/agents/langchain.yaml", Format: "yaml"},
+		{DetectorID: "agentlanggraph", Framework: "langgraph", ConfigPath: ".wrkr/agents/langgraph.yaml", Format: "yaml"},
+	})
+	if err != nil {
+		t.Fatalf("detect many: %v", err)
+	}
+	if len(findings) != 1 || findings[0].ToolType != "langgraph" {
+		t.Fatalf("expected a single langgraph finding, got %+v", findings)
+	}
+}
+
+func TestMatchesSourceImportsKeepsVercelAIMarkerExact(t *testing.T) {
+	t.Parallel()
+
+	profile, ok := sourceProfileForFramework("vercel_ai")
+	if !ok {
+		t.Fatal("expected vercel_ai source profile")
+	}
+	for _, tc := range []struct {
+		source string
+		want   bool
+	}{
+		{source: `import { generateText } from "ai";`, want: true},
+		{source: `import { streamUI } from "ai/rsc";`, want: true},
+		{source: `import { openai } from "@ai-sdk/openai";`, want: false},
+		{source: `import { generateText } from "ai-toolkit";`, want: false},
+	} {
+		if got := matchesSourceImports("javascript", parseImportSummary("javascript", tc.source), profile); got != tc.want {
+			t.Fatalf("expected %q match=%t, got %t", tc.source, tc.want, got)
+		}
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/Clyra-AI/wrkr/core/detect"
 	"github.com/Clyra-AI/wrkr/core/model"
+	"github.com/Clyra-AI/wrkr/core/risk/autonomy"
 )
 
 type sourcePlan struct {
@@ -17,6 +18,11 @@ type sourcePlan struct {
 	Profile       sourceProfile
 	pythonPattern *regexp.Regexp
 	jsPattern     *regexp.Regexp
+	// nativePatterns holds the assignment pattern for each native source
+	// family that has call names in the profile.
+	nativePatterns map[string]*regexp.Regexp
+	// dotnetTypedPattern matches C# target-typed `new()` construction.
+	dotnetTypedPattern *regexp.Regexp
 }
 
 type sourceProfile struct {
@@ -28,11 +34,43 @@ type sourceProfile struct {
 	dataKeys             []string
 	authKeys             []string
 	deploymentKeys       []string
+	// Go, JVM, and .NET imports are package paths or namespaces and calls
+	// are qualified (`pkg.Func`, `Type.builder`), so those source families
+	// match on their own markers and call names, keyed by sourceFamily.
+	nativeImportMarkers map[string][]string
+	nativeCallNames     map[string][]string
+	// exactImportMarkers match a module only as a whole dotted path or its
+	// prefix, for short package names such as `agents` that other
+	// frameworks use as submodules (`google.adk.agents`, `smolagents`).
+	exactImportMarkers []string
+	// modelKeys name the arguments that carry a model or generator, whose
+	// construction holds the credentials and provider for the agent.
+	modelKeys []string
+	// contextCalls are module-level calls that configure every agent in the
+	// file, such as `dspy.configure(lm=...)`.
+	contextCalls []string
+	// codeExecutionCalls construct agents that write and run code rather
+	// than calling declared tools.
+	codeExecutionCalls []string
+	// excludedImportMarkers name modules that belong to another profile even
+	// though they match this one's markers, such as `@langchain/langgraph`
+	// under the `@langchain` scope. Calls imported from them are skipped.
+	excludedImportMarkers []string
+	// stepLimitKeys bound how many model or tool steps an agent run may take.
+	stepLimitKeys []string
+	// humanGateKeys and humanGateCalls pause a run for a human decision:
+	// `needsApproval` on a tool, `interruptBefore` on a graph, or a call to
+	// `interrupt()` inside a node.
+	humanGateKeys  []string
+	humanGateCalls []string
 }
 
 type importSummary struct {
 	Modules []string
 	Names   []string
+	// Sources maps an imported name to the module it came from, for Python
+	// and JS/TS named imports.
+	Sources map[string]string
 }
 
 var (
@@ -45,7 +83,8 @@ var (
 	jsRequirePattern        = regexp.MustCompile(`require\(\s*['"]([^'"]+)['"]\s*\)`)
 	processEnvPattern       = regexp.MustCompile(`process\.env\.([A-Z][A-Z0-9_]+)`)
 	osGetEnvPattern         = regexp.MustCompile(`(?:os\.getenv|env\.get)\(\s*["']([A-Z][A-Z0-9_]+)["']\s*\)`)
-	osEnvironPattern        = regexp.MustCompile(`os\.environ\[\s*["']([A-Z][A-Z0-9_]+)["']\s*\]`)
+	osEnvironPattern        = regexp.MustCompile(`os\.environ\[\s*["']([A-Z][A-Z0-9_]+)["']`)
+	goGetenvPattern         = regexp.MustCompile(`os\.(?:Getenv|LookupEnv)\(\s*"([A-Z][A-Z0-9_]+)"\s*\)`)
 	genericEnvPattern       = regexp.MustCompile(`\b(?:getenv|env)\(\s*["']([A-Z][A-Z0-9_]+)["']\s*\)`)
 	urlPattern              = regexp.MustCompile(`https?://[A-Za-z0-9._:/?#=&-]+`)
 )
@@ -62,11 +101,13 @@ func buildSourcePlans(configs []DetectorConfig) []sourcePlan {
 			continue
 		}
 		unique[key] = sourcePlan{
-			DetectorID:    strings.TrimSpace(cfg.DetectorID),
-			Framework:     strings.TrimSpace(cfg.Framework),
-			Profile:       profile,
-			pythonPattern: buildSourceAssignmentPattern("python", profile.callNames),
-			jsPattern:     buildSourceAssignmentPattern("javascript", profile.callNames),
+			DetectorID:         strings.TrimSpace(cfg.DetectorID),
+			Framework:          strings.TrimSpace(cfg.Framework),
+			Profile:            profile,
+			pythonPattern:      buildSourceAssignmentPattern("python", profile.callNames),
+			jsPattern:          buildSourceAssignmentPattern("javascript", profile.callNames),
+			nativePatterns:     buildNativePatterns(profile.nativeCallNames),
+			dotnetTypedPattern: buildDotnetTypedPattern(profile.nativeCallNames["dotnet"]),
 		}
 	}
 
@@ -96,6 +137,8 @@ func detectFromSource(scope detect.Scope, plans []sourcePlan, options detect.Opt
 		return nil, err
 	}
 
+	toolIndex := newAnnotatedToolIndex(scope.Root, planDetectorID(plans), files)
+	specIndex := newOpenAPISpecIndex(scope.Root, files)
 	findings := make([]model.Finding, 0)
 	for _, rel := range files {
 		language := sourceLanguage(rel)
@@ -114,10 +157,10 @@ func detectFromSource(scope detect.Scope, plans []sourcePlan, options detect.Opt
 		}
 
 		for _, plan := range plans {
-			if !matchesSourceImports(imports, plan.Profile) {
+			if !matchesSourceImports(language, imports, plan.Profile) {
 				continue
 			}
-			findings = append(findings, detectSourceAgents(scope, rel, content, language, plan)...)
+			findings = append(findings, detectSourceAgents(scope, rel, content, language, imports, plan, toolIndex, specIndex)...)
 		}
 	}
 
@@ -135,13 +178,22 @@ func sourceProfileForFramework(framework string) (sourceProfile, bool) {
 	switch strings.ToLower(strings.TrimSpace(framework)) {
 	case "langchain":
 		return sourceProfile{
-			importMarkers:  []string{"langchain", "@langchain"},
-			callNames:      []string{"initializeAgentExecutorWithOptions", "create_openai_functions_agent", "create_openai_tools_agent", "create_react_agent", "createToolCallingAgent", "createReactAgent", "initialize_agent", "AgentExecutor", "StructuredChatAgent"},
-			nameKeys:       []string{"name", "agent_name", "agentName", "id"},
-			toolKeys:       []string{"tools"},
-			dataKeys:       []string{"data_sources", "dataSources", "retriever", "retrievers", "knowledge_base", "knowledgeBase", "vector_store", "vectorStore", "memory", "datasets"},
-			authKeys:       []string{"auth_surfaces", "authSurfaces", "auth", "credentials", "credential", "api_key", "apiKey", "token", "headers"},
-			deploymentKeys: []string{"deployment_artifacts", "deploymentArtifacts", "entrypoint", "entryPoint", "workflow", "dockerfile", "manifest"},
+			importMarkers:         []string{"langchain", "@langchain"},
+			excludedImportMarkers: []string{"langgraph"},
+			callNames:             []string{"initializeAgentExecutorWithOptions", "create_openai_functions_agent", "create_openai_tools_agent", "create_react_agent", "createToolCallingAgent", "createReactAgent", "initialize_agent", "AgentExecutor", "StructuredChatAgent"},
+			nameKeys:              []string{"name", "agent_name", "agentName", "id"},
+			toolKeys:              []string{"tools", "toolProvider"},
+			dataKeys:              []string{"data_sources", "dataSources", "retriever", "retrievers", "knowledge_base", "knowledgeBase", "vector_store", "vectorStore", "memory", "datasets", "contentRetriever", "retrievalAugmentor", "chatMemory", "chatMemoryProvider"},
+			authKeys:              []string{"auth_surfaces", "authSurfaces", "auth", "credentials", "credential", "api_key", "apiKey", "token", "headers"},
+			deploymentKeys:        []string{"deployment_artifacts", "deploymentArtifacts", "entrypoint", "entryPoint", "workflow", "dockerfile", "manifest"},
+			nativeImportMarkers: map[string][]string{
+				"go":  {"github.com/tmc/langchaingo"},
+				"jvm": {"dev.langchain4j"},
+			},
+			nativeCallNames: map[string][]string{
+				"go":  {"agents.NewExecutor", "agents.NewOneShotAgent", "agents.NewConversationalAgent", "agents.NewOpenAIFunctionsAgent", "agents.Initialize"},
+				"jvm": {"AiServices.builder", "AiServices.create"},
+			},
 		}, true
 	case "crewai":
 		return sourceProfile{
@@ -156,7 +208,8 @@ func sourceProfileForFramework(framework string) (sourceProfile, bool) {
 		}, true
 	case "openai_agents":
 		return sourceProfile{
-			importMarkers:        []string{"@openai/agents", "agents"},
+			importMarkers:        []string{"@openai/agents"},
+			exactImportMarkers:   []string{"agents"},
 			requiredImportedName: []string{"Agent"},
 			callNames:            []string{"Agent"},
 			nameKeys:             []string{"name", "agent_name", "agentName", "id"},
@@ -191,17 +244,177 @@ func sourceProfileForFramework(framework string) (sourceProfile, bool) {
 			importMarkers:        []string{"@modelcontextprotocol/sdk", "modelcontextprotocol", "mcp"},
 			requiredImportedName: []string{"Client", "ClientSession", "MCPClient"},
 			callNames:            []string{"ClientSession", "MCPClient", "Client"},
-			nameKeys:             []string{"name", "client_name", "clientName", "id"},
+			nameKeys:             []string{"name", "client_name", "clientName", "id", "serverInfo", "clientInfo"},
 			toolKeys:             []string{"servers", "mcp_servers", "mcpServers", "tools"},
 			dataKeys:             []string{"resources", "data_sources", "dataSources"},
 			authKeys:             []string{"auth_surfaces", "authSurfaces", "auth", "credentials", "credential", "api_key", "apiKey", "token", "headers"},
 			deploymentKeys:       []string{"deployment_artifacts", "deploymentArtifacts", "entrypoint", "entryPoint", "transport", "workflow"},
+			nativeImportMarkers: map[string][]string{
+				"go":     {"github.com/modelcontextprotocol/go-sdk", "github.com/mark3labs/mcp-go"},
+				"jvm":    {"io.modelcontextprotocol"},
+				"dotnet": {"modelcontextprotocol"},
+			},
+			nativeCallNames: map[string][]string{
+				"go":     {"mcp.NewClient", "mcp.NewServer", "client.NewClient", "client.NewStdioMCPClient", "client.NewSSEMCPClient", "client.NewStreamableHttpClient", "server.NewMCPServer"},
+				"jvm":    {"McpClient.sync", "McpClient.async", "McpServer.sync", "McpServer.async"},
+				"dotnet": {"McpClientFactory.CreateAsync", "McpClient.CreateAsync"},
+			},
+		}, true
+	case "spring_ai":
+		return sourceProfile{
+			nameKeys:            []string{"name"},
+			toolKeys:            []string{"defaultTools", "tools", "defaultToolCallbacks", "toolCallbacks", "defaultToolNames", "toolNames"},
+			dataKeys:            []string{"defaultAdvisors", "advisors", "vectorStore", "chatMemory"},
+			authKeys:            []string{"auth_surfaces", "authSurfaces", "apiKey", "credentials", "token"},
+			deploymentKeys:      []string{"deployment_artifacts", "deploymentArtifacts"},
+			nativeImportMarkers: map[string][]string{"jvm": {"org.springframework.ai"}},
+			nativeCallNames:     map[string][]string{"jvm": {"ChatClient.builder", "ChatClient.create"}},
+		}, true
+	case "semantic_kernel":
+		// Microsoft Agent Framework (`Microsoft.Agents.AI`) is the successor
+		// to Semantic Kernel agents and reports under the same framework.
+		return sourceProfile{
+			nameKeys:            []string{"name"},
+			toolKeys:            []string{"tools"},
+			dataKeys:            []string{"memory", "vectorStore", "textSearch", "historyReducer"},
+			authKeys:            []string{"apiKey", "credential", "credentials", "token"},
+			deploymentKeys:      []string{"deployment_artifacts", "deploymentArtifacts"},
+			nativeImportMarkers: map[string][]string{"dotnet": {"microsoft.semantickernel", "microsoft.agents.ai"}},
+			nativeCallNames:     map[string][]string{"dotnet": {"Kernel.CreateBuilder", "ChatCompletionAgent", "ChatClientAgent", "CreateAIAgent", "AsAIAgent"}},
+		}, true
+	case "google_adk":
+		return sourceProfile{
+			importMarkers:       []string{"google.adk"},
+			callNames:           []string{"LlmAgent", "Agent", "SequentialAgent", "ParallelAgent", "LoopAgent"},
+			nameKeys:            []string{"name", "agent_name", "agentName"},
+			toolKeys:            []string{"tools", "toolsets", "sub_agents", "subAgents"},
+			dataKeys:            []string{"data_sources", "dataSources", "memory", "memory_service", "memoryService", "artifact_service", "artifactService"},
+			authKeys:            []string{"auth_surfaces", "authSurfaces", "auth", "credentials", "credential", "api_key", "apiKey", "token", "headers"},
+			deploymentKeys:      []string{"deployment_artifacts", "deploymentArtifacts", "entrypoint", "entryPoint", "workflow", "dockerfile", "manifest"},
+			nativeImportMarkers: map[string][]string{"go": {"google.golang.org/adk"}},
+			nativeCallNames:     map[string][]string{"go": {"llmagent.New", "sequentialagent.New", "parallelagent.New", "loopagent.New"}},
+			modelKeys:           []string{"model"},
+		}, true
+	case "pydantic_ai":
+		return sourceProfile{
+			importMarkers:  []string{"pydantic_ai"},
+			callNames:      []string{"Agent"},
+			nameKeys:       []string{"name"},
+			toolKeys:       []string{"tools", "toolsets"},
+			dataKeys:       []string{"data_sources", "dataSources", "deps_type", "memory", "retriever", "retrievers"},
+			authKeys:       []string{"auth_surfaces", "authSurfaces", "auth", "credentials", "credential", "api_key", "apiKey", "token", "headers"},
+			deploymentKeys: []string{"deployment_artifacts", "deploymentArtifacts", "entrypoint", "entryPoint", "workflow", "dockerfile", "manifest"},
+			modelKeys:      []string{"model"},
+		}, true
+	case "smolagents":
+		return sourceProfile{
+			importMarkers:      []string{"smolagents"},
+			callNames:          []string{"CodeAgent", "ToolCallingAgent"},
+			nameKeys:           []string{"name"},
+			toolKeys:           []string{"tools", "managed_agents"},
+			dataKeys:           []string{"data_sources", "dataSources", "memory", "retriever", "retrievers", "knowledge_base"},
+			authKeys:           []string{"auth_surfaces", "authSurfaces", "auth", "credentials", "credential", "api_key", "apiKey", "token", "headers"},
+			deploymentKeys:     []string{"deployment_artifacts", "deploymentArtifacts", "entrypoint", "entryPoint", "workflow", "dockerfile", "manifest"},
+			modelKeys:          []string{"model"},
+			codeExecutionCalls: []string{"CodeAgent"},
+			stepLimitKeys:      []string{"max_steps"},
+		}, true
+	case "dspy":
+		return sourceProfile{
+			importMarkers:      []string{"dspy"},
+			callNames:          []string{"ReAct", "CodeAct"},
+			nameKeys:           []string{"name"},
+			toolKeys:           []string{"tools"},
+			dataKeys:           []string{"data_sources", "dataSources", "rm", "retriever", "retrievers"},
+			authKeys:           []string{"auth_surfaces", "authSurfaces", "auth", "credentials", "credential", "api_key", "apiKey", "token", "headers"},
+			deploymentKeys:     []string{"deployment_artifacts", "deploymentArtifacts", "entrypoint", "entryPoint", "workflow", "dockerfile", "manifest"},
+			modelKeys:          []string{"lm"},
+			contextCalls:       []string{"dspy.configure", "dspy.settings.configure", "dspy.LM"},
+			codeExecutionCalls: []string{"CodeAct"},
+		}, true
+	case "haystack":
+		return sourceProfile{
+			importMarkers:  []string{"haystack"},
+			callNames:      []string{"Agent", "Pipeline", "AsyncPipeline"},
+			nameKeys:       []string{"name"},
+			toolKeys:       []string{"tools"},
+			dataKeys:       []string{"data_sources", "dataSources", "document_store", "retriever", "retrievers", "memory"},
+			authKeys:       []string{"auth_surfaces", "authSurfaces", "auth", "credentials", "credential", "api_key", "apiKey", "token", "headers"},
+			deploymentKeys: []string{"deployment_artifacts", "deploymentArtifacts", "entrypoint", "entryPoint", "workflow", "dockerfile", "manifest"},
+			modelKeys:      []string{"chat_generator", "generator"},
+		}, true
+	case "strands":
+		return sourceProfile{
+			importMarkers:  []string{"strands"},
+			callNames:      []string{"Agent"},
+			nameKeys:       []string{"name", "agent_id"},
+			toolKeys:       []string{"tools"},
+			dataKeys:       []string{"data_sources", "dataSources", "session_manager", "memory", "retriever", "retrievers"},
+			authKeys:       []string{"auth_surfaces", "authSurfaces", "auth", "credentials", "credential", "api_key", "apiKey", "token", "headers"},
+			deploymentKeys: []string{"deployment_artifacts", "deploymentArtifacts", "entrypoint", "entryPoint", "workflow", "dockerfile", "manifest"},
+			modelKeys:      []string{"model"},
+		}, true
+	case "vercel_ai":
+		// `generateText` and `streamText` only run an agent loop when they
+		// are given tools; jsAgentSpec drops calls without them.
+		return sourceProfile{
+			exactImportMarkers: []string{"ai"},
+			callNames:          []string{"generateText", "streamText", "Experimental_Agent", "Agent", "experimental_createMCPClient"},
+			toolKeys:           []string{"tools"},
+			dataKeys:           []string{"data_sources", "dataSources", "memory", "retriever", "retrievers"},
+			authKeys:           []string{"auth_surfaces", "authSurfaces", "auth", "credentials", "credential", "apiKey", "token", "headers"},
+			deploymentKeys:     []string{"deployment_artifacts", "deploymentArtifacts", "entrypoint", "entryPoint", "transport", "workflow"},
+			modelKeys:          []string{"model"},
+			stepLimitKeys:      []string{"maxSteps", "stopWhen"},
+			humanGateKeys:      []string{"needsApproval"},
+		}, true
+	case "mastra":
+		return sourceProfile{
+			importMarkers:        []string{"@mastra"},
+			requiredImportedName: []string{"Agent"},
+			callNames:            []string{"Agent"},
+			nameKeys:             []string{"name"},
+			toolKeys:             []string{"tools", "workflows", "agents"},
+			dataKeys:             []string{"data_sources", "dataSources", "memory", "vector", "vectorStore", "retriever", "retrievers"},
+			authKeys:             []string{"auth_surfaces", "authSurfaces", "auth", "credentials", "credential", "apiKey", "token", "headers"},
+			deploymentKeys:       []string{"deployment_artifacts", "deploymentArtifacts", "entrypoint", "entryPoint", "workflow"},
+			modelKeys:            []string{"model"},
+			stepLimitKeys:        []string{"maxSteps", "stopWhen"},
+			humanGateKeys:        []string{"needsApproval"},
+			humanGateCalls:       []string{"suspend"},
+		}, true
+	case "langgraph":
+		return sourceProfile{
+			importMarkers:  []string{"langgraph"},
+			callNames:      []string{"StateGraph", "createReactAgent", "create_react_agent"},
+			nameKeys:       []string{"name"},
+			toolKeys:       []string{"tools"},
+			dataKeys:       []string{"data_sources", "dataSources", "checkpointer", "checkpointSaver", "store", "memory", "retriever", "retrievers"},
+			authKeys:       []string{"auth_surfaces", "authSurfaces", "auth", "credentials", "credential", "api_key", "apiKey", "token", "headers"},
+			deploymentKeys: []string{"deployment_artifacts", "deploymentArtifacts", "entrypoint", "entryPoint", "workflow", "dockerfile", "manifest"},
+			modelKeys:      []string{"llm", "model"},
+			stepLimitKeys:  []string{"recursionLimit", "recursion_limit"},
+			humanGateKeys:  []string{"interruptBefore", "interruptAfter", "interrupt_before", "interrupt_after"},
+			humanGateCalls: []string{"interrupt"},
 		}, true
 	default:
 		return sourceProfile{}, false
 	}
 }
 
+func buildNativePatterns(callNames map[string][]string) map[string]*regexp.Regexp {
+	if len(callNames) == 0 {
+		return nil
+	}
+	patterns := make(map[string]*regexp.Regexp, len(callNames))
+	for family, names := range callNames {
+		if pattern := buildSourceAssignmentPattern(family, names); pattern != nil {
+			patterns[family] = pattern
+		}
+	}
+	return patterns
+}
+
 func buildSourceAssignmentPattern(language string, callNames []string) *regexp.Regexp {
 	escaped := make([]string, 0, len(callNames))
 	sorted := append([]string(nil), callNames...)
@@ -216,8 +429,22 @@ func buildSourceAssignmentPattern(language string, callNames []string) *regexp.R
 	switch language {
 	case "python":
 		return regexp.MustCompile(`^\s*(?:[A-Za-z_][A-Za-z0-9_]*\.)*([A-Za-z_][A-Za-z0-9_]*)\s*=\s*(?:await\s+)?(?:[A-Za-z_][A-Za-z0-9_]*\.)?(` + alt + `)\s*\(`)
+	case "jvm":
+		// Java declarations and field assignments, Kotlin `val`/`var` with an
+		// optional type, and `return` from a bean factory method.
+		return regexp.MustCompile(`^\s*(?:return\s+|(?:(?:private|protected|public|static|final|override|lateinit|internal)\s+)*(?:(?:val|var)\s+|[A-Za-z_][A-Za-z0-9_<>\[\]?.]*\s+)?(?:this\.)?([A-Za-z_][A-Za-z0-9_]*)\s*(?::\s*[A-Za-z_][A-Za-z0-9_<>?.]*\s*)?=\s*)(?:new\s+)?(` + alt + `)\s*\(`)
+	case "dotnet":
+		// `var x =`, typed locals and fields, and `await using var x =`. The
+		// call may hang off a receiver chain, as Agent Framework's
+		// `chatClient.CreateAIAgent(...)` does.
+		return regexp.MustCompile(`^\s*(?:(?:public|private|protected|internal|static|readonly)\s+)*(?:await\s+)?(?:using\s+)?(?:var\s+|[A-Za-z_][A-Za-z0-9_<>\[\]?.]*\s+)?([A-Za-z_][A-Za-z0-9_]*)\s*=\s*(?:await\s+)?(?:new\s+)?(?:[^;=]*?\.)?(` + alt + `)\s*[({]`)
+	case "go":
+		return regexp.MustCompile(`^\s*(?:var\s+)?([A-Za-z_][A-Za-z0-9_]*)(?:\s*,\s*[A-Za-z_][A-Za-z0-9_]*)*\s*:?=\s*&?(` + alt + `)\s*\(`)
 	default:
-		return regexp.MustCompile(`^\s*(?:export\s+)?(?:const|let|var)\s+([A-Za-z_$][A-Za-z0-9_$]*)\s*=\s*(?:await\s+)?(?:new\s+)?(?:[A-Za-z_$][A-Za-z0-9_$]*\.)?(` + alt + `)\s*\(`)
+		// Declarations, including typed and destructured ones, plus calls
+		// that are returned or awaited without an assignment, as route
+		// handlers do with `return streamText(...)`.
+		return regexp.MustCompile(`^\s*(?:(?:export\s+)?(?:const|let|var)\s+(?:([A-Za-z_$][A-Za-z0-9_$]*)|\{[^}]*\}|\[[^\]]*\])\s*(?::[^=]+)?=\s*|return\s+)?(?:await\s+)?(?:new\s+)?(?:[A-Za-z_$][A-Za-z0-9_$]*\.)?(` + alt + `)\s*\(`)
 	}
 }
 
@@ -227,6 +454,29 @@ func sourceLanguage(rel string) string {
 		return "python"
 	case ".js", ".jsx", ".ts", ".tsx", ".mjs", ".cjs", ".mts", ".cts":
 		return "javascript"
+	case ".go":
+		return "go"
+	case ".java":
+		return "java"
+	case ".kt":
+		return "kotlin"
+	case ".cs":
+		return "csharp"
+	default:
+		return ""
+	}
+}
+
+// sourceFamily groups languages that share import and call conventions.
+// Python and JS/TS use the profile's generic markers and return "".
+func sourceFamily(language string) string {
+	switch language {
+	case "go":
+		return "go"
+	case "java", "kotlin":
+		return "jvm"
+	case "csharp":
+		return "dotnet"
 	default:
 		return ""
 	}
@@ -237,7 +487,7 @@ func shouldSkipSourceFile(rel string) bool {
 	if lower == "" {
 		return true
 	}
-	if detect.IsGeneratedPath(lower) {
+	if detect.IsGeneratedPath(lower) || strings.HasSuffix(lower, "_test.go") {
 		return true
 	}
 	for _, prefix := range []string{
@@ -259,8 +509,17 @@ func shouldSkipSourceFile(rel string) bool {
 }
 
 func parseImportSummary(language, content string) importSummary {
+	switch sourceFamily(language) {
+	case "go":
+		return parseGoImportSummary(content)
+	case "jvm":
+		return parseJVMImportSummary(content)
+	case "dotnet":
+		return parseDotnetImportSummary(content)
+	}
 	moduleSet := map[string]struct{}{}
 	nameSet := map[string]struct{}{}
+	sources := map[string]string{}
 	lines := strings.Split(content, "\n")
 	for _, line := range lines {
 		trimmed := strings.TrimSpace(line)
@@ -288,6 +547,7 @@ func parseImportSummary(language, content string) importSummary {
 				}
 				for _, item := range splitImportNames(match[2]) {
 					nameSet[item] = struct{}{}
+					sources[item] = module
 				}
 			}
 		default:
@@ -295,9 +555,11 @@ func parseImportSummary(language, content string) importSummary {
 				continue
 			}
 			if match := jsFromImportPattern.FindStringSubmatch(trimmed); len(match) == 3 {
-				moduleSet[strings.ToLower(strings.TrimSpace(match[2]))] = struct{}{}
+				module := strings.ToLower(strings.TrimSpace(match[2]))
+				moduleSet[module] = struct{}{}
 				for _, item := range splitJSImportNames(match[1]) {
 					nameSet[item] = struct{}{}
+					sources[item] = module
 				}
 				continue
 			}
@@ -322,6 +584,7 @@ func parseImportSummary(language, content string) importSummary {
 	return importSummary{
 		Modules: sortedKeys(moduleSet),
 		Names:   sortedKeys(nameSet),
+		Sources: sources,
 	}
 }
 
@@ -383,15 +646,32 @@ func splitJSImportNames(raw string) []string {
 	return out
 }
 
-func matchesSourceImports(imports importSummary, profile sourceProfile) bool {
+func matchesSourceImports(language string, imports importSummary, profile sourceProfile) bool {
+	family := sourceFamily(language)
+	markers := profile.importMarkers
+	if family != "" {
+		markers = profile.nativeImportMarkers[family]
+	}
 	moduleMatched := false
 	for _, module := range imports.Modules {
-		for _, marker := range profile.importMarkers {
+		if family == "" && excludedImportModule(module, profile) {
+			continue
+		}
+		for _, marker := range markers {
 			if strings.Contains(module, strings.ToLower(strings.TrimSpace(marker))) {
 				moduleMatched = true
 				break
 			}
 		}
+		if family == "" {
+			for _, marker := range profile.exactImportMarkers {
+				marker = strings.ToLower(strings.TrimSpace(marker))
+				if module == marker || strings.HasPrefix(module, marker+".") || strings.HasPrefix(module, marker+"/") {
+					moduleMatched = true
+					break
+				}
+			}
+		}
 		if moduleMatched {
 			break
 		}
@@ -399,7 +679,7 @@ func matchesSourceImports(imports importSummary, profile sourceProfile) bool {
 	if !moduleMatched {
 		return false
 	}
-	if len(profile.requiredImportedName) == 0 {
+	if len(profile.requiredImportedName) == 0 || family != "" {
 		return true
 	}
 	for _, name := range imports.Names {
@@ -412,17 +692,36 @@ func matchesSourceImports(imports importSummary, profile sourceProfile) bool {
 	return false
 }
 
-func detectSourceAgents(scope detect.Scope, rel, content, language string, plan sourcePlan) []model.Finding {
+func excludedImportModule(module string, profile sourceProfile) bool {
+	for _, marker := range profile.excludedImportMarkers {
+		if strings.Contains(module, strings.ToLower(strings.TrimSpace(marker))) {
+			return true
+		}
+	}
+	return false
+}
+
+func detectSourceAgents(scope detect.Scope, rel, content, language string, imports importSummary, plan sourcePlan, toolIndex *annotatedToolIndex, specIndex *openAPISpecIndex) []model.Finding {
 	lines := strings.Split(content, "\n")
 	findings := make([]model.Finding, 0)
+	family := sourceFamily(language)
 	for idx, line := range lines {
 		var match []int
-		switch language {
-		case "python":
+		callName := ""
+		switch {
+		case language == "python":
 			if plan.pythonPattern == nil {
 				continue
 			}
 			match = plan.pythonPattern.FindStringSubmatchIndex(line)
+		case family == "dotnet":
+			match, callName = matchDotnetAssignment(plan, lines, idx)
+		case family != "":
+			pattern := plan.nativePatterns[family]
+			if pattern == nil {
+				continue
+			}
+			match = pattern.FindStringSubmatchIndex(line)
 		default:
 			if plan.jsPattern == nil {
 				continue
@@ -433,14 +732,67 @@ func detectSourceAgents(scope detect.Scope, rel, content, language string, plan
 			continue
 		}
 
-		variableName := strings.TrimSpace(line[match[2]:match[3]])
-		callName := strings.TrimSpace(line[match[4]:match[5]])
-		block, endLine := captureInvocation(lines, idx, match[4])
+		variableName := ""
+		if match[2] >= 0 {
+			variableName = strings.TrimSpace(line[match[2]:match[3]])
+		}
+		if callName == "" {
+			callName = line[match[4]:match[5]]
+		}
+		callName = strings.TrimSpace(callName)
+		if module, ok := imports.Sources[callName]; ok && excludedImportModule(module, plan.Profile) {
+			continue
+		}
+		var block string
+		var endLine int
+		switch family {
+		case "jvm":
+			block, endLine = captureJVMChain(lines, idx, match[4])
+			if variableName == "" {
+				variableName = enclosingJVMMethod(lines, idx)
+			}
+		case "dotnet":
+			// Start at the assignment so the receiver chain and a
+			// target-typed initializer are part of the block.
+			block, endLine = captureDotnetStatement(lines, idx, match[3])
+		default:
+			block, endLine = captureInvocation(lines, idx, match[4])
+			if language != "python" {
+				block, endLine = captureJSChain(lines, block, endLine)
+				if variableName == "" {
+					variableName = enclosingJSFunction(lines, idx)
+				}
+			}
+		}
 		if strings.TrimSpace(block) == "" {
 			continue
 		}
 
 		agent := sourceAgentSpec(rel, content, block, variableName, callName, idx+1, endLine, plan.Profile)
+		if language == "python" {
+			var ok bool
+			agent, block, ok = pythonAgentSpec(agent, content, block, variableName, callName, plan.Profile)
+			if !ok {
+				continue
+			}
+		} else if family == "" {
+			var ok bool
+			agent, block, ok = jsAgentSpec(agent, content, block, variableName, callName, plan.Profile)
+			if !ok {
+				continue
+			}
+		}
+		switch family {
+		case "go":
+			agent = goAgentSpec(agent, content, block, variableName, callName)
+		case "jvm":
+			agent = jvmAgentSpec(agent, content, block, variableName, plan.Profile, toolIndex)
+		case "dotnet":
+			agent, block = dotnetAgentSpec(agent, rel, content, block, variableName, callName, plan.Profile, toolIndex, specIndex)
+		}
+		if !agent.HumanGate {
+			agent.HumanGate = hasHumanGateSignal(humanGateScope(content, block, variableName, language, agent.Tools), plan.Profile)
+		}
 		if strings.TrimSpace(agent.Name) == "" || strings.TrimSpace(agent.File) == "" {
 			continue
 		}
@@ -528,8 +880,8 @@ func sourceAgentSpec(rel, content, block, variableName, callName string, startLi
 	}
 
 	tools := extractNamedValues(block, profile.toolKeys)
-	if len(tools) == 0 && expectsPositionalTools(callName) {
-		tools = firstPositionalValues(block)
+	if index := positionalToolsIndex(callName); len(tools) == 0 && index >= 0 {
+		tools = positionalValues(block, index)
 	}
 
 	dataSources := extractNamedValues(block, profile.dataKeys)
@@ -561,6 +913,7 @@ func sourceAgentSpec(rel, content, block, variableName, callName string, startLi
 		KillSwitch:       extractNamedBool(block, []string{"kill_switch", "killSwitch"}),
 		AutoDeploy:       extractNamedBool(block, []string{"auto_deploy", "autoDeploy"}),
 		HumanGate:        extractNamedBool(block, []string{"human_gate", "humanGate", "approval_required", "approvalRequired"}),
+		StepLimit:        firstStepLimit(block, profile.stepLimitKeys),
 		DeploymentGate:   firstNamedString(block, []string{"deployment_gate", "deploymentGate"}),
 	}
 }
@@ -575,8 +928,9 @@ func sourceFinding(scope detect.Scope, plan sourcePlan, agent AgentSpec, languag
 	retrievers := retrieverHints(dataSources)
 	providers := providerHints(callName, block, strings.Join(append(append([]string(nil), dataSources...), authSurfaces...), ","), strings.Join(tools, ","))
 	workflowInvocations := workflowHints(callName, deployment, agent.DynamicDiscovery)
-	reachableEndpoints := extractURLs(strings.Join(append(append([]string(nil), authSurfaces...), deployment...), "\n"))
-	reachableTargets := targetHints(deployment)
+	localSpecs, remoteSpecs, relationships := apiSpecReach(agent)
+	reachableEndpoints := extractURLs(strings.Join(append(append(append([]string(nil), authSurfaces...), deployment...), remoteSpecs...), "\n"))
+	reachableTargets := targetHints(append(append([]string(nil), deployment...), localSpecs...))
 	confidence, evidenceStrength := sourceEvidenceStrength(agent, providers, retrievers, workflowInvocations, reachableEndpoints, reachableTargets)
 
 	evidence := []model.Evidence{
@@ -608,6 +962,15 @@ func sourceFinding(scope detect.Scope, plan sourcePlan, agent AgentSpec, languag
 		{Key: "reachable_endpoints", Value: strings.Join(reachableEndpoints, ",")},
 		{Key: "reachable_targets", Value: strings.Join(reachableTargets, ",")},
 	}
+	if specs := uniqueSorted(agent.APISpecs); len(specs) > 0 {
+		evidence = append(evidence, model.Evidence{Key: "api_specs", Value: strings.Join(specs, ",")})
+	}
+	if agent.CodeExecution {
+		evidence = append(evidence, model.Evidence{Key: "code_execution", Value: "true"})
+	}
+	if stepLimit := strings.TrimSpace(agent.StepLimit); stepLimit != "" {
+		evidence = append(evidence, model.Evidence{Key: "step_limit", Value: stepLimit})
+	}
 
 	severity := model.SeverityLow
 	if agent.AutoDeploy {
@@ -616,6 +979,10 @@ func sourceFinding(scope detect.Scope, plan sourcePlan, agent AgentSpec, languag
 	if agent.AutoDeploy && !agent.HumanGate {
 		severity = model.SeverityHigh
 	}
+	level := agentAutonomy(plan.Framework, agent)
+	if level == autonomy.LevelHeadlessAuto && severity == model.SeverityLow {
+		severity = model.SeverityMedium
+	}
 
 	var locationRange *model.LocationRange
 	if agent.StartLine > 0 || agent.EndLine > 0 {
@@ -623,17 +990,19 @@ func sourceFinding(scope detect.Scope, plan sourcePlan, agent AgentSpec, languag
 	}
 
 	return model.Finding{
-		FindingType:   "agent_framework",
-		Severity:      severity,
-		ToolType:      strings.TrimSpace(plan.Framework),
-		Location:      strings.TrimSpace(agent.File),
-		LocationRange: locationRange,
-		Repo:          strings.TrimSpace(scope.Repo),
-		Org:           fallbackOrg(scope.Org),
-		Detector:      strings.TrimSpace(plan.DetectorID),
-		Permissions:   permissions,
-		Evidence:      evidence,
-		Remediation:   "Declare deterministic agent bindings, deployment context, and governance controls.",
+		FindingType:            "agent_framework",
+		Severity:               severity,
+		ToolType:               strings.TrimSpace(plan.Framework),
+		Location:               strings.TrimSpace(agent.File),
+		LocationRange:          locationRange,
+		Repo:                   strings.TrimSpace(scope.Repo),
+		Org:                    fallbackOrg(scope.Org),
+		Detector:               strings.TrimSpace(plan.DetectorID),
+		Permissions:            permissions,
+		Autonomy:               level,
+		Evidence:               evidence,
+		ExecutionRelationships: relationships,
+		Remediation:            "Declare deterministic agent bindings, deployment context, and governance controls.",
 	}
 }
 
@@ -672,14 +1041,29 @@ func retrieverHints(values []string) []string {
 func providerHints(values ...string) []string {
 	out := []string{}
 	markers := map[string]string{
-		"chatopenai":         "openai",
-		"azureopenai":        "azure_openai",
-		"chatanthropic":      "anthropic",
-		"watsonx":            "watsonx",
-		"bedrock":            "bedrock",
-		"googlegenerativeai": "google_genai",
-		"gemini":             "google_genai",
-		"ollama":             "ollama",
+		"chatopenai":             "openai",
+		"azureopenai":            "azure_openai",
+		"chatanthropic":          "anthropic",
+		"watsonx":                "watsonx",
+		"bedrock":                "bedrock",
+		"googlegenerativeai":     "google_genai",
+		"gemini":                 "google_genai",
+		"ollama":                 "ollama",
+		"openaichatmodel":        "openai",
+		"anthropicchatmodel":     "anthropic",
+		"openaichatcompletion":   "openai",
+		"openaichatclient":       "openai",
+		"openaimodel":            "openai",
+		"openaiservermodel":      "openai",
+		"openaichatgenerator":    "openai",
+		"anthropicmodel":         "anthropic",
+		"anthropicchatgenerator": "anthropic",
+		"openai:":                "openai",
+		"openai/":                "openai",
+		"anthropic:":             "anthropic",
+		"anthropic/":             "anthropic",
+		"openai(":                "openai",
+		"anthropic(":             "anthropic",
 	}
 	joined := strings.ToLower(strings.Join(values, " "))
 	for marker, name := range markers {
@@ -781,6 +1165,165 @@ func extractNamedBool(block string, keys []string) bool {
 	return false
 }
 
+// firstStepLimit returns the first step bound set on the agent as
+// `key=expression`, such as `maxSteps=5` or `stopWhen=stepCountIs(10)`, so
+// the kind of bound survives alongside its value.
+func firstStepLimit(block string, keys []string) string {
+	for _, key := range keys {
+		for _, expr := range namedCallExpressions(block, key) {
+			return strings.TrimSpace(key) + "=" + expr
+		}
+	}
+	return ""
+}
+
+// humanGateScope returns the code whose gates pause this agent: its own
+// block, the definitions of the tools it binds, and the node functions added
+// to its graph. Gates elsewhere in the file belong to other agents or
+// workflows.
+func humanGateScope(content, block, variableName, language string, tools []string) string {
+	names := append([]string(nil), tools...)
+	nodeCalls := append(callStatements(block, []string{"addNode", "add_node"}), jsMethodCalls(content, variableName, "addNode", "add_node")...)
+	for _, statement := range nodeCalls {
+		if node := strings.TrimSpace(positionalArgAt(statement, 1)); node != "" {
+			names = append(names, node)
+		}
+	}
+	parts := []string{block}
+	for _, name := range uniqueSorted(names) {
+		if definition := symbolDefinition(content, name, language); definition != "" {
+			parts = append(parts, definition)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+// symbolDefinition returns the source that defines name: a Python `def`
+// body, or a JS/TS function declaration or `const` initializer.
+func symbolDefinition(content, name, language string) string {
+	name = strings.TrimSpace(name)
+	if !jsIdentifierPattern.MatchString(name) {
+		return ""
+	}
+	lines := strings.Split(content, "\n")
+	if language == "python" {
+		pattern := regexp.MustCompile(`^([ \t]*)(?:async[ \t]+)?def[ \t]+` + regexp.QuoteMeta(name) + `[ \t]*\(`)
+		for idx, line := range lines {
+			match := pattern.FindStringSubmatch(line)
+			if match == nil {
+				continue
+			}
+			end := idx + 1
+			for ; end < len(lines); end++ {
+				next := lines[end]
+				if strings.TrimSpace(next) != "" && len(next)-len(strings.TrimLeft(next, " \t")) <= len(match[1]) {
+					break
+				}
+			}
+			return strings.Join(lines[idx:end], "\n")
+		}
+		return pythonAssignedExpression(content, name)
+	}
+	pattern := regexp.MustCompile(`^[ \t]*(?:export[ \t]+)?(?:default[ \t]+)?(?:(?:async[ \t]+)?function[ \t]*\*?[ \t]*` + regexp.QuoteMeta(name) + `[ \t]*\(|(?:const|let|var)[ \t]+` + regexp.QuoteMeta(name) + `[ \t]*(?::[^=]+)?=)`)
+	for idx, line := range lines {
+		if !pattern.MatchString(line) {
+			continue
+		}
+		// Read lines until the brackets the definition opens are closed.
+		depth := 0
+		opened := false
+		for end := idx; end < len(lines); end++ {
+			for _, r := range lines[end] {
+				switch r {
+				case '(', '[', '{':
+					depth++
+					opened = true
+				case ')', ']', '}':
+					depth--
+				}
+			}
+			if opened && depth <= 0 {
+				return strings.Join(lines[idx:end+1], "\n")
+			}
+		}
+		return strings.Join(lines[idx:], "\n")
+	}
+	return ""
+}
+
+// hasHumanGateSignal reports whether code pauses agent runs for a human: a
+// gate key set to anything but false, or a call to a gate function.
+func hasHumanGateSignal(content string, profile sourceProfile) bool {
+	for _, key := range profile.humanGateKeys {
+		for _, expr := range namedExpressions(content, key) {
+			switch strings.ToLower(strings.TrimSpace(expr)) {
+			case "", "false", "none", "null", "undefined", "[]":
+				continue
+			}
+			return true
+		}
+	}
+	for _, call := range profile.humanGateCalls {
+		pattern := regexp.MustCompile(`(?:^|[^A-Za-z0-9_$.])` + regexp.QuoteMeta(strings.TrimSpace(call)) + `\s*\(`)
+		if pattern.MatchString(content) {
+			return true
+		}
+	}
+	return false
+}
+
+// callStatements returns every invocation of the given calls.
+func callStatements(content string, calls []string) []string {
+	out := make([]string, 0)
+	for _, call := range calls {
+		pattern := regexp.MustCompile(`\b` + regexp.QuoteMeta(strings.TrimSpace(call)) + `\s*\(`)
+		for _, loc := range pattern.FindAllStringIndex(content, -1) {
+			fragment, _ := captureBalancedFragment(content, loc[1]-1)
+			out = append(out, strings.TrimSpace(call)+fragment)
+		}
+	}
+	return out
+}
+
+// namedCallExpressions is namedExpressions for values that may be calls,
+// which parseExpression cuts at the first closing parenthesis.
+func namedCallExpressions(block, key string) []string {
+	pattern := regexp.MustCompile(`(?i)\b` + regexp.QuoteMeta(strings.TrimSpace(key)) + `\b\s*(?:=|:)`)
+	out := make([]string, 0)
+	for _, match := range pattern.FindAllStringIndex(block, -1) {
+		if expr := parseCallExpression(block[match[1]:]); expr != "" && !strings.HasPrefix(expr, "=") {
+			out = append(out, expr)
+		}
+	}
+	return out
+}
+
+// parseCallExpression reads a value that may be a call, keeping its
+// arguments: `stepCountIs(5)`, `await client.tools()`.
+func parseCallExpression(raw string) string {
+	trimmed := strings.TrimLeft(raw, " \t")
+	if trimmed == "" {
+		return ""
+	}
+	switch trimmed[0] {
+	case '[', '{', '(':
+		fragment, _ := captureBalancedFragment(trimmed, 0)
+		return fragment
+	case '\'', '"', '`':
+		fragment, _ := captureQuotedFragment(trimmed)
+		return fragment
+	}
+	end := strings.IndexAny(trimmed, ",;\n)}](")
+	if end < 0 {
+		return strings.TrimSpace(trimmed)
+	}
+	if trimmed[end] != '(' {
+		return strings.TrimSpace(trimmed[:end])
+	}
+	fragment, _ := captureBalancedFragment(trimmed, end)
+	return strings.TrimSpace(trimmed[:end]) + fragment
+}
+
 func namedExpressions(block, key string) []string {
 	pattern := regexp.MustCompile(`(?i)\b` + regexp.QuoteMeta(strings.TrimSpace(key)) + `\b\s*(?:=|:)`)
 	matches := pattern.FindAllStringIndex(block, -1)
@@ -804,6 +1347,16 @@ func parseExpression(raw string) (string, int) {
 		return "", offset
 	}
 
+	// Go slice literals (`[]tool.Tool{a, b}`) are read as a list of items.
+	if strings.HasPrefix(trimmed, "[]") {
+		if open := strings.Index(trimmed, "{"); open > 0 {
+			fragment, end := captureBalancedFragment(trimmed, open)
+			if strings.HasSuffix(fragment, "}") {
+				return "[" + fragment[1:len(fragment)-1] + "]", offset + end
+			}
+		}
+	}
+
 	switch trimmed[0] {
 	case '[', '{', '(':
 		return captureBalancedFragment(trimmed, 0)
@@ -1021,6 +1574,11 @@ func normalizeExpressionItem(item string) []string {
 			return []string{strings.TrimSpace(match[1])}
 		}
 	}
+	for _, match := range goGetenvPattern.FindAllStringSubmatch(trimmed, -1) {
+		if len(match) == 2 {
+			return []string{strings.TrimSpace(match[1])}
+		}
+	}
 	if open := strings.Index(trimmed, "("); open >= 0 {
 		inner, _ := parseExpression(trimmed[open+1:])
 		values := parseExpressionValues(inner)
@@ -1067,31 +1625,41 @@ func quotedValue(value string) string {
 	return ""
 }
 
-func firstPositionalValues(block string) []string {
+func positionalValues(block string, index int) []string {
+	args := positionalArgs(block)
+	if index < 0 || index >= len(args) {
+		return nil
+	}
+	return parseExpressionValues(args[index])
+}
+
+func positionalArgs(block string) []string {
 	open := strings.Index(block, "(")
 	close := strings.LastIndex(block, ")")
 	if open < 0 || close <= open {
 		return nil
 	}
-	args := splitTopLevel(block[open+1:close], ',')
-	if len(args) == 0 {
-		return nil
-	}
-	return normalizeExpressionItems([]string{args[0]})
+	return splitTopLevel(block[open+1:close], ',')
 }
 
-func expectsPositionalTools(callName string) bool {
+// positionalToolsIndex returns the argument that carries the tool list for
+// constructors that take tools positionally, or -1.
+func positionalToolsIndex(callName string) int {
 	switch strings.TrimSpace(callName) {
-	case "initialize_agent", "initializeAgentExecutorWithOptions", "createReactAgent", "create_react_agent":
-		return true
+	case "initialize_agent", "initializeAgentExecutorWithOptions", "createReactAgent":
+		return 0
+	case "ReAct", "CodeAct", "create_react_agent":
+		return 1
+	case "agents.NewOneShotAgent", "agents.NewConversationalAgent", "agents.NewOpenAIFunctionsAgent", "agents.Initialize":
+		return 1
 	default:
-		return false
+		return -1
 	}
 }
 
 func extractEnvVars(block string) []string {
 	out := make([]string, 0)
-	for _, pattern := range []*regexp.Regexp{processEnvPattern, osGetEnvPattern, osEnvironPattern, genericEnvPattern} {
+	for _, pattern := range []*regexp.Regexp{processEnvPattern, osGetEnvPattern, osEnvironPattern, goGetenvPattern, genericEnvPattern, dotnetEnvPattern, haystackSecretPattern} {
 		for _, match := range pattern.FindAllStringSubmatch(block, -1) {
 			if len(match) == 2 {
 				out = append(out, strings.TrimSpace(match[1]))
@@ -1115,6 +1683,16 @@ func deploymentHints(rel, content, block string) []string {
 		"runner.run(",
 		"serve(",
 		"lambda_handler",
+		"func main()",
+		"http.listenandserve(",
+		"server.servestdio(",
+		"lambda.start(",
+		"springapplication.run(",
+		"fun main(",
+		"webapplication.createbuilder(",
+		"host.createapplicationbuilder(",
+		"static async task main(",
+		"static void main(",
 	) {
 		hints = append(hints, rel)
 	}
@@ -0,0 +1,418 @@
+package agentframework
+
+import (
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Clyra-AI/wrkr/core/detect/openapi"
+)
+
+var (
+	dotnetUsingPattern            = regexp.MustCompile(`^(?:global\s+)?using\s+(?:static\s+)?(?:[A-Za-z_][A-Za-z0-9_]*\s*=\s*)?([A-Za-z_][A-Za-z0-9_.]*)\s*;`)
+	dotnetClassPattern            = regexp.MustCompile(`\b(?:class|record|struct|interface)\s+([A-Z][A-Za-z0-9_]*)`)
+	dotnetKernelFunctionPattern   = regexp.MustCompile(`(?:\[|,)\s*KernelFunction(?:Attribute)?\b`)
+	dotnetPluginTypePattern       = regexp.MustCompile(`\.(?:ImportPluginFromType|AddFromType|CreatePluginFromType)\s*<\s*([A-Za-z_][A-Za-z0-9_.]*)\s*>`)
+	dotnetPluginObjectPattern     = regexp.MustCompile(`\.(?:ImportPluginFromObject|AddFromObject|CreatePluginFromObject)\s*\(`)
+	dotnetPluginFuncsPattern      = regexp.MustCompile(`\.(?:ImportPluginFromFunctions|AddFromFunctions|CreatePluginFromFunctions)\s*\(`)
+	dotnetOpenAPIPluginPattern    = regexp.MustCompile(`\.(?:ImportPluginFromOpenApiAsync|AddFromOpenApiAsync|CreatePluginFromOpenApiAsync)\s*\(`)
+	dotnetAssignmentPrefixPattern = regexp.MustCompile(`^\s*(?:(?:public|private|protected|internal|static|readonly)\s+)*(?:await\s+)?(?:using\s+)?(?:var\s+|[A-Za-z_][A-Za-z0-9_<>\[\]?.]*\s+)?([A-Za-z_][A-Za-z0-9_]*)\s*=[^=]`)
+	dotnetNamedArgPattern         = regexp.MustCompile(`^([A-Za-z_][A-Za-z0-9_]*)\s*:\s*([^:].*)$`)
+	dotnetEnvPattern              = regexp.MustCompile(`Environment\.GetEnvironmentVariable\(\s*"([A-Z][A-Z0-9_]+)"\s*\)`)
+)
+
+// buildDotnetTypedPattern matches target-typed construction such as
+// `ChatCompletionAgent agent = new() { ... }`, where the call name is the
+// declared type rather than the expression. Group 1 is the call name and
+// group 2 the variable.
+func buildDotnetTypedPattern(callNames []string) *regexp.Regexp {
+	escaped := make([]string, 0, len(callNames))
+	for _, name := range callNames {
+		if strings.Contains(name, ".") {
+			continue
+		}
+		escaped = append(escaped, regexp.QuoteMeta(strings.TrimSpace(name)))
+	}
+	if len(escaped) == 0 {
+		return nil
+	}
+	sort.Slice(escaped, func(i, j int) bool { return len(escaped[i]) > len(escaped[j]) })
+	return regexp.MustCompile(`^\s*(?:(?:public|private|protected|internal|static|readonly)\s+)*(` + strings.Join(escaped, "|") + `)\s+([A-Za-z_][A-Za-z0-9_]*)\s*=\s*new\s*\(`)
+}
+
+// matchDotnetAssignment returns submatch indexes in the order the generic
+// assignment pattern uses, variable first, together with the call name.
+// When the call sits on a continuation line of a fluent receiver chain, as
+// in `AIAgent a = new AzureOpenAIClient(...)` followed by
+// `.GetChatClient(...)` and `.CreateAIAgent(...)`, the call indexes are -1
+// and only the returned name identifies the call.
+func matchDotnetAssignment(plan sourcePlan, lines []string, idx int) ([]int, string) {
+	line := lines[idx]
+	pattern := plan.nativePatterns["dotnet"]
+	if pattern != nil {
+		if match := pattern.FindStringSubmatchIndex(line); len(match) == 6 {
+			return match, line[match[4]:match[5]]
+		}
+		if idx+1 < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[idx+1]), ".") {
+			if prefix := dotnetAssignmentPrefixPattern.FindStringSubmatchIndex(line); len(prefix) == 4 {
+				statement, _ := captureDotnetStatement(lines, idx, prefix[3])
+				flat := "x " + strings.Join(strings.Fields(statement), " ")
+				if match := pattern.FindStringSubmatchIndex(flat); len(match) == 6 {
+					return []int{0, len(line), prefix[2], prefix[3], -1, -1}, flat[match[4]:match[5]]
+				}
+			}
+		}
+	}
+	if plan.dotnetTypedPattern == nil {
+		return nil, ""
+	}
+	match := plan.dotnetTypedPattern.FindStringSubmatchIndex(line)
+	if len(match) != 6 {
+		return nil, ""
+	}
+	return []int{match[0], match[1], match[4], match[5], match[2], match[3]}, line[match[2]:match[3]]
+}
+
+// parseDotnetImportSummary reads C# `using` directives. Namespaces are the
+// modules; C# imports no individual names.
+func parseDotnetImportSummary(content string) importSummary {
+	moduleSet := map[string]struct{}{}
+	for _, line := range strings.Split(content, "\n") {
+		if match := dotnetUsingPattern.FindStringSubmatch(strings.TrimSpace(line)); len(match) == 2 {
+			moduleSet[strings.ToLower(match[1])] = struct{}{}
+		}
+	}
+	return importSummary{Modules: sortedKeys(moduleSet)}
+}
+
+// captureDotnetStatement captures from startCol to the semicolon that ends
+// the statement, so builder chains and object initializers spread over
+// several lines are read whole.
+func captureDotnetStatement(lines []string, startLine, startCol int) (string, int) {
+	var builder strings.Builder
+	depth := 0
+	inString := false
+	inChar := false
+	escaped := false
+	for idx := startLine; idx < len(lines); idx++ {
+		segment := lines[idx]
+		if idx == startLine {
+			segment = segment[startCol:]
+		}
+		if builder.Len() > 0 {
+			builder.WriteByte('\n')
+		}
+		for pos := 0; pos < len(segment); pos++ {
+			ch := segment[pos]
+			switch {
+			case escaped:
+				escaped = false
+				continue
+			case ch == '\\' && (inString || inChar):
+				escaped = true
+				continue
+			case ch == '"' && !inChar:
+				inString = !inString
+				continue
+			case ch == '\'' && !inString:
+				inChar = !inChar
+				continue
+			case inString || inChar:
+				continue
+			case ch == '/' && pos+1 < len(segment) && segment[pos+1] == '/':
+				// Drop the line comment; this ends the loop.
+				segment = segment[:pos]
+				continue
+			}
+			switch ch {
+			case '(', '[', '{':
+				depth++
+			case ')', ']', '}':
+				if depth > 0 {
+					depth--
+				}
+			case ';':
+				if depth == 0 {
+					builder.WriteString(segment[:pos+1])
+					return builder.String(), idx + 1
+				}
+			}
+		}
+		builder.WriteString(segment)
+	}
+	return builder.String(), len(lines)
+}
+
+// dotnetAgentSpec adds the plugins registered on the kernel an agent or
+// kernel builder uses. Semantic Kernel registers plugins in statements after
+// construction (`builder.Plugins.AddFromType<T>()`,
+// `kernel.ImportPluginFromOpenApiAsync(...)`), so those statements are
+// returned with the block for provider and credential hints.
+func dotnetAgentSpec(agent AgentSpec, rel, content, block, variableName, callName string, profile sourceProfile, toolIndex *annotatedToolIndex, specs *openAPISpecIndex) (AgentSpec, string) {
+	kernel := strings.TrimSpace(variableName)
+	if callName != "Kernel.CreateBuilder" {
+		kernel = ""
+		if exprs := namedExpressions(block, "Kernel"); len(exprs) > 0 {
+			kernel = leadingIdentifier(exprs[0])
+		}
+	}
+	statements := dotnetReceiverStatements(content, dotnetKernelVars(content, kernel))
+	scope := block + "\n" + statements
+
+	tools := make([]string, 0, len(agent.Tools))
+	expand := func(value string) {
+		// Collection expressions spread other tool lists with `..`.
+		value = strings.TrimSpace(strings.TrimPrefix(value, ".."))
+		if methods := toolIndex.lookup(jvmValueType(content, value)); len(methods) > 0 {
+			tools = append(tools, methods...)
+			return
+		}
+		tools = append(tools, value)
+	}
+	for _, value := range agent.Tools {
+		expand(value)
+	}
+	for _, match := range dotnetPluginTypePattern.FindAllStringSubmatch(scope, -1) {
+		expand(match[1][strings.LastIndex(match[1], ".")+1:])
+	}
+	for _, args := range dotnetCallArgs(scope, dotnetPluginObjectPattern) {
+		if len(args) > 0 {
+			for _, value := range normalizeJVMValue(args[0]) {
+				expand(value)
+			}
+		}
+	}
+	for _, args := range dotnetCallArgs(scope, dotnetPluginFuncsPattern) {
+		if name := quotedValue(dotnetArgument(args, 0, "pluginName")); name != "" {
+			tools = append(tools, name)
+		}
+	}
+	for _, args := range dotnetCallArgs(scope, dotnetOpenAPIPluginPattern) {
+		if name := quotedValue(dotnetArgument(args, 0, "pluginName")); name != "" {
+			tools = append(tools, name)
+		}
+		location := dotnetSpecLocation(dotnetArgument(args, 1, "uri", "filePath"))
+		if urlPattern.MatchString(location) {
+			agent.APISpecs = append(agent.APISpecs, location)
+		} else if spec := specs.resolve(rel, location); spec != "" {
+			agent.APISpecs = append(agent.APISpecs, spec)
+		}
+	}
+	agent.Tools = uniqueSorted(tools)
+	agent.APISpecs = uniqueSorted(agent.APISpecs)
+	agent.AuthSurfaces = uniqueSorted(append(append(agent.AuthSurfaces, extractNamedValues(statements, profile.authKeys)...), extractEnvVars(statements)...))
+	return agent, scope
+}
+
+// dotnetKernelVars returns the kernel variable together with the builder it
+// was built from, or the kernel built from it when seed is a builder.
+func dotnetKernelVars(content, seed string) []string {
+	if seed == "" {
+		return nil
+	}
+	vars := []string{seed}
+	seen := map[string]struct{}{seed: {}}
+	for idx := 0; idx < len(vars); idx++ {
+		quoted := regexp.QuoteMeta(vars[idx])
+		for _, pattern := range []*regexp.Regexp{
+			regexp.MustCompile(`\b([A-Za-z_][A-Za-z0-9_]*)\s*=\s*` + quoted + `\s*\.\s*Build\s*\(`),
+			regexp.MustCompile(`\b` + quoted + `\s*=\s*([A-Za-z_][A-Za-z0-9_]*)\s*\.\s*Build\s*\(`),
+		} {
+			for _, match := range pattern.FindAllStringSubmatch(content, -1) {
+				if _, ok := seen[match[1]]; !ok {
+					seen[match[1]] = struct{}{}
+					vars = append(vars, match[1])
+				}
+			}
+		}
+	}
+	return vars
+}
+
+// dotnetReceiverStatements returns every statement in content that calls a
+// member of one of the given variables.
+func dotnetReceiverStatements(content string, vars []string) string {
+	if len(vars) == 0 {
+		return ""
+	}
+	quoted := make([]string, 0, len(vars))
+	for _, name := range vars {
+		quoted = append(quoted, regexp.QuoteMeta(name))
+	}
+	pattern := regexp.MustCompile(`\b(?:` + strings.Join(quoted, "|") + `)\s*\.`)
+	lines := strings.Split(content, "\n")
+	statements := make([]string, 0)
+	for idx := 0; idx < len(lines); idx++ {
+		loc := pattern.FindStringIndex(lines[idx])
+		if loc == nil {
+			continue
+		}
+		statement, endLine := captureDotnetStatement(lines, idx, loc[0])
+		statements = append(statements, statement)
+		idx = endLine - 1
+	}
+	return strings.Join(statements, "\n")
+}
+
+// dotnetCallArgs returns the top-level arguments of every call matched by
+// pattern, which must end at the opening parenthesis.
+func dotnetCallArgs(block string, pattern *regexp.Regexp) [][]string {
+	out := make([][]string, 0)
+	for _, loc := range pattern.FindAllStringIndex(block, -1) {
+		fragment, _ := captureBalancedFragment(block, loc[1]-1)
+		out = append(out, splitTopLevel(strings.TrimSuffix(strings.TrimPrefix(fragment, "("), ")"), ','))
+	}
+	return out
+}
+
+// dotnetArgument returns a call argument passed by name, or the positional
+// argument at index when no named argument matches.
+func dotnetArgument(args []string, index int, names ...string) string {
+	positional := make([]string, 0, len(args))
+	for _, arg := range args {
+		match := dotnetNamedArgPattern.FindStringSubmatch(strings.TrimSpace(arg))
+		if len(match) != 3 {
+			positional = append(positional, arg)
+			continue
+		}
+		for _, name := range names {
+			if match[1] == name {
+				return strings.TrimSpace(match[2])
+			}
+		}
+	}
+	if index < len(positional) {
+		return strings.TrimSpace(positional[index])
+	}
+	return ""
+}
+
+// dotnetSpecLocation reads a spec location from a string literal,
+// `new Uri("...")`, or `Path.Combine(..., "dir", "spec.yaml")`. Arguments
+// that are not literals, such as `AppContext.BaseDirectory`, are dropped.
+func dotnetSpecLocation(expr string) string {
+	expr = strings.TrimPrefix(strings.TrimSpace(expr), "@")
+	if value := quotedValue(expr); value != "" {
+		return value
+	}
+	open := strings.Index(expr, "(")
+	if open < 0 {
+		return ""
+	}
+	fragment, _ := captureBalancedFragment(expr, open)
+	parts := make([]string, 0)
+	for _, arg := range splitTopLevel(strings.TrimSuffix(strings.TrimPrefix(fragment, "("), ")"), ',') {
+		if value := dotnetSpecLocation(arg); value != "" {
+			parts = append(parts, value)
+		}
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	if strings.HasSuffix(strings.TrimSpace(expr[:open]), "Path.Combine") {
+		return path.Join(parts...)
+	}
+	return parts[0]
+}
+
+func leadingIdentifier(expr string) string {
+	expr = strings.TrimSpace(expr)
+	end := 0
+	for end < len(expr) && (expr[end] == '_' || isAlphaNumeric(expr[end])) {
+		end++
+	}
+	return expr[:end]
+}
+
+// dotnetAnnotatedTools returns, per declaring class, the Semantic Kernel
+// function names of methods marked `[KernelFunction]`. An explicit
+// `[KernelFunction("name")]` wins; otherwise the method name is used with
+// the `Async` suffix removed, as Semantic Kernel does.
+func dotnetAnnotatedTools(content string) map[string][]string {
+	attributes := dotnetKernelFunctionPattern.FindAllStringIndex(content, -1)
+	if len(attributes) == 0 {
+		return nil
+	}
+	classes := dotnetClassPattern.FindAllStringSubmatchIndex(content, -1)
+	out := map[string][]string{}
+	for _, loc := range attributes {
+		className := ""
+		for _, class := range classes {
+			if class[0] > loc[0] {
+				break
+			}
+			className = content[class[2]:class[3]]
+		}
+		open := strings.LastIndex(content[:loc[1]], "[")
+		if className == "" || open < 0 {
+			continue
+		}
+		name := ""
+		if args := strings.TrimLeft(content[loc[1]:], " \t"); strings.HasPrefix(args, "(") {
+			fragment, _ := captureBalancedFragment(args, 0)
+			inner := splitTopLevel(strings.TrimSuffix(strings.TrimPrefix(fragment, "("), ")"), ',')
+			if len(inner) > 0 {
+				name = quotedValue(inner[0])
+			}
+		}
+		section, _ := captureBalancedFragment(content, open)
+		rest := strings.TrimSpace(content[open+len(section):])
+		for strings.HasPrefix(rest, "[") {
+			next, _ := captureBalancedFragment(rest, 0)
+			rest = strings.TrimSpace(rest[len(next):])
+		}
+		if name == "" {
+			if match := jvmMethodNamePattern.FindStringSubmatch(rest); len(match) == 2 {
+				name = strings.TrimSuffix(match[1], "Async")
+			}
+		}
+		if name != "" {
+			out[className] = append(out[className], name)
+		}
+	}
+	for className := range out {
+		sort.Strings(out[className])
+	}
+	return out
+}
+
+// openAPISpecIndex resolves the spec location passed to an OpenAPI plugin
+// import to a spec file in the scope that the openapi detector reports.
+type openAPISpecIndex struct {
+	root  string
+	files []string
+	set   map[string]struct{}
+}
+
+func newOpenAPISpecIndex(root string, files []string) *openAPISpecIndex {
+	return &openAPISpecIndex{root: root, files: files}
+}
+
+// resolve tries the location relative to the importing file, then relative
+// to the scope root, then as the tail of a deeper path, since the runtime
+// working directory is usually the project directory.
+func (idx *openAPISpecIndex) resolve(sourceRel, location string) string {
+	location = strings.TrimPrefix(path.Clean(strings.ReplaceAll(strings.TrimSpace(location), "\\", "/")), "./")
+	if idx == nil || location == "" || location == "." {
+		return ""
+	}
+	if idx.set == nil {
+		idx.set = make(map[string]struct{}, len(idx.files))
+		for _, rel := range idx.files {
+			idx.set[rel] = struct{}{}
+		}
+	}
+	candidates := []string{path.Join(path.Dir(sourceRel), location), location}
+	for _, rel := range idx.files {
+		if strings.HasSuffix(rel, "/"+location) {
+			candidates = append(candidates, rel)
+		}
+	}
+	for _, candidate := range candidates {
+		if _, ok := idx.set[candidate]; ok && openapi.IsSpecification(idx.root, candidate) {
+			return candidate
+		}
+	}
+	return ""
+}
@@ -0,0 +1,143 @@
+package agentframework
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	goImportLinePattern = regexp.MustCompile(`^(?:([A-Za-z_][A-Za-z0-9_]*|\.)\s+)?"([^"]+)"`)
+	goNewToolPattern    = regexp.MustCompile(`\.NewTool\(\s*"([^"]+)"`)
+)
+
+// parseGoImportSummary reads single-line imports and `import (...)` blocks.
+// Go imports bring packages rather than names into scope, so only module
+// paths are returned.
+func parseGoImportSummary(content string) importSummary {
+	moduleSet := map[string]struct{}{}
+	inBlock := false
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "//") {
+			continue
+		}
+		switch {
+		case inBlock && strings.HasPrefix(trimmed, ")"):
+			inBlock = false
+			continue
+		case inBlock:
+		case strings.HasPrefix(trimmed, "import ("):
+			inBlock = true
+			continue
+		case strings.HasPrefix(trimmed, "import "):
+			trimmed = strings.TrimSpace(strings.TrimPrefix(trimmed, "import "))
+		case strings.HasPrefix(trimmed, "func "), strings.HasPrefix(trimmed, "type "), strings.HasPrefix(trimmed, "var "), strings.HasPrefix(trimmed, "const "):
+			// Imports precede all other declarations.
+			return importSummary{Modules: sortedKeys(moduleSet)}
+		default:
+			continue
+		}
+		if match := goImportLinePattern.FindStringSubmatch(trimmed); len(match) == 3 {
+			moduleSet[strings.ToLower(strings.TrimSpace(match[2]))] = struct{}{}
+		}
+	}
+	return importSummary{Modules: sortedKeys(moduleSet)}
+}
+
+// goAgentSpec fills in what Go constructors express outside the call: a
+// server name passed positionally, tool slices bound to a variable, and
+// tools registered on an MCP server after it is constructed.
+func goAgentSpec(agent AgentSpec, content, block, variableName, callName string) AgentSpec {
+	if strings.TrimSpace(agent.Name) == strings.TrimSpace(variableName) {
+		if args := positionalArgs(block); len(args) > 0 {
+			if name := quotedValue(args[0]); name != "" {
+				agent.Name = name
+			}
+		}
+	}
+
+	tools := make([]string, 0, len(agent.Tools))
+	for _, tool := range agent.Tools {
+		if resolved := resolveGoSliceVar(content, tool); len(resolved) > 0 {
+			tools = append(tools, resolved...)
+			continue
+		}
+		tools = append(tools, tool)
+	}
+	switch strings.TrimSpace(callName) {
+	case "mcp.NewServer", "server.NewMCPServer":
+		tools = append(tools, goRegisteredTools(content, variableName)...)
+	}
+	agent.Tools = uniqueSorted(tools)
+	return agent
+}
+
+// resolveGoSliceVar returns the items of `name := []T{...}` when name is a
+// local identifier bound to a slice literal in the same file.
+func resolveGoSliceVar(content, name string) []string {
+	name = strings.TrimSpace(name)
+	if name == "" || strings.ContainsAny(name, ".{}()[] ") {
+		return nil
+	}
+	pattern := regexp.MustCompile(`(?m)^\s*(?:var\s+)?` + regexp.QuoteMeta(name) + `\s*:?=\s*\[\]`)
+	loc := pattern.FindStringIndex(content)
+	if loc == nil {
+		return nil
+	}
+	expr, _ := parseExpression(content[loc[1]-2:])
+	if !strings.HasPrefix(expr, "[") || expr == "[]" {
+		return nil
+	}
+	return parseExpressionValues(expr)
+}
+
+// goRegisteredTools returns the tools added to an MCP server variable, via
+// the official SDK's `mcp.AddTool(server, &mcp.Tool{Name: ...}, handler)` or
+// mcp-go's `s.AddTool(mcp.NewTool("name", ...), handler)`.
+func goRegisteredTools(content, serverVar string) []string {
+	serverVar = strings.TrimSpace(serverVar)
+	if serverVar == "" {
+		return nil
+	}
+	quoted := regexp.QuoteMeta(serverVar)
+	calls := []struct {
+		pattern  *regexp.Regexp
+		argIndex int
+	}{
+		{regexp.MustCompile(`\bmcp\.AddTool\(\s*&?` + quoted + `\s*,`), 1},
+		{regexp.MustCompile(`\b` + quoted + `\.AddTool\(`), 0},
+	}
+	tools := make([]string, 0)
+	for _, call := range calls {
+		for _, loc := range call.pattern.FindAllStringIndex(content, -1) {
+			open := loc[0] + strings.Index(content[loc[0]:loc[1]], "(")
+			invocation, _ := captureBalancedFragment(content, open)
+			args := positionalArgs(invocation)
+			if call.argIndex >= len(args) {
+				continue
+			}
+			if name := goToolName(content, args[call.argIndex]); name != "" {
+				tools = append(tools, name)
+			}
+		}
+	}
+	return uniqueSorted(tools)
+}
+
+func goToolName(content, arg string) string {
+	if name := firstNamedString(arg, []string{"Name"}); name != "" {
+		return name
+	}
+	if match := goNewToolPattern.FindStringSubmatch(arg); len(match) == 2 {
+		return strings.TrimSpace(match[1])
+	}
+	ident := strings.TrimSpace(arg)
+	if ident == "" || strings.ContainsAny(ident, ".{}()[] &") {
+		return ident
+	}
+	pattern := regexp.MustCompile(`\b` + regexp.QuoteMeta(ident) + `\s*:?=\s*mcp\.NewTool\(\s*"([^"]+)"`)
+	if match := pattern.FindStringSubmatch(content); len(match) == 2 {
+		return strings.TrimSpace(match[1])
+	}
+	return ident
+}
@@ -0,0 +1,262 @@
+package agentframework
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	jsIdentifierPattern = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+	jsChainPattern      = regexp.MustCompile(`^\s*\.\s*[A-Za-z_$][A-Za-z0-9_$]*\s*\(`)
+	jsFunctionPattern   = regexp.MustCompile(`^\s*(?:export\s+)?(?:default\s+)?(?:async\s+)?function\s*\*?\s*([A-Za-z_$][A-Za-z0-9_$]*)|^\s*(?:export\s+)?(?:const|let|var)\s+([A-Za-z_$][A-Za-z0-9_$]*)\s*(?::[^=]+)?=\s*(?:async\s*)?(?:\([^)]*\)|[A-Za-z_$][A-Za-z0-9_$]*)\s*(?::[^=]+)?=>`)
+	// jsDiscoveredToolsPattern matches tool sets an MCP client lists at run
+	// time: `await mcp.tools()`, `await mcp.getTools()`.
+	jsDiscoveredToolsPattern = regexp.MustCompile(`^\(?\s*(?:await\s+)?([A-Za-z_$][A-Za-z0-9_$]*)\s*\.\s*(?:tools|getTools|getToolsets|listTools)\(\s*\)\s*\)?$`)
+)
+
+// jsAgentSpec resolves what JS/TS agents bind by name: tool objects keyed by
+// tool name (`tools: { weather, search: searchTool }`), tool sets spread in
+// or listed from an MCP client, models built into a variable, the options
+// passed when an agent or graph is compiled and invoked, and the tool nodes
+// of a LangGraph `StateGraph`. A Vercel AI SDK `generateText` or
+// `streamText` call without tools is a single completion rather than an
+// agent loop and returns false.
+func jsAgentSpec(agent AgentSpec, content, block, variableName, callName string, profile sourceProfile) (AgentSpec, string, bool) {
+	tools := make([]string, 0)
+	discovered := false
+	for _, key := range profile.toolKeys {
+		for _, expr := range jsNamedExpressions(block, key) {
+			names, dynamic := jsToolNames(content, expr, 0)
+			tools = append(tools, names...)
+			discovered = discovered || dynamic
+		}
+	}
+
+	related := make([]string, 0)
+	for _, key := range profile.modelKeys {
+		for _, expr := range jsNamedExpressions(block, key) {
+			if assigned := jsAssignedExpression(content, expr); assigned != "" {
+				related = append(related, assigned)
+			}
+		}
+	}
+	receivers := []string{variableName}
+	if strings.TrimSpace(callName) == "StateGraph" {
+		for _, statement := range callStatements(content, []string{"ToolNode", "bindTools"}) {
+			names, dynamic := jsToolNames(content, positionalArgAt(statement, 0), 0)
+			tools = append(tools, names...)
+			discovered = discovered || dynamic
+		}
+		receivers = append(receivers, jsCompiledGraphs(content, variableName)...)
+	}
+	for _, receiver := range receivers {
+		related = append(related, jsMethodCalls(content, receiver, "compile", "invoke", "stream", "streamEvents", "generate")...)
+	}
+
+	if len(tools) > 0 {
+		agent.Tools = uniqueSorted(tools)
+	}
+	switch strings.TrimSpace(callName) {
+	case "generateText", "streamText":
+		if len(agent.Tools) == 0 {
+			return agent, block, false
+		}
+	case "experimental_createMCPClient":
+		discovered = true
+	}
+
+	extra := strings.Join(related, "\n")
+	if extra != "" {
+		block += "\n" + extra
+	}
+	agent.DataSources = uniqueSorted(jsDataSources(content, block, profile.dataKeys))
+	agent.AuthSurfaces = uniqueSorted(append(append(agent.AuthSurfaces, extractNamedValues(extra, profile.authKeys)...), extractEnvVars(extra)...))
+	agent.DataClass = inferSourceDataClass(agent.DataSources, agent.AuthSurfaces)
+	agent.StepLimit = firstStepLimit(block, profile.stepLimitKeys)
+	agent.DynamicDiscovery = agent.DynamicDiscovery || discovered
+	return agent, block, true
+}
+
+// jsNamedExpressions returns the values of `key: value` properties, keeping
+// call arguments, and the key itself for shorthand properties (`{ tools }`)
+// so it can be resolved as a variable.
+func jsNamedExpressions(block, key string) []string {
+	out := namedCallExpressions(block, key)
+	shorthand := regexp.MustCompile(`(?:^|[{,])\s*` + regexp.QuoteMeta(strings.TrimSpace(key)) + `\s*(?:,|}|$)`)
+	if shorthand.MatchString(block) {
+		out = append(out, strings.TrimSpace(key))
+	}
+	return out
+}
+
+// jsToolNames names the tools in a tools expression. Object literals are
+// keyed by tool name, arrays list tool variables, variables are resolved to
+// their literal, and spreads are expanded. It also reports tool sets that an
+// MCP client discovers at run time, which are named by the client variable.
+func jsToolNames(content, expr string, depth int) ([]string, bool) {
+	expr = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(expr), "..."))
+	if expr == "" {
+		return nil, false
+	}
+	if match := jsDiscoveredToolsPattern.FindStringSubmatch(expr); len(match) == 2 {
+		return []string{match[1]}, true
+	}
+	switch {
+	case len(expr) >= 2 && (expr[0] == '{' || expr[0] == '['):
+		out := make([]string, 0)
+		discovered := false
+		for _, item := range splitTopLevel(expr[1:len(expr)-1], ',') {
+			switch {
+			case item == "":
+			case strings.HasPrefix(item, "..."):
+				names, dynamic := jsToolNames(content, item, depth+1)
+				out = append(out, names...)
+				discovered = discovered || dynamic
+			case expr[0] == '{':
+				if key := jsObjectKey(item); key != "" {
+					out = append(out, key)
+				}
+			case jsIdentifierPattern.MatchString(item):
+				out = append(out, item)
+			default:
+				out = append(out, normalizeExpressionItem(item)...)
+			}
+		}
+		return out, discovered
+	case jsIdentifierPattern.MatchString(expr):
+		if depth < 3 {
+			assigned := jsAssignedExpression(content, expr)
+			if strings.HasPrefix(assigned, "{") || strings.HasPrefix(assigned, "[") || jsDiscoveredToolsPattern.MatchString(assigned) {
+				return jsToolNames(content, assigned, depth+1)
+			}
+		}
+		return []string{expr}, false
+	default:
+		return normalizeExpressionItems([]string{expr}), false
+	}
+}
+
+func jsObjectKey(item string) string {
+	key := item
+	if colon := strings.Index(item, ":"); colon >= 0 {
+		key = item[:colon]
+	}
+	key = strings.TrimSpace(key)
+	if value := quotedValue(key); value != "" {
+		return value
+	}
+	if jsIdentifierPattern.MatchString(key) {
+		return key
+	}
+	return ""
+}
+
+// jsDataSources names memory, vector stores, and checkpointers by the class
+// they construct rather than by their constructor arguments.
+func jsDataSources(content, block string, keys []string) []string {
+	out := make([]string, 0)
+	for _, key := range keys {
+		for _, expr := range jsNamedExpressions(block, key) {
+			value := expr
+			if assigned := jsAssignedExpression(content, expr); assigned != "" {
+				value = assigned
+			}
+			if callee := jsCallee(value); callee != "" {
+				out = append(out, callee)
+				continue
+			}
+			if jsIdentifierPattern.MatchString(value) {
+				out = append(out, value)
+				continue
+			}
+			out = append(out, parseExpressionValues(value)...)
+		}
+	}
+	return out
+}
+
+// jsCallee returns the class or function an expression constructs or calls,
+// without its receiver.
+func jsCallee(expr string) string {
+	open := strings.Index(expr, "(")
+	if open <= 0 {
+		return ""
+	}
+	callee := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(expr[:open]), "new "))
+	if dot := strings.LastIndex(callee, "."); dot >= 0 {
+		callee = callee[dot+1:]
+	}
+	if !jsIdentifierPattern.MatchString(callee) {
+		return ""
+	}
+	return callee
+}
+
+// jsAssignedExpression returns the initializer of the first `const`, `let`,
+// or `var` declaration of name in the file, without a leading `await`.
+func jsAssignedExpression(content, name string) string {
+	name = strings.TrimSpace(name)
+	if !jsIdentifierPattern.MatchString(name) {
+		return ""
+	}
+	pattern := regexp.MustCompile(`(?m)^[ \t]*(?:export[ \t]+)?(?:const|let|var)[ \t]+` + regexp.QuoteMeta(name) + `[ \t]*(?::[^=\n]+)?=[ \t]*(?:await[ \t]+)?`)
+	loc := pattern.FindStringIndex(content)
+	if loc == nil {
+		return ""
+	}
+	return parseCallExpression(content[loc[1]:])
+}
+
+// jsMethodCalls returns calls of the given methods on receiver, such as
+// `graph.invoke(input, { recursionLimit: 10 })`.
+func jsMethodCalls(content, receiver string, methods ...string) []string {
+	receiver = strings.TrimSpace(receiver)
+	if !jsIdentifierPattern.MatchString(receiver) || len(methods) == 0 {
+		return nil
+	}
+	pattern := regexp.MustCompile(`(?:^|[^A-Za-z0-9_$.])(` + regexp.QuoteMeta(receiver) + `\s*\.\s*(?:` + strings.Join(methods, "|") + `)\s*)\(`)
+	out := make([]string, 0)
+	for _, match := range pattern.FindAllStringSubmatchIndex(content, -1) {
+		fragment, _ := captureBalancedFragment(content, match[1]-1)
+		out = append(out, content[match[2]:match[3]]+fragment)
+	}
+	return out
+}
+
+// jsCompiledGraphs returns variables holding a compiled LangGraph graph,
+// `const app = workflow.compile(...)`, whose invocations configure it.
+func jsCompiledGraphs(content, graphVar string) []string {
+	graphVar = strings.TrimSpace(graphVar)
+	if !jsIdentifierPattern.MatchString(graphVar) {
+		return nil
+	}
+	pattern := regexp.MustCompile(`(?:const|let|var)\s+([A-Za-z_$][A-Za-z0-9_$]*)\s*(?::[^=\n]+)?=\s*(?:await\s+)?` + regexp.QuoteMeta(graphVar) + `\s*\.\s*compile\s*\(`)
+	out := make([]string, 0)
+	for _, match := range pattern.FindAllStringSubmatch(content, -1) {
+		out = append(out, match[1])
+	}
+	return out
+}
+
+// captureJSChain extends an invocation with the method calls chained onto it
+// on the following lines, as LangGraph graphs are built with
+// `.addNode(...)`, `.addEdge(...)`, and `.compile(...)`.
+func captureJSChain(lines []string, block string, endLine int) (string, int) {
+	for endLine < len(lines) && jsChainPattern.MatchString(lines[endLine]) {
+		segment, next := captureInvocation(lines, endLine, strings.Index(lines[endLine], "."))
+		block += "\n" + segment
+		endLine = next
+	}
+	return block, endLine
+}
+
+// enclosingJSFunction names the function a call without an assignment
+// belongs to, such as a route handler that returns `streamText(...)`.
+func enclosingJSFunction(lines []string, lineIdx int) string {
+	for idx := lineIdx; idx >= 0; idx-- {
+		if match := jsFunctionPattern.FindStringSubmatch(lines[idx]); len(match) == 3 {
+			return firstNonEmpty(match[1], match[2])
+		}
+	}
+	return ""
+}
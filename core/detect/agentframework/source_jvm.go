@@ -0,0 +1,300 @@
+package agentframework
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Clyra-AI/wrkr/core/detect"
+)
+
+var (
+	jvmImportLinePattern   = regexp.MustCompile(`^import\s+(?:static\s+)?([A-Za-z_][A-Za-z0-9_.]*(?:\.\*)?)(?:\s+as\s+[A-Za-z_][A-Za-z0-9_]*)?\s*;?$`)
+	jvmClassPattern        = regexp.MustCompile(`\b(?:class|object|record|interface)\s+([A-Z][A-Za-z0-9_]*)`)
+	jvmToolAnnotation      = regexp.MustCompile(`@Tool\b`)
+	jvmMethodNamePattern   = regexp.MustCompile(`^[^(;{=]*?\b([A-Za-z_][A-Za-z0-9_]*)\s*\(`)
+	jvmMethodDeclPattern   = regexp.MustCompile(`^\s*(?:@[A-Za-z_][A-Za-z0-9_.]*\s+)*(?:(?:public|protected|private|static|final|synchronized|override|open|suspend|internal)\s+)*(?:fun\s+([A-Za-z_][A-Za-z0-9_]*)|[A-Za-z_][A-Za-z0-9_<>\[\]?,. ]*\s+([A-Za-z_][A-Za-z0-9_]*))\s*\(`)
+	jvmStatementKeywords   = map[string]struct{}{"return": {}, "new": {}, "throw": {}, "if": {}, "else": {}, "for": {}, "while": {}, "switch": {}, "when": {}, "catch": {}, "val": {}, "var": {}}
+	jvmCollectionFactories = map[string]struct{}{"List.of": {}, "Set.of": {}, "Arrays.asList": {}, "listOf": {}, "setOf": {}, "mutableListOf": {}, "arrayOf": {}}
+)
+
+// parseJVMImportSummary reads Java and Kotlin import declarations. Modules
+// are the lowercased import paths and names are the imported simple names.
+func parseJVMImportSummary(content string) importSummary {
+	moduleSet := map[string]struct{}{}
+	nameSet := map[string]struct{}{}
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		match := jvmImportLinePattern.FindStringSubmatch(trimmed)
+		if len(match) != 2 {
+			continue
+		}
+		path := strings.TrimSuffix(match[1], ".*")
+		moduleSet[strings.ToLower(path)] = struct{}{}
+		if !strings.HasSuffix(match[1], ".*") {
+			nameSet[path[strings.LastIndex(path, ".")+1:]] = struct{}{}
+		}
+	}
+	return importSummary{Modules: sortedKeys(moduleSet), Names: sortedKeys(nameSet)}
+}
+
+// captureJVMChain captures a builder invocation together with the fluent
+// calls chained onto it on following lines (`.defaultTools(...)`, `.build()`).
+func captureJVMChain(lines []string, startLine, startCol int) (string, int) {
+	block, endLine := captureInvocation(lines, startLine, startCol)
+	for endLine < len(lines) {
+		next := lines[endLine]
+		if !strings.HasPrefix(strings.TrimSpace(next), ".") {
+			break
+		}
+		more, nextEnd := captureInvocation(lines, endLine, strings.Index(next, "."))
+		block += "\n" + more
+		endLine = nextEnd
+	}
+	return block, endLine
+}
+
+// enclosingJVMMethod names the method a `return ChatClient.builder(...)`
+// statement belongs to, typically a Spring `@Bean` factory.
+func enclosingJVMMethod(lines []string, lineIdx int) string {
+	for idx := lineIdx; idx >= 0; idx-- {
+		match := jvmMethodDeclPattern.FindStringSubmatch(lines[idx])
+		if len(match) != 3 {
+			continue
+		}
+		declared := strings.Fields(strings.TrimSpace(lines[idx]))
+		if len(declared) > 0 {
+			if _, statement := jvmStatementKeywords[declared[0]]; statement {
+				continue
+			}
+		}
+		return firstNonEmpty(match[1], match[2])
+	}
+	return ""
+}
+
+// jvmAgentSpec adds what builder chains pass through fluent methods and
+// expands tool objects into the `@Tool` methods their classes declare.
+func jvmAgentSpec(agent AgentSpec, content, block, variableName string, profile sourceProfile, toolIndex *annotatedToolIndex) AgentSpec {
+	tools := make([]string, 0, len(agent.Tools))
+	for _, value := range append(append([]string(nil), agent.Tools...), jvmBuilderValues(block, profile.toolKeys)...) {
+		if methods := toolIndex.lookup(jvmValueType(content, value)); len(methods) > 0 {
+			tools = append(tools, methods...)
+			continue
+		}
+		tools = append(tools, value)
+	}
+	agent.Tools = uniqueSorted(tools)
+	agent.DataSources = uniqueSorted(append(agent.DataSources, jvmBuilderValues(block, profile.dataKeys)...))
+	if name := firstJVMBuilderString(block, profile.nameKeys); name != "" && strings.TrimSpace(agent.Name) == strings.TrimSpace(variableName) {
+		agent.Name = name
+	}
+	return agent
+}
+
+// jvmBuilderValues returns the arguments passed to `.method(...)` calls in a
+// builder chain for each of the given method names.
+func jvmBuilderValues(block string, methods []string) []string {
+	values := make([]string, 0)
+	for _, method := range methods {
+		pattern := regexp.MustCompile(`\.` + regexp.QuoteMeta(strings.TrimSpace(method)) + `\s*\(`)
+		for _, loc := range pattern.FindAllStringIndex(block, -1) {
+			fragment, _ := captureBalancedFragment(block, loc[1]-1)
+			inner := strings.TrimSuffix(strings.TrimPrefix(fragment, "("), ")")
+			for _, arg := range splitTopLevel(inner, ',') {
+				values = append(values, normalizeJVMValue(arg)...)
+			}
+		}
+	}
+	return uniqueSorted(values)
+}
+
+func firstJVMBuilderString(block string, methods []string) string {
+	for _, method := range methods {
+		pattern := regexp.MustCompile(`\.` + regexp.QuoteMeta(strings.TrimSpace(method)) + `\s*\(\s*("[^"]*")`)
+		if match := pattern.FindStringSubmatch(block); len(match) == 2 {
+			return quotedValue(match[1])
+		}
+	}
+	return ""
+}
+
+// normalizeJVMValue reduces a builder argument to a readable binding:
+// `new WeatherTools()` becomes WeatherTools, `List.of(a, b)` expands to its
+// items, and factory calls such as `MessageWindowChatMemory.withMaxMessages(10)`
+// keep their type name.
+func normalizeJVMValue(raw string) []string {
+	trimmed := strings.TrimSpace(raw)
+	if trimmed == "" {
+		return nil
+	}
+	if value := quotedValue(trimmed); value != "" {
+		return []string{value}
+	}
+	trimmed = strings.TrimSpace(strings.TrimPrefix(trimmed, "new "))
+	if open := strings.Index(trimmed, "("); open >= 0 {
+		prefix := strings.TrimSpace(trimmed[:open])
+		if _, collection := jvmCollectionFactories[prefix]; collection {
+			fragment, _ := captureBalancedFragment(trimmed, open)
+			out := make([]string, 0)
+			for _, item := range splitTopLevel(strings.TrimSuffix(strings.TrimPrefix(fragment, "("), ")"), ',') {
+				out = append(out, normalizeJVMValue(item)...)
+			}
+			return out
+		}
+		trimmed = prefix
+	}
+	for _, suffix := range []string{"::class.java", "::class", ".class"} {
+		trimmed = strings.TrimSuffix(trimmed, suffix)
+	}
+	trimmed = strings.TrimPrefix(trimmed, "this.")
+	if parts := strings.Split(trimmed, "."); len(parts) > 1 && isUpperIdent(parts[0]) && !isUpperIdent(parts[len(parts)-1]) {
+		trimmed = parts[0]
+	}
+	if trimmed == "" {
+		return nil
+	}
+	return []string{trimmed}
+}
+
+// jvmValueType returns the class behind a tool binding: the value itself
+// when it names a type, or the declared type of a field, parameter, or
+// local variable with that name.
+func jvmValueType(content, value string) string {
+	value = strings.TrimSpace(value)
+	if value == "" || isUpperIdent(value) {
+		return value
+	}
+	quoted := regexp.QuoteMeta(value)
+	for _, pattern := range []*regexp.Regexp{
+		regexp.MustCompile(`\b([A-Z][A-Za-z0-9_]*)(?:<[^>]*>)?\s+` + quoted + `\s*[;,=)]`),
+		regexp.MustCompile(`\b` + quoted + `\s*:\s*([A-Z][A-Za-z0-9_]*)`),
+		regexp.MustCompile(`\b` + quoted + `\s*=\s*(?:new\s+)?([A-Z][A-Za-z0-9_]*)\s*\(`),
+	} {
+		if match := pattern.FindStringSubmatch(content); len(match) == 2 {
+			return match[1]
+		}
+	}
+	return value
+}
+
+func isUpperIdent(value string) bool {
+	return value != "" && value[0] >= 'A' && value[0] <= 'Z'
+}
+
+// annotatedToolIndex maps class names to the tool methods they declare
+// with `@Tool` in JVM sources or `[KernelFunction]` in C# sources, across
+// the scope. It is built on first lookup so scans without JVM or .NET agents
+// never read those files.
+type annotatedToolIndex struct {
+	root       string
+	detectorID string
+	files      []string
+	byClass    map[string][]string
+}
+
+func newAnnotatedToolIndex(root, detectorID string, files []string) *annotatedToolIndex {
+	return &annotatedToolIndex{root: root, detectorID: detectorID, files: files}
+}
+
+func (idx *annotatedToolIndex) lookup(className string) []string {
+	if idx == nil || strings.TrimSpace(className) == "" {
+		return nil
+	}
+	if idx.byClass == nil {
+		idx.build()
+	}
+	return idx.byClass[className]
+}
+
+func (idx *annotatedToolIndex) build() {
+	idx.byClass = map[string][]string{}
+	for _, rel := range idx.files {
+		var extract func(string) map[string][]string
+		switch sourceFamily(sourceLanguage(rel)) {
+		case "jvm":
+			extract = jvmAnnotatedTools
+		case "dotnet":
+			extract = dotnetAnnotatedTools
+		}
+		if extract == nil || shouldSkipSourceFile(rel) {
+			continue
+		}
+		// Unreadable files are reported by the source scan itself; the index
+		// only enriches bindings, so it skips them.
+		payload, parseErr := detect.ReadFileWithinRoot(idx.detectorID, idx.root, rel)
+		if parseErr != nil {
+			continue
+		}
+		for className, tools := range extract(string(payload)) {
+			idx.byClass[className] = uniqueSorted(append(idx.byClass[className], tools...))
+		}
+	}
+}
+
+// jvmAnnotatedTools returns, per declaring class, the names of methods
+// annotated with Spring AI or LangChain4j `@Tool`. An explicit
+// `name = "..."` overrides the method name.
+func jvmAnnotatedTools(content string) map[string][]string {
+	annotations := jvmToolAnnotation.FindAllStringIndex(content, -1)
+	if len(annotations) == 0 {
+		return nil
+	}
+	classes := jvmClassPattern.FindAllStringSubmatchIndex(content, -1)
+	out := map[string][]string{}
+	for _, loc := range annotations {
+		className := ""
+		for _, class := range classes {
+			if class[0] > loc[0] {
+				break
+			}
+			className = content[class[2]:class[3]]
+		}
+		if className == "" {
+			continue
+		}
+		rest := content[loc[1]:]
+		annotation := ""
+		if trimmed := strings.TrimLeft(rest, " \t"); strings.HasPrefix(trimmed, "(") {
+			annotation, _ = captureBalancedFragment(trimmed, 0)
+			rest = trimmed[len(annotation):]
+		}
+		name := firstNamedString(annotation, []string{"name"})
+		if name == "" {
+			name = jvmAnnotatedMethodName(rest)
+		}
+		if name != "" {
+			out[className] = append(out[className], name)
+		}
+	}
+	for className := range out {
+		sort.Strings(out[className])
+	}
+	return out
+}
+
+// jvmAnnotatedMethodName returns the name of the method declared after an
+// annotation, skipping any further annotations on the same method.
+func jvmAnnotatedMethodName(rest string) string {
+	rest = strings.TrimSpace(rest)
+	for strings.HasPrefix(rest, "@") {
+		end := 1
+		for end < len(rest) && (rest[end] == '_' || rest[end] == '.' || isAlphaNumeric(rest[end])) {
+			end++
+		}
+		rest = strings.TrimSpace(rest[end:])
+		if strings.HasPrefix(rest, "(") {
+			fragment, _ := captureBalancedFragment(rest, 0)
+			rest = strings.TrimSpace(rest[len(fragment):])
+		}
+	}
+	rest = strings.TrimPrefix(rest, "fun ")
+	if match := jvmMethodNamePattern.FindStringSubmatch(rest); len(match) == 2 {
+		return match[1]
+	}
+	return ""
+}
+
+func isAlphaNumeric(ch byte) bool {
+	return (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z') || (ch >= '0' && ch <= '9')
+}
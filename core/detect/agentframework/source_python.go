@@ -0,0 +1,205 @@
+package agentframework
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	pythonIdentifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	haystackSecretPattern   = regexp.MustCompile(`Secret\.from_env_var\(\s*["']([A-Z][A-Z0-9_]+)["']`)
+)
+
+// pythonAgentSpec fills in what Python agents bind by name elsewhere in the
+// file: tool lists and models assigned to variables, tools registered with
+// `@agent.tool` decorators, module-level configuration such as
+// `dspy.configure(lm=...)`, and the components added to a Haystack
+// pipeline. It returns the block extended with the statements it read so
+// provider hints see them, and false for a pipeline without tool
+// components, which is a retrieval pipeline rather than an agent.
+func pythonAgentSpec(agent AgentSpec, content, block, variableName, callName string, profile sourceProfile) (AgentSpec, string, bool) {
+	tools := make([]string, 0, len(agent.Tools))
+	for _, tool := range agent.Tools {
+		tools = append(tools, resolvePythonTool(content, tool)...)
+	}
+	tools = append(tools, pythonDecoratedTools(content, variableName)...)
+
+	related := make([]string, 0)
+	for _, key := range profile.modelKeys {
+		for _, expr := range namedExpressions(block, key) {
+			if assigned := pythonAssignedExpression(content, expr); assigned != "" {
+				related = append(related, assigned)
+			}
+		}
+	}
+	// Pydantic AI takes the model as its first positional argument.
+	if len(profile.modelKeys) > 0 {
+		assigned := pythonAssignedExpression(content, positionalArgAt(block, 0))
+		if strings.HasSuffix(pythonCallee(assigned), "Model") {
+			related = append(related, assigned)
+		}
+	}
+	related = append(related, callStatements(content, profile.contextCalls)...)
+
+	dataSources := append([]string(nil), agent.DataSources...)
+	switch strings.TrimSpace(callName) {
+	case "Pipeline", "AsyncPipeline":
+		componentTools := make([]string, 0)
+		for _, component := range pythonPipelineComponents(content, variableName) {
+			for _, tool := range extractNamedValues(component, profile.toolKeys) {
+				componentTools = append(componentTools, resolvePythonTool(content, tool)...)
+			}
+			if callee := pythonCallee(component); strings.Contains(callee, "Retriever") {
+				dataSources = append(dataSources, callee)
+			}
+			related = append(related, component)
+		}
+		if len(componentTools) == 0 {
+			return agent, block, false
+		}
+		tools = append(tools, componentTools...)
+	}
+
+	extra := strings.Join(related, "\n")
+	agent.Tools = uniqueSorted(tools)
+	agent.DataSources = uniqueSorted(append(dataSources, extractNamedValues(extra, profile.dataKeys)...))
+	agent.AuthSurfaces = uniqueSorted(append(append(agent.AuthSurfaces, extractNamedValues(extra, profile.authKeys)...), extractEnvVars(extra)...))
+	agent.DataClass = inferSourceDataClass(agent.DataSources, agent.AuthSurfaces)
+	if len(namedExpressions(block, "sub_agents")) > 0 || len(namedExpressions(block, "managed_agents")) > 0 {
+		agent.DynamicDiscovery = true
+	}
+	for _, name := range profile.codeExecutionCalls {
+		if strings.TrimSpace(callName) == name {
+			agent.CodeExecution = true
+		}
+	}
+	if extra != "" {
+		block += "\n" + extra
+	}
+	return agent, block, true
+}
+
+// resolvePythonTool expands a tool list bound to a variable and names a
+// tool object by its `name=` argument, as Haystack `Tool` and
+// `ComponentTool` take it.
+func resolvePythonTool(content, tool string) []string {
+	tool = strings.TrimPrefix(strings.TrimSpace(tool), "*")
+	expr := pythonAssignedExpression(content, tool)
+	if !strings.HasPrefix(expr, "[") {
+		return []string{pythonToolName(tool, expr)}
+	}
+	out := make([]string, 0)
+	for _, item := range parseExpressionValues(expr) {
+		out = append(out, pythonToolName(item, pythonAssignedExpression(content, item)))
+	}
+	return out
+}
+
+func pythonToolName(tool, assigned string) string {
+	callee := pythonCallee(assigned)
+	if strings.HasSuffix(callee, "Tool") {
+		if name := firstNamedString(assigned, []string{"name"}); name != "" {
+			return name
+		}
+	}
+	return tool
+}
+
+// pythonAssignedExpression returns the right-hand side of the first
+// `name = ...` assignment in the file, including a bracketed value that
+// spans several lines. Keyword arguments (`name=value,`) are skipped.
+func pythonAssignedExpression(content, name string) string {
+	name = strings.TrimSpace(name)
+	if !pythonIdentifierPattern.MatchString(name) {
+		return ""
+	}
+	pattern := regexp.MustCompile(`(?m)^[ \t]*` + regexp.QuoteMeta(name) + `[ \t]*(?::[^=\n]+)?=[ \t]*`)
+	for _, loc := range pattern.FindAllStringIndex(content, -1) {
+		rest := content[loc[1]:]
+		line := rest
+		if end := strings.IndexByte(rest, '\n'); end >= 0 {
+			line = rest[:end]
+		}
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "=") || strings.HasSuffix(line, ",") {
+			continue
+		}
+		open := strings.IndexAny(line, "([{")
+		if open < 0 {
+			return line
+		}
+		fragment, _ := captureBalancedFragment(rest, open)
+		return strings.TrimSpace(line[:open]) + fragment
+	}
+	return ""
+}
+
+// pythonPipelineComponents returns the components added to a Haystack
+// pipeline with `pipe.add_component("name", Component(...))`, resolving
+// components built into a variable first.
+func pythonPipelineComponents(content, pipelineVar string) []string {
+	pipelineVar = strings.TrimSpace(pipelineVar)
+	if pipelineVar == "" {
+		return nil
+	}
+	pattern := regexp.MustCompile(`\b` + regexp.QuoteMeta(pipelineVar) + `\.add_component\(`)
+	out := make([]string, 0)
+	for _, loc := range pattern.FindAllStringIndex(content, -1) {
+		invocation, _ := captureBalancedFragment(content, loc[1]-1)
+		component := firstNonEmpty(firstNamedExpression(invocation, "instance"), positionalArgAt(invocation, 1))
+		if assigned := pythonAssignedExpression(content, component); assigned != "" {
+			component = assigned
+		}
+		if component != "" {
+			out = append(out, component)
+		}
+	}
+	return out
+}
+
+// pythonDecoratedTools returns functions registered on an agent variable
+// with Pydantic AI's `@agent.tool` and `@agent.tool_plain` decorators.
+func pythonDecoratedTools(content, agentVar string) []string {
+	agentVar = strings.TrimSpace(agentVar)
+	if agentVar == "" {
+		return nil
+	}
+	pattern := regexp.MustCompile(`(?m)^[ \t]*@` + regexp.QuoteMeta(agentVar) + `\.tool(?:_plain)?\b[^\n]*\n(?:[ \t]*@[^\n]*\n)*[ \t]*(?:async[ \t]+)?def[ \t]+([A-Za-z_][A-Za-z0-9_]*)`)
+	out := make([]string, 0)
+	for _, match := range pattern.FindAllStringSubmatch(content, -1) {
+		out = append(out, match[1])
+	}
+	return out
+}
+
+// pythonCallee returns the class or function an expression calls, without
+// its module qualifier.
+func pythonCallee(expr string) string {
+	open := strings.Index(expr, "(")
+	if open <= 0 {
+		return ""
+	}
+	callee := strings.TrimSpace(expr[:open])
+	if dot := strings.LastIndex(callee, "."); dot >= 0 {
+		callee = callee[dot+1:]
+	}
+	if !pythonIdentifierPattern.MatchString(callee) {
+		return ""
+	}
+	return callee
+}
+
+func firstNamedExpression(block, key string) string {
+	if exprs := namedExpressions(block, key); len(exprs) > 0 {
+		return strings.TrimSpace(exprs[0])
+	}
+	return ""
+}
+
+func positionalArgAt(block string, index int) string {
+	args := positionalArgs(block)
+	if index < 0 || index >= len(args) {
+		return ""
+	}
+	return strings.TrimSpace(args[index])
+}
@@ -0,0 +1,47 @@
+package agenthaystack
+
+import (
+	"context"
+
+	"github.com/Clyra-AI/wrkr/core/detect"
+	"github.com/Clyra-AI/wrkr/core/detect/agentframework"
+	"github.com/Clyra-AI/wrkr/core/model"
+)
+
+const detectorID = "agenthaystack"
+
+type Detector struct{}
+
+func New() Detector { return Detector{} }
+
+func (Detector) ID() string { return detectorID }
+
+func (Detector) Detect(ctx context.Context, scope detect.Scope, options detect.Options) ([]model.Finding, error) {
+	_ = ctx
+	return agentframework.DetectManyWithOptions(scope, []agentframework.DetectorConfig{
+		{
+			DetectorID: detectorID,
+			Framework:  "haystack",
+			ConfigPath: ".wrkr/agents/haystack.yaml",
+			Format:     "yaml",
+		},
+		{
+			DetectorID: detectorID,
+			Framework:  "haystack",
+			ConfigPath: ".wrkr/agents/haystack.yml",
+			Format:     "yaml",
+		},
+		{
+			DetectorID: detectorID,
+			Framework:  "haystack",
+			ConfigPath: ".wrkr/agents/haystack.json",
+			Format:     "json",
+		},
+		{
+			DetectorID: detectorID,
+			Framework:  "haystack",
+			ConfigPath: ".wrkr/agents/haystack.toml",
+			Format:     "toml",
+		},
+	}, options)
+}
@@ -0,0 +1,118 @@
+package agenthaystack
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Clyra-AI/wrkr/core/detect"
+	"github.com/Clyra-AI/wrkr/core/model"
+)
+
+func TestHaystackDetector_DeclarationBaseline(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeFile(t, root, ".wrkr/agents/haystack.yaml", `agents:
+  - name: rag
+    file: agents/pipeline_agent.py
+    tools: [weather]
+`)
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Org: "acme", Repo: "weather", Root: root}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect: %v", err)
+	}
+	if len(findings) != 1 {
+		t.Fatalf("expected one finding, got %d", len(findings))
+	}
+	if findings[0].ToolType != "haystack" || findings[0].Detector != detectorID {
+		t.Fatalf("unexpected finding %+v", findings[0])
+	}
+}
+
+func TestHaystackDetector_AgentAndToolPipeline(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeFile(t, root, "agents/pipeline_agent.py", `from haystack import Pipeline
+from haystack.components.agents import Agent
+from haystack.components.generators.chat import OpenAIChatGenerator
+from haystack.components.retrievers.in_memory import InMemoryBM25Retriever
+from haystack.components.tools import ToolInvoker
+from haystack.tools import Tool
+from haystack.utils import Secret
+
+weather_tool = Tool(
+    name="weather",
+    description="Get the weather for a city",
+    parameters={"type": "object"},
+    function=get_weather,
+)
+generator = OpenAIChatGenerator(model="gpt-4o-mini", api_key=Secret.from_env_var("OPENAI_API_KEY"))
+
+agent = Agent(chat_generator=generator, tools=[weather_tool], system_prompt="Answer weather questions.")
+
+rag = Pipeline()
+rag.add_component("retriever", InMemoryBM25Retriever(document_store=store))
+rag.add_component("llm", generator)
+rag.add_component("tool_invoker", ToolInvoker(tools=[weather_tool]))
+
+docs_only = Pipeline()
+docs_only.add_component("retriever", InMemoryBM25Retriever(document_store=store))
+`)
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Org: "acme", Repo: "weather", Root: root}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect: %v", err)
+	}
+	bySymbol := map[string]model.Finding{}
+	for _, finding := range findings {
+		bySymbol[evidenceValue(finding.Evidence, "symbol")] = finding
+	}
+	if len(bySymbol) != 2 {
+		t.Fatalf("expected the agent and the tool pipeline only, got %+v", findings)
+	}
+	for symbol, want := range map[string]map[string]string{
+		"agent": {
+			"source_call":     "Agent",
+			"bound_tools":     "weather",
+			"auth_surfaces":   "OPENAI_API_KEY",
+			"model_providers": "openai",
+		},
+		"rag": {
+			"source_call":     "Pipeline",
+			"bound_tools":     "weather",
+			"data_sources":    "InMemoryBM25Retriever,store",
+			"auth_surfaces":   "OPENAI_API_KEY",
+			"model_providers": "openai",
+		},
+	} {
+		for key, value := range want {
+			if got := evidenceValue(bySymbol[symbol].Evidence, key); got != value {
+				t.Fatalf("expected %s %s=%q, got %q", symbol, key, value, got)
+			}
+		}
+	}
+}
+
+func writeFile(t *testing.T, root, rel, content string) {
+	t.Helper()
+	path := filepath.Join(root, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir %s: %v", rel, err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write %s: %v", rel, err)
+	}
+}
+
+func evidenceValue(evidence []model.Evidence, key string) string {
+	for _, item := range evidence {
+		if item.Key == key {
+			return item.Value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,47 @@
+package agentlanggraph
+
+import (
+	"context"
+
+	"github.com/Clyra-AI/wrkr/core/detect"
+	"github.com/Clyra-AI/wrkr/core/detect/agentframework"
+	"github.com/Clyra-AI/wrkr/core/model"
+)
+
+const detectorID = "agentlanggraph"
+
+type Detector struct{}
+
+func New() Detector { return Detector{} }
+
+func (Detector) ID() string { return detectorID }
+
+func (Detector) Detect(ctx context.Context, scope detect.Scope, options detect.Options) ([]model.Finding, error) {
+	_ = ctx
+	return agentframework.DetectManyWithOptions(scope, []agentframework.DetectorConfig{
+		{
+			DetectorID: detectorID,
+			Framework:  "langgraph",
+			ConfigPath: ".wrkr/agents/langgraph.yaml",
+			Format:     "yaml",
+		},
+		{
+			DetectorID: detectorID,
+			Framework:  "langgraph",
+			ConfigPath: ".wrkr/agents/langgraph.yml",
+			Format:     "yaml",
+		},
+		{
+			DetectorID: detectorID,
+			Framework:  "langgraph",
+			ConfigPath: ".wrkr/agents/langgraph.json",
+			Format:     "json",
+		},
+		{
+			DetectorID: detectorID,
+			Framework:  "langgraph",
+			ConfigPath: ".wrkr/agents/langgraph.toml",
+			Format:     "toml",
+		},
+	}, options)
+}
@@ -0,0 +1,184 @@
+package agentlanggraph
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Clyra-AI/wrkr/core/detect"
+	"github.com/Clyra-AI/wrkr/core/model"
+)
+
+func TestLangGraphDetector_DeclarationBaseline(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeFile(t, root, ".wrkr/agents/langgraph.yaml", `agents:
+  - name: support_graph
+    file: src/agent/graph.ts
+    tools: [searchTool]
+    human_gate: true
+`)
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Org: "acme", Repo: "support", Root: root}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect: %v", err)
+	}
+	if len(findings) != 1 {
+		t.Fatalf("expected one finding, got %d", len(findings))
+	}
+	if findings[0].ToolType != "langgraph" || findings[0].Detector != detectorID {
+		t.Fatalf("unexpected finding %+v", findings[0])
+	}
+}
+
+func TestLangGraphDetector_TypeScriptSourceOnlyRepo(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeFile(t, root, "src/agent/graph.ts", `import { ChatOpenAI } from "@langchain/openai";
+import { MemorySaver, MessagesAnnotation, StateGraph, interrupt } from "@langchain/langgraph";
+import { ToolNode, createReactAgent } from "@langchain/langgraph/prebuilt";
+import { refundTool, searchTool } from "./tools";
+
+const tools = [searchTool, refundTool];
+const model = new ChatOpenAI({ model: "gpt-4o", apiKey: process.env.OPENAI_API_KEY }).bindTools(tools);
+const toolNode = new ToolNode(tools);
+
+async function callModel(state: typeof MessagesAnnotation.State) {
+  return { messages: [await model.invoke(state.messages)] };
+}
+
+function approveRefund(state: typeof MessagesAnnotation.State) {
+  const decision = interrupt({ question: "Approve this refund?" });
+  return { messages: [decision] };
+}
+
+const workflow = new StateGraph(MessagesAnnotation)
+  .addNode("agent", callModel)
+  .addNode("approve", approveRefund)
+  .addNode("tools", toolNode)
+  .addEdge("__start__", "agent");
+
+export const app = workflow.compile({ checkpointer: new MemorySaver() });
+await app.invoke({ messages: [] }, { recursionLimit: 12 });
+
+export const researcher = createReactAgent({
+  llm: new ChatOpenAI({ model: "gpt-4o-mini" }),
+  tools: [searchTool],
+  checkpointSaver: new MemorySaver(),
+  interruptBefore: ["tools"],
+  name: "researcher",
+});
+`)
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Org: "acme", Repo: "support", Root: root}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect: %v", err)
+	}
+	bySymbol := map[string]model.Finding{}
+	for _, finding := range findings {
+		bySymbol[evidenceValue(finding.Evidence, "symbol")] = finding
+	}
+	if len(bySymbol) != 2 {
+		t.Fatalf("expected the graph and the prebuilt agent, got %+v", bySymbol)
+	}
+
+	graph, ok := bySymbol["workflow"]
+	if !ok {
+		t.Fatalf("expected StateGraph finding, got %+v", bySymbol)
+	}
+	for key, want := range map[string]string{
+		"source_call":          "StateGraph",
+		"bound_tools":          "refundTool,searchTool",
+		"data_sources":         "MemorySaver",
+		"step_limit":           "recursionLimit=12",
+		"human_gate":           "true",
+		"workflow_invocations": "graph_workflow",
+	} {
+		if got := evidenceValue(graph.Evidence, key); got != want {
+			t.Fatalf("expected %s=%q, got %q", key, want, got)
+		}
+	}
+
+	researcher, ok := bySymbol["researcher"]
+	if !ok {
+		t.Fatalf("expected createReactAgent finding, got %+v", bySymbol)
+	}
+	for key, want := range map[string]string{
+		"bound_tools":     "searchTool",
+		"data_sources":    "MemorySaver",
+		"human_gate":      "true",
+		"model_providers": "openai",
+	} {
+		if got := evidenceValue(researcher.Evidence, key); got != want {
+			t.Fatalf("expected %s=%q, got %q", key, want, got)
+		}
+	}
+}
+
+func TestLangGraphDetector_PythonInterrupt(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeFile(t, root, "agents/graph.py", `from langchain_openai import ChatOpenAI
+from langgraph.prebuilt import create_react_agent
+from langgraph.types import interrupt
+
+
+def issue_refund(order_id: str) -> str:
+    answer = interrupt({"question": f"Refund {order_id}?"})
+    return answer
+
+
+def lookup_order(order_id: str) -> str:
+    return order_id
+
+
+agent = create_react_agent(ChatOpenAI(model="gpt-4o"), [issue_refund], name="refunds")
+lookup = create_react_agent(ChatOpenAI(model="gpt-4o"), [lookup_order], name="lookup")
+`)
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Org: "acme", Repo: "support", Root: root}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect: %v", err)
+	}
+	bySymbol := map[string]model.Finding{}
+	for _, finding := range findings {
+		bySymbol[evidenceValue(finding.Evidence, "symbol")] = finding
+	}
+	if len(bySymbol) != 2 {
+		t.Fatalf("expected two LangGraph agents, got %+v", findings)
+	}
+	refunds := bySymbol["refunds"]
+	if got := evidenceValue(refunds.Evidence, "bound_tools"); got != "issue_refund" {
+		t.Fatalf("expected positional tools, got %q", got)
+	}
+	if got := evidenceValue(refunds.Evidence, "human_gate"); got != "true" {
+		t.Fatalf("expected interrupt to count as a human gate, got %q", got)
+	}
+	if got := evidenceValue(bySymbol["lookup"].Evidence, "human_gate"); got != "false" {
+		t.Fatalf("expected interrupt in another agent's tool not to gate lookup, got %q", got)
+	}
+}
+
+func writeFile(t *testing.T, root, rel, content string) {
+	t.Helper()
+	path := filepath.Join(root, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir %s: %v", rel, err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write %s: %v", rel, err)
+	}
+}
+
+func evidenceValue(evidence []model.Evidence, key string) string {
+	for _, item := range evidence {
+		if item.Key == key {
+			return item.Value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,47 @@
+package agentmastra
+
+import (
+	"context"
+
+	"github.com/Clyra-AI/wrkr/core/detect"
+	"github.com/Clyra-AI/wrkr/core/detect/agentframework"
+	"github.com/Clyra-AI/wrkr/core/model"
+)
+
+const detectorID = "agentmastra"
+
+type Detector struct{}
+
+func New() Detector { return Detector{} }
+
+func (Detector) ID() string { return detectorID }
+
+func (Detector) Detect(ctx context.Context, scope detect.Scope, options detect.Options) ([]model.Finding, error) {
+	_ = ctx
+	return agentframework.DetectManyWithOptions(scope, []agentframework.DetectorConfig{
+		{
+			DetectorID: detectorID,
+			Framework:  "mastra",
+			ConfigPath: ".wrkr/agents/mastra.yaml",
+			Format:     "yaml",
+		},
+		{
+			DetectorID: detectorID,
+			Framework:  "mastra",
+			ConfigPath: ".wrkr/agents/mastra.yml",
+			Format:     "yaml",
+		},
+		{
+			DetectorID: detectorID,
+			Framework:  "mastra",
+			ConfigPath: ".wrkr/agents/mastra.json",
+			Format:     "json",
+		},
+		{
+			DetectorID: detectorID,
+			Framework:  "mastra",
+			ConfigPath: ".wrkr/agents/mastra.toml",
+			Format:     "toml",
+		},
+	}, options)
+}
@@ -0,0 +1,174 @@
+package agentmastra
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Clyra-AI/wrkr/core/detect"
+	"github.com/Clyra-AI/wrkr/core/model"
+)
+
+func TestMastraDetector_DeclarationBaseline(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeFile(t, root, ".wrkr/agents/mastra.yaml", `agents:
+  - name: weather_agent
+    file: src/mastra/agents/weather-agent.ts
+    tools: [weatherTool]
+`)
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Org: "acme", Repo: "weather", Root: root}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect: %v", err)
+	}
+	if len(findings) != 1 {
+		t.Fatalf("expected one finding, got %d", len(findings))
+	}
+	if findings[0].ToolType != "mastra" || findings[0].Detector != detectorID {
+		t.Fatalf("unexpected finding %+v", findings[0])
+	}
+}
+
+func TestMastraDetector_TypeScriptSourceOnlyRepo(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeFile(t, root, "src/mastra/agents/weather-agent.ts", `import { openai } from "@ai-sdk/openai";
+import { Agent } from "@mastra/core/agent";
+import { LibSQLStore } from "@mastra/libsql";
+import { Memory } from "@mastra/memory";
+import { mcp } from "../mcp";
+import { weatherTool } from "../tools/weather-tool";
+import { weatherWorkflow } from "../workflows/weather-workflow";
+
+export const weatherAgent = new Agent({
+  name: "Weather Agent",
+  instructions: "You are a helpful weather assistant.",
+  model: openai("gpt-4o-mini"),
+  tools: { weatherTool },
+  workflows: { weatherWorkflow },
+  memory: new Memory({ storage: new LibSQLStore({ url: "file:../mastra.db" }) }),
+});
+
+export const opsAgent = new Agent({
+  name: "Ops Agent",
+  instructions: "Operate the GitHub MCP tools.",
+  model: openai("gpt-4o"),
+  tools: await mcp.getTools(),
+});
+
+const response = await weatherAgent.generate("What is the weather in Paris?", { maxSteps: 4 });
+`)
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Org: "acme", Repo: "weather", Root: root}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect: %v", err)
+	}
+	bySymbol := map[string]model.Finding{}
+	for _, finding := range findings {
+		bySymbol[evidenceValue(finding.Evidence, "symbol")] = finding
+	}
+	if len(bySymbol) != 2 {
+		t.Fatalf("expected two Mastra agents, got %+v", bySymbol)
+	}
+
+	weather, ok := bySymbol["Weather Agent"]
+	if !ok {
+		t.Fatalf("expected Weather Agent finding, got %+v", bySymbol)
+	}
+	for key, want := range map[string]string{
+		"bound_tools":     "weatherTool,weatherWorkflow",
+		"data_sources":    "Memory",
+		"step_limit":      "maxSteps=4",
+		"human_gate":      "false",
+		"model_providers": "openai",
+	} {
+		if got := evidenceValue(weather.Evidence, key); got != want {
+			t.Fatalf("expected %s=%q, got %q", key, want, got)
+		}
+	}
+
+	ops, ok := bySymbol["Ops Agent"]
+	if !ok {
+		t.Fatalf("expected Ops Agent finding, got %+v", bySymbol)
+	}
+	if got := evidenceValue(ops.Evidence, "bound_tools"); got != "mcp" {
+		t.Fatalf("expected MCP client tool set, got %q", got)
+	}
+	if got := evidenceValue(ops.Evidence, "dynamic_discovery"); got != "true" {
+		t.Fatalf("expected discovered MCP tools, got %q", got)
+	}
+}
+
+func TestMastraDetector_ToolSuspendGatesOnlyItsAgent(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeFile(t, root, "src/mastra/agents/refund-agent.ts", `import { Agent } from "@mastra/core/agent";
+import { createTool } from "@mastra/core/tools";
+import { searchTool } from "../tools/search-tool";
+
+export const refundTool = createTool({
+  id: "refund",
+  description: "Refund an order",
+  execute: async ({ context, resumeData, suspend }) => {
+    if (!resumeData?.approved) {
+      await suspend({ reason: "Refunds need a human approver" });
+    }
+    return { refunded: true };
+  },
+});
+
+export const refundAgent = new Agent({
+  name: "Refund Agent",
+  instructions: "Process refunds.",
+  model: "openai/gpt-4o",
+  tools: { refundTool },
+});
+
+export const searchAgent = new Agent({
+  name: "Search Agent",
+  instructions: "Search orders.",
+  model: "openai/gpt-4o",
+  tools: { searchTool },
+});
+`)
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Org: "acme", Repo: "weather", Root: root}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect: %v", err)
+	}
+	gates := map[string]string{}
+	for _, finding := range findings {
+		gates[evidenceValue(finding.Evidence, "symbol")] = evidenceValue(finding.Evidence, "human_gate")
+	}
+	if gates["Refund Agent"] != "true" {
+		t.Fatalf("expected a suspending tool to gate its agent, got %+v", gates)
+	}
+	if gates["Search Agent"] != "false" {
+		t.Fatalf("expected another agent's tool gate not to apply, got %+v", gates)
+	}
+}
+
+func writeFile(t *testing.T, root, rel, content string) {
+	t.Helper()
+	path := filepath.Join(root, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir %s: %v", rel, err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write %s: %v", rel, err)
+	}
+}
+
+func evidenceValue(evidence []model.Evidence, key string) string {
+	for _, item := range evidence {
+		if item.Key == key {
+			return item.Value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,47 @@
+package agentpydanticai
+
+import (
+	"context"
+
+	"github.com/Clyra-AI/wrkr/core/detect"
+	"github.com/Clyra-AI/wrkr/core/detect/agentframework"
+	"github.com/Clyra-AI/wrkr/core/model"
+)
+
+const detectorID = "agentpydanticai"
+
+type Detector struct{}
+
+func New() Detector { return Detector{} }
+
+func (Detector) ID() string { return detectorID }
+
+func (Detector) Detect(ctx context.Context, scope detect.Scope, options detect.Options) ([]model.Finding, error) {
+	_ = ctx
+	return agentframework.DetectManyWithOptions(scope, []agentframework.DetectorConfig{
+		{
+			DetectorID: detectorID,
+			Framework:  "pydantic_ai",
+			ConfigPath: ".wrkr/agents/pydantic-ai.yaml",
+			Format:     "yaml",
+		},
+		{
+			DetectorID: detectorID,
+			Framework:  "pydantic_ai",
+			ConfigPath: ".wrkr/agents/pydantic-ai.yml",
+			Format:     "yaml",
+		},
+		{
+			DetectorID: detectorID,
+			Framework:  "pydantic_ai",
+			ConfigPath: ".wrkr/agents/pydantic-ai.json",
+			Format:     "json",
+		},
+		{
+			DetectorID: detectorID,
+			Framework:  "pydantic_ai",
+			ConfigPath: ".wrkr/agents/pydantic-ai.toml",
+			Format:     "toml",
+		},
+	}, options)
+}
@@ -0,0 +1,107 @@
+package agentpydanticai
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Clyra-AI/wrkr/core/detect"
+	"github.com/Clyra-AI/wrkr/core/model"
+)
+
+func TestPydanticAIDetector_DeclarationBaseline(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeFile(t, root, ".wrkr/agents/pydantic-ai.yaml", `agents:
+  - name: support_agent
+    file: agents/support_agent.py
+    tools: [customer_balance]
+`)
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Org: "acme", Repo: "support", Root: root}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect: %v", err)
+	}
+	if len(findings) != 1 {
+		t.Fatalf("expected one finding, got %d", len(findings))
+	}
+	if findings[0].ToolType != "pydantic_ai" || findings[0].Detector != detectorID {
+		t.Fatalf("unexpected finding %+v", findings[0])
+	}
+}
+
+func TestPydanticAIDetector_PythonSourceOnlyRepo(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeFile(t, root, "agents/support_agent.py", `import os
+
+from pydantic_ai import Agent, RunContext
+from pydantic_ai.mcp import MCPServerStdio
+from pydantic_ai.models.openai import OpenAIModel
+from pydantic_ai.providers.openai import OpenAIProvider
+
+model = OpenAIModel(
+    "gpt-4o",
+    provider=OpenAIProvider(api_key=os.environ["OPENAI_API_KEY"]),
+)
+github = MCPServerStdio("npx", args=["-y", "@modelcontextprotocol/server-github"])
+support_tools = [lookup_order, refund_order]
+
+support_agent = Agent(
+    model,
+    name="support",
+    deps_type=SupportDependencies,
+    tools=support_tools,
+    toolsets=[github],
+)
+
+
+@support_agent.tool
+async def customer_balance(ctx: RunContext[SupportDependencies]) -> float:
+    return await ctx.deps.db.customer_balance(ctx.deps.customer_id)
+`)
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Org: "acme", Repo: "support", Root: root}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect: %v", err)
+	}
+	if len(findings) != 1 {
+		t.Fatalf("expected one source finding, got %+v", findings)
+	}
+	for key, want := range map[string]string{
+		"symbol":          "support",
+		"source_language": "python",
+		"source_call":     "Agent",
+		"bound_tools":     "customer_balance,github,lookup_order,refund_order",
+		"data_sources":    "SupportDependencies",
+		"auth_surfaces":   "OPENAI_API_KEY",
+		"model_providers": "openai",
+	} {
+		if got := evidenceValue(findings[0].Evidence, key); got != want {
+			t.Fatalf("expected %s=%q, got %q", key, want, got)
+		}
+	}
+}
+
+func writeFile(t *testing.T, root, rel, content string) {
+	t.Helper()
+	path := filepath.Join(root, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir %s: %v", rel, err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write %s: %v", rel, err)
+	}
+}
+
+func evidenceValue(evidence []model.Evidence, key string) string {
+	for _, item := range evidence {
+		if item.Key == key {
+			return item.Value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,47 @@
+package agentsemantickernel
+
+import (
+	"context"
+
+	"github.com/Clyra-AI/wrkr/core/detect"
+	"github.com/Clyra-AI/wrkr/core/detect/agentframework"
+	"github.com/Clyra-AI/wrkr/core/model"
+)
+
+const detectorID = "agentsemantickernel"
+
+type Detector struct{}
+
+func New() Detector { return Detector{} }
+
+func (Detector) ID() string { return detectorID }
+
+func (Detector) Detect(ctx context.Context, scope detect.Scope, options detect.Options) ([]model.Finding, error) {
+	_ = ctx
+	return agentframework.DetectManyWithOptions(scope, []agentframework.DetectorConfig{
+		{
+			DetectorID: detectorID,
+			Framework:  "semantic_kernel",
+			ConfigPath: ".wrkr/agents/semantic-kernel.yaml",
+			Format:     "yaml",
+		},
+		{
+			DetectorID: detectorID,
+			Framework:  "semantic_kernel",
+			ConfigPath: ".wrkr/agents/semantic-kernel.yml",
+			Format:     "yaml",
+		},
+		{
+			DetectorID: detectorID,
+			Framework:  "semantic_kernel",
+			ConfigPath: ".wrkr/agents/semantic-kernel.json",
+			Format:     "json",
+		},
+		{
+			DetectorID: detectorID,
+			Framework:  "semantic_kernel",
+			ConfigPath: ".wrkr/agents/semantic-kernel.toml",
+			Format:     "toml",
+		},
+	}, options)
+}
@@ -0,0 +1,136 @@
+package agentsemantickernel
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Clyra-AI/wrkr/core/detect"
+	"github.com/Clyra-AI/wrkr/core/model"
+)
+
+func TestSemanticKernelDetector_DeclarationBaseline(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeFile(t, root, ".wrkr/agents/semantic-kernel.yaml", `agents:
+  - name: host_agent
+    file: src/Support/Program.cs
+    tools: [get_lights]
+    api_specs: [src/Support/Plugins/github.yaml]
+`)
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Org: "acme", Repo: "support", Root: root}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect: %v", err)
+	}
+	if len(findings) != 1 {
+		t.Fatalf("expected one finding, got %d", len(findings))
+	}
+	if findings[0].ToolType != "semantic_kernel" || findings[0].Detector != detectorID {
+		t.Fatalf("unexpected finding %+v", findings[0])
+	}
+	if got := evidenceValue(findings[0].Evidence, "api_specs"); got != "src/Support/Plugins/github.yaml" {
+		t.Fatalf("expected declared api spec, got %q", got)
+	}
+}
+
+func TestSemanticKernelDetector_CSharpSourceOnlyRepo(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeFile(t, root, "src/Support/Program.cs", `using Microsoft.SemanticKernel;
+using Microsoft.SemanticKernel.Agents;
+
+var builder = Kernel.CreateBuilder();
+builder.AddOpenAIChatCompletion("gpt-4o", Environment.GetEnvironmentVariable("OPENAI_API_KEY"));
+builder.Plugins.AddFromType<LightsPlugin>();
+Kernel kernel = builder.Build();
+await kernel.ImportPluginFromOpenApiAsync("github", Path.Combine(AppContext.BaseDirectory, "Plugins", "github.yaml"));
+
+ChatCompletionAgent agent = new()
+{
+    Name = "HostAgent",
+    Instructions = "Answer questions about the house.",
+    Kernel = kernel,
+};
+`)
+	writeFile(t, root, "src/Support/Plugins/LightsPlugin.cs", `using System.ComponentModel;
+using Microsoft.SemanticKernel;
+
+public class LightsPlugin
+{
+    [KernelFunction("get_lights")]
+    [Description("Gets a list of lights and their current state")]
+    public Task<List<LightModel>> GetLightsAsync() => Task.FromResult(lights);
+
+    [KernelFunction, Description("Changes the state of the light")]
+    public async Task<LightModel?> ChangeStateAsync(int id, bool isOn) => null;
+}
+`)
+	writeFile(t, root, "src/Support/Plugins/github.yaml", `openapi: 3.0.0
+info:
+  title: GitHub
+  version: "1"
+paths:
+  /repos/{owner}/{repo}/issues:
+    post:
+      operationId: createIssue
+`)
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Org: "acme", Repo: "support", Root: root}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect: %v", err)
+	}
+	bySymbol := map[string]model.Finding{}
+	for _, finding := range findings {
+		bySymbol[evidenceValue(finding.Evidence, "symbol")] = finding
+	}
+	if len(findings) != 2 {
+		t.Fatalf("expected kernel and agent findings, got %+v", findings)
+	}
+	agent, ok := bySymbol["HostAgent"]
+	if !ok {
+		t.Fatalf("missing HostAgent finding in %+v", findings)
+	}
+	for key, want := range map[string]string{
+		"source_language":   "csharp",
+		"source_call":       "ChatCompletionAgent",
+		"bound_tools":       "ChangeState,get_lights,github",
+		"auth_surfaces":     "OPENAI_API_KEY",
+		"api_specs":         "src/Support/Plugins/github.yaml",
+		"reachable_targets": "src/Support/Plugins/github.yaml",
+		"model_providers":   "openai",
+	} {
+		if got := evidenceValue(agent.Evidence, key); got != want {
+			t.Fatalf("expected %s=%q, got %q", key, want, got)
+		}
+	}
+	if len(agent.ExecutionRelationships) != 1 || agent.ExecutionRelationships[0].Caller != "src/Support/Plugins/github.yaml" || agent.ExecutionRelationships[0].Callee != "src/Support/Program.cs" {
+		t.Fatalf("expected spec consumer relationship, got %+v", agent.ExecutionRelationships)
+	}
+	if got := evidenceValue(bySymbol["builder"].Evidence, "source_call"); got != "Kernel.CreateBuilder" {
+		t.Fatalf("expected Kernel.CreateBuilder finding, got %q", got)
+	}
+}
+
+func writeFile(t *testing.T, root, rel, content string) {
+	t.Helper()
+	path := filepath.Join(root, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir %s: %v", rel, err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write %s: %v", rel, err)
+	}
+}
+
+func evidenceValue(evidence []model.Evidence, key string) string {
+	for _, item := range evidence {
+		if item.Key == key {
+			return item.Value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,47 @@
+package agentsmolagents
+
+import (
+	"context"
+
+	"github.com/Clyra-AI/wrkr/core/detect"
+	"github.com/Clyra-AI/wrkr/core/detect/agentframework"
+	"github.com/Clyra-AI/wrkr/core/model"
+)
+
+const detectorID = "agentsmolagents"
+
+type Detector struct{}
+
+func New() Detector { return Detector{} }
+
+func (Detector) ID() string { return detectorID }
+
+func (Detector) Detect(ctx context.Context, scope detect.Scope, options detect.Options) ([]model.Finding, error) {
+	_ = ctx
+	return agentframework.DetectManyWithOptions(scope, []agentframework.DetectorConfig{
+		{
+			DetectorID: detectorID,
+			Framework:  "smolagents",
+			ConfigPath: ".wrkr/agents/smolagents.yaml",
+			Format:     "yaml",
+		},
+		{
+			DetectorID: detectorID,
+			Framework:  "smolagents",
+			ConfigPath: ".wrkr/agents/smolagents.yml",
+			Format:     "yaml",
+		},
+		{
+			DetectorID: detectorID,
+			Framework:  "smolagents",
+			ConfigPath: ".wrkr/agents/smolagents.json",
+			Format:     "json",
+		},
+		{
+			DetectorID: detectorID,
+			Framework:  "smolagents",
+			ConfigPath: ".wrkr/agents/smolagents.toml",
+			Format:     "toml",
+		},
+	}, options)
+}
@@ -0,0 +1,121 @@
+package agentsmolagents
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Clyra-AI/wrkr/core/detect"
+	"github.com/Clyra-AI/wrkr/core/model"
+	"github.com/Clyra-AI/wrkr/core/risk/autonomy"
+)
+
+func TestSmolagentsDetector_DeclarationBaseline(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeFile(t, root, ".wrkr/agents/smolagents.yaml", `agents:
+  - name: manager
+    file: agents/research_agent.py
+    tools: [web_search]
+`)
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Org: "acme", Repo: "research", Root: root}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect: %v", err)
+	}
+	if len(findings) != 1 {
+		t.Fatalf("expected one finding, got %d", len(findings))
+	}
+	if findings[0].ToolType != "smolagents" || findings[0].Detector != detectorID {
+		t.Fatalf("unexpected finding %+v", findings[0])
+	}
+}
+
+func TestSmolagentsDetector_CodeAgentRunsWithHighAutonomy(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeFile(t, root, "agents/research_agent.py", `import os
+
+from smolagents import CodeAgent, DuckDuckGoSearchTool, InferenceClientModel, ToolCallingAgent
+
+model = InferenceClientModel(model_id="Qwen/Qwen2.5-Coder-32B-Instruct", token=os.getenv("HF_TOKEN"))
+
+search_agent = ToolCallingAgent(
+    tools=[DuckDuckGoSearchTool()],
+    model=model,
+    name="web_search",
+)
+manager = CodeAgent(
+    tools=[],
+    model=model,
+    managed_agents=[search_agent],
+    additional_authorized_imports=["pandas"],
+)
+`)
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Org: "acme", Repo: "research", Root: root}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect: %v", err)
+	}
+	bySymbol := map[string]model.Finding{}
+	for _, finding := range findings {
+		bySymbol[evidenceValue(finding.Evidence, "symbol")] = finding
+	}
+	if len(bySymbol) != 2 {
+		t.Fatalf("expected tool-calling and code agents, got %+v", findings)
+	}
+
+	search := bySymbol["web_search"]
+	if got := evidenceValue(search.Evidence, "bound_tools"); got != "DuckDuckGoSearchTool" {
+		t.Fatalf("expected search tool binding, got %q", got)
+	}
+	if search.Autonomy != "" || evidenceValue(search.Evidence, "code_execution") != "" {
+		t.Fatalf("expected tool-calling agent without code execution, got %+v", search)
+	}
+
+	manager := bySymbol["manager"]
+	for key, want := range map[string]string{
+		"source_call":       "CodeAgent",
+		"bound_tools":       "search_agent",
+		"auth_surfaces":     "HF_TOKEN",
+		"code_execution":    "true",
+		"dynamic_discovery": "true",
+	} {
+		if got := evidenceValue(manager.Evidence, key); got != want {
+			t.Fatalf("expected %s=%q, got %q", key, want, got)
+		}
+	}
+	if manager.Autonomy != autonomy.LevelHeadlessAuto || manager.Severity != model.SeverityMedium {
+		t.Fatalf("expected ungated code agent to run headless, got autonomy=%q severity=%q", manager.Autonomy, manager.Severity)
+	}
+	hasExec := false
+	for _, permission := range manager.Permissions {
+		hasExec = hasExec || permission == "proc.exec"
+	}
+	if !hasExec {
+		t.Fatalf("expected proc.exec permission, got %+v", manager.Permissions)
+	}
+}
+
+func writeFile(t *testing.T, root, rel, content string) {
+	t.Helper()
+	path := filepath.Join(root, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir %s: %v", rel, err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write %s: %v", rel, err)
+	}
+}
+
+func evidenceValue(evidence []model.Evidence, key string) string {
+	for _, item := range evidence {
+		if item.Key == key {
+			return item.Value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,47 @@
+package agentspringai
+
+import (
+	"context"
+
+	"github.com/Clyra-AI/wrkr/core/detect"
+	"github.com/Clyra-AI/wrkr/core/detect/agentframework"
+	"github.com/Clyra-AI/wrkr/core/model"
+)
+
+const detectorID = "agentspringai"
+
+type Detector struct{}
+
+func New() Detector { return Detector{} }
+
+func (Detector) ID() string { return detectorID }
+
+func (Detector) Detect(ctx context.Context, scope detect.Scope, options detect.Options) ([]model.Finding, error) {
+	_ = ctx
+	return agentframework.DetectManyWithOptions(scope, []agentframework.DetectorConfig{
+		{
+			DetectorID: detectorID,
+			Framework:  "spring_ai",
+			ConfigPath: ".wrkr/agents/spring-ai.yaml",
+			Format:     "yaml",
+		},
+		{
+			DetectorID: detectorID,
+			Framework:  "spring_ai",
+			ConfigPath: ".wrkr/agents/spring-ai.yml",
+			Format:     "yaml",
+		},
+		{
+			DetectorID: detectorID,
+			Framework:  "spring_ai",
+			ConfigPath: ".wrkr/agents/spring-ai.json",
+			Format:     "json",
+		},
+		{
+			DetectorID: detectorID,
+			Framework:  "spring_ai",
+			ConfigPath: ".wrkr/agents/spring-ai.toml",
+			Format:     "toml",
+		},
+	}, options)
+}
@@ -0,0 +1,115 @@
+package agentspringai
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Clyra-AI/wrkr/core/detect"
+	"github.com/Clyra-AI/wrkr/core/model"
+)
+
+func TestSpringAIDetector_DeclarationBaseline(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeFile(t, root, ".wrkr/agents/spring-ai.yaml", `agents:
+  - name: support_client
+    file: src/main/java/com/acme/ChatConfig.java
+    tools: [currentWeather]
+`)
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Org: "acme", Repo: "support", Root: root}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect: %v", err)
+	}
+	if len(findings) != 1 {
+		t.Fatalf("expected one finding, got %d", len(findings))
+	}
+	if findings[0].ToolType != "spring_ai" || findings[0].Detector != detectorID {
+		t.Fatalf("unexpected finding %+v", findings[0])
+	}
+}
+
+func TestSpringAIDetector_KotlinSourceOnlyRepo(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeFile(t, root, "src/main/kotlin/acme/ChatService.kt", `package acme
+
+import org.springframework.ai.chat.client.ChatClient
+import org.springframework.boot.runApplication
+
+@Service
+class ChatService(builder: ChatClient.Builder, private val mcpTools: ToolCallbackProvider) {
+    private val chatClient = ChatClient.create(model)
+    private val opsClient: ChatClient = ChatClient.builder(model)
+        .defaultToolCallbacks(mcpTools)
+        .defaultTools(DeployTools())
+        .build()
+}
+
+fun main(args: Array<String>) {
+    runApplication<Application>(*args)
+}
+`)
+	writeFile(t, root, "src/main/kotlin/acme/DeployTools.kt", `package acme
+
+import org.springframework.ai.tool.annotation.Tool
+
+class DeployTools {
+    @Tool(description = "Roll out a release")
+    fun rollout(version: String) = "ok"
+}
+`)
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Org: "acme", Repo: "ops", Root: root}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect: %v", err)
+	}
+	if len(findings) != 2 {
+		t.Fatalf("expected one finding per ChatClient, got %+v", findings)
+	}
+	bySymbol := map[string]model.Finding{}
+	for _, finding := range findings {
+		bySymbol[evidenceValue(finding.Evidence, "symbol")] = finding
+	}
+	ops, ok := bySymbol["opsClient"]
+	if !ok {
+		t.Fatalf("missing opsClient finding in %+v", findings)
+	}
+	for key, want := range map[string]string{
+		"source_language":      "kotlin",
+		"source_call":          "ChatClient.builder",
+		"bound_tools":          "mcpTools,rollout",
+		"deployment_artifacts": "src/main/kotlin/acme/ChatService.kt",
+	} {
+		if got := evidenceValue(ops.Evidence, key); got != want {
+			t.Fatalf("expected %s=%q, got %q", key, want, got)
+		}
+	}
+	if got := evidenceValue(bySymbol["chatClient"].Evidence, "source_call"); got != "ChatClient.create" {
+		t.Fatalf("expected ChatClient.create finding, got %q", got)
+	}
+}
+
+func writeFile(t *testing.T, root, rel, content string) {
+	t.Helper()
+	path := filepath.Join(root, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir %s: %v", rel, err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write %s: %v", rel, err)
+	}
+}
+
+func evidenceValue(evidence []model.Evidence, key string) string {
+	for _, item := range evidence {
+		if item.Key == key {
+			return item.Value
+		}
+	}
+	return ""
+}
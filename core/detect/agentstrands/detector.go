@@ -0,0 +1,47 @@
+package agentstrands
+
+import (
+	"context"
+
+	"github.com/Clyra-AI/wrkr/core/detect"
+	"github.com/Clyra-AI/wrkr/core/detect/agentframework"
+	"github.com/Clyra-AI/wrkr/core/model"
+)
+
+const detectorID = "agentstrands"
+
+type Detector struct{}
+
+func New() Detector { return Detector{} }
+
+func (Detector) ID() string { return detectorID }
+
+func (Detector) Detect(ctx context.Context, scope detect.Scope, options detect.Options) ([]model.Finding, error) {
+	_ = ctx
+	return agentframework.DetectManyWithOptions(scope, []agentframework.DetectorConfig{
+		{
+			DetectorID: detectorID,
+			Framework:  "strands",
+			ConfigPath: ".wrkr/agents/strands.yaml",
+			Format:     "yaml",
+		},
+		{
+			DetectorID: detectorID,
+			Framework:  "strands",
+			ConfigPath: ".wrkr/agents/strands.yml",
+			Format:     "yaml",
+		},
+		{
+			DetectorID: detectorID,
+			Framework:  "strands",
+			ConfigPath: ".wrkr/agents/strands.json",
+			Format:     "json",
+		},
+		{
+			DetectorID: detectorID,
+			Framework:  "strands",
+			ConfigPath: ".wrkr/agents/strands.toml",
+			Format:     "toml",
+		},
+	}, options)
+}
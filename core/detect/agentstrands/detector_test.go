@@ -0,0 +1,102 @@
+package agentstrands
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Clyra-AI/wrkr/core/detect"
+	"github.com/Clyra-AI/wrkr/core/model"
+)
+
+func TestStrandsDetector_DeclarationBaseline(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeFile(t, root, ".wrkr/agents/strands.yaml", `agents:
+  - name: assistant
+    file: agents/strands_agent.py
+    tools: [calculator]
+`)
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Org: "acme", Repo: "assistant", Root: root}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect: %v", err)
+	}
+	if len(findings) != 1 {
+		t.Fatalf("expected one finding, got %d", len(findings))
+	}
+	if findings[0].ToolType != "strands" || findings[0].Detector != detectorID {
+		t.Fatalf("unexpected finding %+v", findings[0])
+	}
+}
+
+func TestStrandsDetector_PythonSourceOnlyRepo(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeFile(t, root, "agents/strands_agent.py", `import os
+
+from strands import Agent, tool
+from strands.models.openai import OpenAIModel
+from strands.session.s3_session_manager import S3SessionManager
+from strands_tools import calculator, http_request
+
+
+@tool
+def word_count(text: str) -> int:
+    return len(text.split())
+
+
+model = OpenAIModel(client_args={"api_key": os.getenv("OPENAI_API_KEY")}, model_id="gpt-4o")
+
+assistant = Agent(
+    name="assistant",
+    model=model,
+    tools=[calculator, http_request, word_count],
+    session_manager=S3SessionManager(session_id="support", bucket="agent-sessions"),
+)
+`)
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Org: "acme", Repo: "assistant", Root: root}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect: %v", err)
+	}
+	if len(findings) != 1 {
+		t.Fatalf("expected one source finding, got %+v", findings)
+	}
+	for key, want := range map[string]string{
+		"symbol":          "assistant",
+		"bound_tools":     "calculator,http_request,word_count",
+		"auth_surfaces":   "OPENAI_API_KEY",
+		"model_providers": "openai",
+	} {
+		if got := evidenceValue(findings[0].Evidence, key); got != want {
+			t.Fatalf("expected %s=%q, got %q", key, want, got)
+		}
+	}
+	if got := evidenceValue(findings[0].Evidence, "data_sources"); got == "" {
+		t.Fatalf("expected session manager data source, got %+v", findings[0].Evidence)
+	}
+}
+
+func writeFile(t *testing.T, root, rel, content string) {
+	t.Helper()
+	path := filepath.Join(root, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir %s: %v", rel, err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write %s: %v", rel, err)
+	}
+}
+
+func evidenceValue(evidence []model.Evidence, key string) string {
+	for _, item := range evidence {
+		if item.Key == key {
+			return item.Value
+		}
+	}
+	return ""
+}
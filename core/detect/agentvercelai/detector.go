@@ -0,0 +1,47 @@
+package agentvercelai
+
+import (
+	"context"
+
+	"github.com/Clyra-AI/wrkr/core/detect"
+	"github.com/Clyra-AI/wrkr/core/detect/agentframework"
+	"github.com/Clyra-AI/wrkr/core/model"
+)
+
+const detectorID = "agentvercelai"
+
+type Detector struct{}
+
+func New() Detector { return Detector{} }
+
+func (Detector) ID() string { return detectorID }
+
+func (Detector) Detect(ctx context.Context, scope detect.Scope, options detect.Options) ([]model.Finding, error) {
+	_ = ctx
+	return agentframework.DetectManyWithOptions(scope, []agentframework.DetectorConfig{
+		{
+			DetectorID: detectorID,
+			Framework:  "vercel_ai",
+			ConfigPath: ".wrkr/agents/vercel-ai.yaml",
+			Format:     "yaml",
+		},
+		{
+			DetectorID: detectorID,
+			Framework:  "vercel_ai",
+			ConfigPath: ".wrkr/agents/vercel-ai.yml",
+			Format:     "yaml",
+		},
+		{
+			DetectorID: detectorID,
+			Framework:  "vercel_ai",
+			ConfigPath: ".wrkr/agents/vercel-ai.json",
+			Format:     "json",
+		},
+		{
+			DetectorID: detectorID,
+			Framework:  "vercel_ai",
+			ConfigPath: ".wrkr/agents/vercel-ai.toml",
+			Format:     "toml",
+		},
+	}, options)
+}
@@ -0,0 +1,185 @@
+package agentvercelai
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Clyra-AI/wrkr/core/detect"
+	"github.com/Clyra-AI/wrkr/core/model"
+)
+
+func TestVercelAIDetector_DeclarationBaseline(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeFile(t, root, ".wrkr/agents/vercel-ai.yaml", `agents:
+  - name: support_chat
+    file: app/api/chat/route.ts
+    tools: [lookupOrder]
+    step_limit: maxSteps=5
+`)
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Org: "acme", Repo: "web", Root: root}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect: %v", err)
+	}
+	if len(findings) != 1 {
+		t.Fatalf("expected one finding, got %d", len(findings))
+	}
+	if findings[0].ToolType != "vercel_ai" || findings[0].Detector != detectorID {
+		t.Fatalf("unexpected finding %+v", findings[0])
+	}
+	if got := evidenceValue(findings[0].Evidence, "step_limit"); got != "maxSteps=5" {
+		t.Fatalf("expected declared step limit, got %q", got)
+	}
+}
+
+func TestVercelAIDetector_RouteHandlerSource(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeFile(t, root, "app/api/chat/route.ts", `import { openai } from "@ai-sdk/openai";
+import { experimental_createMCPClient, generateText, stepCountIs, streamText, tool } from "ai";
+import { z } from "zod";
+
+const weather = tool({
+  description: "Get the weather for a city",
+  inputSchema: z.object({ city: z.string() }),
+  execute: async ({ city }) => ({ city, forecast: "sunny" }),
+});
+
+const refund = tool({
+  description: "Refund an order",
+  inputSchema: z.object({ orderId: z.string(), amount: z.number() }),
+  needsApproval: async ({ amount }) => amount > 100,
+  execute: async ({ orderId }) => ({ orderId, refunded: true }),
+});
+
+export async function POST(req: Request) {
+  const { messages } = await req.json();
+  const mcpClient = await experimental_createMCPClient({
+    transport: { type: "sse", url: "https://mcp.acme.example/sse" },
+  });
+  const mcpTools = await mcpClient.tools();
+
+  return streamText({
+    model: openai("gpt-4o"),
+    messages,
+    tools: { weather, refund, ...mcpTools },
+    stopWhen: stepCountIs(5),
+  });
+}
+
+export async function summarize(text: string) {
+  const { text: summary } = await generateText({ model: openai("gpt-4o-mini"), prompt: text });
+  return summary;
+}
+`)
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Org: "acme", Repo: "web", Root: root}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect: %v", err)
+	}
+	bySymbol := map[string]model.Finding{}
+	for _, finding := range findings {
+		bySymbol[evidenceValue(finding.Evidence, "symbol")] = finding
+	}
+	if len(bySymbol) != 2 {
+		t.Fatalf("expected the route handler and the MCP client, got %+v", bySymbol)
+	}
+
+	handler, ok := bySymbol["POST"]
+	if !ok {
+		t.Fatalf("expected streamText in POST to be named by its handler, got %+v", bySymbol)
+	}
+	for key, want := range map[string]string{
+		"source_call":       "streamText",
+		"bound_tools":       "mcpClient,refund,weather",
+		"step_limit":        "stopWhen=stepCountIs(5)",
+		"human_gate":        "true",
+		"dynamic_discovery": "true",
+		"model_providers":   "openai",
+	} {
+		if got := evidenceValue(handler.Evidence, key); got != want {
+			t.Fatalf("expected %s=%q, got %q", key, want, got)
+		}
+	}
+
+	client, ok := bySymbol["mcpClient"]
+	if !ok {
+		t.Fatalf("expected MCP client finding, got %+v", bySymbol)
+	}
+	if got := evidenceValue(client.Evidence, "reachable_endpoints"); got != "https://mcp.acme.example/sse" {
+		t.Fatalf("expected MCP endpoint, got %q", got)
+	}
+	if got := evidenceValue(client.Evidence, "dynamic_discovery"); got != "true" {
+		t.Fatalf("expected MCP client to discover tools, got %q", got)
+	}
+}
+
+func TestVercelAIDetector_MaxStepsAndUnrelatedAIPackages(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeFile(t, root, "agents/support.ts", `import { anthropic } from "@ai-sdk/anthropic";
+import { generateText } from "ai";
+import { lookupOrder } from "./tools";
+
+export const supportTools = { lookupOrder };
+
+export async function answer(prompt: string) {
+  const result = await generateText({
+    model: anthropic("claude-sonnet-4"),
+    tools: supportTools,
+    maxSteps: 5,
+    prompt,
+  });
+  return result.text;
+}
+`)
+	writeFile(t, root, "agents/other.ts", `import { generateText } from "ai-toolkit";
+
+const result = await generateText({ tools: { search }, maxSteps: 3 });
+`)
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Org: "acme", Repo: "web", Root: root}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect: %v", err)
+	}
+	if len(findings) != 1 {
+		t.Fatalf("expected only the ai package call, got %+v", findings)
+	}
+	for key, want := range map[string]string{
+		"symbol":          "result",
+		"bound_tools":     "lookupOrder",
+		"step_limit":      "maxSteps=5",
+		"human_gate":      "false",
+		"model_providers": "anthropic",
+	} {
+		if got := evidenceValue(findings[0].Evidence, key); got != want {
+			t.Fatalf("expected %s=%q, got %q", key, want, got)
+		}
+	}
+}
+
+func writeFile(t *testing.T, root, rel, content string) {
+	t.Helper()
+	path := filepath.Join(root, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir %s: %v", rel, err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write %s: %v", rel, err)
+	}
+}
+
+func evidenceValue(evidence []model.Evidence, key string) string {
+	for _, item := range evidence {
+		if item.Key == key {
+			return item.Value
+		}
+	}
+	return ""
+}
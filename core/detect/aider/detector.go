@@ -0,0 +1,148 @@
+package aider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Clyra-AI/wrkr/core/detect"
+	"github.com/Clyra-AI/wrkr/core/model"
+	"github.com/Clyra-AI/wrkr/core/risk/autonomy"
+)
+
+const detectorID = "aider"
+
+const configPath = ".aider.conf.yml"
+
+type Detector struct{}
+
+func New() Detector { return Detector{} }
+
+func (Detector) ID() string { return detectorID }
+
+// configFile reads the Aider options that grant commit and exec rights.
+// Unset booleans keep Aider's defaults: auto-commits on, yes-always off.
+type configFile struct {
+	AutoCommits *bool  `yaml:"auto-commits"`
+	YesAlways   *bool  `yaml:"yes-always"`
+	AutoTest    *bool  `yaml:"auto-test"`
+	AutoLint    *bool  `yaml:"auto-lint"`
+	TestCmd     string `yaml:"test-cmd"`
+	LintCmd     any    `yaml:"lint-cmd"`
+}
+
+func (Detector) Detect(_ context.Context, scope detect.Scope, _ detect.Options) ([]model.Finding, error) {
+	if err := detect.ValidateScopeRoot(scope.Root); err != nil {
+		return nil, err
+	}
+
+	findings := make([]model.Finding, 0)
+	exists, parseErr := detect.FileExistsWithinRoot(detectorID, scope.Root, configPath)
+	if parseErr != nil {
+		findings = append(findings, parseErrorFinding(scope, configPath, parseErr))
+	} else if exists {
+		findings = append(findings, parseConfig(scope, configPath))
+	}
+
+	model.SortFindings(findings)
+	return findings, nil
+}
+
+// parseConfig treats yes-always with auto-commits as headless write: every
+// confirmation is answered yes and edits are committed without review.
+func parseConfig(scope detect.Scope, rel string) model.Finding {
+	var parsed configFile
+	if parseErr := detect.ParseYAMLFileAllowUnknownFields(detectorID, scope.Root, rel, &parsed); parseErr != nil {
+		return parseErrorFinding(scope, rel, parseErr)
+	}
+	autoCommits := parsed.AutoCommits == nil || *parsed.AutoCommits
+	yesAlways := parsed.YesAlways != nil && *parsed.YesAlways
+	testCmd := strings.TrimSpace(parsed.TestCmd)
+	lintCmd := lintCommand(parsed.LintCmd)
+
+	permissions := []string{"filesystem.write"}
+	if autoCommits {
+		permissions = append(permissions, "repo.write")
+	}
+	if testCmd != "" || lintCmd != "" {
+		permissions = append(permissions, "proc.exec")
+	}
+
+	signals := autonomy.Signals{
+		Tool:     detectorID,
+		Headless: yesAlways && autoCommits,
+	}
+	finding := baseFinding(scope, rel, permissions,
+		model.Evidence{Key: "auto_commits", Value: fmt.Sprintf("%t", autoCommits)},
+		model.Evidence{Key: "yes_always", Value: fmt.Sprintf("%t", yesAlways)},
+		model.Evidence{Key: "auto_test", Value: fmt.Sprintf("%t", parsed.AutoTest != nil && *parsed.AutoTest)},
+		model.Evidence{Key: "auto_lint", Value: fmt.Sprintf("%t", parsed.AutoLint == nil || *parsed.AutoLint)},
+		model.Evidence{Key: "test_cmd", Value: testCmd},
+		model.Evidence{Key: "lint_cmd", Value: lintCmd},
+		model.Evidence{Key: "headless", Value: fmt.Sprintf("%t", signals.Headless)},
+		model.Evidence{Key: "approval_gate", Value: fmt.Sprintf("%t", signals.HasApprovalGate)},
+		model.Evidence{Key: "resolver_ref", Value: rel},
+	)
+	finding.Autonomy = autonomy.Classify(signals)
+	if finding.Autonomy == autonomy.LevelHeadlessAuto {
+		finding.Severity = model.SeverityMedium
+		finding.Remediation = "Disable yes-always or auto-commits in .aider.conf.yml so Aider edits are reviewed before they are committed."
+	}
+	return finding
+}
+
+// lintCommand accepts lint-cmd as a single command or a per-language list.
+func lintCommand(raw any) string {
+	switch typed := raw.(type) {
+	case string:
+		return strings.TrimSpace(typed)
+	case []any:
+		parts := make([]string, 0, len(typed))
+		for _, item := range typed {
+			if value, ok := item.(string); ok && strings.TrimSpace(value) != "" {
+				parts = append(parts, strings.TrimSpace(value))
+			}
+		}
+		return strings.Join(parts, ",")
+	default:
+		return ""
+	}
+}
+
+func baseFinding(scope detect.Scope, location string, permissions []string, extra ...model.Evidence) model.Finding {
+	evidence := []model.Evidence{{Key: "delivery_harness", Value: "aider_cli"}}
+	evidence = append(evidence, extra...)
+	return model.Finding{
+		FindingType: "tool_config",
+		Severity:    model.SeverityLow,
+		ToolType:    "aider",
+		Location:    location,
+		Repo:        scope.Repo,
+		Org:         fallbackOrg(scope.Org),
+		Detector:    detectorID,
+		Permissions: permissions,
+		Evidence:    evidence,
+	}
+}
+
+func parseErrorFinding(scope detect.Scope, location string, parseErr *model.ParseError) model.Finding {
+	parseErr.Detector = detectorID
+	return model.Finding{
+		FindingType: "parse_error",
+		Severity:    model.SeverityMedium,
+		ToolType:    "aider",
+		Location:    location,
+		Repo:        scope.Repo,
+		Org:         fallbackOrg(scope.Org),
+		Detector:    detectorID,
+		ParseError:  parseErr,
+		Remediation: "Fix malformed Aider configuration so deterministic parsing can proceed.",
+	}
+}
+
+func fallbackOrg(org string) string {
+	if strings.TrimSpace(org) == "" {
+		return "local"
+	}
+	return org
+}
@@ -0,0 +1,95 @@
+package aider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/Clyra-AI/wrkr/core/detect"
+	"github.com/Clyra-AI/wrkr/core/model"
+	"github.com/Clyra-AI/wrkr/core/risk/autonomy"
+)
+
+func TestAiderDetectorYesAlwaysWithAutoCommitsIsHeadlessWrite(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeAiderConfig(t, root, "yes-always: true\ntest-cmd: go test ./...\nlint-cmd:\n  - \"go: golangci-lint run\"\n")
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Root: root, Repo: "repo", Org: "local"}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect: %v", err)
+	}
+	if len(findings) != 1 {
+		t.Fatalf("expected one aider finding, got %+v", findings)
+	}
+	finding := findings[0]
+	if finding.Autonomy != autonomy.LevelHeadlessAuto || finding.Severity != model.SeverityMedium {
+		t.Fatalf("expected headless_auto medium finding, got %+v", finding)
+	}
+	if want := []string{"filesystem.write", "proc.exec", "repo.write"}; !reflect.DeepEqual(finding.Permissions, want) {
+		t.Fatalf("unexpected permissions: got %v want %v", finding.Permissions, want)
+	}
+	for key, want := range map[string]string{
+		"auto_commits":     "true",
+		"yes_always":       "true",
+		"test_cmd":         "go test ./...",
+		"lint_cmd":         "go: golangci-lint run",
+		"delivery_harness": "aider_cli",
+	} {
+		if got := evidenceValue(finding, key); got != want {
+			t.Fatalf("expected %s=%q, got %q", key, want, got)
+		}
+	}
+}
+
+func TestAiderDetectorWithoutAutoCommitsStaysInteractive(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeAiderConfig(t, root, "yes-always: true\nauto-commits: false\n")
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Root: root, Repo: "repo", Org: "local"}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect: %v", err)
+	}
+	if len(findings) != 1 || findings[0].Autonomy != autonomy.LevelInteractive {
+		t.Fatalf("expected interactive aider finding, got %+v", findings)
+	}
+	if want := []string{"filesystem.write"}; !reflect.DeepEqual(findings[0].Permissions, want) {
+		t.Fatalf("unexpected permissions: got %v want %v", findings[0].Permissions, want)
+	}
+}
+
+func TestAiderDetectorReportsMalformedConfig(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeAiderConfig(t, root, "yes-always: [\n")
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Root: root, Repo: "repo", Org: "local"}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect: %v", err)
+	}
+	if len(findings) != 1 || findings[0].FindingType != "parse_error" || findings[0].ParseError == nil || findings[0].ParseError.Detector != detectorID {
+		t.Fatalf("expected aider parse_error, got %+v", findings)
+	}
+}
+
+func evidenceValue(finding model.Finding, key string) string {
+	for _, item := range finding.Evidence {
+		if item.Key == key {
+			return item.Value
+		}
+	}
+	return ""
+}
+
+func writeAiderConfig(t *testing.T, root, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(root, configPath), []byte(content), 0o600); err != nil {
+		t.Fatalf("write aider config: %v", err)
+	}
+}
@@ -0,0 +1,140 @@
+package amazonq
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Clyra-AI/wrkr/core/detect"
+	"github.com/Clyra-AI/wrkr/core/model"
+)
+
+const detectorID = "amazonq"
+
+// mcpConfigPaths are the workspace and global Amazon Q MCP configs. The
+// global path only exists under a --my-setup home root.
+var mcpConfigPaths = []string{
+	".amazonq/mcp.json",
+	".aws/amazonq/mcp.json",
+}
+
+// rulePatterns cover `.amazonq/rules/**` to the depth rule folders are
+// nested in practice.
+var rulePatterns = []string{
+	".amazonq/rules/*",
+	".amazonq/rules/*/*",
+	".amazonq/rules/*/*/*",
+}
+
+type Detector struct{}
+
+func New() Detector { return Detector{} }
+
+func (Detector) ID() string { return detectorID }
+
+// mcpConfig only counts servers; server posture is scored by the mcp
+// detector.
+type mcpConfig struct {
+	MCPServers map[string]struct {
+		Disabled bool `json:"disabled"`
+	} `json:"mcpServers"`
+}
+
+func (Detector) Detect(_ context.Context, scope detect.Scope, _ detect.Options) ([]model.Finding, error) {
+	if err := detect.ValidateScopeRoot(scope.Root); err != nil {
+		return nil, err
+	}
+
+	findings := make([]model.Finding, 0)
+	rules := make([]string, 0)
+	for _, pattern := range rulePatterns {
+		matches, globErr := detect.Glob(scope.Root, pattern)
+		if globErr != nil {
+			return nil, fmt.Errorf("glob amazon q rules: %w", globErr)
+		}
+		rules = append(rules, matches...)
+	}
+	sort.Strings(rules)
+	for _, rel := range rules {
+		if detect.DirExists(scope.Root, rel) {
+			continue
+		}
+		findings = append(findings, baseFinding(scope, rel, nil,
+			model.Evidence{Key: "note", Value: "amazon q project rule discovered"},
+			model.Evidence{Key: "resolver_ref", Value: rel},
+		))
+	}
+
+	for _, rel := range mcpConfigPaths {
+		exists, parseErr := detect.FileExistsWithinRoot(detectorID, scope.Root, rel)
+		if parseErr != nil {
+			findings = append(findings, parseErrorFinding(scope, rel, parseErr))
+			continue
+		}
+		if !exists {
+			continue
+		}
+		var parsed mcpConfig
+		if parseErr := detect.ParseJSONFileAllowUnknownFields(detectorID, scope.Root, rel, &parsed); parseErr != nil {
+			findings = append(findings, parseErrorFinding(scope, rel, parseErr))
+			continue
+		}
+		enabled := 0
+		for _, server := range parsed.MCPServers {
+			if !server.Disabled {
+				enabled++
+			}
+		}
+		var permissions []string
+		if enabled > 0 {
+			permissions = []string{"mcp.access"}
+		}
+		findings = append(findings, baseFinding(scope, rel, permissions,
+			model.Evidence{Key: "mcp_server_count", Value: fmt.Sprintf("%d", len(parsed.MCPServers))},
+			model.Evidence{Key: "mcp_enabled_server_count", Value: fmt.Sprintf("%d", enabled)},
+			model.Evidence{Key: "resolver_ref", Value: rel},
+		))
+	}
+
+	model.SortFindings(findings)
+	return findings, nil
+}
+
+func baseFinding(scope detect.Scope, location string, permissions []string, extra ...model.Evidence) model.Finding {
+	evidence := []model.Evidence{{Key: "delivery_harness", Value: "amazon_q_developer"}}
+	evidence = append(evidence, extra...)
+	return model.Finding{
+		FindingType: "tool_config",
+		Severity:    model.SeverityLow,
+		ToolType:    "amazon_q",
+		Location:    location,
+		Repo:        scope.Repo,
+		Org:         fallbackOrg(scope.Org),
+		Detector:    detectorID,
+		Permissions: permissions,
+		Evidence:    evidence,
+	}
+}
+
+func parseErrorFinding(scope detect.Scope, location string, parseErr *model.ParseError) model.Finding {
+	parseErr.Detector = detectorID
+	return model.Finding{
+		FindingType: "parse_error",
+		Severity:    model.SeverityMedium,
+		ToolType:    "amazon_q",
+		Location:    location,
+		Repo:        scope.Repo,
+		Org:         fallbackOrg(scope.Org),
+		Detector:    detectorID,
+		ParseError:  parseErr,
+		Remediation: "Fix malformed Amazon Q Developer configuration so deterministic parsing can proceed.",
+	}
+}
+
+func fallbackOrg(org string) string {
+	if strings.TrimSpace(org) == "" {
+		return "local"
+	}
+	return org
+}
@@ -0,0 +1,71 @@
+package amazonq
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/Clyra-AI/wrkr/core/detect"
+	"github.com/Clyra-AI/wrkr/core/model"
+)
+
+func TestAmazonQDetectorFindsRulesAndMCPConfigs(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeAmazonQFile(t, root, ".amazonq/rules/style.md", "Use gofmt.\n")
+	writeAmazonQFile(t, root, ".amazonq/rules/security/secrets.md", "Never print credentials.\n")
+	writeAmazonQFile(t, root, ".amazonq/mcp.json", `{"mcpServers":{"aws-docs":{"command":"uvx","args":["awslabs.aws-documentation-mcp-server@latest"]}}}`)
+	writeAmazonQFile(t, root, ".aws/amazonq/mcp.json", `{"mcpServers":{"old":{"command":"npx","disabled":true}}}`)
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Root: root, Repo: "repo", Org: "local"}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect: %v", err)
+	}
+	byLocation := map[string]model.Finding{}
+	for _, finding := range findings {
+		if finding.FindingType != "tool_config" || finding.ToolType != "amazon_q" {
+			t.Fatalf("expected amazon_q tool_config findings, got %+v", finding)
+		}
+		byLocation[finding.Location] = finding
+	}
+	for _, location := range []string{".amazonq/rules/style.md", ".amazonq/rules/security/secrets.md", ".amazonq/mcp.json", ".aws/amazonq/mcp.json"} {
+		if _, ok := byLocation[location]; !ok {
+			t.Fatalf("expected finding for %s, got %+v", location, findings)
+		}
+	}
+	if got := byLocation[".amazonq/mcp.json"].Permissions; !reflect.DeepEqual(got, []string{"mcp.access"}) {
+		t.Fatalf("expected workspace MCP config to carry mcp.access, got %v", got)
+	}
+	if got := byLocation[".aws/amazonq/mcp.json"].Permissions; len(got) != 0 {
+		t.Fatalf("expected disabled servers to carry no permissions, got %v", got)
+	}
+}
+
+func TestAmazonQDetectorReportsMalformedMCPConfig(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeAmazonQFile(t, root, ".amazonq/mcp.json", `{"mcpServers":`)
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Root: root, Repo: "repo", Org: "local"}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect: %v", err)
+	}
+	if len(findings) != 1 || findings[0].FindingType != "parse_error" || findings[0].ParseError == nil || findings[0].ParseError.Detector != detectorID {
+		t.Fatalf("expected amazonq parse_error, got %+v", findings)
+	}
+}
+
+func writeAmazonQFile(t *testing.T, root, rel, content string) {
+	t.Helper()
+	path := filepath.Join(root, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir %s: %v", rel, err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write %s: %v", rel, err)
+	}
+}
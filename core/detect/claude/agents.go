@@ -0,0 +1,162 @@
+package claude
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/Clyra-AI/wrkr/core/detect"
+	"github.com/Clyra-AI/wrkr/core/model"
+	"github.com/Clyra-AI/wrkr/core/risk/autonomy"
+)
+
+// inheritedToolPermissions is the grant of a subagent that omits `tools:`;
+// such subagents inherit every tool available to the main session.
+var inheritedToolPermissions = []string{"filesystem.read", "filesystem.write", "mcp.access", "network.access", "proc.exec"}
+
+type subagentFrontmatter struct {
+	Name           string `yaml:"name"`
+	Tools          any    `yaml:"tools"`
+	Model          string `yaml:"model"`
+	PermissionMode string `yaml:"permissionMode"`
+}
+
+type commandFrontmatter struct {
+	AllowedTools any `yaml:"allowed-tools"`
+}
+
+// detectSubagents reports each `<dir>/*.md` subagent as its own finding. The
+// `name` evidence gives every subagent a distinct agent identity.
+func detectSubagents(scope detect.Scope, dir string) ([]model.Finding, error) {
+	files, globErr := detect.Glob(scope.Root, dir+"/*.md")
+	if globErr != nil {
+		return nil, fmt.Errorf("glob claude subagents: %w", globErr)
+	}
+	findings := make([]model.Finding, 0, len(files))
+	for _, rel := range files {
+		var front subagentFrontmatter
+		if _, parseErr := detect.ParseYAMLFrontmatter(detectorID, scope.Root, rel, &front); parseErr != nil {
+			findings = append(findings, parseErrorFinding(scope, rel, parseErr))
+			continue
+		}
+		tools := toolList(front.Tools)
+		grant := "explicit"
+		permissions := toolPermissions(tools)
+		if len(tools) == 0 {
+			grant = "inherited"
+			permissions = append([]string(nil), inheritedToolPermissions...)
+		}
+		mode := strings.TrimSpace(front.PermissionMode)
+		signals := autonomy.Signals{
+			Tool:              detectorID,
+			Headless:          strings.EqualFold(mode, "bypassPermissions"),
+			DangerousFlags:    strings.EqualFold(mode, "bypassPermissions"),
+			AutoApprovedWrite: strings.EqualFold(mode, "acceptEdits"),
+		}
+		finding := baseFinding(scope, rel, "claude subagent discovered", permissions,
+			model.Evidence{Key: "name", Value: fallback(strings.TrimSpace(front.Name), strings.TrimSuffix(path.Base(rel), ".md"))},
+			model.Evidence{Key: "tool_grant", Value: grant},
+			model.Evidence{Key: "tools", Value: strings.Join(tools, ",")},
+			model.Evidence{Key: "model", Value: fallback(strings.TrimSpace(front.Model), "inherit")},
+			model.Evidence{Key: "permission_mode", Value: fallback(mode, "default")},
+			model.Evidence{Key: "headless", Value: fmt.Sprintf("%t", signals.Headless)},
+			model.Evidence{Key: "approval_gate", Value: fmt.Sprintf("%t", signals.HasApprovalGate)},
+		)
+		finding.Autonomy = autonomy.Classify(signals)
+		if finding.Autonomy == autonomy.LevelHeadlessAuto {
+			finding.Severity = model.SeverityMedium
+			finding.Remediation = "Remove bypassPermissions or acceptEdits from the subagent permissionMode so its edits and commands need approval."
+		}
+		findings = append(findings, finding)
+	}
+	return findings, nil
+}
+
+// commandsFinding summarizes the slash commands under dir. Commands keep the
+// directory-level proc.exec grant and add the tools named in `allowed-tools`.
+func commandsFinding(scope detect.Scope, dir string) ([]model.Finding, error) {
+	files := make([]string, 0)
+	for _, pattern := range []string{dir + "/*.md", dir + "/*/*.md"} {
+		matches, globErr := detect.Glob(scope.Root, pattern)
+		if globErr != nil {
+			return nil, fmt.Errorf("glob claude commands: %w", globErr)
+		}
+		files = append(files, matches...)
+	}
+	sort.Strings(files)
+
+	findings := make([]model.Finding, 0, 1)
+	names := make([]string, 0, len(files))
+	allowed := make([]string, 0)
+	injection := make([]string, 0)
+	for _, rel := range files {
+		var front commandFrontmatter
+		body, parseErr := detect.ParseYAMLFrontmatter(detectorID, scope.Root, rel, &front)
+		if parseErr != nil {
+			findings = append(findings, parseErrorFinding(scope, rel, parseErr))
+			continue
+		}
+		name := strings.TrimSuffix(strings.TrimPrefix(rel, dir+"/"), ".md")
+		names = append(names, name)
+		allowed = append(allowed, toolList(front.AllowedTools)...)
+		// `!` followed by a backtick runs a shell command before the prompt is sent.
+		if strings.Contains(body, "!`") {
+			injection = append(injection, name)
+		}
+	}
+	allowed = dedupeSorted(allowed)
+	permissions := dedupeSorted(append(toolPermissions(allowed), "proc.exec"))
+	findings = append(findings, baseFinding(scope, dir, "claude commands discovered", permissions,
+		model.Evidence{Key: "command_count", Value: fmt.Sprintf("%d", len(names))},
+		model.Evidence{Key: "commands", Value: strings.Join(names, ",")},
+		model.Evidence{Key: "command_allowed_tools", Value: strings.Join(allowed, ",")},
+		model.Evidence{Key: "shell_injection_commands", Value: strings.Join(injection, ",")},
+	))
+	return findings, nil
+}
+
+// toolList accepts `tools:` as a comma-separated string or a YAML list.
+// Rules such as `Bash(git add:*)` keep their specifier intact.
+func toolList(raw any) []string {
+	values := make([]string, 0)
+	switch typed := raw.(type) {
+	case string:
+		values = append(values, splitToolString(typed)...)
+	case []any:
+		for _, item := range typed {
+			if value, ok := item.(string); ok {
+				values = append(values, strings.TrimSpace(value))
+			}
+		}
+	}
+	return dedupeSorted(values)
+}
+
+func splitToolString(value string) []string {
+	out := make([]string, 0)
+	depth := 0
+	start := 0
+	for idx, r := range value {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				out = append(out, strings.TrimSpace(value[start:idx]))
+				start = idx + 1
+			}
+		}
+	}
+	return append(out, strings.TrimSpace(value[start:]))
+}
+
+func toolPermissions(tools []string) []string {
+	permissions := make([]string, 0, len(tools))
+	for _, tool := range tools {
+		permissions = append(permissions, autonomy.RulePermission(tool))
+	}
+	return dedupeSorted(permissions)
+}
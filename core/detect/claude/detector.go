@@ -2,11 +2,14 @@ package claude
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/Clyra-AI/wrkr/core/detect"
 	"github.com/Clyra-AI/wrkr/core/model"
+	"github.com/Clyra-AI/wrkr/core/risk/autonomy"
 )
 
 const detectorID = "claude"
@@ -18,10 +21,41 @@ func New() Detector { return Detector{} }
 func (Detector) ID() string { return detectorID }
 
 type settingsFile struct {
-	AllowedTools []string         `json:"allowedTools"`
-	Hooks        []string         `json:"hooks"`
-	Commands     map[string]any   `json:"commands"`
-	MCPServers   map[string]mcpV1 `json:"mcpServers"`
+	AllowedTools               []string         `json:"allowedTools"`
+	Permissions                permissionsBlock `json:"permissions"`
+	Hooks                      json.RawMessage  `json:"hooks"`
+	Commands                   map[string]any   `json:"commands"`
+	MCPServers                 map[string]mcpV1 `json:"mcpServers"`
+	EnableAllProjectMcpServers bool             `json:"enableAllProjectMcpServers"`
+	EnabledMcpjsonServers      []string         `json:"enabledMcpjsonServers"`
+	Sandbox                    sandboxBlock     `json:"sandbox"`
+}
+
+// permissionsBlock holds Claude Code permission rules. Each rule is `Tool` or
+// `Tool(specifier)`, e.g. `Bash(git push:*)` or `WebFetch(domain:example.com)`.
+type permissionsBlock struct {
+	Allow       []string `json:"allow"`
+	Deny        []string `json:"deny"`
+	Ask         []string `json:"ask"`
+	DefaultMode string   `json:"defaultMode"`
+}
+
+type sandboxBlock struct {
+	Enabled                  bool `json:"enabled"`
+	AutoAllowBashIfSandboxed bool `json:"autoAllowBashIfSandboxed"`
+	Network                  struct {
+		AllowedDomains []string `json:"allowedDomains"`
+	} `json:"network"`
+}
+
+// hookMatcher is one entry of a settings.json hook event, e.g.
+// `"PreToolUse": [{"matcher": "Bash", "hooks": [{"type": "command", ...}]}]`.
+type hookMatcher struct {
+	Matcher string `json:"matcher"`
+	Hooks   []struct {
+		Type    string `json:"type"`
+		Command string `json:"command"`
+	} `json:"hooks"`
 }
 
 type mcpV1 struct {
@@ -44,8 +78,22 @@ func (Detector) Detect(_ context.Context, scope detect.Scope, _ detect.Options)
 		findings = append(findings, baseFinding(scope, "CLAUDE.md", "claude instructions file discovered", nil))
 	}
 	if detect.DirExists(scope.Root, ".claude/commands") {
-		findings = append(findings, baseFinding(scope, ".claude/commands", "claude commands discovered", []string{"proc.exec"}))
+		commands, err := commandsFinding(scope, ".claude/commands")
+		if err != nil {
+			return nil, err
+		}
+		findings = append(findings, commands...)
+	}
+	subagents, err := detectSubagents(scope, ".claude/agents")
+	if err != nil {
+		return nil, err
 	}
+	findings = append(findings, subagents...)
+	plugins, err := detectPlugins(scope)
+	if err != nil {
+		return nil, err
+	}
+	findings = append(findings, plugins...)
 	if detect.DirExists(scope.Root, ".claude/hooks") {
 		findings = append(findings, baseFinding(scope, ".claude/hooks", "claude hooks discovered", []string{"proc.exec"}))
 	}
@@ -59,49 +107,219 @@ func (Detector) Detect(_ context.Context, scope detect.Scope, _ detect.Options)
 		if !exists {
 			continue
 		}
-		var parsed settingsFile
-		if parseErr := detect.ParseJSONFileAllowUnknownFields(detectorID, scope.Root, rel, &parsed); parseErr != nil {
-			findings = append(findings, parseErrorFinding(scope, rel, parseErr))
+		findings = append(findings, parseSettings(scope, rel))
+	}
+
+	model.SortFindings(findings)
+	return findings, nil
+}
+
+// parseSettings computes a permission ceiling from allow rules, hooks, MCP
+// enablement, and the default mode. Deny rules are emitted as control
+// evidence; bypassPermissions and acceptEdits escalate autonomy.
+func parseSettings(scope detect.Scope, rel string) model.Finding {
+	var parsed settingsFile
+	if parseErr := detect.ParseJSONFileAllowUnknownFields(detectorID, scope.Root, rel, &parsed); parseErr != nil {
+		return parseErrorFinding(scope, rel, parseErr)
+	}
+	note := fmt.Sprintf("claude structured config parsed (%d MCP servers)", len(parsed.MCPServers))
+	if rel == ".mcp.json" {
+		var permissions []string
+		if len(parsed.MCPServers) > 0 {
+			permissions = []string{"mcp.access"}
+		}
+		return baseFinding(scope, rel, note, permissions)
+	}
+	hookEvents, hookCommands, hookErr := parseHooks(rel, parsed.Hooks)
+	if hookErr != nil {
+		return parseErrorFinding(scope, rel, hookErr)
+	}
+
+	mode := strings.TrimSpace(parsed.Permissions.DefaultMode)
+	bypass := strings.EqualFold(mode, "bypassPermissions")
+	acceptEdits := strings.EqualFold(mode, "acceptEdits")
+
+	// A deny rule without a specifier removes that tool from the ceiling.
+	deniedTools := map[string]struct{}{}
+	for _, raw := range parsed.Permissions.Deny {
+		if rule := autonomy.ParsePermissionRule(raw); rule.Specifier == "" {
+			deniedTools[strings.ToLower(rule.Tool)] = struct{}{}
+		}
+	}
+	ceiling := map[string]struct{}{}
+	execCommands := make([]string, 0)
+	egressDomains := make([]string, 0)
+	unscopedExec := false
+	unscopedWrite := false
+	for _, item := range parsed.AllowedTools {
+		ceiling[normalizeToolPermission(item)] = struct{}{}
+	}
+	for _, raw := range parsed.Permissions.Allow {
+		rule := autonomy.ParsePermissionRule(raw)
+		permission := autonomy.ToolPermission(rule.Tool)
+		if _, denied := deniedTools[strings.ToLower(rule.Tool)]; denied || permission == "" {
 			continue
 		}
-		perms := make([]string, 0, len(parsed.AllowedTools)+len(parsed.Hooks)+len(parsed.Commands))
-		for _, item := range parsed.AllowedTools {
-			perms = append(perms, normalizeToolPermission(item))
+		ceiling[permission] = struct{}{}
+		switch {
+		case permission == "proc.exec" && (rule.Specifier == "" || rule.Specifier == "*"):
+			unscopedExec = true
+		case permission == "filesystem.write" && (rule.Specifier == "" || rule.Specifier == "*"):
+			unscopedWrite = true
+		case permission == "proc.exec":
+			execCommands = append(execCommands, rule.Specifier)
+		case strings.HasPrefix(rule.Specifier, "domain:"):
+			egressDomains = append(egressDomains, strings.TrimPrefix(rule.Specifier, "domain:"))
 		}
-		if len(parsed.Hooks) > 0 || len(parsed.Commands) > 0 {
-			perms = append(perms, "proc.exec")
+	}
+	switch {
+	case bypass:
+		for _, permission := range []string{"filesystem.read", "filesystem.write", "proc.exec", "network.access"} {
+			ceiling[permission] = struct{}{}
 		}
-		if len(parsed.MCPServers) > 0 {
-			perms = append(perms, "mcp.access")
+	case acceptEdits:
+		ceiling["filesystem.write"] = struct{}{}
+	}
+	if len(hookCommands) > 0 || len(parsed.Commands) > 0 || (parsed.Sandbox.Enabled && parsed.Sandbox.AutoAllowBashIfSandboxed) {
+		ceiling["proc.exec"] = struct{}{}
+	}
+	if len(parsed.MCPServers) > 0 || parsed.EnableAllProjectMcpServers || len(parsed.EnabledMcpjsonServers) > 0 {
+		ceiling["mcp.access"] = struct{}{}
+	}
+	delete(ceiling, "")
+	permissions := make([]string, 0, len(ceiling))
+	for permission := range ceiling {
+		permissions = append(permissions, permission)
+	}
+	sort.Strings(permissions)
+	execCommands = append(execCommands, hookCommands...)
+	egressDomains = append(egressDomains, parsed.Sandbox.Network.AllowedDomains...)
+
+	// Deny and ask rules gate only the tools they name, so an approval gate
+	// needs one on each auto-approved write or exec permission: a Read deny
+	// does not gate unscoped Bash, and a Bash ask does not gate acceptEdits.
+	gatedPermissions := map[string]struct{}{}
+	for _, raw := range append(append([]string(nil), parsed.Permissions.Deny...), parsed.Permissions.Ask...) {
+		gatedPermissions[autonomy.RulePermission(raw)] = struct{}{}
+	}
+	autoApproved := make([]string, 0, 2)
+	if acceptEdits || unscopedWrite {
+		autoApproved = append(autoApproved, "filesystem.write")
+	}
+	if unscopedExec {
+		autoApproved = append(autoApproved, "proc.exec")
+	}
+	approvalGate := !bypass
+	if len(autoApproved) == 0 {
+		_, gatesWrite := gatedPermissions["filesystem.write"]
+		_, gatesExec := gatedPermissions["proc.exec"]
+		approvalGate = approvalGate && (gatesWrite || gatesExec)
+	}
+	for _, permission := range autoApproved {
+		if _, ok := gatedPermissions[permission]; !ok {
+			approvalGate = false
 		}
-		findings = append(findings, baseFinding(scope, rel, fmt.Sprintf("claude structured config parsed (%d MCP servers)", len(parsed.MCPServers)), perms))
 	}
 
-	model.SortFindings(findings)
-	return findings, nil
+	signals := autonomy.Signals{
+		Tool:              detectorID,
+		Headless:          bypass,
+		DangerousFlags:    bypass,
+		HasApprovalGate:   approvalGate,
+		AutoApprovedWrite: len(autoApproved) > 0,
+	}
+	extra := []model.Evidence{
+		{Key: "permission_ceiling", Value: strings.Join(permissions, ",")},
+		{Key: "default_mode", Value: fallback(mode, "default")},
+		{Key: "allow_rules", Value: strings.Join(parsed.Permissions.Allow, ",")},
+		{Key: "ask_rules", Value: strings.Join(parsed.Permissions.Ask, ",")},
+		{Key: "exec_commands", Value: strings.Join(dedupeSorted(execCommands), ",")},
+		{Key: "egress_domains", Value: strings.Join(dedupeSorted(egressDomains), ",")},
+		{Key: "hook_events", Value: strings.Join(hookEvents, ",")},
+		{Key: "enable_all_project_mcp_servers", Value: fmt.Sprintf("%t", parsed.EnableAllProjectMcpServers)},
+		{Key: "enabled_mcpjson_servers", Value: strings.Join(dedupeSorted(parsed.EnabledMcpjsonServers), ",")},
+		{Key: "headless", Value: fmt.Sprintf("%t", signals.Headless)},
+		{Key: "approval_gate", Value: fmt.Sprintf("%t", signals.HasApprovalGate)},
+	}
+	if len(parsed.Permissions.Deny) > 0 {
+		extra = append(extra, model.Evidence{Key: "deny_rule", Value: strings.Join(parsed.Permissions.Deny, ",")})
+	}
+	if parsed.Sandbox.Enabled {
+		extra = append(extra,
+			model.Evidence{Key: "sandbox_gate", Value: "sandbox:enabled"},
+			model.Evidence{Key: "sandbox_auto_allow_bash", Value: fmt.Sprintf("%t", parsed.Sandbox.AutoAllowBashIfSandboxed)},
+		)
+	}
+	if len(hookCommands) > 0 {
+		extra = append(extra, model.Evidence{Key: "validation_requirement", Value: "review_hook_execution"})
+	}
+
+	finding := baseFinding(scope, rel, note, permissions, extra...)
+	finding.Autonomy = autonomy.Classify(signals)
+	if finding.Autonomy == autonomy.LevelHeadlessAuto {
+		finding.Severity = model.SeverityMedium
+		finding.Remediation = "Add ask or deny rules for write and shell tools so Claude Code actions outside the reviewed scope need approval."
+		if bypass {
+			finding.Remediation = "Remove defaultMode bypassPermissions from Claude Code settings and scope access with allow, ask, and deny rules."
+		}
+	}
+	return finding
+}
+
+// parseHooks accepts the legacy list form and the event-to-matcher map,
+// returning sorted `event:matcher` labels and hook commands.
+func parseHooks(rel string, raw json.RawMessage) ([]string, []string, *model.ParseError) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil, nil
+	}
+	var legacy []string
+	if err := json.Unmarshal(raw, &legacy); err == nil {
+		return nil, dedupeSorted(legacy), nil
+	}
+	var events map[string][]hookMatcher
+	if err := json.Unmarshal(raw, &events); err != nil {
+		return nil, nil, &model.ParseError{Kind: "parse_error", Format: "json", Path: rel, Message: "hooks: " + err.Error()}
+	}
+	labels := make([]string, 0)
+	commands := make([]string, 0)
+	for event, matchers := range events {
+		for _, matcher := range matchers {
+			labels = append(labels, event+":"+fallback(strings.TrimSpace(matcher.Matcher), "*"))
+			for _, hook := range matcher.Hooks {
+				if strings.EqualFold(strings.TrimSpace(hook.Type), "command") && strings.TrimSpace(hook.Command) != "" {
+					commands = append(commands, strings.TrimSpace(hook.Command))
+				}
+			}
+		}
+	}
+	return dedupeSorted(labels), dedupeSorted(commands), nil
 }
 
-func baseFinding(scope detect.Scope, location, note string, permissions []string) model.Finding {
+func baseFinding(scope detect.Scope, location, note string, permissions []string, extra ...model.Evidence) model.Finding {
 	evidence := []model.Evidence{{Key: "note", Value: note}}
+	lower := strings.ToLower(strings.TrimSpace(location))
 	switch {
-	case strings.EqualFold(strings.TrimSpace(location), "CLAUDE.md"):
+	case lower == "claude.md",
+		strings.Contains(lower, ".claude-plugin/"),
+		strings.Contains(lower, "agents/") && strings.HasSuffix(lower, ".md"):
 		evidence = append(evidence,
 			model.Evidence{Key: "delivery_harness", Value: "claude_code"},
 			model.Evidence{Key: "resolver_ref", Value: location},
 		)
-	case strings.Contains(strings.ToLower(strings.TrimSpace(location)), ".claude/hooks"),
-		strings.Contains(strings.ToLower(strings.TrimSpace(location)), ".claude/commands"):
+	case strings.Contains(lower, ".claude/hooks"),
+		strings.HasSuffix(lower, "commands"):
 		evidence = append(evidence,
 			model.Evidence{Key: "delivery_harness", Value: "claude_code"},
 			model.Evidence{Key: "validation_requirement", Value: "review_hook_execution"},
 		)
-	case strings.Contains(strings.ToLower(strings.TrimSpace(location)), "settings"),
-		strings.EqualFold(strings.TrimSpace(location), ".mcp.json"):
+	case strings.Contains(lower, "settings"),
+		lower == ".mcp.json":
 		evidence = append(evidence,
 			model.Evidence{Key: "delivery_harness", Value: "claude_code"},
 			model.Evidence{Key: "resolver_ref", Value: location},
 		)
 	}
+	evidence = append(evidence, extra...)
 	if strings.TrimSpace(scope.Repo) != "" {
 		evidence = append(evidence, model.Evidence{Key: "repo", Value: scope.Repo})
 	}
@@ -150,6 +368,28 @@ func normalizeToolPermission(tool string) string {
 	}
 }
 
+func dedupeSorted(values []string) []string {
+	seen := map[string]struct{}{}
+	out := make([]string, 0, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if _, ok := seen[value]; ok || value == "" {
+			continue
+		}
+		seen[value] = struct{}{}
+		out = append(out, value)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func fallback(value, fallbackValue string) string {
+	if strings.TrimSpace(value) == "" {
+		return fallbackValue
+	}
+	return value
+}
+
 func fallbackOrg(org string) string {
 	if strings.TrimSpace(org) == "" {
 		return "local"
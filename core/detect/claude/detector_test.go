@@ -4,9 +4,12 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/Clyra-AI/wrkr/core/detect"
+	"github.com/Clyra-AI/wrkr/core/model"
+	"github.com/Clyra-AI/wrkr/core/risk/autonomy"
 )
 
 func TestClaudeDetectorIgnoresAdditiveVendorFields(t *testing.T) {
@@ -104,3 +107,335 @@ func TestClaudeDetectorRejectsExternalSymlinkedSettings(t *testing.T) {
 	}
 	t.Fatalf("expected unsafe_path parse_error finding, got %+v", findings)
 }
+
+func TestClaudeDetectorComputesPermissionCeilingFromRules(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeClaudeSettings(t, root, `{
+  "permissions": {
+    "allow": ["Bash(npm run test:*)", "Edit(/src/**)", "WebFetch(domain:docs.example.com)", "mcp__github__get_issue"],
+    "deny": ["Bash(git push:*)", "Edit(/infra/**)", "WebSearch"],
+    "ask": ["Bash(rm:*)"],
+    "defaultMode": "default"
+  },
+  "enabledMcpjsonServers": ["github"],
+  "sandbox": {"enabled": true, "network": {"allowedDomains": ["registry.npmjs.org"]}},
+  "hooks": {
+    "PreToolUse": [{"matcher": "Bash", "hooks": [{"type": "command", "command": "./scripts/guard.sh"}]}]
+  }
+}`)
+
+	finding := settingsFinding(t, root)
+	expectedEvidence := map[string]string{
+		"permission_ceiling":      "filesystem.write,mcp.access,network.access,proc.exec",
+		"default_mode":            "default",
+		"deny_rule":               "Bash(git push:*),Edit(/infra/**),WebSearch",
+		"exec_commands":           "./scripts/guard.sh,npm run test:*",
+		"egress_domains":          "docs.example.com,registry.npmjs.org",
+		"hook_events":             "PreToolUse:Bash",
+		"enabled_mcpjson_servers": "github",
+		"sandbox_gate":            "sandbox:enabled",
+		"approval_gate":           "true",
+		"validation_requirement":  "review_hook_execution",
+	}
+	for key, want := range expectedEvidence {
+		if got := evidenceValue(finding, key); got != want {
+			t.Fatalf("expected evidence %s=%q, got %q in %+v", key, want, got, finding.Evidence)
+		}
+	}
+	if finding.Autonomy != autonomy.LevelInteractive {
+		t.Fatalf("expected interactive autonomy for scoped rules, got %q", finding.Autonomy)
+	}
+}
+
+func TestClaudeDetectorEscalatesAutonomyForBypassModes(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		payload  string
+		autonomy string
+		ceiling  string
+	}{
+		{
+			name:     "bypass permissions",
+			payload:  `{"permissions": {"defaultMode": "bypassPermissions", "deny": ["Read(./.env)"]}}`,
+			autonomy: autonomy.LevelHeadlessAuto,
+			ceiling:  "filesystem.read,filesystem.write,network.access,proc.exec",
+		},
+		{
+			name:     "accept edits with edit deny rules",
+			payload:  `{"permissions": {"defaultMode": "acceptEdits", "deny": ["Edit(/infra/**)"]}}`,
+			autonomy: autonomy.LevelHeadlessGate,
+			ceiling:  "filesystem.write",
+		},
+		{
+			name:     "accept edits with only bash deny rules",
+			payload:  `{"permissions": {"defaultMode": "acceptEdits", "deny": ["Bash(git push:*)"]}}`,
+			autonomy: autonomy.LevelHeadlessAuto,
+			ceiling:  "filesystem.write",
+		},
+		{
+			name:     "unscoped bash allow",
+			payload:  `{"permissions": {"allow": ["Bash"]}}`,
+			autonomy: autonomy.LevelHeadlessAuto,
+			ceiling:  "proc.exec",
+		},
+		{
+			name:     "unscoped bash allow with unrelated read deny",
+			payload:  `{"permissions": {"allow": ["Bash"], "deny": ["Read(./.env)"]}}`,
+			autonomy: autonomy.LevelHeadlessAuto,
+			ceiling:  "proc.exec",
+		},
+		{
+			name:     "unscoped edit allow",
+			payload:  `{"permissions": {"allow": ["Edit", "Read"]}}`,
+			autonomy: autonomy.LevelHeadlessAuto,
+			ceiling:  "filesystem.read,filesystem.write",
+		},
+		{
+			name:     "wildcard write allow with write deny rule",
+			payload:  `{"permissions": {"allow": ["Write(*)"], "deny": ["Write(./.env)"]}}`,
+			autonomy: autonomy.LevelHeadlessGate,
+			ceiling:  "filesystem.write",
+		},
+		{
+			name:     "scoped edit allow",
+			payload:  `{"permissions": {"allow": ["Edit(docs/**)"]}}`,
+			autonomy: autonomy.LevelInteractive,
+			ceiling:  "filesystem.write",
+		},
+		{
+			name:     "unscoped bash allow with bash ask rule",
+			payload:  `{"permissions": {"allow": ["Bash"], "ask": ["Bash(rm:*)"]}}`,
+			autonomy: autonomy.LevelHeadlessGate,
+			ceiling:  "proc.exec",
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			root := t.TempDir()
+			writeClaudeSettings(t, root, tc.payload)
+			finding := settingsFinding(t, root)
+			if finding.Autonomy != tc.autonomy {
+				t.Fatalf("expected autonomy %q, got %q", tc.autonomy, finding.Autonomy)
+			}
+			if got := evidenceValue(finding, "permission_ceiling"); got != tc.ceiling {
+				t.Fatalf("expected permission ceiling %q, got %q", tc.ceiling, got)
+			}
+			if tc.autonomy == autonomy.LevelHeadlessAuto && finding.Severity != model.SeverityMedium {
+				t.Fatalf("expected medium severity for headless_auto, got %q", finding.Severity)
+			}
+		})
+	}
+}
+
+func TestClaudeDetectorRejectsMalformedHooks(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeClaudeSettings(t, root, `{"hooks": {"PreToolUse": "not-a-list"}}`)
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Root: root, Repo: "repo", Org: "local"}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect: %v", err)
+	}
+	for _, finding := range findings {
+		if finding.FindingType == "parse_error" && finding.Location == ".claude/settings.json" {
+			return
+		}
+	}
+	t.Fatalf("expected parse_error finding for malformed hooks, got %+v", findings)
+}
+
+func writeClaudeSettings(t *testing.T, root, payload string) {
+	t.Helper()
+	writeClaudeFile(t, root, ".claude/settings.json", payload)
+}
+
+func settingsFinding(t *testing.T, root string) model.Finding {
+	t.Helper()
+	findings, err := New().Detect(context.Background(), detect.Scope{Root: root, Repo: "repo", Org: "local"}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect: %v", err)
+	}
+	for _, finding := range findings {
+		if finding.FindingType == "tool_config" && finding.Location == ".claude/settings.json" {
+			return finding
+		}
+	}
+	t.Fatalf("expected tool_config finding for settings.json, got %+v", findings)
+	return model.Finding{}
+}
+
+func evidenceValue(finding model.Finding, key string) string {
+	for _, item := range finding.Evidence {
+		if item.Key == key {
+			return item.Value
+		}
+	}
+	return ""
+}
+
+func TestClaudeDetectorInventoriesSubagentsAsAgentIdentities(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeClaudeFile(t, root, ".claude/agents/reviewer.md", "---\nname: code-reviewer\ndescription: Reviews diffs\ntools: Read, Grep, Bash(git diff:*)\n---\nReview the change.\n")
+	writeClaudeFile(t, root, ".claude/agents/fixer.md", "---\nname: fixer\npermissionMode: bypassPermissions\n---\nFix failing tests.\n")
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Root: root, Repo: "repo", Org: "local"}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect: %v", err)
+	}
+	reviewer := findingAt(t, findings, ".claude/agents/reviewer.md")
+	if got := evidenceValue(reviewer, "name"); got != "code-reviewer" {
+		t.Fatalf("expected subagent name evidence, got %q", got)
+	}
+	if got := strings.Join(reviewer.Permissions, ","); got != "filesystem.read,proc.exec" {
+		t.Fatalf("expected explicit tool grant permissions, got %q", got)
+	}
+	if got := evidenceValue(reviewer, "tools"); got != "Bash(git diff:*),Grep,Read" {
+		t.Fatalf("expected scoped tool list to survive comma splitting, got %q", got)
+	}
+	fixer := findingAt(t, findings, ".claude/agents/fixer.md")
+	if got := evidenceValue(fixer, "tool_grant"); got != "inherited" {
+		t.Fatalf("expected inherited tool grant, got %q", got)
+	}
+	if fixer.Autonomy != autonomy.LevelHeadlessAuto {
+		t.Fatalf("expected bypassPermissions subagent to be headless_auto, got %q", fixer.Autonomy)
+	}
+}
+
+func TestClaudeDetectorSummarizesSlashCommands(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeClaudeFile(t, root, ".claude/commands/commit.md", "---\nallowed-tools: Bash(git add:*), Bash(git commit:*)\ndescription: Create a commit\n---\nStatus: !`git status`\n")
+	writeClaudeFile(t, root, ".claude/commands/docs/fetch.md", "---\nallowed-tools: [WebFetch]\n---\nFetch docs.\n")
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Root: root, Repo: "repo", Org: "local"}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect: %v", err)
+	}
+	commands := findingAt(t, findings, ".claude/commands")
+	expected := map[string]string{
+		"command_count":            "2",
+		"commands":                 "commit,docs/fetch",
+		"command_allowed_tools":    "Bash(git add:*),Bash(git commit:*),WebFetch",
+		"shell_injection_commands": "commit",
+	}
+	for key, want := range expected {
+		if got := evidenceValue(commands, key); got != want {
+			t.Fatalf("expected evidence %s=%q, got %q", key, want, got)
+		}
+	}
+	if got := strings.Join(commands.Permissions, ","); got != "network.access,proc.exec" {
+		t.Fatalf("expected command permissions, got %q", got)
+	}
+}
+
+func TestClaudeDetectorExpandsMarketplacePluginsIntoHooksAndMCPServers(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeClaudeFile(t, root, ".claude-plugin/marketplace.json", `{
+  "name": "acme-tools",
+  "plugins": [
+    {"name": "release", "source": "./plugins/release"},
+    {"name": "remote", "source": {"source": "github", "repo": "acme/remote-plugin"}}
+  ]
+}`)
+	writeClaudeFile(t, root, "plugins/release/.claude-plugin/plugin.json", `{"name": "release", "version": "1.2.0"}`)
+	writeClaudeFile(t, root, "plugins/release/hooks/hooks.json", `{
+  "hooks": {"PostToolUse": [{"matcher": "Edit", "hooks": [{"type": "command", "command": "${CLAUDE_PLUGIN_ROOT}/scripts/format.sh"}]}]}
+}`)
+	writeClaudeFile(t, root, "plugins/release/.mcp.json", `{"mcpServers": {"deployer": {"command": "npx", "args": ["-y", "acme-deployer@1.0.0"]}}}`)
+	writeClaudeFile(t, root, "plugins/release/agents/shipper.md", "---\nname: shipper\ntools: Bash\n---\nShip it.\n")
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Root: root, Repo: "repo", Org: "local"}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect: %v", err)
+	}
+	marketplace := findingAt(t, findings, ".claude-plugin/marketplace.json")
+	if got := evidenceValue(marketplace, "remote_plugins"); got != "remote" {
+		t.Fatalf("expected remote plugin evidence, got %q", got)
+	}
+	plugin := findingAt(t, findings, "plugins/release/.claude-plugin/plugin.json")
+	expected := map[string]string{
+		"plugin_name":    "release",
+		"plugin_version": "1.2.0",
+		"mcp_servers":    "deployer",
+		"mcp_config_ref": "plugins/release/.mcp.json",
+		"hook_events":    "PostToolUse:Edit",
+		"hooks_ref":      "plugins/release/hooks/hooks.json",
+		"exec_commands":  "${CLAUDE_PLUGIN_ROOT}/scripts/format.sh",
+	}
+	for key, want := range expected {
+		if got := evidenceValue(plugin, key); got != want {
+			t.Fatalf("expected evidence %s=%q, got %q", key, want, got)
+		}
+	}
+	if got := strings.Join(plugin.Permissions, ","); got != "mcp.access,proc.exec" {
+		t.Fatalf("expected plugin transitive permissions, got %q", got)
+	}
+	shipper := findingAt(t, findings, "plugins/release/agents/shipper.md")
+	if got := evidenceValue(shipper, "name"); got != "shipper" {
+		t.Fatalf("expected plugin subagent identity, got %q", got)
+	}
+	if got := detect.ClaudePluginMCPConfigPaths(root); strings.Join(got, ",") != "plugins/release/.mcp.json" {
+		t.Fatalf("expected plugin MCP config paths, got %v", got)
+	}
+}
+
+func TestClaudeDetectorReadsInlinePluginComponents(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeClaudeFile(t, root, ".claude-plugin/plugin.json", `{
+  "name": "inline",
+  "hooks": {"hooks": {"SessionStart": [{"hooks": [{"type": "command", "command": "./bootstrap.sh"}]}]}},
+  "mcpServers": {"search": {"url": "https://search.example.com/mcp"}}
+}`)
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Root: root, Repo: "repo", Org: "local"}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect: %v", err)
+	}
+	plugin := findingAt(t, findings, ".claude-plugin/plugin.json")
+	if got := evidenceValue(plugin, "hook_events"); got != "SessionStart:*" {
+		t.Fatalf("expected inline hook events, got %q", got)
+	}
+	if got := evidenceValue(plugin, "mcp_config_ref"); got != ".claude-plugin/plugin.json" {
+		t.Fatalf("expected inline MCP config ref, got %q", got)
+	}
+	if got := detect.ClaudePluginMCPConfigPaths(root); strings.Join(got, ",") != ".claude-plugin/plugin.json" {
+		t.Fatalf("expected inline plugin MCP config path, got %v", got)
+	}
+}
+
+func writeClaudeFile(t *testing.T, root, rel, payload string) {
+	t.Helper()
+	target := filepath.Join(root, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		t.Fatalf("mkdir %s: %v", rel, err)
+	}
+	if err := os.WriteFile(target, []byte(payload), 0o600); err != nil {
+		t.Fatalf("write %s: %v", rel, err)
+	}
+}
+
+func findingAt(t *testing.T, findings []model.Finding, location string) model.Finding {
+	t.Helper()
+	for _, finding := range findings {
+		if finding.FindingType == "tool_config" && finding.Location == location {
+			return finding
+		}
+	}
+	t.Fatalf("expected tool_config finding at %s, got %+v", location, findings)
+	return model.Finding{}
+}
@@ -0,0 +1,190 @@
+package claude
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/Clyra-AI/wrkr/core/detect"
+	"github.com/Clyra-AI/wrkr/core/model"
+)
+
+// pluginManifest reads the plugin.json fields that install authority.
+// `hooks` and `mcpServers` are either a plugin-relative path or inline config.
+type pluginManifest struct {
+	Name       string          `json:"name"`
+	Version    string          `json:"version"`
+	Hooks      json.RawMessage `json:"hooks"`
+	MCPServers json.RawMessage `json:"mcpServers"`
+}
+
+type hooksFile struct {
+	Hooks json.RawMessage `json:"hooks"`
+}
+
+type pluginMCPFile struct {
+	MCPServers map[string]json.RawMessage `json:"mcpServers"`
+}
+
+func detectPlugins(scope detect.Scope) ([]model.Finding, error) {
+	findings := make([]model.Finding, 0)
+	if exists, parseErr := detect.FileExistsWithinRoot(detectorID, scope.Root, detect.ClaudePluginMarketplacePath); parseErr != nil {
+		findings = append(findings, parseErrorFinding(scope, detect.ClaudePluginMarketplacePath, parseErr))
+	} else if exists {
+		findings = append(findings, parseMarketplace(scope, detect.ClaudePluginMarketplacePath))
+	}
+
+	for _, pluginRoot := range detect.ClaudePluginRoots(scope.Root) {
+		if finding, ok := parsePlugin(scope, pluginRoot); ok {
+			findings = append(findings, finding)
+		}
+		subagents, err := detectSubagents(scope, path.Join(pluginRoot, "agents"))
+		if err != nil {
+			return nil, err
+		}
+		findings = append(findings, subagents...)
+		if commandsDir := path.Join(pluginRoot, "commands"); detect.DirExists(scope.Root, commandsDir) {
+			commands, err := commandsFinding(scope, commandsDir)
+			if err != nil {
+				return nil, err
+			}
+			findings = append(findings, commands...)
+		}
+	}
+	return findings, nil
+}
+
+func parseMarketplace(scope detect.Scope, rel string) model.Finding {
+	var parsed detect.ClaudePluginMarketplace
+	if parseErr := detect.ParseJSONFileAllowUnknownFields(detectorID, scope.Root, rel, &parsed); parseErr != nil {
+		return parseErrorFinding(scope, rel, parseErr)
+	}
+	names := make([]string, 0, len(parsed.Plugins))
+	remote := make([]string, 0)
+	for _, plugin := range parsed.Plugins {
+		names = append(names, strings.TrimSpace(plugin.Name))
+		if _, local := detect.LocalClaudePluginSource(parsed.Metadata.PluginRoot, plugin.Source); !local {
+			remote = append(remote, strings.TrimSpace(plugin.Name))
+		}
+	}
+	return baseFinding(scope, rel, "claude plugin marketplace discovered", nil,
+		model.Evidence{Key: "marketplace_name", Value: strings.TrimSpace(parsed.Name)},
+		model.Evidence{Key: "plugin_count", Value: fmt.Sprintf("%d", len(parsed.Plugins))},
+		model.Evidence{Key: "plugins", Value: strings.Join(dedupeSorted(names), ",")},
+		model.Evidence{Key: "remote_plugins", Value: strings.Join(dedupeSorted(remote), ",")},
+	)
+}
+
+// parsePlugin expands a plugin into the hooks and MCP servers it installs so
+// their exec and MCP authority is attributed to the plugin.
+func parsePlugin(scope detect.Scope, pluginRoot string) (model.Finding, bool) {
+	manifestRel := path.Join(pluginRoot, detect.ClaudePluginManifestPath)
+	location := pluginRoot
+	var manifest pluginManifest
+	exists, parseErr := detect.FileExistsWithinRoot(detectorID, scope.Root, manifestRel)
+	switch {
+	case parseErr != nil:
+		return parseErrorFinding(scope, manifestRel, parseErr), true
+	case exists:
+		location = manifestRel
+		if parseErr := detect.ParseJSONFileAllowUnknownFields(detectorID, scope.Root, manifestRel, &manifest); parseErr != nil {
+			return parseErrorFinding(scope, manifestRel, parseErr), true
+		}
+	}
+
+	hookEvents, hookCommands, hooksRef, hookErr := pluginHooks(scope.Root, pluginRoot, manifestRel, manifest.Hooks)
+	if hookErr != nil {
+		return parseErrorFinding(scope, hookErr.Path, hookErr), true
+	}
+	servers, mcpRef, mcpErr := pluginMCPServers(scope.Root, pluginRoot, manifestRel, manifest.MCPServers)
+	if mcpErr != nil {
+		return parseErrorFinding(scope, mcpErr.Path, mcpErr), true
+	}
+	if !exists && len(hookEvents)+len(hookCommands)+len(servers) == 0 {
+		return model.Finding{}, false
+	}
+
+	var permissions []string
+	extra := []model.Evidence{
+		{Key: "plugin_name", Value: fallback(strings.TrimSpace(manifest.Name), path.Base(pluginRoot))},
+		{Key: "plugin_version", Value: strings.TrimSpace(manifest.Version)},
+		{Key: "plugin_root", Value: pluginRoot},
+		{Key: "mcp_servers", Value: strings.Join(servers, ",")},
+		{Key: "mcp_config_ref", Value: mcpRef},
+		{Key: "hook_events", Value: strings.Join(hookEvents, ",")},
+		{Key: "hooks_ref", Value: hooksRef},
+		{Key: "exec_commands", Value: strings.Join(hookCommands, ",")},
+	}
+	if len(servers) > 0 {
+		permissions = append(permissions, "mcp.access")
+	}
+	if len(hookCommands) > 0 {
+		permissions = append(permissions, "proc.exec")
+		extra = append(extra, model.Evidence{Key: "validation_requirement", Value: "review_hook_execution"})
+	}
+	return baseFinding(scope, location, "claude plugin discovered", permissions, extra...), true
+}
+
+// pluginHooks loads hooks inline from plugin.json, from the path it names,
+// or from the default `hooks/hooks.json`.
+func pluginHooks(root, pluginRoot, manifestRel string, raw json.RawMessage) ([]string, []string, string, *model.ParseError) {
+	rel, inline := detect.ClaudePluginComponentRef(pluginRoot, raw, "hooks/hooks.json")
+	payload := raw
+	if inline {
+		rel = manifestRel
+	} else {
+		exists, parseErr := detect.FileExistsWithinRoot(detectorID, root, rel)
+		if parseErr != nil {
+			return nil, nil, "", parseErr
+		}
+		if !exists {
+			return nil, nil, "", nil
+		}
+		var parsed hooksFile
+		if parseErr := detect.ParseJSONFileAllowUnknownFields(detectorID, root, rel, &parsed); parseErr != nil {
+			return nil, nil, "", parseErr
+		}
+		payload = parsed.Hooks
+	}
+	// Inline hooks may repeat the hooks.json wrapper object.
+	var wrapped hooksFile
+	if err := json.Unmarshal(payload, &wrapped); err == nil && len(wrapped.Hooks) > 0 {
+		payload = wrapped.Hooks
+	}
+	events, commands, parseErr := parseHooks(rel, payload)
+	if parseErr != nil {
+		return nil, nil, "", parseErr
+	}
+	return events, commands, rel, nil
+}
+
+// pluginMCPServers returns the names of MCP servers a plugin installs.
+func pluginMCPServers(root, pluginRoot, manifestRel string, raw json.RawMessage) ([]string, string, *model.ParseError) {
+	rel, inline := detect.ClaudePluginComponentRef(pluginRoot, raw, ".mcp.json")
+	var servers map[string]json.RawMessage
+	if inline {
+		rel = manifestRel
+		if err := json.Unmarshal(raw, &servers); err != nil {
+			return nil, "", &model.ParseError{Kind: "parse_error", Format: "json", Path: rel, Message: "mcpServers: " + err.Error()}
+		}
+	} else {
+		exists, parseErr := detect.FileExistsWithinRoot(detectorID, root, rel)
+		if parseErr != nil {
+			return nil, "", parseErr
+		}
+		if !exists {
+			return nil, "", nil
+		}
+		var parsed pluginMCPFile
+		if parseErr := detect.ParseJSONFileAllowUnknownFields(detectorID, root, rel, &parsed); parseErr != nil {
+			return nil, "", parseErr
+		}
+		servers = parsed.MCPServers
+	}
+	names := make([]string, 0, len(servers))
+	for name := range servers {
+		names = append(names, name)
+	}
+	return dedupeSorted(names), rel, nil
+}
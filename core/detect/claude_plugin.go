@@ -0,0 +1,135 @@
+package detect
+
+import (
+	"encoding/json"
+	"path"
+	"sort"
+	"strings"
+)
+
+const (
+	ClaudePluginManifestPath    = ".claude-plugin/plugin.json"
+	ClaudePluginMarketplacePath = ".claude-plugin/marketplace.json"
+)
+
+// ClaudePluginMarketplace reads the `.claude-plugin/marketplace.json` fields
+// that locate plugins.
+type ClaudePluginMarketplace struct {
+	Name     string `json:"name"`
+	Metadata struct {
+		PluginRoot string `json:"pluginRoot"`
+	} `json:"metadata"`
+	Plugins []struct {
+		Name   string          `json:"name"`
+		Source json.RawMessage `json:"source"`
+	} `json:"plugins"`
+}
+
+// LocalSources returns the repo-relative roots of the marketplace plugins
+// whose source is a relative path. Object sources (github, git url) are
+// remote and skipped.
+func (m ClaudePluginMarketplace) LocalSources() []string {
+	out := make([]string, 0, len(m.Plugins))
+	for _, plugin := range m.Plugins {
+		if rel, ok := LocalClaudePluginSource(m.Metadata.PluginRoot, plugin.Source); ok {
+			out = append(out, rel)
+		}
+	}
+	return out
+}
+
+// LocalClaudePluginSource resolves a marketplace entry source against the
+// marketplace pluginRoot, reporting false for remote or escaping sources.
+func LocalClaudePluginSource(pluginRoot string, raw json.RawMessage) (string, bool) {
+	var source string
+	if err := json.Unmarshal(raw, &source); err != nil {
+		return "", false
+	}
+	source = strings.TrimSpace(source)
+	if source == "" || strings.Contains(source, "://") {
+		return "", false
+	}
+	return cleanClaudePluginRel(path.Join(strings.TrimSpace(pluginRoot), source))
+}
+
+// ClaudePluginComponentRef resolves a plugin.json component field. A string
+// is a plugin-relative path, an object is inline config, and an absent field
+// falls back to the plugin's default file.
+func ClaudePluginComponentRef(pluginRoot string, raw json.RawMessage, defaultRel string) (string, bool) {
+	trimmed := strings.TrimSpace(string(raw))
+	if strings.HasPrefix(trimmed, "{") {
+		return "", true
+	}
+	var ref string
+	if err := json.Unmarshal(raw, &ref); err == nil && strings.TrimSpace(ref) != "" {
+		if rel, ok := cleanClaudePluginRel(path.Join(pluginRoot, strings.TrimSpace(ref))); ok {
+			return rel, false
+		}
+	}
+	return path.Join(pluginRoot, defaultRel), false
+}
+
+// ClaudePluginRoots returns the repo-relative roots of Claude Code plugins:
+// the repository itself when it ships `.claude-plugin/plugin.json`, plus
+// every local source listed in `.claude-plugin/marketplace.json`.
+func ClaudePluginRoots(root string) []string {
+	set := map[string]struct{}{}
+	if exists, _ := FileExistsWithinRoot("claude", root, ClaudePluginManifestPath); exists {
+		set["."] = struct{}{}
+	}
+	if exists, _ := FileExistsWithinRoot("claude", root, ClaudePluginMarketplacePath); exists {
+		var parsed ClaudePluginMarketplace
+		if parseErr := ParseJSONFileAllowUnknownFields("claude", root, ClaudePluginMarketplacePath, &parsed); parseErr == nil {
+			for _, rel := range parsed.LocalSources() {
+				if DirExists(root, rel) {
+					set[rel] = struct{}{}
+				}
+			}
+		}
+	}
+	out := make([]string, 0, len(set))
+	for rel := range set {
+		out = append(out, rel)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// ClaudePluginMCPConfigPaths returns the files that declare MCP servers
+// installed by Claude Code plugins, either plugin.json itself for inline
+// servers or the `.mcp.json` it names.
+func ClaudePluginMCPConfigPaths(root string) []string {
+	set := map[string]struct{}{}
+	for _, pluginRoot := range ClaudePluginRoots(root) {
+		manifestRel := path.Join(pluginRoot, ClaudePluginManifestPath)
+		var manifest struct {
+			MCPServers json.RawMessage `json:"mcpServers"`
+		}
+		if exists, _ := FileExistsWithinRoot("claude", root, manifestRel); exists {
+			if parseErr := ParseJSONFileAllowUnknownFields("claude", root, manifestRel, &manifest); parseErr != nil {
+				continue
+			}
+		}
+		rel, inline := ClaudePluginComponentRef(pluginRoot, manifest.MCPServers, ".mcp.json")
+		if inline {
+			rel = manifestRel
+		}
+		if exists, _ := FileExistsWithinRoot("claude", root, rel); exists {
+			set[rel] = struct{}{}
+		}
+	}
+	out := make([]string, 0, len(set))
+	for rel := range set {
+		out = append(out, rel)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func cleanClaudePluginRel(rel string) (string, bool) {
+	cleaned := path.Clean(rel)
+	if cleaned == ".." || strings.HasPrefix(cleaned, "../") || path.IsAbs(cleaned) {
+		return "", false
+	}
+	return cleaned, true
+}
@@ -0,0 +1,278 @@
+package cline
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/Clyra-AI/wrkr/core/detect"
+	"github.com/Clyra-AI/wrkr/core/model"
+	"github.com/Clyra-AI/wrkr/core/risk/autonomy"
+)
+
+const detectorID = "cline"
+
+const (
+	toolTypeCline = "cline"
+	toolTypeRoo   = "roo_code"
+)
+
+// mcpSettingsPaths are the Cline MCP settings files relative to the scan
+// root. The globalStorage paths only exist under a --my-setup home root.
+var mcpSettingsPaths = []string{
+	"cline_mcp_settings.json",
+	".config/Code/User/globalStorage/saoudrizwan.claude-dev/settings/cline_mcp_settings.json",
+	"Library/Application Support/Code/User/globalStorage/saoudrizwan.claude-dev/settings/cline_mcp_settings.json",
+	"AppData/Roaming/Code/User/globalStorage/saoudrizwan.claude-dev/settings/cline_mcp_settings.json",
+}
+
+type Detector struct{}
+
+func New() Detector { return Detector{} }
+
+func (Detector) ID() string { return detectorID }
+
+type mcpSettings struct {
+	MCPServers map[string]mcpServer `json:"mcpServers"`
+}
+
+// mcpServer only reads the approval fields; server posture is scored by the
+// mcp detector. Cline uses autoApprove and Roo Code uses alwaysAllow.
+type mcpServer struct {
+	Disabled    bool     `json:"disabled"`
+	AutoApprove []string `json:"autoApprove"`
+	AlwaysAllow []string `json:"alwaysAllow"`
+}
+
+type roomodesFile struct {
+	CustomModes []struct {
+		Slug   string `json:"slug" yaml:"slug"`
+		Groups []any  `json:"groups" yaml:"groups"`
+	} `json:"customModes" yaml:"customModes"`
+}
+
+func (Detector) Detect(_ context.Context, scope detect.Scope, _ detect.Options) ([]model.Finding, error) {
+	if err := detect.ValidateScopeRoot(scope.Root); err != nil {
+		return nil, err
+	}
+
+	findings := make([]model.Finding, 0)
+	if detect.DirExists(scope.Root, ".clinerules") {
+		rules, globErr := detect.Glob(scope.Root, ".clinerules/*")
+		if globErr != nil {
+			return nil, fmt.Errorf("glob cline rules: %w", globErr)
+		}
+		for _, rel := range rules {
+			if detect.DirExists(scope.Root, rel) {
+				continue
+			}
+			findings = append(findings, baseFinding(scope, toolTypeCline, rel, nil,
+				model.Evidence{Key: "note", Value: "cline rules file discovered"},
+				model.Evidence{Key: "resolver_ref", Value: rel},
+			))
+		}
+	} else if exists, parseErr := detect.FileExistsWithinRoot(detectorID, scope.Root, ".clinerules"); parseErr != nil {
+		findings = append(findings, parseErrorFinding(scope, toolTypeCline, ".clinerules", parseErr))
+	} else if exists {
+		findings = append(findings, baseFinding(scope, toolTypeCline, ".clinerules", nil,
+			model.Evidence{Key: "note", Value: "cline rules file discovered"},
+			model.Evidence{Key: "resolver_ref", Value: ".clinerules"},
+		))
+	}
+
+	if exists, parseErr := detect.FileExistsWithinRoot(detectorID, scope.Root, ".roomodes"); parseErr != nil {
+		findings = append(findings, parseErrorFinding(scope, toolTypeRoo, ".roomodes", parseErr))
+	} else if exists {
+		findings = append(findings, parseRoomodes(scope, ".roomodes"))
+	}
+
+	roRules, globErr := detect.Glob(scope.Root, ".roo/rules*/*")
+	if globErr != nil {
+		return nil, fmt.Errorf("glob roo rules: %w", globErr)
+	}
+	for _, rel := range roRules {
+		if detect.DirExists(scope.Root, rel) {
+			continue
+		}
+		// `.roo/rules/` applies to every mode; `.roo/rules-<slug>/` to one.
+		mode := strings.TrimPrefix(strings.TrimPrefix(path.Base(path.Dir(rel)), "rules"), "-")
+		findings = append(findings, baseFinding(scope, toolTypeRoo, rel, nil,
+			model.Evidence{Key: "note", Value: "roo code rules file discovered"},
+			model.Evidence{Key: "mode", Value: fallback(mode, "all")},
+			model.Evidence{Key: "resolver_ref", Value: rel},
+		))
+	}
+
+	for _, rel := range mcpSettingsPaths {
+		exists, parseErr := detect.FileExistsWithinRoot(detectorID, scope.Root, rel)
+		if parseErr != nil {
+			findings = append(findings, parseErrorFinding(scope, toolTypeCline, rel, parseErr))
+			continue
+		}
+		if exists {
+			findings = append(findings, parseMCPSettings(scope, rel))
+		}
+	}
+
+	model.SortFindings(findings)
+	return findings, nil
+}
+
+// parseMCPSettings reports per-server auto-approved tools. Auto-approving a
+// write or exec tool removes the per-call prompt, so the finding is
+// classified with headless-equivalent autonomy.
+func parseMCPSettings(scope detect.Scope, rel string) model.Finding {
+	var parsed mcpSettings
+	if parseErr := detect.ParseJSONFileAllowUnknownFields(detectorID, scope.Root, rel, &parsed); parseErr != nil {
+		return parseErrorFinding(scope, toolTypeCline, rel, parseErr)
+	}
+	names := make([]string, 0, len(parsed.MCPServers))
+	for name := range parsed.MCPServers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	approved := make([]string, 0)
+	approvedWrite := make([]string, 0)
+	enabled := 0
+	for _, name := range names {
+		server := parsed.MCPServers[name]
+		if server.Disabled {
+			continue
+		}
+		enabled++
+		tools := append(append([]string(nil), server.AutoApprove...), server.AlwaysAllow...)
+		sort.Strings(tools)
+		seen := map[string]struct{}{}
+		for _, tool := range tools {
+			tool = strings.TrimSpace(tool)
+			if _, ok := seen[tool]; ok || tool == "" {
+				continue
+			}
+			seen[tool] = struct{}{}
+			ref := name + ":" + tool
+			approved = append(approved, ref)
+			if autonomy.IsWriteOrExecTool(tool) {
+				approvedWrite = append(approvedWrite, ref)
+			}
+		}
+	}
+
+	signals := autonomy.Signals{Tool: detectorID, AutoApprovedWrite: len(approvedWrite) > 0}
+	var permissions []string
+	if enabled > 0 {
+		permissions = []string{"mcp.access"}
+	}
+	finding := baseFinding(scope, toolTypeCline, rel, permissions,
+		model.Evidence{Key: "mcp_server_count", Value: fmt.Sprintf("%d", len(parsed.MCPServers))},
+		model.Evidence{Key: "mcp_enabled_server_count", Value: fmt.Sprintf("%d", enabled)},
+		model.Evidence{Key: "auto_approved_tools", Value: strings.Join(approved, ",")},
+		model.Evidence{Key: "auto_approved_write_tools", Value: strings.Join(approvedWrite, ",")},
+		model.Evidence{Key: "headless", Value: "false"},
+		model.Evidence{Key: "approval_gate", Value: fmt.Sprintf("%t", !signals.AutoApprovedWrite)},
+		model.Evidence{Key: "resolver_ref", Value: rel},
+	)
+	finding.Autonomy = autonomy.Classify(signals)
+	if signals.AutoApprovedWrite {
+		finding.Severity = model.SeverityMedium
+		finding.Remediation = "Remove write and exec tools from MCP autoApprove/alwaysAllow lists so each call needs review."
+	}
+	return finding
+}
+
+// parseRoomodes maps Roo Code custom mode tool groups to permissions.
+func parseRoomodes(scope detect.Scope, rel string) model.Finding {
+	var parsed roomodesFile
+	if parseErr := detect.ParseYAMLFileAllowUnknownFields(detectorID, scope.Root, rel, &parsed); parseErr != nil {
+		return parseErrorFinding(scope, toolTypeRoo, rel, parseErr)
+	}
+	slugs := make([]string, 0, len(parsed.CustomModes))
+	seen := map[string]struct{}{}
+	permissions := make([]string, 0)
+	for _, mode := range parsed.CustomModes {
+		slugs = append(slugs, strings.TrimSpace(mode.Slug))
+		for _, group := range mode.Groups {
+			// A group is either a name or a [name, {fileRegex: ...}] pair.
+			name, _ := group.(string)
+			if pair, ok := group.([]any); ok && len(pair) > 0 {
+				name, _ = pair[0].(string)
+			}
+			permission := groupPermission(name)
+			if _, ok := seen[permission]; ok || permission == "" {
+				continue
+			}
+			seen[permission] = struct{}{}
+			permissions = append(permissions, permission)
+		}
+	}
+	sort.Strings(slugs)
+	return baseFinding(scope, toolTypeRoo, rel, permissions,
+		model.Evidence{Key: "custom_mode_count", Value: fmt.Sprintf("%d", len(parsed.CustomModes))},
+		model.Evidence{Key: "custom_modes", Value: strings.Join(slugs, ",")},
+		model.Evidence{Key: "resolver_ref", Value: rel},
+	)
+}
+
+func groupPermission(group string) string {
+	switch strings.ToLower(strings.TrimSpace(group)) {
+	case "read":
+		return "filesystem.read"
+	case "edit":
+		return "filesystem.write"
+	case "command":
+		return "proc.exec"
+	case "browser":
+		return "network.access"
+	case "mcp":
+		return "mcp.access"
+	default:
+		return ""
+	}
+}
+
+func baseFinding(scope detect.Scope, toolType, location string, permissions []string, extra ...model.Evidence) model.Finding {
+	evidence := []model.Evidence{{Key: "delivery_harness", Value: toolType}}
+	evidence = append(evidence, extra...)
+	return model.Finding{
+		FindingType: "tool_config",
+		Severity:    model.SeverityLow,
+		ToolType:    toolType,
+		Location:    location,
+		Repo:        scope.Repo,
+		Org:         fallbackOrg(scope.Org),
+		Detector:    detectorID,
+		Permissions: permissions,
+		Evidence:    evidence,
+	}
+}
+
+func parseErrorFinding(scope detect.Scope, toolType, location string, parseErr *model.ParseError) model.Finding {
+	parseErr.Detector = detectorID
+	return model.Finding{
+		FindingType: "parse_error",
+		Severity:    model.SeverityMedium,
+		ToolType:    toolType,
+		Location:    location,
+		Repo:        scope.Repo,
+		Org:         fallbackOrg(scope.Org),
+		Detector:    detectorID,
+		ParseError:  parseErr,
+		Remediation: "Fix malformed Cline or Roo Code configuration so deterministic parsing can proceed.",
+	}
+}
+
+func fallback(value, fallbackValue string) string {
+	if strings.TrimSpace(value) == "" {
+		return fallbackValue
+	}
+	return value
+}
+
+func fallbackOrg(org string) string {
+	if strings.TrimSpace(org) == "" {
+		return "local"
+	}
+	return org
+}
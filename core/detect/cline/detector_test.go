@@ -0,0 +1,168 @@
+package cline
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/Clyra-AI/wrkr/core/detect"
+	"github.com/Clyra-AI/wrkr/core/model"
+	"github.com/Clyra-AI/wrkr/core/risk/autonomy"
+)
+
+func TestClineDetectorFindsRulesAndRooModes(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeClineFile(t, root, ".clinerules/01-style.md", "Use gofmt.\n")
+	writeClineFile(t, root, ".clinerules/02-tests.md", "Run go test.\n")
+	writeClineFile(t, root, ".roomodes", `customModes:
+  - slug: docs-writer
+    name: Docs Writer
+    groups:
+      - read
+      - - edit
+        - fileRegex: \.md$
+  - slug: release
+    name: Release
+    groups: [read, command, mcp]
+`)
+	writeClineFile(t, root, ".roo/rules/general.md", "Be concise.\n")
+	writeClineFile(t, root, ".roo/rules-docs-writer/tone.md", "Use plain words.\n")
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Root: root, Repo: "repo", Org: "local"}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect: %v", err)
+	}
+	byLocation := map[string]model.Finding{}
+	for _, finding := range findings {
+		if finding.FindingType != "tool_config" {
+			t.Fatalf("expected only tool_config findings, got %+v", finding)
+		}
+		byLocation[finding.Location] = finding
+	}
+	for _, location := range []string{".clinerules/01-style.md", ".clinerules/02-tests.md", ".roomodes", ".roo/rules/general.md", ".roo/rules-docs-writer/tone.md"} {
+		if _, ok := byLocation[location]; !ok {
+			t.Fatalf("expected finding for %s, got %+v", location, findings)
+		}
+	}
+
+	modes := byLocation[".roomodes"]
+	if modes.ToolType != "roo_code" {
+		t.Fatalf("expected roo_code tool type, got %q", modes.ToolType)
+	}
+	if want := []string{"filesystem.read", "filesystem.write", "mcp.access", "proc.exec"}; !reflect.DeepEqual(modes.Permissions, want) {
+		t.Fatalf("unexpected roomodes permissions: got %v want %v", modes.Permissions, want)
+	}
+	if got := evidenceValue(modes, "custom_modes"); got != "docs-writer,release" {
+		t.Fatalf("unexpected custom modes evidence %q", got)
+	}
+	if got := evidenceValue(byLocation[".roo/rules-docs-writer/tone.md"], "mode"); got != "docs-writer" {
+		t.Fatalf("expected mode-scoped rules, got %q", got)
+	}
+	if got := evidenceValue(byLocation[".roo/rules/general.md"], "mode"); got != "all" {
+		t.Fatalf("expected shared rules, got %q", got)
+	}
+}
+
+func TestClineDetectorSingleRulesFile(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeClineFile(t, root, ".clinerules", "Prefer small commits.\n")
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Root: root, Repo: "repo", Org: "local"}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect: %v", err)
+	}
+	if len(findings) != 1 || findings[0].Location != ".clinerules" || findings[0].ToolType != "cline" {
+		t.Fatalf("expected one .clinerules finding, got %+v", findings)
+	}
+}
+
+func TestClineDetectorAutoApprovedWriteToolsAreHeadlessEquivalent(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeClineFile(t, root, "cline_mcp_settings.json", `{
+  "mcpServers": {
+    "github": {"command": "npx", "args": ["-y", "@modelcontextprotocol/server-github"], "autoApprove": ["get_issue", "create_pull_request"]},
+    "fs": {"command": "npx", "alwaysAllow": ["read_file", "list_directory"]},
+    "shell": {"command": "mcp-shell", "autoApprove": ["run_command"], "disabled": true}
+  }
+}`)
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Root: root, Repo: "repo", Org: "local"}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect: %v", err)
+	}
+	if len(findings) != 1 {
+		t.Fatalf("expected one mcp settings finding, got %+v", findings)
+	}
+	finding := findings[0]
+	if finding.Autonomy != autonomy.LevelHeadlessAuto || finding.Severity != model.SeverityMedium {
+		t.Fatalf("expected auto-approved write tool to classify as headless_auto, got %+v", finding)
+	}
+	for key, want := range map[string]string{
+		"auto_approved_tools":       "fs:list_directory,fs:read_file,github:create_pull_request,github:get_issue",
+		"auto_approved_write_tools": "github:create_pull_request",
+		"mcp_enabled_server_count":  "2",
+		"approval_gate":             "false",
+	} {
+		if got := evidenceValue(finding, key); got != want {
+			t.Fatalf("expected %s=%q, got %q", key, want, got)
+		}
+	}
+}
+
+func TestClineDetectorReadOnlyAutoApproveStaysInteractive(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeClineFile(t, root, "cline_mcp_settings.json", `{"mcpServers": {"docs": {"url": "https://docs.example.com/mcp", "autoApprove": ["search_docs"]}}}`)
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Root: root, Repo: "repo", Org: "local"}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect: %v", err)
+	}
+	if len(findings) != 1 || findings[0].Autonomy != autonomy.LevelInteractive || findings[0].Severity != model.SeverityLow {
+		t.Fatalf("expected read-only auto-approve to stay interactive, got %+v", findings)
+	}
+}
+
+func TestClineDetectorReportsMalformedSettings(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeClineFile(t, root, "cline_mcp_settings.json", `{"mcpServers": `)
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Root: root, Repo: "repo", Org: "local"}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect: %v", err)
+	}
+	if len(findings) != 1 || findings[0].FindingType != "parse_error" || findings[0].ParseError == nil || findings[0].ParseError.Detector != detectorID {
+		t.Fatalf("expected cline parse_error, got %+v", findings)
+	}
+}
+
+func evidenceValue(finding model.Finding, key string) string {
+	for _, item := range finding.Evidence {
+		if item.Key == key {
+			return item.Value
+		}
+	}
+	return ""
+}
+
+func writeClineFile(t *testing.T, root, rel, content string) {
+	t.Helper()
+	path := filepath.Join(root, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir %s: %v", rel, err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write %s: %v", rel, err)
+	}
+}
@@ -3,10 +3,12 @@ package codex
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/Clyra-AI/wrkr/core/detect"
 	"github.com/Clyra-AI/wrkr/core/model"
+	"github.com/Clyra-AI/wrkr/core/risk/autonomy"
 )
 
 const detectorID = "codex"
@@ -18,9 +20,38 @@ func New() Detector { return Detector{} }
 func (Detector) ID() string { return detectorID }
 
 type configModel struct {
+	SandboxMode            string                  `toml:"sandbox_mode" yaml:"sandbox_mode"`
+	ApprovalPolicy         string                  `toml:"approval_policy" yaml:"approval_policy"`
+	NetworkAccess          bool                    `toml:"network_access" yaml:"network_access"`
+	Profile                string                  `toml:"profile" yaml:"profile"`
+	Profiles               map[string]profileModel `toml:"profiles" yaml:"profiles"`
+	Projects               map[string]projectModel `toml:"projects" yaml:"projects"`
+	SandboxWorkspaceWrite  workspaceWriteModel     `toml:"sandbox_workspace_write" yaml:"sandbox_workspace_write"`
+	ShellEnvironmentPolicy shellEnvironmentModel   `toml:"shell_environment_policy" yaml:"shell_environment_policy"`
+}
+
+// profileModel is a `[profiles.<name>]` table. Unset fields inherit the
+// top-level value when the profile is selected.
+type profileModel struct {
 	SandboxMode    string `toml:"sandbox_mode" yaml:"sandbox_mode"`
 	ApprovalPolicy string `toml:"approval_policy" yaml:"approval_policy"`
-	NetworkAccess  bool   `toml:"network_access" yaml:"network_access"`
+}
+
+type projectModel struct {
+	TrustLevel string `toml:"trust_level" yaml:"trust_level"`
+}
+
+type workspaceWriteModel struct {
+	NetworkAccess bool `toml:"network_access" yaml:"network_access"`
+}
+
+// shellEnvironmentModel controls which environment variables reach commands
+// Codex spawns. By default variables named like KEY, SECRET, or TOKEN are
+// dropped unless ignore_default_excludes is set.
+type shellEnvironmentModel struct {
+	Inherit               string   `toml:"inherit" yaml:"inherit"`
+	IgnoreDefaultExcludes bool     `toml:"ignore_default_excludes" yaml:"ignore_default_excludes"`
+	IncludeOnly           []string `toml:"include_only" yaml:"include_only"`
 }
 
 func (Detector) Detect(_ context.Context, scope detect.Scope, _ detect.Options) ([]model.Finding, error) {
@@ -76,12 +107,7 @@ func (Detector) Detect(_ context.Context, scope detect.Scope, _ detect.Options)
 			Remediation: "Keep Codex configuration inside the selected repository root.",
 		})
 	} else if exists {
-		items, parseErr := parseConfig(scope, ".codex/config.toml", "toml")
-		if parseErr != nil {
-			findings = append(findings, *parseErr)
-		} else {
-			findings = append(findings, items)
-		}
+		findings = append(findings, parseConfig(scope, ".codex/config.toml", "toml")...)
 	}
 	if exists, parseErr := detect.FileExistsWithinRoot(detectorID, scope.Root, ".codex/config.yaml"); parseErr != nil {
 		findings = append(findings, model.Finding{
@@ -96,19 +122,14 @@ func (Detector) Detect(_ context.Context, scope detect.Scope, _ detect.Options)
 			Remediation: "Keep Codex configuration inside the selected repository root.",
 		})
 	} else if exists {
-		items, parseErr := parseConfig(scope, ".codex/config.yaml", "yaml")
-		if parseErr != nil {
-			findings = append(findings, *parseErr)
-		} else {
-			findings = append(findings, items)
-		}
+		findings = append(findings, parseConfig(scope, ".codex/config.yaml", "yaml")...)
 	}
 
 	model.SortFindings(findings)
 	return findings, nil
 }
 
-func parseConfig(scope detect.Scope, rel, format string) (model.Finding, *model.Finding) {
+func parseConfig(scope detect.Scope, rel, format string) []model.Finding {
 	var parsed configModel
 	var parseErr *model.ParseError
 	switch format {
@@ -121,7 +142,7 @@ func parseConfig(scope detect.Scope, rel, format string) (model.Finding, *model.
 	}
 	if parseErr != nil {
 		parseErr.Detector = detectorID
-		finding := model.Finding{
+		return []model.Finding{{
 			FindingType: "parse_error",
 			Severity:    model.SeverityMedium,
 			ToolType:    "codex",
@@ -131,22 +152,35 @@ func parseConfig(scope detect.Scope, rel, format string) (model.Finding, *model.
 			Detector:    detectorID,
 			ParseError:  parseErr,
 			Remediation: "Fix malformed Codex configuration.",
-		}
-		return model.Finding{}, &finding
+		}}
 	}
 
-	permissions := make([]string, 0, 3)
-	if strings.EqualFold(parsed.SandboxMode, "danger-full-access") {
+	posture := effectivePosture(parsed)
+	networkAccess := parsed.NetworkAccess || parsed.SandboxWorkspaceWrite.NetworkAccess
+	secretsExposed := shellEnvironmentExposesSecrets(parsed.ShellEnvironmentPolicy)
+	permissions := make([]string, 0, 4)
+	if strings.EqualFold(posture.SandboxMode, "danger-full-access") {
 		permissions = append(permissions, "filesystem.write")
 	}
-	if parsed.NetworkAccess {
+	if networkAccess {
 		permissions = append(permissions, "network.access")
 	}
-	if strings.EqualFold(parsed.ApprovalPolicy, "never") {
+	if strings.EqualFold(posture.ApprovalPolicy, "never") {
 		permissions = append(permissions, "proc.exec")
 	}
+	if secretsExposed {
+		permissions = append(permissions, "secret.read")
+	}
+	trusted := trustedProjects(parsed.Projects)
 
-	return model.Finding{
+	signals := autonomy.Signals{
+		Tool:            detectorID,
+		Headless:        strings.EqualFold(posture.ApprovalPolicy, "never"),
+		HasApprovalGate: !strings.EqualFold(posture.ApprovalPolicy, "never"),
+		HasSecretAccess: secretsExposed,
+		DangerousFlags:  strings.EqualFold(posture.SandboxMode, "danger-full-access"),
+	}
+	finding := model.Finding{
 		FindingType: "tool_config",
 		Severity:    model.SeverityLow,
 		ToolType:    "codex",
@@ -155,16 +189,104 @@ func parseConfig(scope detect.Scope, rel, format string) (model.Finding, *model.
 		Org:         fallbackOrg(scope.Org),
 		Detector:    detectorID,
 		Permissions: permissions,
+		Autonomy:    autonomy.Classify(signals),
 		Evidence: []model.Evidence{
 			{Key: "delivery_harness", Value: "codex_cli"},
 			{Key: "sandbox_mode", Value: parsed.SandboxMode},
 			{Key: "approval_policy", Value: parsed.ApprovalPolicy},
-			{Key: "network_access", Value: fmt.Sprintf("%t", parsed.NetworkAccess)},
+			{Key: "network_access", Value: fmt.Sprintf("%t", networkAccess)},
+			{Key: "default_profile", Value: strings.TrimSpace(parsed.Profile)},
+			{Key: "profiles", Value: strings.Join(sortedKeys(parsed.Profiles), ",")},
+			{Key: "effective_sandbox_mode", Value: posture.SandboxMode},
+			{Key: "effective_sandbox_source", Value: posture.SandboxSource},
+			{Key: "effective_approval_policy", Value: posture.ApprovalPolicy},
+			{Key: "effective_approval_source", Value: posture.ApprovalSource},
+			{Key: "shell_environment_inherit", Value: fallback(strings.TrimSpace(parsed.ShellEnvironmentPolicy.Inherit), "all")},
+			{Key: "shell_environment_secrets_exposed", Value: fmt.Sprintf("%t", secretsExposed)},
+			{Key: "trusted_project_count", Value: fmt.Sprintf("%d", len(trusted))},
 			{Key: "resolver_ref", Value: rel},
-			{Key: "sandbox_gate", Value: "sandbox_mode:" + strings.TrimSpace(parsed.SandboxMode)},
+			{Key: "sandbox_gate", Value: "sandbox_mode:" + strings.TrimSpace(posture.SandboxMode)},
 			{Key: "validation_requirement", Value: "respect_codex_sandbox_mode"},
 		},
-	}, nil
+	}
+	if finding.Autonomy == autonomy.LevelHeadlessAuto {
+		finding.Severity = model.SeverityMedium
+		finding.Remediation = "Set approval_policy to on-request or untrusted in every Codex profile so commands require approval."
+	}
+
+	findings := []model.Finding{finding}
+	if detect.IsLocalMachineScope(scope) {
+		findings = append(findings, trustedProjectFindings(scope, rel, trusted)...)
+	}
+	return findings
+}
+
+// posture is the least-restrictive sandbox and approval setting reachable
+// through the top-level config or any profile, with the source that sets it.
+type posture struct {
+	SandboxMode    string
+	SandboxSource  string
+	ApprovalPolicy string
+	ApprovalSource string
+}
+
+var sandboxRank = map[string]int{
+	"read-only":          1,
+	"workspace-write":    2,
+	"danger-full-access": 3,
+}
+
+var approvalRank = map[string]int{
+	"untrusted":  1,
+	"on-request": 2,
+	"on-failure": 3,
+	"never":      4,
+}
+
+// effectivePosture evaluates every profile because any of them can be
+// selected with --profile; profiles inherit unset fields from the top level.
+func effectivePosture(parsed configModel) posture {
+	out := posture{
+		SandboxMode:    strings.TrimSpace(parsed.SandboxMode),
+		SandboxSource:  "top_level",
+		ApprovalPolicy: strings.TrimSpace(parsed.ApprovalPolicy),
+		ApprovalSource: "top_level",
+	}
+	for _, name := range sortedKeys(parsed.Profiles) {
+		profile := parsed.Profiles[name]
+		if mode := strings.TrimSpace(profile.SandboxMode); sandboxRank[strings.ToLower(mode)] > sandboxRank[strings.ToLower(out.SandboxMode)] {
+			out.SandboxMode = mode
+			out.SandboxSource = "profile:" + name
+		}
+		if policy := strings.TrimSpace(profile.ApprovalPolicy); approvalRank[strings.ToLower(policy)] > approvalRank[strings.ToLower(out.ApprovalPolicy)] {
+			out.ApprovalPolicy = policy
+			out.ApprovalSource = "profile:" + name
+		}
+	}
+	return out
+}
+
+func shellEnvironmentExposesSecrets(policy shellEnvironmentModel) bool {
+	if strings.EqualFold(strings.TrimSpace(policy.Inherit), "none") || len(policy.IncludeOnly) > 0 {
+		return false
+	}
+	return policy.IgnoreDefaultExcludes
+}
+
+func sortedKeys[V any](values map[string]V) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func fallback(value, fallbackValue string) string {
+	if value == "" {
+		return fallbackValue
+	}
+	return value
 }
 
 func fallbackOrg(org string) string {
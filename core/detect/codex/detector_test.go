@@ -4,9 +4,12 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/Clyra-AI/wrkr/core/detect"
+	"github.com/Clyra-AI/wrkr/core/model"
+	"github.com/Clyra-AI/wrkr/core/risk/autonomy"
 )
 
 func TestCodexDetectorIgnoresAdditiveVendorFields(t *testing.T) {
@@ -92,3 +95,175 @@ func TestCodexDetectorRejectsExternalSymlinkedConfig(t *testing.T) {
 		t.Fatalf("expected unsafe_path parse error, got %+v", findings[0].ParseError)
 	}
 }
+
+func TestCodexDetectorEmitsLeastRestrictiveProfilePosture(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeCodexConfig(t, root, `sandbox_mode = "read-only"
+approval_policy = "on-request"
+profile = "safe"
+
+[profiles.safe]
+model = "gpt-5-codex"
+
+[profiles.yolo]
+approval_policy = "never"
+
+[profiles.deploy]
+sandbox_mode = "danger-full-access"
+
+[shell_environment_policy]
+inherit = "all"
+ignore_default_excludes = true
+`)
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Root: root, Repo: "repo", Org: "local"}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect: %v", err)
+	}
+	if len(findings) != 1 {
+		t.Fatalf("expected 1 finding, got %+v", findings)
+	}
+	finding := findings[0]
+	for key, want := range map[string]string{
+		"sandbox_mode":                      "read-only",
+		"approval_policy":                   "on-request",
+		"default_profile":                   "safe",
+		"profiles":                          "deploy,safe,yolo",
+		"effective_sandbox_mode":            "danger-full-access",
+		"effective_sandbox_source":          "profile:deploy",
+		"effective_approval_policy":         "never",
+		"effective_approval_source":         "profile:yolo",
+		"shell_environment_secrets_exposed": "true",
+		"sandbox_gate":                      "sandbox_mode:danger-full-access",
+	} {
+		if got := evidenceValue(finding, key); got != want {
+			t.Fatalf("expected %s=%q, got %q", key, want, got)
+		}
+	}
+	if got := strings.Join(finding.Permissions, ","); got != "filesystem.write,proc.exec,secret.read" {
+		t.Fatalf("unexpected permissions %q", got)
+	}
+	if finding.Autonomy != autonomy.LevelHeadlessAuto || finding.Severity != model.SeverityMedium {
+		t.Fatalf("expected headless_auto medium posture, got %+v", finding)
+	}
+}
+
+func TestCodexDetectorKeepsGatedPostureWithoutRiskyProfiles(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeCodexConfig(t, root, `sandbox_mode = "workspace-write"
+approval_policy = "on-request"
+
+[profiles.review]
+approval_policy = "untrusted"
+sandbox_mode = "read-only"
+
+[shell_environment_policy]
+ignore_default_excludes = true
+include_only = ["PATH", "HOME"]
+`)
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Root: root, Repo: "repo", Org: "local"}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect: %v", err)
+	}
+	if len(findings) != 1 {
+		t.Fatalf("expected 1 finding, got %+v", findings)
+	}
+	finding := findings[0]
+	if got := evidenceValue(finding, "effective_approval_source"); got != "top_level" {
+		t.Fatalf("expected top-level approval source, got %q", got)
+	}
+	if got := evidenceValue(finding, "shell_environment_secrets_exposed"); got != "false" {
+		t.Fatalf("expected include_only to keep secrets out, got %q", got)
+	}
+	if len(finding.Permissions) != 0 || finding.Autonomy != autonomy.LevelInteractive {
+		t.Fatalf("expected interactive posture without permissions, got %+v", finding)
+	}
+}
+
+func TestCodexDetectorCorrelatesTrustedProjectsForMySetup(t *testing.T) {
+	t.Parallel()
+
+	home := t.TempDir()
+	checkout := filepath.Join(home, "Projects", "api")
+	if err := os.MkdirAll(filepath.Join(checkout, ".git"), 0o755); err != nil {
+		t.Fatalf("mkdir checkout: %v", err)
+	}
+	gitConfig := "[core]\n\tbare = false\n[remote \"origin\"]\n\turl = git@github.com:acme/api.git\n"
+	if err := os.WriteFile(filepath.Join(checkout, ".git", "config"), []byte(gitConfig), 0o600); err != nil {
+		t.Fatalf("write git config: %v", err)
+	}
+	writeCodexConfig(t, home, `[projects."`+filepath.ToSlash(checkout)+`"]
+trust_level = "trusted"
+
+[projects."/srv/elsewhere"]
+trust_level = "trusted"
+
+[projects."`+filepath.ToSlash(filepath.Join(home, "Projects", "scratch"))+`"]
+trust_level = "untrusted"
+`)
+
+	repoFindings, err := New().Detect(context.Background(), detect.Scope{Root: home, Repo: "repo", Org: "local"}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect repo scope: %v", err)
+	}
+	if len(repoFindings) != 1 || evidenceValue(repoFindings[0], "trusted_project_count") != "2" {
+		t.Fatalf("expected repo scans to only count trusted projects, got %+v", repoFindings)
+	}
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Root: home, Repo: "local-machine", Org: "local", TargetMode: "my_setup"}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect my_setup scope: %v", err)
+	}
+	projects := map[string]model.Finding{}
+	for _, finding := range findings {
+		if finding.FindingType == "trusted_project" {
+			projects[finding.Location] = finding
+		}
+	}
+	if len(projects) != 2 {
+		t.Fatalf("expected 2 trusted project findings, got %+v", findings)
+	}
+	api, ok := projects["Projects/api"]
+	if !ok {
+		t.Fatalf("expected home-relative project location, got %+v", projects)
+	}
+	for key, want := range map[string]string{
+		"project_name":        "api",
+		"workspace_root":      "Projects",
+		"project_correlation": "origin_remote",
+		"repo_host":           "github.com",
+		"project_repo":        "acme/api",
+	} {
+		if got := evidenceValue(api, key); got != want {
+			t.Fatalf("expected %s=%q, got %q", key, want, got)
+		}
+	}
+	outside, ok := projects[".codex/config.toml"]
+	if !ok || evidenceValue(outside, "project_correlation") != "outside_scope" {
+		t.Fatalf("expected outside-home project to stay on the config location, got %+v", projects)
+	}
+}
+
+func writeCodexConfig(t *testing.T, root, payload string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Join(root, ".codex"), 0o755); err != nil {
+		t.Fatalf("mkdir .codex: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(root, ".codex", "config.toml"), []byte(payload), 0o600); err != nil {
+		t.Fatalf("write config.toml: %v", err)
+	}
+}
+
+func evidenceValue(finding model.Finding, key string) string {
+	for _, item := range finding.Evidence {
+		if item.Key == key {
+			return item.Value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,134 @@
+package codex
+
+import (
+	"bufio"
+	"bytes"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Clyra-AI/wrkr/core/detect"
+	"github.com/Clyra-AI/wrkr/core/model"
+)
+
+// trustedProjects returns the `[projects."<path>"]` entries marked trusted.
+// Codex skips its first-run approval prompt for these directories.
+func trustedProjects(projects map[string]projectModel) []string {
+	out := make([]string, 0, len(projects))
+	for projectPath, project := range projects {
+		if strings.EqualFold(strings.TrimSpace(project.TrustLevel), "trusted") && strings.TrimSpace(projectPath) != "" {
+			out = append(out, strings.TrimSpace(projectPath))
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// trustedProjectFindings correlates trusted projects from a user-level config
+// with the checkouts they point at. Projects under the scanned home directory
+// are located by their home-relative path, which matches the workstation
+// project markers, and carry the origin repository when one is configured.
+func trustedProjectFindings(scope detect.Scope, configRel string, projects []string) []model.Finding {
+	findings := make([]model.Finding, 0, len(projects))
+	for _, projectPath := range projects {
+		location := configRel
+		correlation := "outside_scope"
+		workspaceRoot := ""
+		repo := ""
+		host := ""
+		if rel, ok := homeRelative(scope.Root, projectPath); ok {
+			location = rel
+			correlation = "missing"
+			workspaceRoot = path.Dir(rel)
+			if workspaceRoot == "." {
+				workspaceRoot = ""
+			}
+			if detect.DirExists(scope.Root, rel) {
+				correlation = "local_checkout"
+				host, repo = originRepo(scope.Root, rel)
+				if repo != "" {
+					correlation = "origin_remote"
+				}
+			}
+		}
+		findings = append(findings, model.Finding{
+			FindingType: "trusted_project",
+			Severity:    model.SeverityLow,
+			ToolType:    "codex",
+			Location:    location,
+			Repo:        scope.Repo,
+			Org:         fallbackOrg(scope.Org),
+			Detector:    detectorID,
+			Evidence: []model.Evidence{
+				{Key: "project_name", Value: path.Base(filepath.ToSlash(filepath.Clean(projectPath)))},
+				{Key: "workspace_root", Value: workspaceRoot},
+				{Key: "trust_level", Value: "trusted"},
+				{Key: "project_correlation", Value: correlation},
+				{Key: "repo_host", Value: host},
+				{Key: "project_repo", Value: repo},
+				{Key: "config_source", Value: configRel},
+			},
+			Remediation: "Review trusted Codex projects; trusted directories run without the first-run approval prompt.",
+		})
+	}
+	return findings
+}
+
+func homeRelative(root, projectPath string) (string, bool) {
+	projectPath = strings.TrimSpace(projectPath)
+	if strings.HasPrefix(projectPath, "~/") {
+		projectPath = filepath.Join(root, projectPath[2:])
+	}
+	if !filepath.IsAbs(projectPath) {
+		return "", false
+	}
+	rel, err := filepath.Rel(root, projectPath)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	return filepath.ToSlash(rel), true
+}
+
+// originRepo reads the origin remote of a checkout and returns its host and
+// owner/name so the project can be joined to hosted repository scans.
+func originRepo(root, projectRel string) (string, string) {
+	payload, parseErr := detect.ReadFileWithinRoot(detectorID, root, path.Join(projectRel, ".git", "config"))
+	if parseErr != nil {
+		return "", ""
+	}
+	inOrigin := false
+	scanner := bufio.NewScanner(bytes.NewReader(payload))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "[") {
+			inOrigin = line == `[remote "origin"]`
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if inOrigin && ok && strings.TrimSpace(key) == "url" {
+			return parseRemoteURL(strings.TrimSpace(value))
+		}
+	}
+	return "", ""
+}
+
+// parseRemoteURL accepts https and scp-style ssh remotes.
+func parseRemoteURL(remote string) (string, string) {
+	remote = strings.TrimSuffix(strings.TrimSuffix(remote, "/"), ".git")
+	if _, rest, ok := strings.Cut(remote, "://"); ok {
+		remote = rest
+		if _, afterUser, hasUser := strings.Cut(remote, "@"); hasUser {
+			remote = afterUser
+		}
+		host, repoPath, _ := strings.Cut(remote, "/")
+		return host, repoPath
+	}
+	if _, afterUser, hasUser := strings.Cut(remote, "@"); hasUser {
+		host, repoPath, ok := strings.Cut(afterUser, ":")
+		if ok {
+			return host, repoPath
+		}
+	}
+	return "", ""
+}
@@ -0,0 +1,203 @@
+package continuedev
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Clyra-AI/wrkr/core/detect"
+	"github.com/Clyra-AI/wrkr/core/model"
+	"github.com/Clyra-AI/wrkr/core/risk/autonomy"
+)
+
+const detectorID = "continue"
+
+type Detector struct{}
+
+func New() Detector { return Detector{} }
+
+func (Detector) ID() string { return detectorID }
+
+// configFile only reads the inventory-relevant blocks of config.yaml; MCP
+// server posture is scored by the mcp detector.
+type configFile struct {
+	Name       string `yaml:"name"`
+	Models     []any  `yaml:"models"`
+	Rules      []any  `yaml:"rules"`
+	Prompts    []any  `yaml:"prompts"`
+	MCPServers []struct {
+		Name string `yaml:"name"`
+	} `yaml:"mcpServers"`
+}
+
+// permissionsFile is the Continue CLI tool policy. Tools in `allow` run
+// without prompting.
+type permissionsFile struct {
+	Allow   []string `yaml:"allow"`
+	Ask     []string `yaml:"ask"`
+	Exclude []string `yaml:"exclude"`
+}
+
+func (Detector) Detect(_ context.Context, scope detect.Scope, _ detect.Options) ([]model.Finding, error) {
+	if err := detect.ValidateScopeRoot(scope.Root); err != nil {
+		return nil, err
+	}
+
+	findings := make([]model.Finding, 0)
+	if exists, parseErr := detect.FileExistsWithinRoot(detectorID, scope.Root, ".continue/config.yaml"); parseErr != nil {
+		findings = append(findings, parseErrorFinding(scope, ".continue/config.yaml", parseErr))
+	} else if exists {
+		findings = append(findings, parseConfig(scope, ".continue/config.yaml", "continue config parsed"))
+	}
+
+	blocks, globErr := detect.Glob(scope.Root, ".continue/mcpServers/*.yaml")
+	if globErr != nil {
+		return nil, fmt.Errorf("glob continue mcp blocks: %w", globErr)
+	}
+	for _, rel := range blocks {
+		findings = append(findings, parseConfig(scope, rel, "continue mcp block parsed"))
+	}
+
+	rules, globErr := detect.Glob(scope.Root, ".continue/rules/*")
+	if globErr != nil {
+		return nil, fmt.Errorf("glob continue rules: %w", globErr)
+	}
+	for _, rel := range rules {
+		if detect.DirExists(scope.Root, rel) {
+			continue
+		}
+		findings = append(findings, baseFinding(scope, rel, nil,
+			model.Evidence{Key: "note", Value: "continue rule discovered"},
+			model.Evidence{Key: "resolver_ref", Value: rel},
+		))
+	}
+
+	if exists, parseErr := detect.FileExistsWithinRoot(detectorID, scope.Root, ".continue/permissions.yaml"); parseErr != nil {
+		findings = append(findings, parseErrorFinding(scope, ".continue/permissions.yaml", parseErr))
+	} else if exists {
+		findings = append(findings, parsePermissions(scope, ".continue/permissions.yaml"))
+	}
+
+	model.SortFindings(findings)
+	return findings, nil
+}
+
+func parseConfig(scope detect.Scope, rel, note string) model.Finding {
+	var parsed configFile
+	if parseErr := detect.ParseYAMLFileAllowUnknownFields(detectorID, scope.Root, rel, &parsed); parseErr != nil {
+		return parseErrorFinding(scope, rel, parseErr)
+	}
+	servers := make([]string, 0, len(parsed.MCPServers))
+	for _, server := range parsed.MCPServers {
+		servers = append(servers, strings.TrimSpace(server.Name))
+	}
+	sort.Strings(servers)
+	var permissions []string
+	if len(servers) > 0 {
+		permissions = []string{"mcp.access"}
+	}
+	return baseFinding(scope, rel, permissions,
+		model.Evidence{Key: "note", Value: note},
+		model.Evidence{Key: "model_count", Value: fmt.Sprintf("%d", len(parsed.Models))},
+		model.Evidence{Key: "rule_count", Value: fmt.Sprintf("%d", len(parsed.Rules))},
+		model.Evidence{Key: "prompt_count", Value: fmt.Sprintf("%d", len(parsed.Prompts))},
+		model.Evidence{Key: "mcp_servers", Value: strings.Join(servers, ",")},
+		model.Evidence{Key: "resolver_ref", Value: rel},
+	)
+}
+
+// parsePermissions maps allowed tools to permissions. Allowing a write or
+// exec tool without a prompt is classified as headless-equivalent autonomy.
+func parsePermissions(scope detect.Scope, rel string) model.Finding {
+	var parsed permissionsFile
+	if parseErr := detect.ParseYAMLFileAllowUnknownFields(detectorID, scope.Root, rel, &parsed); parseErr != nil {
+		return parseErrorFinding(scope, rel, parseErr)
+	}
+	seen := map[string]struct{}{}
+	permissions := make([]string, 0)
+	for _, tool := range parsed.Allow {
+		permission := toolPermission(tool)
+		if _, ok := seen[permission]; ok || permission == "" {
+			continue
+		}
+		seen[permission] = struct{}{}
+		permissions = append(permissions, permission)
+	}
+	_, allowsWrite := seen["filesystem.write"]
+	_, allowsExec := seen["proc.exec"]
+	signals := autonomy.Signals{Tool: detectorID, AutoApprovedWrite: allowsWrite || allowsExec}
+	finding := baseFinding(scope, rel, permissions,
+		model.Evidence{Key: "allow_tools", Value: strings.Join(parsed.Allow, ",")},
+		model.Evidence{Key: "ask_tools", Value: strings.Join(parsed.Ask, ",")},
+		model.Evidence{Key: "exclude_tools", Value: strings.Join(parsed.Exclude, ",")},
+		model.Evidence{Key: "headless", Value: "false"},
+		model.Evidence{Key: "approval_gate", Value: fmt.Sprintf("%t", !signals.AutoApprovedWrite)},
+		model.Evidence{Key: "resolver_ref", Value: rel},
+	)
+	finding.Autonomy = autonomy.Classify(signals)
+	if signals.AutoApprovedWrite {
+		finding.Severity = model.SeverityMedium
+		finding.Remediation = "Move write and shell tools from the Continue allow list to ask so each call needs review."
+	}
+	return finding
+}
+
+// toolPermission maps Continue built-in tool names, including scoped entries
+// such as `Bash(git *)`, to permissions.
+func toolPermission(tool string) string {
+	name := strings.ToLower(strings.TrimSpace(tool))
+	if idx := strings.Index(name, "("); idx >= 0 {
+		name = strings.TrimSpace(name[:idx])
+	}
+	switch name {
+	case "bash", "run_terminal_command", "terminal":
+		return "proc.exec"
+	case "write", "edit", "multiedit", "edit_existing_file", "create_new_file", "single_find_and_replace":
+		return "filesystem.write"
+	case "read", "list", "search", "read_file", "ls", "file_glob_search", "grep_search", "view_diff":
+		return "filesystem.read"
+	case "fetch", "fetch_url_content", "search_web":
+		return "network.access"
+	default:
+		return ""
+	}
+}
+
+func baseFinding(scope detect.Scope, location string, permissions []string, extra ...model.Evidence) model.Finding {
+	evidence := []model.Evidence{{Key: "delivery_harness", Value: "continue"}}
+	evidence = append(evidence, extra...)
+	return model.Finding{
+		FindingType: "tool_config",
+		Severity:    model.SeverityLow,
+		ToolType:    "continue",
+		Location:    location,
+		Repo:        scope.Repo,
+		Org:         fallbackOrg(scope.Org),
+		Detector:    detectorID,
+		Permissions: permissions,
+		Evidence:    evidence,
+	}
+}
+
+func parseErrorFinding(scope detect.Scope, location string, parseErr *model.ParseError) model.Finding {
+	parseErr.Detector = detectorID
+	return model.Finding{
+		FindingType: "parse_error",
+		Severity:    model.SeverityMedium,
+		ToolType:    "continue",
+		Location:    location,
+		Repo:        scope.Repo,
+		Org:         fallbackOrg(scope.Org),
+		Detector:    detectorID,
+		ParseError:  parseErr,
+		Remediation: "Fix malformed Continue configuration so deterministic parsing can proceed.",
+	}
+}
+
+func fallbackOrg(org string) string {
+	if strings.TrimSpace(org) == "" {
+		return "local"
+	}
+	return org
+}
@@ -0,0 +1,118 @@
+package continuedev
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/Clyra-AI/wrkr/core/detect"
+	"github.com/Clyra-AI/wrkr/core/model"
+	"github.com/Clyra-AI/wrkr/core/risk/autonomy"
+)
+
+func TestContinueDetectorFindsConfigBlocksAndRules(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeContinueFile(t, root, ".continue/config.yaml", `name: team
+version: 1.0.0
+schema: v1
+models:
+  - name: sonnet
+    provider: anthropic
+rules:
+  - Always write tests
+mcpServers:
+  - name: sqlite
+    command: npx
+    args: ["-y", "mcp-sqlite"]
+`)
+	writeContinueFile(t, root, ".continue/mcpServers/github.yaml", "name: GitHub\nversion: 0.0.1\nschema: v1\nmcpServers:\n  - name: github\n    type: streamable-http\n    url: https://api.githubcopilot.com/mcp/\n")
+	writeContinueFile(t, root, ".continue/rules/style.md", "---\nname: style\n---\nUse gofmt.\n")
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Root: root, Repo: "repo", Org: "local"}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect: %v", err)
+	}
+	byLocation := map[string]model.Finding{}
+	for _, finding := range findings {
+		if finding.FindingType != "tool_config" || finding.ToolType != "continue" {
+			t.Fatalf("expected continue tool_config findings, got %+v", finding)
+		}
+		byLocation[finding.Location] = finding
+	}
+	if len(byLocation) != 3 {
+		t.Fatalf("expected three continue findings, got %+v", findings)
+	}
+	config := byLocation[".continue/config.yaml"]
+	if !reflect.DeepEqual(config.Permissions, []string{"mcp.access"}) {
+		t.Fatalf("unexpected config permissions: %v", config.Permissions)
+	}
+	for key, want := range map[string]string{"model_count": "1", "rule_count": "1", "mcp_servers": "sqlite"} {
+		if got := evidenceValue(config, key); got != want {
+			t.Fatalf("expected %s=%q, got %q", key, want, got)
+		}
+	}
+	if got := evidenceValue(byLocation[".continue/mcpServers/github.yaml"], "mcp_servers"); got != "github" {
+		t.Fatalf("unexpected block servers %q", got)
+	}
+}
+
+func TestContinueDetectorAllowedWriteToolsAreHeadlessEquivalent(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeContinueFile(t, root, ".continue/permissions.yaml", "allow:\n  - Read\n  - Bash(git status)\nask:\n  - Write\n")
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Root: root, Repo: "repo", Org: "local"}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect: %v", err)
+	}
+	if len(findings) != 1 {
+		t.Fatalf("expected one permissions finding, got %+v", findings)
+	}
+	finding := findings[0]
+	if finding.Autonomy != autonomy.LevelHeadlessAuto || finding.Severity != model.SeverityMedium {
+		t.Fatalf("expected allowed Bash to classify as headless_auto, got %+v", finding)
+	}
+	if want := []string{"filesystem.read", "proc.exec"}; !reflect.DeepEqual(finding.Permissions, want) {
+		t.Fatalf("unexpected permissions: got %v want %v", finding.Permissions, want)
+	}
+}
+
+func TestContinueDetectorReportsMalformedConfig(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeContinueFile(t, root, ".continue/config.yaml", "mcpServers: [\n")
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Root: root, Repo: "repo", Org: "local"}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect: %v", err)
+	}
+	if len(findings) != 1 || findings[0].FindingType != "parse_error" || findings[0].ParseError == nil || findings[0].ParseError.Detector != detectorID {
+		t.Fatalf("expected continue parse_error, got %+v", findings)
+	}
+}
+
+func evidenceValue(finding model.Finding, key string) string {
+	for _, item := range finding.Evidence {
+		if item.Key == key {
+			return item.Value
+		}
+	}
+	return ""
+}
+
+func writeContinueFile(t *testing.T, root, rel, content string) {
+	t.Helper()
+	path := filepath.Join(root, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir %s: %v", rel, err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write %s: %v", rel, err)
+	}
+}
@@ -0,0 +1,133 @@
+package copilot
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Clyra-AI/wrkr/core/detect"
+	"github.com/Clyra-AI/wrkr/core/detect/workflowcap"
+	"github.com/Clyra-AI/wrkr/core/model"
+	"github.com/Clyra-AI/wrkr/core/risk/autonomy"
+)
+
+// setupStepsPaths are the workflow GitHub runs to prepare the coding agent's
+// Actions environment before it starts work. The agent's MCP servers are
+// configured in repository settings, not in a committed file.
+var setupStepsPaths = []string{
+	".github/workflows/copilot-setup-steps.yml",
+	".github/workflows/copilot-setup-steps.yaml",
+}
+
+// agentGateKeys are set by the coding agent itself; the setup workflow's
+// own harness and gate evidence describe the workflow run, not the agent's
+// changes.
+var agentGateKeys = map[string]struct{}{
+	"delivery_harness":  {},
+	"approval_source":   {},
+	"deployment_gate":   {},
+	"proof_requirement": {},
+	"target_class_hint": {},
+}
+
+// codingAgentFinding models the Copilot coding agent. It pushes to its own
+// `copilot/*` branches and opens pull requests that a human must review
+// before merge, so the agent has write-to-branch authority gated by review.
+func codingAgentFinding(scope detect.Scope, options detect.Options) ([]model.Finding, error) {
+	setupStepsPath := ""
+	for _, candidate := range setupStepsPaths {
+		if detect.FileExists(scope.Root, candidate) {
+			setupStepsPath = candidate
+			break
+		}
+	}
+	if setupStepsPath == "" {
+		return nil, nil
+	}
+
+	findings := make([]model.Finding, 0, 2)
+	permissions := []string{"repo.write", "pull_request.write", "proc.exec"}
+	evidence := []model.Evidence{
+		{Key: "delivery_harness", Value: "copilot_coding_agent"},
+		{Key: "branch_scope", Value: "copilot/*"},
+		{Key: "approval_source", Value: "pull_request_review"},
+		{Key: "deployment_gate", Value: "pull_request_review"},
+		{Key: "validation_requirement", Value: "pull_request_review"},
+	}
+	hasSecretAccess := false
+
+	evidence = append(evidence, model.Evidence{Key: "setup_steps_ref", Value: setupStepsPath})
+	catalog, err := workflowcap.CatalogFor(scope.Root, options)
+	if err != nil {
+		return nil, err
+	}
+	if entry, ok := catalog.Lookup(setupStepsPath); ok {
+		if entry.ParseError != nil {
+			findings = append(findings, parseErrorFinding(scope, setupStepsPath, entry.ParseError))
+		}
+		evidence = append(evidence, model.Evidence{Key: "setup_steps_job", Value: fmt.Sprintf("%t", containsString(entry.Result.JobNames, "copilot-setup-steps"))})
+		for _, item := range entry.Result.Evidence {
+			if _, skip := agentGateKeys[item.Key]; !skip {
+				evidence = append(evidence, item)
+			}
+		}
+		permissions = append(permissions, entry.Result.Capabilities...)
+		hasSecretAccess = entry.Result.HasSecretAccess
+	}
+
+	// The coding agent runs unattended in Actions, so it is classified as a
+	// headless agent rather than with the interactive Copilot level.
+	signals := autonomy.Signals{
+		Tool:            "coding_agent",
+		Headless:        true,
+		HasApprovalGate: true,
+		HasSecretAccess: hasSecretAccess,
+	}
+	permissions = append(permissions, "headless.execute")
+	if hasSecretAccess {
+		permissions = append(permissions, "secret.read")
+	}
+	evidence = append(evidence,
+		model.Evidence{Key: "headless", Value: "true"},
+		model.Evidence{Key: "approval_gate", Value: "true"},
+		model.Evidence{Key: "secret_access", Value: fmt.Sprintf("%t", hasSecretAccess)},
+	)
+	findings = append(findings, model.Finding{
+		FindingType: "tool_config",
+		Severity:    model.SeverityMedium,
+		ToolType:    "copilot",
+		Location:    setupStepsPath,
+		Repo:        scope.Repo,
+		Org:         fallbackOrg(scope.Org),
+		Detector:    detectorID,
+		Permissions: sortedUnique(permissions),
+		Autonomy:    autonomy.Classify(signals),
+		Evidence:    evidence,
+		Remediation: "Keep required pull request reviews on branches the Copilot coding agent targets and scope setup-step secrets to what the agent needs.",
+	})
+	return findings, nil
+}
+
+func containsString(values []string, want string) bool {
+	for _, value := range values {
+		if strings.TrimSpace(value) == want {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedUnique(values []string) []string {
+	set := map[string]struct{}{}
+	out := make([]string, 0, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if _, seen := set[value]; seen || value == "" {
+			continue
+		}
+		set[value] = struct{}{}
+		out = append(out, value)
+	}
+	sort.Strings(out)
+	return out
+}
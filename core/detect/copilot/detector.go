@@ -2,11 +2,14 @@ package copilot
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/Clyra-AI/wrkr/core/detect"
 	"github.com/Clyra-AI/wrkr/core/model"
+	"github.com/Clyra-AI/wrkr/core/risk/autonomy"
 )
 
 const detectorID = "copilot"
@@ -17,13 +20,30 @@ func New() Detector { return Detector{} }
 
 func (Detector) ID() string { return detectorID }
 
+// mcpConfig accepts the native VS Code schema (`servers` and `inputs`) and
+// the `mcpServers` map shared with other MCP clients.
 type mcpConfig struct {
-	MCPServers map[string]struct {
-		URL string `json:"url"`
-	} `json:"mcpServers"`
+	MCPServers map[string]json.RawMessage `json:"mcpServers"`
+	Servers    map[string]json.RawMessage `json:"servers"`
+	Inputs     []json.RawMessage          `json:"inputs"`
 }
 
-func (Detector) Detect(_ context.Context, scope detect.Scope, _ detect.Options) ([]model.Finding, error) {
+func (cfg mcpConfig) serverCount() int {
+	names := map[string]struct{}{}
+	for name := range cfg.MCPServers {
+		names[name] = struct{}{}
+	}
+	for name := range cfg.Servers {
+		names[name] = struct{}{}
+	}
+	return len(names)
+}
+
+// autoApproveSettings are the VS Code keys that let agent mode run tools
+// without a confirmation prompt.
+var autoApproveSettings = []string{"chat.tools.autoApprove", "chat.tools.global.autoApprove"}
+
+func (Detector) Detect(_ context.Context, scope detect.Scope, options detect.Options) ([]model.Finding, error) {
 	if err := detect.ValidateScopeRoot(scope.Root); err != nil {
 		return nil, err
 	}
@@ -47,18 +67,8 @@ func (Detector) Detect(_ context.Context, scope detect.Scope, _ detect.Options)
 
 	if detect.FileExists(scope.Root, ".vscode/mcp.json") {
 		var parsed mcpConfig
-		if parseErr := detect.ParseJSONFileAllowUnknownFields(detectorID, scope.Root, ".vscode/mcp.json", &parsed); parseErr != nil {
-			parseErr.Detector = detectorID
-			findings = append(findings, model.Finding{
-				FindingType: "parse_error",
-				Severity:    model.SeverityMedium,
-				ToolType:    "copilot",
-				Location:    ".vscode/mcp.json",
-				Repo:        scope.Repo,
-				Org:         fallbackOrg(scope.Org),
-				Detector:    detectorID,
-				ParseError:  parseErr,
-			})
+		if parseErr := detect.ParseJSONCFileAllowUnknownFields(detectorID, scope.Root, ".vscode/mcp.json", &parsed); parseErr != nil {
+			findings = append(findings, parseErrorFinding(scope, ".vscode/mcp.json", parseErr))
 		} else {
 			findings = append(findings, model.Finding{
 				FindingType: "tool_config",
@@ -69,15 +79,150 @@ func (Detector) Detect(_ context.Context, scope detect.Scope, _ detect.Options)
 				Org:         fallbackOrg(scope.Org),
 				Detector:    detectorID,
 				Permissions: []string{"mcp.access"},
-				Evidence:    []model.Evidence{{Key: "mcp_server_count", Value: fmt.Sprintf("%d", len(parsed.MCPServers))}},
+				Evidence: []model.Evidence{
+					{Key: "mcp_server_count", Value: fmt.Sprintf("%d", parsed.serverCount())},
+					{Key: "mcp_input_count", Value: fmt.Sprintf("%d", len(parsed.Inputs))},
+				},
 			})
 		}
 	}
 
+	agent, err := codingAgentFinding(scope, options)
+	if err != nil {
+		return nil, err
+	}
+	findings = append(findings, agent...)
+	instructions, err := instructionsFinding(scope)
+	if err != nil {
+		return nil, err
+	}
+	findings = append(findings, instructions...)
+	prompts, err := promptsFinding(scope)
+	if err != nil {
+		return nil, err
+	}
+	findings = append(findings, prompts...)
+
+	settingsFiles := []string{".vscode/settings.json"}
+	workspaces, globErr := detect.Glob(scope.Root, "*.code-workspace")
+	if globErr != nil {
+		return nil, fmt.Errorf("glob code workspaces: %w", globErr)
+	}
+	settingsFiles = append(settingsFiles, workspaces...)
+	for _, rel := range settingsFiles {
+		if !detect.FileExists(scope.Root, rel) {
+			continue
+		}
+		if finding, ok := settingsFinding(scope, rel); ok {
+			findings = append(findings, finding)
+		}
+	}
+
 	model.SortFindings(findings)
 	return findings, nil
 }
 
+// settingsFinding reports the MCP block and tool auto-approval in VS Code
+// settings. Workspace files nest the same keys under `settings`.
+func settingsFinding(scope detect.Scope, rel string) (model.Finding, bool) {
+	var settings map[string]json.RawMessage
+	if parseErr := detect.ParseJSONCFileAllowUnknownFields(detectorID, scope.Root, rel, &settings); parseErr != nil {
+		return parseErrorFinding(scope, rel, parseErr), true
+	}
+	if strings.HasSuffix(rel, ".code-workspace") {
+		var nested map[string]json.RawMessage
+		if err := json.Unmarshal(settings["settings"], &nested); err != nil {
+			nested = nil
+		}
+		settings = nested
+	}
+	var mcp mcpConfig
+	if raw, ok := settings["mcp"]; ok {
+		if err := json.Unmarshal(raw, &mcp); err != nil {
+			return parseErrorFinding(scope, rel, &model.ParseError{Kind: "parse_error", Format: "json", Path: rel, Message: "mcp: " + err.Error()}), true
+		}
+	}
+	approveAll, autoApprovedTools := autoApproval(settings)
+	autoApprove := approveAll || len(autoApprovedTools) > 0
+	if mcp.serverCount() == 0 && !autoApprove {
+		return model.Finding{}, false
+	}
+
+	permissions := make([]string, 0, 1)
+	if mcp.serverCount() > 0 {
+		permissions = append(permissions, "mcp.access")
+	}
+	approvedWrite := approveAll
+	for _, tool := range autoApprovedTools {
+		approvedWrite = approvedWrite || autonomy.IsWriteOrExecTool(tool)
+	}
+	signals := autonomy.Signals{Tool: detectorID, AutoApprovedWrite: approvedWrite}
+	finding := model.Finding{
+		FindingType: "tool_config",
+		Severity:    model.SeverityLow,
+		ToolType:    "copilot",
+		Location:    rel,
+		Repo:        scope.Repo,
+		Org:         fallbackOrg(scope.Org),
+		Detector:    detectorID,
+		Permissions: permissions,
+		Autonomy:    autonomy.Classify(signals),
+		Evidence: []model.Evidence{
+			{Key: "mcp_server_count", Value: fmt.Sprintf("%d", mcp.serverCount())},
+			{Key: "mcp_input_count", Value: fmt.Sprintf("%d", len(mcp.Inputs))},
+			{Key: "auto_approve", Value: fmt.Sprintf("%t", autoApprove)},
+			{Key: "auto_approved_tools", Value: strings.Join(autoApprovedTools, ",")},
+		},
+	}
+	if finding.Autonomy == autonomy.LevelHeadlessAuto {
+		finding.Severity = model.SeverityMedium
+		finding.Remediation = "Disable chat.tools.autoApprove so Copilot agent mode asks before running tools."
+	}
+	return finding, true
+}
+
+// autoApproval accepts `true` for every tool or an object naming the tools
+// that skip confirmation.
+func autoApproval(settings map[string]json.RawMessage) (bool, []string) {
+	all := false
+	tools := make([]string, 0)
+	for _, key := range autoApproveSettings {
+		raw, ok := settings[key]
+		if !ok {
+			continue
+		}
+		var enabled bool
+		if err := json.Unmarshal(raw, &enabled); err == nil {
+			all = all || enabled
+			continue
+		}
+		var perTool map[string]bool
+		if err := json.Unmarshal(raw, &perTool); err == nil {
+			for tool, approved := range perTool {
+				if approved {
+					tools = append(tools, tool)
+				}
+			}
+		}
+	}
+	sort.Strings(tools)
+	return all, tools
+}
+
+func parseErrorFinding(scope detect.Scope, rel string, parseErr *model.ParseError) model.Finding {
+	parseErr.Detector = detectorID
+	return model.Finding{
+		FindingType: "parse_error",
+		Severity:    model.SeverityMedium,
+		ToolType:    "copilot",
+		Location:    rel,
+		Repo:        scope.Repo,
+		Org:         fallbackOrg(scope.Org),
+		Detector:    detectorID,
+		ParseError:  parseErr,
+	}
+}
+
 func fallbackOrg(org string) string {
 	if strings.TrimSpace(org) == "" {
 		return "local"
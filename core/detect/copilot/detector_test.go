@@ -0,0 +1,231 @@
+package copilot
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Clyra-AI/wrkr/core/detect"
+	"github.com/Clyra-AI/wrkr/core/model"
+	"github.com/Clyra-AI/wrkr/core/risk/autonomy"
+)
+
+func TestCopilotDetectorCountsNativeVSCodeServers(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeFile(t, root, ".vscode/mcp.json", `{
+  // VS Code allows comments in mcp.json.
+  "inputs": [{"type": "promptString", "id": "github-token", "password": true}],
+  "servers": {
+    "github": {"type": "http", "url": "https://api.githubcopilot.com/mcp/", "headers": {"Authorization": "Bearer ${input:github-token}"}},
+    "fs": {"type": "stdio", "command": "npx", "args": ["-y", "@modelcontextprotocol/server-filesystem@1.0.0"]},
+  },
+}`)
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Root: root, Repo: "repo", Org: "local"}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect: %v", err)
+	}
+	finding := findingAt(t, findings, ".vscode/mcp.json")
+	if got := evidenceValue(finding, "mcp_server_count"); got != "2" {
+		t.Fatalf("expected 2 servers, got %q", got)
+	}
+	if got := evidenceValue(finding, "mcp_input_count"); got != "1" {
+		t.Fatalf("expected 1 input, got %q", got)
+	}
+}
+
+func TestCopilotDetectorReadsSettingsAndWorkspaceAutoApprove(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeFile(t, root, ".vscode/settings.json", `{
+  "editor.tabSize": 2,
+  "chat.tools.autoApprove": true,
+  "mcp": {"servers": {"docs": {"url": "https://docs.example.com/mcp"}}}
+}`)
+	writeFile(t, root, "app.code-workspace", `{
+  "folders": [{"path": "."}],
+  "settings": {"chat.tools.global.autoApprove": {"runInTerminal": true, "fetch": false}}
+}`)
+	writeFile(t, root, "plain.code-workspace", `{"folders": [{"path": "."}]}`)
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Root: root, Repo: "repo", Org: "local"}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect: %v", err)
+	}
+	if len(findings) != 2 {
+		t.Fatalf("expected settings and workspace findings only, got %+v", findings)
+	}
+
+	settings := findingAt(t, findings, ".vscode/settings.json")
+	if got := evidenceValue(settings, "mcp_server_count"); got != "1" {
+		t.Fatalf("expected settings mcp server, got %q", got)
+	}
+	if settings.Autonomy != autonomy.LevelHeadlessAuto || settings.Severity != model.SeverityMedium {
+		t.Fatalf("expected auto-approve to raise autonomy, got %+v", settings)
+	}
+
+	workspace := findingAt(t, findings, "app.code-workspace")
+	if got := evidenceValue(workspace, "auto_approved_tools"); got != "runInTerminal" {
+		t.Fatalf("expected per-tool auto approval, got %q", got)
+	}
+	if workspace.Autonomy != autonomy.LevelHeadlessAuto {
+		t.Fatalf("expected terminal auto-approval to raise autonomy, got %+v", workspace)
+	}
+	if len(workspace.Permissions) != 0 {
+		t.Fatalf("expected no MCP permission without servers, got %+v", workspace.Permissions)
+	}
+}
+
+func TestCopilotDetectorModelsCodingAgent(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeFile(t, root, ".github/workflows/copilot-setup-steps.yml", `name: Copilot Setup Steps
+on: workflow_dispatch
+jobs:
+  copilot-setup-steps:
+    runs-on: ubuntu-latest
+    permissions:
+      contents: read
+    steps:
+      - uses: actions/checkout@v4
+      - run: npm ci
+        env:
+          NPM_TOKEN: ${{ secrets.NPM_TOKEN }}
+`)
+	writeFile(t, root, ".github/copilot-instructions.md", "Use Go 1.26.\n")
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Root: root, Repo: "repo", Org: "local"}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect: %v", err)
+	}
+	findingAt(t, findings, ".github/copilot-instructions.md")
+
+	agent := findingAt(t, findings, ".github/workflows/copilot-setup-steps.yml")
+	if agent.Autonomy != autonomy.LevelHeadlessGate || agent.Severity != model.SeverityMedium {
+		t.Fatalf("expected headless gated coding agent, got %+v", agent)
+	}
+	for key, want := range map[string]string{
+		"delivery_harness": "copilot_coding_agent",
+		"branch_scope":     "copilot/*",
+		"approval_source":  "pull_request_review",
+		"deployment_gate":  "pull_request_review",
+		"setup_steps_job":  "true",
+		"secret_access":    "true",
+	} {
+		if got := evidenceValue(agent, key); got != want {
+			t.Fatalf("expected %s=%q, got %q", key, want, got)
+		}
+	}
+	for _, permission := range []string{"repo.write", "pull_request.write", "secret.read", "headless.execute"} {
+		if !containsString(agent.Permissions, permission) {
+			t.Fatalf("expected permission %s, got %+v", permission, agent.Permissions)
+		}
+	}
+}
+
+func TestCopilotDetectorReadsYAMLSetupStepsExtension(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeFile(t, root, ".github/workflows/copilot-setup-steps.yaml", `name: Copilot Setup Steps
+on: workflow_dispatch
+jobs:
+  copilot-setup-steps:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+`)
+	writeFile(t, root, ".github/copilot-mcp.json", `{"mcpServers": {}}`)
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Root: root, Repo: "repo", Org: "local"}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect: %v", err)
+	}
+	agent := findingAt(t, findings, ".github/workflows/copilot-setup-steps.yaml")
+	if got := evidenceValue(agent, "setup_steps_ref"); got != ".github/workflows/copilot-setup-steps.yaml" {
+		t.Fatalf("unexpected setup steps ref %q", got)
+	}
+	if containsString(agent.Permissions, "mcp.access") || evidenceValue(agent, "mcp_config_ref") != "" {
+		t.Fatalf("expected no coding agent MCP access from .github/copilot-mcp.json, got %+v", agent)
+	}
+}
+
+func TestCopilotDetectorSummarizesInstructionsAndPrompts(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeFile(t, root, ".github/instructions/go.instructions.md", "---\napplyTo: \"**/*.go,go.mod\"\n---\nRun gofmt.\n")
+	writeFile(t, root, ".github/instructions/docs.instructions.md", "---\napplyTo: \"docs/**\"\n---\nUse sentence case.\n")
+	writeFile(t, root, ".github/prompts/release.prompt.md", "---\nmode: agent\ntools: [\"runCommands\", \"editFiles\", \"github/create_pull_request\"]\n---\nCut a release.\n")
+	writeFile(t, root, ".github/prompts/explain.prompt.md", "---\nmode: ask\ntools: [\"codebase\"]\n---\nExplain this.\n")
+	writeFile(t, root, ".github/prompts/broken.prompt.md", "---\ntools: [\n---\n")
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Root: root, Repo: "repo", Org: "local"}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect: %v", err)
+	}
+
+	instructions := findingAt(t, findings, ".github/instructions")
+	if got := evidenceValue(instructions, "instructions"); got != "docs,go" {
+		t.Fatalf("unexpected instructions %q", got)
+	}
+	if got := evidenceValue(instructions, "apply_to"); got != "**/*.go,docs/**,go.mod" {
+		t.Fatalf("unexpected applyTo globs %q", got)
+	}
+
+	prompts := findingAt(t, findings, ".github/prompts")
+	if got := evidenceValue(prompts, "prompt_count"); got != "2" {
+		t.Fatalf("expected 2 parsed prompts, got %q", got)
+	}
+	if got := evidenceValue(prompts, "agent_mode_prompts"); got != "release" {
+		t.Fatalf("unexpected agent mode prompts %q", got)
+	}
+	want := []string{"filesystem.read", "filesystem.write", "mcp.access", "proc.exec"}
+	if len(prompts.Permissions) != len(want) {
+		t.Fatalf("expected permissions %v, got %v", want, prompts.Permissions)
+	}
+	for i := range want {
+		if prompts.Permissions[i] != want[i] {
+			t.Fatalf("expected permissions %v, got %v", want, prompts.Permissions)
+		}
+	}
+	if broken := findingAt(t, findings, ".github/prompts/broken.prompt.md"); broken.FindingType != "parse_error" {
+		t.Fatalf("expected parse error for malformed frontmatter, got %+v", broken)
+	}
+}
+
+func writeFile(t *testing.T, root, rel, payload string) {
+	t.Helper()
+	path := filepath.Join(root, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir %s: %v", rel, err)
+	}
+	if err := os.WriteFile(path, []byte(payload), 0o600); err != nil {
+		t.Fatalf("write %s: %v", rel, err)
+	}
+}
+
+func findingAt(t *testing.T, findings []model.Finding, location string) model.Finding {
+	t.Helper()
+	for _, finding := range findings {
+		if finding.Location == location {
+			return finding
+		}
+	}
+	t.Fatalf("missing finding at %s in %+v", location, findings)
+	return model.Finding{}
+}
+
+func evidenceValue(finding model.Finding, key string) string {
+	for _, item := range finding.Evidence {
+		if item.Key == key {
+			return item.Value
+		}
+	}
+	return ""
+}
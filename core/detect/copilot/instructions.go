@@ -0,0 +1,138 @@
+package copilot
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/Clyra-AI/wrkr/core/detect"
+	"github.com/Clyra-AI/wrkr/core/model"
+)
+
+type instructionFrontmatter struct {
+	ApplyTo string `yaml:"applyTo"`
+}
+
+type promptFrontmatter struct {
+	Mode  string   `yaml:"mode"`
+	Tools []string `yaml:"tools"`
+	Model string   `yaml:"model"`
+}
+
+// promptToolPermissions maps built-in agent-mode tool names to permissions.
+// MCP tools are named `<server>/<tool>` and map to mcp.access.
+var promptToolPermissions = map[string]string{
+	"codebase":          "filesystem.read",
+	"search":            "filesystem.read",
+	"githubrepo":        "filesystem.read",
+	"usages":            "filesystem.read",
+	"editfiles":         "filesystem.write",
+	"edit":              "filesystem.write",
+	"new":               "filesystem.write",
+	"runcommands":       "proc.exec",
+	"runinterminal":     "proc.exec",
+	"terminal":          "proc.exec",
+	"runtasks":          "proc.exec",
+	"runtests":          "proc.exec",
+	"fetch":             "network.access",
+	"opensimplebrowser": "network.access",
+}
+
+// instructionsFinding summarizes `.github/instructions/*.instructions.md`
+// files and the `applyTo` globs that scope them.
+func instructionsFinding(scope detect.Scope) ([]model.Finding, error) {
+	files, globErr := detect.Glob(scope.Root, ".github/instructions/*.instructions.md")
+	if globErr != nil {
+		return nil, fmt.Errorf("glob copilot instructions: %w", globErr)
+	}
+	if len(files) == 0 {
+		return nil, nil
+	}
+	findings := make([]model.Finding, 0, 1)
+	names := make([]string, 0, len(files))
+	globs := make([]string, 0, len(files))
+	for _, rel := range files {
+		var front instructionFrontmatter
+		if _, parseErr := detect.ParseYAMLFrontmatter(detectorID, scope.Root, rel, &front); parseErr != nil {
+			findings = append(findings, parseErrorFinding(scope, rel, parseErr))
+			continue
+		}
+		names = append(names, strings.TrimSuffix(path.Base(rel), ".instructions.md"))
+		globs = append(globs, strings.Split(front.ApplyTo, ",")...)
+	}
+	findings = append(findings, model.Finding{
+		FindingType: "tool_config",
+		Severity:    model.SeverityLow,
+		ToolType:    "copilot",
+		Location:    ".github/instructions",
+		Repo:        scope.Repo,
+		Org:         fallbackOrg(scope.Org),
+		Detector:    detectorID,
+		Evidence: []model.Evidence{
+			{Key: "instruction_count", Value: fmt.Sprintf("%d", len(names))},
+			{Key: "instructions", Value: strings.Join(sortedUnique(names), ",")},
+			{Key: "apply_to", Value: strings.Join(sortedUnique(globs), ",")},
+			{Key: "resolver_ref", Value: ".github/instructions"},
+		},
+	})
+	return findings, nil
+}
+
+// promptsFinding summarizes `.github/prompts/*.prompt.md` files. Prompts
+// in agent mode run with the tools they list.
+func promptsFinding(scope detect.Scope) ([]model.Finding, error) {
+	files, globErr := detect.Glob(scope.Root, ".github/prompts/*.prompt.md")
+	if globErr != nil {
+		return nil, fmt.Errorf("glob copilot prompts: %w", globErr)
+	}
+	if len(files) == 0 {
+		return nil, nil
+	}
+	findings := make([]model.Finding, 0, 1)
+	names := make([]string, 0, len(files))
+	agentPrompts := make([]string, 0)
+	tools := make([]string, 0)
+	for _, rel := range files {
+		var front promptFrontmatter
+		if _, parseErr := detect.ParseYAMLFrontmatter(detectorID, scope.Root, rel, &front); parseErr != nil {
+			findings = append(findings, parseErrorFinding(scope, rel, parseErr))
+			continue
+		}
+		name := strings.TrimSuffix(path.Base(rel), ".prompt.md")
+		names = append(names, name)
+		if strings.EqualFold(strings.TrimSpace(front.Mode), "agent") {
+			agentPrompts = append(agentPrompts, name)
+		}
+		tools = append(tools, front.Tools...)
+	}
+	tools = sortedUnique(tools)
+	permissions := make([]string, 0, len(tools))
+	for _, tool := range tools {
+		permissions = append(permissions, promptToolPermission(tool))
+	}
+	findings = append(findings, model.Finding{
+		FindingType: "tool_config",
+		Severity:    model.SeverityLow,
+		ToolType:    "copilot",
+		Location:    ".github/prompts",
+		Repo:        scope.Repo,
+		Org:         fallbackOrg(scope.Org),
+		Detector:    detectorID,
+		Permissions: sortedUnique(permissions),
+		Evidence: []model.Evidence{
+			{Key: "prompt_count", Value: fmt.Sprintf("%d", len(names))},
+			{Key: "prompts", Value: strings.Join(sortedUnique(names), ",")},
+			{Key: "agent_mode_prompts", Value: strings.Join(sortedUnique(agentPrompts), ",")},
+			{Key: "prompt_tools", Value: strings.Join(tools, ",")},
+			{Key: "resolver_ref", Value: ".github/prompts"},
+		},
+	})
+	return findings, nil
+}
+
+func promptToolPermission(tool string) string {
+	if strings.Contains(tool, "/") {
+		return "mcp.access"
+	}
+	return promptToolPermissions[strings.ToLower(strings.TrimSpace(tool))]
+}
@@ -3,23 +3,40 @@ package defaults
 import (
 	"github.com/Clyra-AI/wrkr/core/detect"
 	"github.com/Clyra-AI/wrkr/core/detect/a2a"
+	"github.com/Clyra-AI/wrkr/core/detect/agentadk"
 	"github.com/Clyra-AI/wrkr/core/detect/agentautogen"
 	"github.com/Clyra-AI/wrkr/core/detect/agentcrewai"
 	"github.com/Clyra-AI/wrkr/core/detect/agentcustom"
+	"github.com/Clyra-AI/wrkr/core/detect/agentdspy"
+	"github.com/Clyra-AI/wrkr/core/detect/agenthaystack"
 	"github.com/Clyra-AI/wrkr/core/detect/agentlangchain"
+	"github.com/Clyra-AI/wrkr/core/detect/agentlanggraph"
 	"github.com/Clyra-AI/wrkr/core/detect/agentllamaindex"
+	"github.com/Clyra-AI/wrkr/core/detect/agentmastra"
 	"github.com/Clyra-AI/wrkr/core/detect/agentmcpclient"
 	"github.com/Clyra-AI/wrkr/core/detect/agentopenai"
+	"github.com/Clyra-AI/wrkr/core/detect/agentpydanticai"
+	"github.com/Clyra-AI/wrkr/core/detect/agentsemantickernel"
+	"github.com/Clyra-AI/wrkr/core/detect/agentsmolagents"
+	"github.com/Clyra-AI/wrkr/core/detect/agentspringai"
+	"github.com/Clyra-AI/wrkr/core/detect/agentstrands"
+	"github.com/Clyra-AI/wrkr/core/detect/agentvercelai"
 	"github.com/Clyra-AI/wrkr/core/detect/agnt"
+	"github.com/Clyra-AI/wrkr/core/detect/aider"
+	"github.com/Clyra-AI/wrkr/core/detect/amazonq"
 	"github.com/Clyra-AI/wrkr/core/detect/ciagent"
 	"github.com/Clyra-AI/wrkr/core/detect/claude"
+	"github.com/Clyra-AI/wrkr/core/detect/cline"
 	"github.com/Clyra-AI/wrkr/core/detect/codex"
 	"github.com/Clyra-AI/wrkr/core/detect/compiledaction"
+	"github.com/Clyra-AI/wrkr/core/detect/continuedev"
 	"github.com/Clyra-AI/wrkr/core/detect/copilot"
 	"github.com/Clyra-AI/wrkr/core/detect/cursor"
 	"github.com/Clyra-AI/wrkr/core/detect/dependency"
 	"github.com/Clyra-AI/wrkr/core/detect/extension"
 	"github.com/Clyra-AI/wrkr/core/detect/gaitpolicy"
+	"github.com/Clyra-AI/wrkr/core/detect/gemini"
+	"github.com/Clyra-AI/wrkr/core/detect/kiro"
 	"github.com/Clyra-AI/wrkr/core/detect/mcp"
 	"github.com/Clyra-AI/wrkr/core/detect/mcpgateway"
 	"github.com/Clyra-AI/wrkr/core/detect/nonhumanidentity"
@@ -29,6 +46,7 @@ import (
 	"github.com/Clyra-AI/wrkr/core/detect/secrets"
 	"github.com/Clyra-AI/wrkr/core/detect/skills"
 	"github.com/Clyra-AI/wrkr/core/detect/webmcp"
+	"github.com/Clyra-AI/wrkr/core/detect/windsurf"
 	"github.com/Clyra-AI/wrkr/core/detect/workstation"
 )
 
@@ -54,6 +72,13 @@ func detectorsForMode(mode string) []detect.Detector {
 			claude.New(),
 			cursor.New(),
 			codex.New(),
+			gemini.New(),
+			windsurf.New(),
+			cline.New(),
+			continuedev.New(),
+			aider.New(),
+			amazonq.New(),
+			kiro.New(),
 			copilot.New(),
 			mcp.New(),
 			mcpgateway.New(),
@@ -71,11 +96,29 @@ func detectorsForMode(mode string) []detect.Detector {
 			agentopenai.New(),
 			agentautogen.New(),
 			agentllamaindex.New(),
+			agentadk.New(),
+			agentpydanticai.New(),
+			agentsmolagents.New(),
+			agentdspy.New(),
+			agenthaystack.New(),
+			agentstrands.New(),
+			agentvercelai.New(),
+			agentmastra.New(),
+			agentlanggraph.New(),
+			agentspringai.New(),
+			agentsemantickernel.New(),
 			agentmcpclient.New(),
 			agentcustom.New(),
 			claude.New(),
 			cursor.New(),
 			codex.New(),
+			gemini.New(),
+			windsurf.New(),
+			cline.New(),
+			continuedev.New(),
+			aider.New(),
+			amazonq.New(),
+			kiro.New(),
 			copilot.New(),
 			mcp.New(),
 			workstation.New(),
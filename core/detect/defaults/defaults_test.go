@@ -77,6 +77,17 @@ func TestRegistryIncludesAgentFrameworkDetectors(t *testing.T) {
 	writeFixtureFile(t, root, ".wrkr/agents/openai-agents.json", `{"agents":[{"name":"oa_agent","file":"agents/openai.py"}]}`)
 	writeFixtureFile(t, root, ".wrkr/agents/autogen.json", `{"agents":[{"name":"ag_agent","file":"agents/autogen.py"}]}`)
 	writeFixtureFile(t, root, ".wrkr/agents/llamaindex.yaml", "agents:\n  - name: li_agent\n    file: agents/llamaindex.py\n")
+	writeFixtureFile(t, root, ".wrkr/agents/google-adk.yaml", "agents:\n  - name: adk_agent\n    file: agents/adk/main.go\n")
+	writeFixtureFile(t, root, ".wrkr/agents/pydantic-ai.yaml", "agents:\n  - name: pai_agent\n    file: agents/support.py\n")
+	writeFixtureFile(t, root, ".wrkr/agents/smolagents.yaml", "agents:\n  - name: smol_agent\n    file: agents/research.py\n")
+	writeFixtureFile(t, root, ".wrkr/agents/dspy.yaml", "agents:\n  - name: dspy_agent\n    file: agents/react.py\n")
+	writeFixtureFile(t, root, ".wrkr/agents/haystack.yaml", "agents:\n  - name: haystack_agent\n    file: agents/pipeline.py\n")
+	writeFixtureFile(t, root, ".wrkr/agents/strands.yaml", "agents:\n  - name: strands_agent\n    file: agents/strands_agent.py\n")
+	writeFixtureFile(t, root, ".wrkr/agents/vercel-ai.yaml", "agents:\n  - name: vercel_ai_agent\n    file: app/api/chat/route.ts\n")
+	writeFixtureFile(t, root, ".wrkr/agents/mastra.yaml", "agents:\n  - name: mastra_agent\n    file: src/mastra/agents/mastra_agent.ts\n")
+	writeFixtureFile(t, root, ".wrkr/agents/langgraph.yaml", "agents:\n  - name: langgraph_agent\n    file: src/agent/graph.ts\n")
+	writeFixtureFile(t, root, ".wrkr/agents/spring-ai.yaml", "agents:\n  - name: spring_agent\n    file: src/main/java/com/acme/ChatConfig.java\n")
+	writeFixtureFile(t, root, ".wrkr/agents/semantic-kernel.yaml", "agents:\n  - name: sk_agent\n    file: src/Support/Program.cs\n")
 	writeFixtureFile(t, root, ".wrkr/agents/mcp-client.yaml", "agents:\n  - name: mcpc_agent\n    file: agents/mcp_client.py\n")
 	writeFixtureFile(t, root, ".wrkr/agents/custom-agent.yaml", "agents:\n  - name: custom_agent\n    file: agents/custom.py\n    tools: [deploy.write]\n")
 	writeFixtureFile(t, root, "AGENTS.md", "# Agent instructions\n")
@@ -98,13 +109,47 @@ func TestRegistryIncludesAgentFrameworkDetectors(t *testing.T) {
 	for _, finding := range result.Findings {
 		seen[finding.Detector] = true
 	}
-	for _, detectorID := range []string{"agentlangchain", "agentcrewai", "agentopenai", "agentautogen", "agentllamaindex", "agentmcpclient", "agentcustom"} {
+	for _, detectorID := range []string{"agentlangchain", "agentcrewai", "agentopenai", "agentautogen", "agentllamaindex", "agentadk", "agentpydanticai", "agentsmolagents", "agentdspy", "agenthaystack", "agentstrands", "agentvercelai", "agentmastra", "agentlanggraph", "agentspringai", "agentsemantickernel", "agentmcpclient", "agentcustom"} {
 		if !seen[detectorID] {
 			t.Fatalf("expected detector %s finding in registry run, got %+v", detectorID, result.Findings)
 		}
 	}
 }
 
+func TestRegistryIncludesAssistantConfigDetectors(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeFixtureFile(t, root, ".gemini/settings.json", `{"coreTools":["run_shell_command"],"mcpServers":{"fs":{"command":"npx"}}}`)
+	writeFixtureFile(t, root, "GEMINI.md", "# Gemini context\n")
+	writeFixtureFile(t, root, ".windsurf/rules/style.md", "---\ntrigger: always_on\n---\nUse tabs.\n")
+	writeFixtureFile(t, root, ".clinerules", "Prefer small commits.\n")
+	writeFixtureFile(t, root, ".continue/rules/style.md", "Use gofmt.\n")
+	writeFixtureFile(t, root, ".aider.conf.yml", "auto-commits: false\n")
+	writeFixtureFile(t, root, ".amazonq/rules/style.md", "Use gofmt.\n")
+	writeFixtureFile(t, root, ".kiro/steering/tech.md", "Use Go.\n")
+
+	for _, mode := range []string{"quick", "governance"} {
+		registry, err := RegistryForMode(mode)
+		if err != nil {
+			t.Fatalf("create %s detector registry: %v", mode, err)
+		}
+		result, err := registry.Run(context.Background(), []detect.Scope{{Org: "local", Repo: "assistants", Root: root}}, detect.Options{})
+		if err != nil {
+			t.Fatalf("run %s detector registry: %v", mode, err)
+		}
+		seen := map[string]bool{}
+		for _, finding := range result.Findings {
+			seen[finding.Detector+"|"+finding.Location] = true
+		}
+		for _, key := range []string{"gemini|.gemini/settings.json", "gemini|GEMINI.md", "mcp|.gemini/settings.json", "windsurf|.windsurf/rules/style.md", "cline|.clinerules", "continue|.continue/rules/style.md", "aider|.aider.conf.yml", "amazonq|.amazonq/rules/style.md", "kiro|.kiro/steering/tech.md"} {
+			if !seen[key] {
+				t.Fatalf("expected %s finding in %s registry run, got %+v", key, mode, result.Findings)
+			}
+		}
+	}
+}
+
 func mustFindRepoRoot(t *testing.T) string {
 	t.Helper()
 
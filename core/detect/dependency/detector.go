@@ -42,30 +42,60 @@ var aiKeywords = []string{
 	"crewai",
 	"pydantic-ai",
 	"semantic-kernel",
+	"semantickernel",
 	"litellm",
 	"dspy",
 	"haystack",
+	"spring-ai",
 	"smolagents",
+	"google-adk",
+	"@ai-sdk",
+	"mastra",
 	"agent",
 	"copilot",
 }
 
 var knownFrameworkPackages = map[string]string{
-	"langchain":         "langchain",
-	"langgraph":         "langgraph",
-	"crewai":            "crewai",
-	"autogen":           "autogen",
-	"llamaindex":        "llamaindex",
-	"llama_index":       "llamaindex",
-	"semantic-kernel":   "semantic_kernel",
-	"semantic_kernel":   "semantic_kernel",
-	"haystack":          "haystack",
-	"@langchain/core":   "langchain",
-	"@langchain/openai": "langchain",
-	"@llamaindex/core":  "llamaindex",
-	"openai-agents":     "openai_agents",
-	"@openai/agents":    "openai_agents",
-	"microsoft/autogen": "autogen",
+	"langchain":                              "langchain",
+	"langgraph":                              "langgraph",
+	"crewai":                                 "crewai",
+	"autogen":                                "autogen",
+	"llamaindex":                             "llamaindex",
+	"llama_index":                            "llamaindex",
+	"semantic-kernel":                        "semantic_kernel",
+	"semantic_kernel":                        "semantic_kernel",
+	"haystack":                               "haystack",
+	"@langchain/core":                        "langchain",
+	"@langchain/openai":                      "langchain",
+	"@llamaindex/core":                       "llamaindex",
+	"openai-agents":                          "openai_agents",
+	"@openai/agents":                         "openai_agents",
+	"microsoft/autogen":                      "autogen",
+	"google.golang.org/adk":                  "google_adk",
+	"github.com/modelcontextprotocol/go-sdk": "mcp_client",
+	"github.com/mark3labs/mcp-go":            "mcp_client",
+	"spring-ai":                              "spring_ai",
+	"io.modelcontextprotocol.sdk":            "mcp_client",
+	"microsoft.semantickernel":               "semantic_kernel",
+	"microsoft.agents.ai":                    "semantic_kernel",
+	"pydantic-ai":                            "pydantic_ai",
+	"smolagents":                             "smolagents",
+	"dspy":                                   "dspy",
+	"strands-agents":                         "strands",
+	"google-adk":                             "google_adk",
+	"@langchain/langgraph":                   "langgraph",
+	"@mastra":                                "mastra",
+}
+
+// knownExactFrameworkPackages only match whole package names. The C# MCP SDK
+// is published as `ModelContextProtocol`, which would otherwise also match
+// the npm `@modelcontextprotocol/*` scope, and the Vercel AI SDK is
+// published as `ai`.
+var knownExactFrameworkPackages = map[string]string{
+	"modelcontextprotocol":            "mcp_client",
+	"modelcontextprotocol.core":       "mcp_client",
+	"modelcontextprotocol.aspnetcore": "mcp_client",
+	"ai":                              "vercel_ai",
 }
 
 var projectSignalKeywords = []string{
@@ -151,6 +181,34 @@ func (Detector) Detect(_ context.Context, scope detect.Scope, options detect.Opt
 			} else {
 				findings = append(findings, manifestFindings(scope, rel, deps)...)
 			}
+		case base == "pom.xml":
+			deps, parseErr := parsePomXML(scope.Root, rel)
+			if parseErr != nil {
+				findings = append(findings, parseErrorFinding(scope, rel, parseErr))
+			} else {
+				findings = append(findings, manifestFindings(scope, rel, deps)...)
+			}
+		case base == "build.gradle", base == "build.gradle.kts":
+			deps, parseErr := parseGradleBuild(scope.Root, rel)
+			if parseErr != nil {
+				findings = append(findings, parseErrorFinding(scope, rel, parseErr))
+			} else {
+				findings = append(findings, manifestFindings(scope, rel, deps)...)
+			}
+		case strings.HasSuffix(base, ".csproj"), base == "directory.packages.props":
+			deps, parseErr := parseMSBuildPackages(scope.Root, rel)
+			if parseErr != nil {
+				findings = append(findings, parseErrorFinding(scope, rel, parseErr))
+			} else {
+				findings = append(findings, manifestFindings(scope, rel, deps)...)
+			}
+		case base == "packages.config":
+			deps, parseErr := parsePackagesConfig(scope.Root, rel)
+			if parseErr != nil {
+				findings = append(findings, parseErrorFinding(scope, rel, parseErr))
+			} else {
+				findings = append(findings, manifestFindings(scope, rel, deps)...)
+			}
 		}
 	}
 	if len(findings) == 0 {
@@ -362,6 +420,9 @@ func frameworkForDependency(dep string) (string, bool) {
 	if framework, ok := knownFrameworkPackages[normalized]; ok {
 		return framework, true
 	}
+	if framework, ok := knownExactFrameworkPackages[normalized]; ok {
+		return framework, true
+	}
 	for name, framework := range knownFrameworkPackages {
 		if strings.Contains(normalized, name) {
 			return framework, true
@@ -409,6 +470,9 @@ func parseErrorFinding(scope detect.Scope, location string, parseErr *model.Pars
 }
 
 func matchesAIKeyword(normalized string) bool {
+	if _, ok := knownExactFrameworkPackages[normalized]; ok {
+		return true
+	}
 	for _, keyword := range aiKeywords {
 		if strings.Contains(normalized, keyword) {
 			return true
@@ -658,6 +722,10 @@ func isDependencyManifest(rel string) bool {
 	switch {
 	case base == "go.mod", base == "package.json", base == "pyproject.toml", base == "cargo.toml":
 		return true
+	case base == "pom.xml", base == "build.gradle", base == "build.gradle.kts":
+		return true
+	case strings.HasSuffix(base, ".csproj"), base == "directory.packages.props", base == "packages.config":
+		return true
 	case strings.HasPrefix(base, "requirements") && strings.HasSuffix(base, ".txt"):
 		return true
 	default:
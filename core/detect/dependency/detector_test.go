@@ -254,6 +254,245 @@ func TestDependencyOnlyFrameworkCandidateDoesNotCreateActionPath(t *testing.T) {
 	}
 }
 
+func TestGoModuleFrameworkCandidates(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeFile(t, root, "go.mod", `module example.com/agents
+
+go 1.26.1
+
+require (
+	github.com/mark3labs/mcp-go v0.32.0
+	github.com/tmc/langchaingo v0.1.13
+	google.golang.org/adk v0.1.0
+)
+`)
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Org: "acme", Repo: "repo", Root: root}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect returned error: %v", err)
+	}
+	frameworkCandidates := map[string]string{}
+	for _, finding := range findings {
+		if finding.FindingType == "framework_candidate" {
+			frameworkCandidates[finding.ToolType] = evidenceValue(finding, "dependency")
+		}
+	}
+	want := map[string]string{
+		"google_adk": "google.golang.org/adk",
+		"langchain":  "github.com/tmc/langchaingo",
+		"mcp_client": "github.com/mark3labs/mcp-go",
+	}
+	for framework, dependency := range want {
+		if frameworkCandidates[framework] != dependency {
+			t.Fatalf("expected %s candidate from %s, got %+v", framework, dependency, frameworkCandidates)
+		}
+	}
+}
+
+func TestPythonAgentFrameworkCandidates(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeFile(t, root, "requirements.txt", `pydantic-ai-slim[openai]==0.4.2
+smolagents[toolkit]>=1.19
+dspy==2.6.27
+haystack-ai==2.15.0
+strands-agents>=1.0
+strands-agents-tools>=0.2
+google-adk==1.5.0
+`)
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Org: "acme", Repo: "repo", Root: root}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect returned error: %v", err)
+	}
+	frameworkCandidates := map[string]bool{}
+	for _, finding := range findings {
+		if finding.FindingType == "framework_candidate" {
+			frameworkCandidates[finding.ToolType] = true
+		}
+	}
+	for _, framework := range []string{"pydantic_ai", "smolagents", "dspy", "haystack", "strands", "google_adk"} {
+		if !frameworkCandidates[framework] {
+			t.Fatalf("expected %s candidate, got %+v", framework, frameworkCandidates)
+		}
+	}
+}
+
+func TestTypeScriptAgentFrameworkCandidates(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeFile(t, root, "package.json", `{
+  "dependencies": {
+    "ai": "^5.0.0",
+    "@ai-sdk/openai": "^2.0.0",
+    "@mastra/core": "^0.10.0",
+    "@mastra/mcp": "^0.10.0",
+    "@langchain/langgraph": "^0.3.0",
+    "aigle": "^1.14.0"
+  }
+}`)
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Org: "acme", Repo: "repo", Root: root}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect returned error: %v", err)
+	}
+	frameworkCandidates := map[string]string{}
+	aiDependencies := map[string]bool{}
+	for _, finding := range findings {
+		switch finding.FindingType {
+		case "framework_candidate":
+			frameworkCandidates[evidenceValue(finding, "dependency")] = finding.ToolType
+		case "ai_dependency":
+			aiDependencies[evidenceValue(finding, "dependency")] = true
+		}
+	}
+	for dep, framework := range map[string]string{"ai": "vercel_ai", "@mastra/core": "mastra", "@langchain/langgraph": "langgraph"} {
+		if frameworkCandidates[dep] != framework {
+			t.Fatalf("expected %s candidate for %s, got %+v", framework, dep, frameworkCandidates)
+		}
+	}
+	if _, ok := frameworkCandidates["aigle"]; ok {
+		t.Fatalf("expected aigle to stay out of framework candidates, got %+v", frameworkCandidates)
+	}
+	for _, dep := range []string{"ai", "@ai-sdk/openai", "@mastra/core"} {
+		if !aiDependencies[dep] {
+			t.Fatalf("expected ai dependency finding for %s, got %+v", dep, aiDependencies)
+		}
+	}
+}
+
+func TestJVMManifestFrameworkCandidates(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeFile(t, root, "pom.xml", `<project xmlns="http://maven.apache.org/POM/4.0.0">
+  <dependencyManagement>
+    <dependencies>
+      <dependency>
+        <groupId>org.springframework.ai</groupId>
+        <artifactId>spring-ai-bom</artifactId>
+        <version>1.0.0</version>
+        <type>pom</type>
+        <scope>import</scope>
+      </dependency>
+    </dependencies>
+  </dependencyManagement>
+  <dependencies>
+    <dependency>
+      <groupId>org.springframework.ai</groupId>
+      <artifactId>spring-ai-starter-mcp-client</artifactId>
+    </dependency>
+  </dependencies>
+</project>`)
+	writeFile(t, root, "assistant/build.gradle.kts", `dependencies {
+    implementation(platform("dev.langchain4j:langchain4j-bom:1.0.0"))
+    implementation("dev.langchain4j:langchain4j-open-ai")
+    implementation(group = "io.modelcontextprotocol.sdk", name = "mcp", version = "0.10.0")
+    testImplementation("org.junit.jupiter:junit-jupiter:5.10.0")
+}`)
+	writeFile(t, root, "broken/pom.xml", `<project><dependencies>`)
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Org: "acme", Repo: "repo", Root: root}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect returned error: %v", err)
+	}
+	candidates := map[string][]string{}
+	parseErrors := 0
+	for _, finding := range findings {
+		switch finding.FindingType {
+		case "framework_candidate":
+			candidates[finding.Location] = append(candidates[finding.Location], finding.ToolType+"="+evidenceValue(finding, "dependency"))
+		case "parse_error":
+			parseErrors++
+			if finding.Location != "broken/pom.xml" || finding.ParseError.Format != "xml" {
+				t.Fatalf("unexpected parse error %+v", finding)
+			}
+		}
+	}
+	if parseErrors != 1 {
+		t.Fatalf("expected one pom parse error, got %d", parseErrors)
+	}
+	want := map[string][]string{
+		"pom.xml": {
+			"spring_ai=org.springframework.ai:spring-ai-bom",
+			"spring_ai=org.springframework.ai:spring-ai-starter-mcp-client",
+		},
+		"assistant/build.gradle.kts": {
+			"langchain=dev.langchain4j:langchain4j-bom",
+			"langchain=dev.langchain4j:langchain4j-open-ai",
+			"mcp_client=io.modelcontextprotocol.sdk:mcp",
+		},
+	}
+	for location, expected := range want {
+		if !reflect.DeepEqual(candidates[location], expected) {
+			t.Fatalf("unexpected candidates for %s: got %v want %v", location, candidates[location], expected)
+		}
+	}
+}
+
+func TestNuGetManifestFrameworkCandidates(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeFile(t, root, "src/Support/Support.csproj", `<Project Sdk="Microsoft.NET.Sdk">
+  <ItemGroup>
+    <PackageReference Include="Microsoft.SemanticKernel" Version="1.40.0" />
+    <PackageReference Include="ModelContextProtocol" Version="0.3.0-preview.1" />
+    <PackageReference Update="Microsoft.Agents.AI.OpenAI" Version="1.0.0-preview" />
+    <PackageReference Include="Serilog" Version="4.0.0" />
+  </ItemGroup>
+</Project>`)
+	writeFile(t, root, "Directory.Packages.props", `<Project>
+  <ItemGroup>
+    <PackageVersion Include="Microsoft.SemanticKernel.Agents.Core" Version="1.40.0" />
+  </ItemGroup>
+</Project>`)
+	writeFile(t, root, "legacy/packages.config", `<?xml version="1.0" encoding="utf-8"?>
+<packages>
+  <package id="Microsoft.SemanticKernel.Core" version="1.0.1" targetFramework="net48" />
+</packages>`)
+	writeFile(t, root, "broken/Broken.csproj", `<Project><ItemGroup>`)
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Org: "acme", Repo: "repo", Root: root}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect returned error: %v", err)
+	}
+	candidates := map[string][]string{}
+	parseErrors := 0
+	for _, finding := range findings {
+		switch finding.FindingType {
+		case "framework_candidate":
+			candidates[finding.Location] = append(candidates[finding.Location], finding.ToolType+"="+evidenceValue(finding, "dependency"))
+		case "parse_error":
+			parseErrors++
+			if finding.Location != "broken/Broken.csproj" || finding.ParseError.Format != "xml" {
+				t.Fatalf("unexpected parse error %+v", finding)
+			}
+		}
+	}
+	if parseErrors != 1 {
+		t.Fatalf("expected one csproj parse error, got %d", parseErrors)
+	}
+	want := map[string][]string{
+		"src/Support/Support.csproj": {
+			"mcp_client=ModelContextProtocol",
+			"semantic_kernel=Microsoft.Agents.AI.OpenAI",
+			"semantic_kernel=Microsoft.SemanticKernel",
+		},
+		"Directory.Packages.props": {"semantic_kernel=Microsoft.SemanticKernel.Agents.Core"},
+		"legacy/packages.config":   {"semantic_kernel=Microsoft.SemanticKernel.Core"},
+	}
+	for location, expected := range want {
+		if !reflect.DeepEqual(candidates[location], expected) {
+			t.Fatalf("unexpected candidates for %s: got %v want %v", location, candidates[location], expected)
+		}
+	}
+}
+
 func TestPrecisionCalibrationDependencyOnlyFixture(t *testing.T) {
 	t.Parallel()
 
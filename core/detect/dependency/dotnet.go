@@ -0,0 +1,81 @@
+package dependency
+
+import (
+	"encoding/xml"
+	"strings"
+
+	"github.com/Clyra-AI/wrkr/core/detect"
+	"github.com/Clyra-AI/wrkr/core/model"
+)
+
+type nugetReference struct {
+	Include string `xml:"Include,attr"`
+	Update  string `xml:"Update,attr"`
+}
+
+// msbuildProject covers SDK-style project files and central package
+// management in Directory.Packages.props, which share the MSBuild schema.
+type msbuildProject struct {
+	ItemGroups []struct {
+		PackageReferences       []nugetReference `xml:"PackageReference"`
+		PackageVersions         []nugetReference `xml:"PackageVersion"`
+		GlobalPackageReferences []nugetReference `xml:"GlobalPackageReference"`
+	} `xml:"ItemGroup"`
+}
+
+type packagesConfig struct {
+	Packages []struct {
+		ID string `xml:"id,attr"`
+	} `xml:"package"`
+}
+
+// parseMSBuildPackages returns NuGet package IDs referenced by a `*.csproj`
+// or pinned in `Directory.Packages.props`.
+func parseMSBuildPackages(root, rel string) ([]string, *model.ParseError) {
+	payload, parseErr := detect.ReadFileWithinRoot(detectorID, root, rel)
+	if parseErr != nil {
+		return nil, parseErr
+	}
+	var parsed msbuildProject
+	if err := xml.Unmarshal(payload, &parsed); err != nil {
+		return nil, &model.ParseError{Kind: "parse_error", Format: "xml", Path: rel, Detector: detectorID, Message: err.Error()}
+	}
+	deps := make([]string, 0)
+	for _, group := range parsed.ItemGroups {
+		refs := append([]nugetReference(nil), group.PackageReferences...)
+		refs = append(refs, group.PackageVersions...)
+		refs = append(refs, group.GlobalPackageReferences...)
+		for _, ref := range refs {
+			id := strings.TrimSpace(ref.Include)
+			if id == "" {
+				// `Update` items adjust a reference declared elsewhere but
+				// still name the package.
+				id = strings.TrimSpace(ref.Update)
+			}
+			if id != "" {
+				deps = append(deps, id)
+			}
+		}
+	}
+	return deps, nil
+}
+
+// parsePackagesConfig returns package IDs from a legacy NuGet
+// `packages.config`.
+func parsePackagesConfig(root, rel string) ([]string, *model.ParseError) {
+	payload, parseErr := detect.ReadFileWithinRoot(detectorID, root, rel)
+	if parseErr != nil {
+		return nil, parseErr
+	}
+	var parsed packagesConfig
+	if err := xml.Unmarshal(payload, &parsed); err != nil {
+		return nil, &model.ParseError{Kind: "parse_error", Format: "xml", Path: rel, Detector: detectorID, Message: err.Error()}
+	}
+	deps := make([]string, 0, len(parsed.Packages))
+	for _, pkg := range parsed.Packages {
+		if id := strings.TrimSpace(pkg.ID); id != "" {
+			deps = append(deps, id)
+		}
+	}
+	return deps, nil
+}
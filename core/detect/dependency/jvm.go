@@ -0,0 +1,77 @@
+package dependency
+
+import (
+	"encoding/xml"
+	"regexp"
+	"strings"
+
+	"github.com/Clyra-AI/wrkr/core/detect"
+	"github.com/Clyra-AI/wrkr/core/model"
+)
+
+var (
+	gradleCoordinatePattern = regexp.MustCompile(`(?m)^\s*(?:implementation|api|compileOnly|runtimeOnly|testImplementation|testRuntimeOnly|annotationProcessor|developmentOnly|kapt|ksp)\s*\(?\s*(?:(?:enforced)?[Pp]latform\s*\(\s*)?["']([^"':\s]+):([^"':\s]+)(?::[^"']*)?["']`)
+	gradleGroupNamePattern  = regexp.MustCompile(`group\s*[:=]\s*["']([^"']+)["']\s*,\s*name\s*[:=]\s*["']([^"']+)["']`)
+)
+
+type pomDependency struct {
+	GroupID    string `xml:"groupId"`
+	ArtifactID string `xml:"artifactId"`
+}
+
+type pomProject struct {
+	Dependencies         []pomDependency `xml:"dependencies>dependency"`
+	DependencyManagement struct {
+		Dependencies []pomDependency `xml:"dependencies>dependency"`
+	} `xml:"dependencyManagement"`
+	Profiles []struct {
+		Dependencies []pomDependency `xml:"dependencies>dependency"`
+	} `xml:"profiles>profile"`
+}
+
+// parsePomXML returns `groupId:artifactId` coordinates from a Maven POM,
+// including BOM imports declared under dependencyManagement.
+func parsePomXML(root, rel string) ([]string, *model.ParseError) {
+	payload, parseErr := detect.ReadFileWithinRoot(detectorID, root, rel)
+	if parseErr != nil {
+		return nil, parseErr
+	}
+	var parsed pomProject
+	if err := xml.Unmarshal(payload, &parsed); err != nil {
+		return nil, &model.ParseError{Kind: "parse_error", Format: "xml", Path: rel, Detector: detectorID, Message: err.Error()}
+	}
+	all := append([]pomDependency(nil), parsed.Dependencies...)
+	all = append(all, parsed.DependencyManagement.Dependencies...)
+	for _, profile := range parsed.Profiles {
+		all = append(all, profile.Dependencies...)
+	}
+	deps := make([]string, 0, len(all))
+	for _, dep := range all {
+		group := strings.TrimSpace(dep.GroupID)
+		artifact := strings.TrimSpace(dep.ArtifactID)
+		if group == "" || artifact == "" {
+			continue
+		}
+		deps = append(deps, group+":"+artifact)
+	}
+	return deps, nil
+}
+
+// parseGradleBuild returns `group:artifact` coordinates declared as string
+// notation or `group:/name:` maps in Groovy and Kotlin DSL build scripts.
+// Version catalog aliases (`libs.x.y`) are not resolved.
+func parseGradleBuild(root, rel string) ([]string, *model.ParseError) {
+	payload, parseErr := detect.ReadFileWithinRoot(detectorID, root, rel)
+	if parseErr != nil {
+		return nil, parseErr
+	}
+	content := string(payload)
+	deps := make([]string, 0)
+	for _, match := range gradleCoordinatePattern.FindAllStringSubmatch(content, -1) {
+		deps = append(deps, strings.TrimSpace(match[1])+":"+strings.TrimSpace(match[2]))
+	}
+	for _, match := range gradleGroupNamePattern.FindAllStringSubmatch(content, -1) {
+		deps = append(deps, strings.TrimSpace(match[1])+":"+strings.TrimSpace(match[2]))
+	}
+	return deps, nil
+}
@@ -164,12 +164,37 @@ func (r *Registry) collectSurfaceCoverage(scopes []Scope, ids []string, options
 			out = append(out, reporter.SurfaceCoverage(scope, options)...)
 		}
 	}
+	sortSurfaceCoverage(out)
+	return out
+}
+
+func sortSurfaceCoverage(out []SurfaceCoverage) {
 	sort.Slice(out, func(i, j int) bool {
 		left := strings.Join([]string{out[i].Org, out[i].Repo, out[i].Surface, out[i].Detector}, "|")
 		right := strings.Join([]string{out[j].Org, out[j].Repo, out[j].Surface, out[j].Detector}, "|")
 		return left < right
 	})
-	return out
+}
+
+// MergeRunResults combines results from registry runs over disjoint scopes
+// into the same deterministic order a single run over every scope produces.
+func MergeRunResults(results ...RunResult) RunResult {
+	merged := RunResult{SurfaceCoverage: []SurfaceCoverage{}}
+	for _, result := range results {
+		merged.Findings = append(merged.Findings, result.Findings...)
+		merged.DetectorErrors = append(merged.DetectorErrors, result.DetectorErrors...)
+		merged.SurfaceCoverage = append(merged.SurfaceCoverage, result.SurfaceCoverage...)
+	}
+	model.SortFindings(merged.Findings)
+	finalizeDetectorErrors(merged.DetectorErrors)
+	sortSurfaceCoverage(merged.SurfaceCoverage)
+	if len(merged.Findings) == 0 {
+		merged.Findings = nil
+	}
+	if len(merged.DetectorErrors) == 0 {
+		merged.DetectorErrors = nil
+	}
+	return merged
 }
 
 func (r *Registry) runSerial(ctx context.Context, sortedScopes []Scope, ids []string, options Options) (RunResult, error) {
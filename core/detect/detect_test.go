@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"reflect"
 	"testing"
 
 	"github.com/Clyra-AI/wrkr/core/model"
@@ -56,6 +57,49 @@ func TestRegistryRunDeterministicOrder(t *testing.T) {
 	}
 }
 
+func TestMergeRunResultsMatchesSingleRun(t *testing.T) {
+	t.Parallel()
+
+	registry := NewRegistry()
+	if err := registry.Register(fakeDetector{id: "config", detectF: func(scope Scope) ([]model.Finding, error) {
+		return []model.Finding{
+			{Severity: model.SeverityLow, FindingType: "tool_config", ToolType: "codex", Location: "b", Org: scope.Org, Repo: scope.Repo},
+			{Severity: model.SeverityHigh, FindingType: "tool_config", ToolType: "codex", Location: "a", Org: scope.Org, Repo: scope.Repo},
+		}, nil
+	}}); err != nil {
+		t.Fatalf("register config: %v", err)
+	}
+	if err := registry.Register(fakeDetector{id: "broken", detectF: func(scope Scope) ([]model.Finding, error) {
+		if scope.Repo == "api" {
+			return nil, fmt.Errorf("read file: %w", os.ErrPermission)
+		}
+		return nil, nil
+	}}); err != nil {
+		t.Fatalf("register broken: %v", err)
+	}
+	scopes := []Scope{
+		{Org: "acme", Repo: "web", Root: t.TempDir()},
+		{Org: "acme", Repo: "api", Root: t.TempDir()},
+	}
+
+	full, err := registry.Run(context.Background(), scopes, Options{})
+	if err != nil {
+		t.Fatalf("run registry: %v", err)
+	}
+	web, err := registry.Run(context.Background(), scopes[:1], Options{})
+	if err != nil {
+		t.Fatalf("run registry for web: %v", err)
+	}
+	api, err := registry.Run(context.Background(), scopes[1:], Options{})
+	if err != nil {
+		t.Fatalf("run registry for api: %v", err)
+	}
+	merged := MergeRunResults(web, api)
+	if !reflect.DeepEqual(full, merged) {
+		t.Fatalf("expected merged result to match single run\nfull=%+v\nmerged=%+v", full, merged)
+	}
+}
+
 func TestRegistryContinuesOnDetectorError(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,350 @@
+package gemini
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Clyra-AI/wrkr/core/detect"
+	"github.com/Clyra-AI/wrkr/core/model"
+	"github.com/Clyra-AI/wrkr/core/risk/autonomy"
+)
+
+const detectorID = "gemini"
+
+type Detector struct{}
+
+func New() Detector { return Detector{} }
+
+func (Detector) ID() string { return detectorID }
+
+// settingsFile covers both the flat settings layout and the nested `tools`
+// block used by newer Gemini CLI releases.
+type settingsFile struct {
+	MCPServers          map[string]mcpServer `json:"mcpServers"`
+	CoreTools           []string             `json:"coreTools"`
+	ExcludeTools        []string             `json:"excludeTools"`
+	AutoAccept          *bool                `json:"autoAccept"`
+	DefaultApprovalMode string               `json:"defaultApprovalMode"`
+	Sandbox             json.RawMessage      `json:"sandbox"`
+	General             struct {
+		DefaultApprovalMode string `json:"defaultApprovalMode"`
+	} `json:"general"`
+	Tools struct {
+		Core       []string        `json:"core"`
+		Exclude    []string        `json:"exclude"`
+		AutoAccept *bool           `json:"autoAccept"`
+		Sandbox    json.RawMessage `json:"sandbox"`
+	} `json:"tools"`
+}
+
+// mcpServer only reads the Gemini-specific trust flag and the tools it
+// applies to; server posture is scored by the mcp detector.
+type mcpServer struct {
+	Trust        bool     `json:"trust"`
+	IncludeTools []string `json:"includeTools"`
+}
+
+type commandFile struct {
+	Description string `toml:"description"`
+	Prompt      string `toml:"prompt"`
+}
+
+func (Detector) Detect(_ context.Context, scope detect.Scope, _ detect.Options) ([]model.Finding, error) {
+	if err := detect.ValidateScopeRoot(scope.Root); err != nil {
+		return nil, err
+	}
+
+	findings := make([]model.Finding, 0)
+	if detect.DirExists(scope.Root, ".gemini") {
+		findings = append(findings, baseFinding(scope, ".gemini", nil, model.Evidence{Key: "note", Value: "gemini config directory discovered"}))
+	}
+	if exists, parseErr := detect.FileExistsWithinRoot(detectorID, scope.Root, "GEMINI.md"); parseErr != nil {
+		findings = append(findings, parseErrorFinding(scope, "GEMINI.md", parseErr))
+	} else if exists {
+		findings = append(findings, baseFinding(scope, "GEMINI.md", nil,
+			model.Evidence{Key: "note", Value: "gemini context file discovered"},
+			model.Evidence{Key: "resolver_ref", Value: "GEMINI.md"},
+		))
+	}
+
+	if exists, parseErr := detect.FileExistsWithinRoot(detectorID, scope.Root, ".gemini/settings.json"); parseErr != nil {
+		findings = append(findings, parseErrorFinding(scope, ".gemini/settings.json", parseErr))
+	} else if exists {
+		findings = append(findings, parseSettings(scope, ".gemini/settings.json"))
+	}
+
+	commands := make([]string, 0)
+	for _, pattern := range []string{".gemini/commands/*.toml", ".gemini/commands/*/*.toml"} {
+		matches, globErr := detect.Glob(scope.Root, pattern)
+		if globErr != nil {
+			return nil, fmt.Errorf("glob gemini commands: %w", globErr)
+		}
+		commands = append(commands, matches...)
+	}
+	for _, rel := range commands {
+		var parsed commandFile
+		if parseErr := detect.ParseTOMLFileAllowUnknownFields(detectorID, scope.Root, rel, &parsed); parseErr != nil {
+			findings = append(findings, parseErrorFinding(scope, rel, parseErr))
+			continue
+		}
+		// `!{...}` blocks run shell commands before the prompt is sent.
+		shellInjection := strings.Contains(parsed.Prompt, "!{")
+		var permissions []string
+		if shellInjection {
+			permissions = []string{"proc.exec"}
+		}
+		evidence := []model.Evidence{
+			{Key: "note", Value: "gemini custom command discovered"},
+			{Key: "shell_injection", Value: fmt.Sprintf("%t", shellInjection)},
+			{Key: "resolver_ref", Value: rel},
+		}
+		if shellInjection {
+			evidence = append(evidence, model.Evidence{Key: "validation_requirement", Value: "review_command_shell_injection"})
+		}
+		findings = append(findings, baseFinding(scope, rel, permissions, evidence...))
+	}
+
+	model.SortFindings(findings)
+	return findings, nil
+}
+
+func parseSettings(scope detect.Scope, rel string) model.Finding {
+	var parsed settingsFile
+	if parseErr := detect.ParseJSONFileAllowUnknownFields(detectorID, scope.Root, rel, &parsed); parseErr != nil {
+		return parseErrorFinding(scope, rel, parseErr)
+	}
+	coreTools := append(append([]string(nil), parsed.CoreTools...), parsed.Tools.Core...)
+	excludeTools := append(append([]string(nil), parsed.ExcludeTools...), parsed.Tools.Exclude...)
+	autoAccept := parsed.AutoAccept != nil && *parsed.AutoAccept
+	if parsed.Tools.AutoAccept != nil {
+		autoAccept = *parsed.Tools.AutoAccept
+	}
+	approvalMode := normalizeApprovalMode(parsed.DefaultApprovalMode)
+	if nested := normalizeApprovalMode(parsed.General.DefaultApprovalMode); nested != "" {
+		approvalMode = nested
+	}
+	if approvalMode == "" {
+		approvalMode = "default"
+	}
+	sandbox := sandboxMode(parsed.Sandbox)
+	if len(parsed.Tools.Sandbox) > 0 {
+		sandbox = sandboxMode(parsed.Tools.Sandbox)
+	}
+
+	// Gemini CLI enables every built-in tool when coreTools is empty. Only
+	// unscoped excludes remove a tool: `run_shell_command(rm -rf)` blocks one
+	// command, not the shell.
+	enabledTools := coreTools
+	if len(enabledTools) == 0 {
+		enabledTools = make([]string, 0, len(builtinToolPermissions))
+		for name := range builtinToolPermissions {
+			enabledTools = append(enabledTools, name)
+		}
+	}
+	excluded := map[string]struct{}{}
+	for _, tool := range excludeTools {
+		if name, scoped := canonicalToolName(tool); !scoped {
+			excluded[name] = struct{}{}
+		}
+	}
+	ceiling := map[string]struct{}{}
+	for _, tool := range enabledTools {
+		name, _ := canonicalToolName(tool)
+		if _, ok := excluded[name]; ok || name == "" {
+			continue
+		}
+		ceiling[normalizeToolPermission(name)] = struct{}{}
+	}
+	// A trusted server skips confirmation for every tool it exposes, so it
+	// auto-approves writes unless includeTools limits it to read-only tools.
+	trusted := make([]string, 0)
+	trustedWrite := make([]string, 0)
+	for name, server := range parsed.MCPServers {
+		if !server.Trust {
+			continue
+		}
+		trusted = append(trusted, name)
+		if len(server.IncludeTools) == 0 {
+			trustedWrite = append(trustedWrite, name)
+			continue
+		}
+		for _, tool := range server.IncludeTools {
+			if autonomy.IsWriteOrExecTool(tool) {
+				trustedWrite = append(trustedWrite, name)
+				break
+			}
+		}
+	}
+	sort.Strings(trusted)
+	sort.Strings(trustedWrite)
+	if len(parsed.MCPServers) > 0 {
+		ceiling["mcp.access"] = struct{}{}
+	}
+	perms := make([]string, 0, len(ceiling))
+	for permission := range ceiling {
+		perms = append(perms, permission)
+	}
+	sort.Strings(perms)
+
+	// autoAccept only skips confirmation for read-only tools, so it stays
+	// evidence. yolo approves every call and auto_edit approves file edits.
+	_, writeEnabled := ceiling["filesystem.write"]
+	autoApprovedWrite := approvalMode == "yolo" || (approvalMode == "auto_edit" && writeEnabled) || len(trustedWrite) > 0
+	signals := autonomy.Signals{Tool: detectorID, AutoApprovedWrite: autoApprovedWrite}
+	evidence := []model.Evidence{
+		{Key: "note", Value: fmt.Sprintf("gemini settings parsed (%d MCP servers)", len(parsed.MCPServers))},
+		{Key: "core_tools", Value: strings.Join(coreTools, ",")},
+		{Key: "exclude_tools", Value: strings.Join(excludeTools, ",")},
+		{Key: "auto_accept", Value: fmt.Sprintf("%t", autoAccept)},
+		{Key: "approval_mode", Value: approvalMode},
+		{Key: "sandbox_mode", Value: sandbox},
+		{Key: "sandbox_gate", Value: "sandbox:" + sandbox},
+		{Key: "trusted_mcp_servers", Value: strings.Join(trusted, ",")},
+		{Key: "trusted_mcp_write_servers", Value: strings.Join(trustedWrite, ",")},
+		{Key: "headless", Value: "false"},
+		{Key: "approval_gate", Value: fmt.Sprintf("%t", !signals.AutoApprovedWrite)},
+		{Key: "resolver_ref", Value: rel},
+		{Key: "validation_requirement", Value: "respect_gemini_sandbox"},
+	}
+	finding := baseFinding(scope, rel, perms, evidence...)
+	finding.Autonomy = autonomy.Classify(signals)
+	if signals.AutoApprovedWrite {
+		finding.Severity = model.SeverityMedium
+		finding.Remediation = "Use the default Gemini CLI approval mode and remove trust from MCP servers with write tools so those calls need confirmation."
+	}
+	return finding
+}
+
+// normalizeApprovalMode lowercases a Gemini CLI approval mode such as
+// `default`, `auto_edit`, or `yolo`, accepting `autoEdit` and `auto-edit`.
+func normalizeApprovalMode(mode string) string {
+	normalized := strings.ToLower(strings.TrimSpace(mode))
+	normalized = strings.ReplaceAll(normalized, "-", "_")
+	if normalized == "autoedit" {
+		normalized = "auto_edit"
+	}
+	return normalized
+}
+
+// sandboxMode normalizes the `sandbox` setting, which is either a boolean or
+// the name of a sandbox runtime such as docker or podman.
+func sandboxMode(raw json.RawMessage) string {
+	trimmed := strings.TrimSpace(string(raw))
+	if trimmed == "" || trimmed == "null" {
+		return "unset"
+	}
+	var enabled bool
+	if err := json.Unmarshal(raw, &enabled); err == nil {
+		if enabled {
+			return "enabled"
+		}
+		return "disabled"
+	}
+	var runtime string
+	if err := json.Unmarshal(raw, &runtime); err == nil && strings.TrimSpace(runtime) != "" {
+		return strings.ToLower(strings.TrimSpace(runtime))
+	}
+	return "unknown"
+}
+
+func baseFinding(scope detect.Scope, location string, permissions []string, extra ...model.Evidence) model.Finding {
+	evidence := []model.Evidence{{Key: "delivery_harness", Value: "gemini_cli"}}
+	evidence = append(evidence, extra...)
+	if strings.TrimSpace(scope.Repo) != "" {
+		evidence = append(evidence, model.Evidence{Key: "repo", Value: scope.Repo})
+	}
+	if strings.TrimSpace(scope.Org) != "" {
+		evidence = append(evidence, model.Evidence{Key: "org", Value: scope.Org})
+	}
+	return model.Finding{
+		FindingType: "tool_config",
+		Severity:    model.SeverityLow,
+		ToolType:    "gemini",
+		Location:    location,
+		Repo:        scope.Repo,
+		Org:         fallbackOrg(scope.Org),
+		Detector:    detectorID,
+		Permissions: permissions,
+		Evidence:    evidence,
+	}
+}
+
+func parseErrorFinding(scope detect.Scope, location string, parseErr *model.ParseError) model.Finding {
+	parseErr.Detector = detectorID
+	return model.Finding{
+		FindingType: "parse_error",
+		Severity:    model.SeverityMedium,
+		ToolType:    "gemini",
+		Location:    location,
+		Repo:        scope.Repo,
+		Org:         fallbackOrg(scope.Org),
+		Detector:    detectorID,
+		ParseError:  parseErr,
+		Remediation: "Fix malformed Gemini CLI configuration so deterministic parsing can proceed.",
+	}
+}
+
+// builtinToolPermissions maps the Gemini CLI built-in tools to permissions.
+var builtinToolPermissions = map[string]string{
+	"run_shell_command":   "proc.exec",
+	"write_file":          "filesystem.write",
+	"replace":             "filesystem.write",
+	"read_file":           "filesystem.read",
+	"read_many_files":     "filesystem.read",
+	"list_directory":      "filesystem.read",
+	"glob":                "filesystem.read",
+	"search_file_content": "filesystem.read",
+	"web_fetch":           "network.access",
+	"google_web_search":   "network.access",
+}
+
+// builtinToolAliases maps the class names older settings use to tool names.
+var builtinToolAliases = map[string]string{
+	"shelltool":         "run_shell_command",
+	"shell":             "run_shell_command",
+	"writefiletool":     "write_file",
+	"edittool":          "replace",
+	"edit":              "replace",
+	"readfiletool":      "read_file",
+	"readmanyfilestool": "read_many_files",
+	"lstool":            "list_directory",
+	"globtool":          "glob",
+	"greptool":          "search_file_content",
+	"webfetchtool":      "web_fetch",
+	"websearchtool":     "google_web_search",
+}
+
+// canonicalToolName lowercases a tool entry, resolves class-name aliases, and
+// reports whether it was scoped, as in `run_shell_command(git status)`.
+func canonicalToolName(tool string) (string, bool) {
+	name := strings.TrimSpace(strings.ToLower(tool))
+	scoped := false
+	if idx := strings.Index(name, "("); idx >= 0 {
+		name = strings.TrimSpace(name[:idx])
+		scoped = true
+	}
+	if alias, ok := builtinToolAliases[name]; ok {
+		name = alias
+	}
+	return name, scoped
+}
+
+// normalizeToolPermission maps a Gemini CLI tool entry to a permission.
+// Unknown tools keep their name.
+func normalizeToolPermission(tool string) string {
+	name, _ := canonicalToolName(tool)
+	if permission, ok := builtinToolPermissions[name]; ok {
+		return permission
+	}
+	return name
+}
+
+func fallbackOrg(org string) string {
+	if strings.TrimSpace(org) == "" {
+		return "local"
+	}
+	return org
+}
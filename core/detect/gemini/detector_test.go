@@ -0,0 +1,220 @@
+package gemini
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/Clyra-AI/wrkr/core/detect"
+	"github.com/Clyra-AI/wrkr/core/model"
+	"github.com/Clyra-AI/wrkr/core/risk/autonomy"
+)
+
+func TestGeminiDetectorParsesSettingsContextAndCommands(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeGeminiFile(t, root, ".gemini/settings.json", `{
+  "coreTools": ["run_shell_command(git status)", "write_file", "read_file", "glob", "web_fetch"],
+  "excludeTools": ["web_fetch"],
+  "autoAccept": true,
+  "sandbox": "docker",
+  "mcpServers": {
+    "github": {"httpUrl": "https://api.githubcopilot.com/mcp/", "trust": true},
+    "fs": {"command": "npx", "args": ["-y", "@modelcontextprotocol/server-filesystem"]}
+  },
+  "theme": "GitHub"
+}`)
+	writeGeminiFile(t, root, "GEMINI.md", "# Project context\n")
+	writeGeminiFile(t, root, ".gemini/commands/git/commit.toml", "description = \"Commit staged work\"\nprompt = \"Summarize !{git diff --staged} and commit it.\"\n")
+	writeGeminiFile(t, root, ".gemini/commands/explain.toml", "prompt = \"Explain {{args}}\"\n")
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Root: root, Repo: "repo", Org: "local"}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect: %v", err)
+	}
+	byLocation := map[string]model.Finding{}
+	for _, finding := range findings {
+		if finding.FindingType != "tool_config" {
+			t.Fatalf("expected only tool_config findings, got %+v", finding)
+		}
+		byLocation[finding.Location] = finding
+	}
+	for _, location := range []string{".gemini", "GEMINI.md", ".gemini/settings.json", ".gemini/commands/git/commit.toml", ".gemini/commands/explain.toml"} {
+		if _, ok := byLocation[location]; !ok {
+			t.Fatalf("expected finding for %s, got %+v", location, findings)
+		}
+	}
+
+	settings := byLocation[".gemini/settings.json"]
+	if want := []string{"filesystem.read", "filesystem.write", "mcp.access", "proc.exec"}; !reflect.DeepEqual(settings.Permissions, want) {
+		t.Fatalf("unexpected settings permissions: got %v want %v", settings.Permissions, want)
+	}
+	for key, want := range map[string]string{
+		"auto_accept":               "true",
+		"sandbox_mode":              "docker",
+		"sandbox_gate":              "sandbox:docker",
+		"trusted_mcp_servers":       "github",
+		"trusted_mcp_write_servers": "github",
+		"approval_mode":             "default",
+		"delivery_harness":          "gemini_cli",
+		"headless":                  "false",
+		"approval_gate":             "false",
+	} {
+		if got := evidenceValue(settings, key); got != want {
+			t.Fatalf("expected %s=%q, got %q", key, want, got)
+		}
+	}
+	if settings.Autonomy != autonomy.LevelHeadlessAuto {
+		t.Fatalf("expected a trusted MCP server without includeTools to be headless_auto, got %q", settings.Autonomy)
+	}
+
+	if got := byLocation[".gemini/commands/git/commit.toml"].Permissions; !reflect.DeepEqual(got, []string{"proc.exec"}) {
+		t.Fatalf("expected shell-injecting command to carry proc.exec, got %v", got)
+	}
+	if got := evidenceValue(byLocation[".gemini/commands/git/commit.toml"], "validation_requirement"); got != "review_command_shell_injection" {
+		t.Fatalf("expected shell-injecting command review requirement, got %q", got)
+	}
+	if got := byLocation[".gemini/commands/explain.toml"].Permissions; len(got) != 0 {
+		t.Fatalf("expected plain command to carry no permissions, got %v", got)
+	}
+	if got := evidenceValue(byLocation[".gemini/commands/explain.toml"], "validation_requirement"); got != "" {
+		t.Fatalf("expected plain command to carry no validation requirement, got %q", got)
+	}
+}
+
+func TestGeminiDetectorDefaultsToAllBuiltinTools(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		settings string
+		want     []string
+		autonomy string
+	}{
+		"empty":          {settings: `{}`, want: []string{"filesystem.read", "filesystem.write", "network.access", "proc.exec"}, autonomy: autonomy.LevelInteractive},
+		"scoped exclude": {settings: `{"excludeTools": ["run_shell_command(rm -rf)"]}`, want: []string{"filesystem.read", "filesystem.write", "network.access", "proc.exec"}, autonomy: autonomy.LevelInteractive},
+		"unscoped exclude": {
+			settings: `{"excludeTools": ["ShellTool", "write_file", "web_fetch", "google_web_search"], "autoAccept": true}`,
+			want:     []string{"filesystem.read", "filesystem.write"},
+			autonomy: autonomy.LevelInteractive,
+		},
+		"yolo approval mode": {
+			settings: `{"general": {"defaultApprovalMode": "yolo"}, "coreTools": ["read_file"]}`,
+			want:     []string{"filesystem.read"},
+			autonomy: autonomy.LevelHeadlessAuto,
+		},
+		"auto edit approval mode": {
+			settings: `{"defaultApprovalMode": "autoEdit", "coreTools": ["write_file"]}`,
+			want:     []string{"filesystem.write"},
+			autonomy: autonomy.LevelHeadlessAuto,
+		},
+		"auto edit without write tools": {
+			settings: `{"defaultApprovalMode": "auto_edit", "coreTools": ["read_file"]}`,
+			want:     []string{"filesystem.read"},
+			autonomy: autonomy.LevelInteractive,
+		},
+		"trusted read-only server": {
+			settings: `{"coreTools": ["read_file"], "mcpServers": {"docs": {"command": "docs-mcp", "trust": true, "includeTools": ["search_docs", "get_page"]}}}`,
+			want:     []string{"filesystem.read", "mcp.access"},
+			autonomy: autonomy.LevelInteractive,
+		},
+		"trusted write server": {
+			settings: `{"coreTools": ["read_file"], "mcpServers": {"github": {"command": "github-mcp", "trust": true, "includeTools": ["get_issue", "create_pull_request"]}}}`,
+			want:     []string{"filesystem.read", "mcp.access"},
+			autonomy: autonomy.LevelHeadlessAuto,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			root := t.TempDir()
+			writeGeminiFile(t, root, ".gemini/settings.json", tc.settings)
+			findings, err := New().Detect(context.Background(), detect.Scope{Root: root, Repo: "repo", Org: "local"}, detect.Options{})
+			if err != nil {
+				t.Fatalf("detect: %v", err)
+			}
+			for _, finding := range findings {
+				if finding.Location != ".gemini/settings.json" {
+					continue
+				}
+				if !reflect.DeepEqual(finding.Permissions, tc.want) {
+					t.Fatalf("unexpected permissions: got %v want %v", finding.Permissions, tc.want)
+				}
+				if finding.Autonomy != tc.autonomy {
+					t.Fatalf("unexpected autonomy: got %q want %q", finding.Autonomy, tc.autonomy)
+				}
+				return
+			}
+			t.Fatalf("expected settings finding, got %+v", findings)
+		})
+	}
+}
+
+func TestGeminiDetectorReadsNestedToolsSettings(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeGeminiFile(t, root, ".gemini/settings.json", `{"tools": {"core": ["ShellTool"], "autoAccept": false, "sandbox": false}}`)
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Root: root, Repo: "repo", Org: "local"}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect: %v", err)
+	}
+	for _, finding := range findings {
+		if finding.Location != ".gemini/settings.json" {
+			continue
+		}
+		if !reflect.DeepEqual(finding.Permissions, []string{"proc.exec"}) {
+			t.Fatalf("unexpected permissions: %v", finding.Permissions)
+		}
+		if got := evidenceValue(finding, "sandbox_mode"); got != "disabled" {
+			t.Fatalf("expected disabled sandbox, got %q", got)
+		}
+		return
+	}
+	t.Fatalf("expected settings finding, got %+v", findings)
+}
+
+func TestGeminiDetectorReportsMalformedSettings(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeGeminiFile(t, root, ".gemini/settings.json", `{"coreTools": [`)
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Root: root, Repo: "repo", Org: "local"}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect: %v", err)
+	}
+	for _, finding := range findings {
+		if finding.Location == ".gemini/settings.json" {
+			if finding.FindingType != "parse_error" || finding.ParseError == nil || finding.ParseError.Detector != detectorID {
+				t.Fatalf("expected gemini parse_error, got %+v", finding)
+			}
+			return
+		}
+	}
+	t.Fatalf("expected parse_error finding, got %+v", findings)
+}
+
+func evidenceValue(finding model.Finding, key string) string {
+	for _, item := range finding.Evidence {
+		if item.Key == key {
+			return item.Value
+		}
+	}
+	return ""
+}
+
+func writeGeminiFile(t *testing.T, root, rel, content string) {
+	t.Helper()
+	path := filepath.Join(root, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir %s: %v", rel, err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write %s: %v", rel, err)
+	}
+}
@@ -0,0 +1,279 @@
+package kiro
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/Clyra-AI/wrkr/core/detect"
+	"github.com/Clyra-AI/wrkr/core/model"
+	"github.com/Clyra-AI/wrkr/core/risk/autonomy"
+)
+
+const detectorID = "kiro"
+
+const mcpConfigPath = ".kiro/settings/mcp.json"
+
+type Detector struct{}
+
+func New() Detector { return Detector{} }
+
+func (Detector) ID() string { return detectorID }
+
+type steeringFrontmatter struct {
+	Inclusion string `yaml:"inclusion"`
+}
+
+// hookFile is a Kiro agent hook. `when` names the IDE event and `then` the
+// action, either a prompt sent to the agent or a shell command.
+type hookFile struct {
+	Enabled *bool  `json:"enabled"`
+	Name    string `json:"name"`
+	When    struct {
+		Type     string   `json:"type"`
+		Patterns []string `json:"patterns"`
+	} `json:"when"`
+	Then struct {
+		Type    string `json:"type"`
+		Command string `json:"command"`
+	} `json:"then"`
+}
+
+// mcpConfig only reads the approval fields; server posture is scored by the
+// mcp detector.
+type mcpConfig struct {
+	MCPServers map[string]struct {
+		Disabled    bool     `json:"disabled"`
+		AutoApprove []string `json:"autoApprove"`
+	} `json:"mcpServers"`
+}
+
+func (Detector) Detect(_ context.Context, scope detect.Scope, _ detect.Options) ([]model.Finding, error) {
+	if err := detect.ValidateScopeRoot(scope.Root); err != nil {
+		return nil, err
+	}
+
+	findings := make([]model.Finding, 0)
+	steering, globErr := detect.Glob(scope.Root, ".kiro/steering/*.md")
+	if globErr != nil {
+		return nil, fmt.Errorf("glob kiro steering: %w", globErr)
+	}
+	for _, rel := range steering {
+		frontmatter, parseErr := parseSteeringFrontmatter(scope.Root, rel)
+		if parseErr != nil {
+			findings = append(findings, parseErrorFinding(scope, rel, parseErr))
+			continue
+		}
+		findings = append(findings, baseFinding(scope, rel, nil,
+			model.Evidence{Key: "note", Value: "kiro steering file discovered"},
+			model.Evidence{Key: "inclusion", Value: fallback(strings.ToLower(strings.TrimSpace(frontmatter.Inclusion)), "always")},
+			model.Evidence{Key: "resolver_ref", Value: rel},
+		))
+	}
+
+	specs, globErr := detect.Glob(scope.Root, ".kiro/specs/*")
+	if globErr != nil {
+		return nil, fmt.Errorf("glob kiro specs: %w", globErr)
+	}
+	for _, rel := range specs {
+		if !detect.DirExists(scope.Root, rel) {
+			continue
+		}
+		documents, docErr := detect.Glob(scope.Root, rel+"/*.md")
+		if docErr != nil {
+			return nil, fmt.Errorf("glob kiro spec documents: %w", docErr)
+		}
+		names := make([]string, 0, len(documents))
+		for _, document := range documents {
+			names = append(names, path.Base(document))
+		}
+		sort.Strings(names)
+		findings = append(findings, baseFinding(scope, rel, nil,
+			model.Evidence{Key: "note", Value: "kiro spec discovered"},
+			model.Evidence{Key: "spec_documents", Value: strings.Join(names, ",")},
+			model.Evidence{Key: "resolver_ref", Value: rel},
+		))
+	}
+
+	hooks, globErr := detect.Glob(scope.Root, ".kiro/hooks/*")
+	if globErr != nil {
+		return nil, fmt.Errorf("glob kiro hooks: %w", globErr)
+	}
+	for _, rel := range hooks {
+		if detect.DirExists(scope.Root, rel) {
+			continue
+		}
+		findings = append(findings, parseHook(scope, rel))
+	}
+
+	if exists, parseErr := detect.FileExistsWithinRoot(detectorID, scope.Root, mcpConfigPath); parseErr != nil {
+		findings = append(findings, parseErrorFinding(scope, mcpConfigPath, parseErr))
+	} else if exists {
+		findings = append(findings, parseMCPConfig(scope, mcpConfigPath))
+	}
+
+	model.SortFindings(findings)
+	return findings, nil
+}
+
+// parseHook models agent hooks like .claude/hooks: enabled runCommand hooks
+// are an exec surface, and hooks fired by file events run without a user
+// prompt.
+func parseHook(scope detect.Scope, rel string) model.Finding {
+	var parsed hookFile
+	if parseErr := detect.ParseJSONFileAllowUnknownFields(detectorID, scope.Root, rel, &parsed); parseErr != nil {
+		return parseErrorFinding(scope, rel, parseErr)
+	}
+	trigger := strings.TrimSpace(parsed.When.Type)
+	action := strings.TrimSpace(parsed.Then.Type)
+	enabled := parsed.Enabled == nil || *parsed.Enabled
+	command := strings.TrimSpace(parsed.Then.Command)
+	fileEvent := strings.HasPrefix(strings.ToLower(trigger), "file")
+	// File-event hooks fire on save without a person starting the run.
+	signals := autonomy.Signals{Tool: detectorID, Headless: fileEvent && enabled}
+	evidence := []model.Evidence{
+		{Key: "note", Value: "kiro agent hook discovered"},
+		{Key: "hook_name", Value: strings.TrimSpace(parsed.Name)},
+		{Key: "trigger", Value: fallback(trigger, "unspecified")},
+		{Key: "action", Value: fallback(action, "unspecified")},
+		{Key: "command", Value: command},
+		{Key: "file_patterns", Value: strings.Join(parsed.When.Patterns, ",")},
+		{Key: "runs_on_file_event", Value: fmt.Sprintf("%t", fileEvent)},
+		{Key: "enabled", Value: fmt.Sprintf("%t", enabled)},
+		{Key: "headless", Value: fmt.Sprintf("%t", signals.Headless)},
+		{Key: "approval_gate", Value: "false"},
+		{Key: "resolver_ref", Value: rel},
+	}
+	// Only an enabled runCommand hook executes a shell command; askAgent hooks
+	// send a prompt to the agent, and disabled hooks do not run. An enabled
+	// askAgent hook on a file event still runs the agent unattended, so it
+	// carries the same review requirement without proc.exec.
+	var permissions []string
+	switch {
+	case enabled && strings.EqualFold(action, "runCommand") && command != "":
+		permissions = []string{"proc.exec"}
+		evidence = append(evidence, model.Evidence{Key: "validation_requirement", Value: "review_hook_execution"})
+	case enabled && fileEvent && strings.EqualFold(action, "askAgent"):
+		evidence = append(evidence, model.Evidence{Key: "validation_requirement", Value: "review_hook_execution"})
+	}
+	finding := baseFinding(scope, rel, permissions, evidence...)
+	finding.Autonomy = autonomy.Classify(signals)
+	if finding.Autonomy == autonomy.LevelHeadlessAuto {
+		finding.Severity = model.SeverityMedium
+		finding.Remediation = "Trigger Kiro hooks manually or review what file-event hooks let the agent do without confirmation."
+	}
+	return finding
+}
+
+// parseMCPConfig reports auto-approved MCP tools. Auto-approving a write or
+// exec tool is classified as headless-equivalent autonomy.
+func parseMCPConfig(scope detect.Scope, rel string) model.Finding {
+	var parsed mcpConfig
+	if parseErr := detect.ParseJSONFileAllowUnknownFields(detectorID, scope.Root, rel, &parsed); parseErr != nil {
+		return parseErrorFinding(scope, rel, parseErr)
+	}
+	names := make([]string, 0, len(parsed.MCPServers))
+	for name := range parsed.MCPServers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	enabled := 0
+	approved := make([]string, 0)
+	approvedWrite := make([]string, 0)
+	for _, name := range names {
+		server := parsed.MCPServers[name]
+		if server.Disabled {
+			continue
+		}
+		enabled++
+		tools := append([]string(nil), server.AutoApprove...)
+		sort.Strings(tools)
+		for _, tool := range tools {
+			tool = strings.TrimSpace(tool)
+			if tool == "" {
+				continue
+			}
+			ref := name + ":" + tool
+			approved = append(approved, ref)
+			if tool == "*" || autonomy.IsWriteOrExecTool(tool) {
+				approvedWrite = append(approvedWrite, ref)
+			}
+		}
+	}
+
+	signals := autonomy.Signals{Tool: detectorID, AutoApprovedWrite: len(approvedWrite) > 0}
+	var permissions []string
+	if enabled > 0 {
+		permissions = []string{"mcp.access"}
+	}
+	finding := baseFinding(scope, rel, permissions,
+		model.Evidence{Key: "mcp_server_count", Value: fmt.Sprintf("%d", len(parsed.MCPServers))},
+		model.Evidence{Key: "mcp_enabled_server_count", Value: fmt.Sprintf("%d", enabled)},
+		model.Evidence{Key: "auto_approved_tools", Value: strings.Join(approved, ",")},
+		model.Evidence{Key: "auto_approved_write_tools", Value: strings.Join(approvedWrite, ",")},
+		model.Evidence{Key: "headless", Value: "false"},
+		model.Evidence{Key: "approval_gate", Value: fmt.Sprintf("%t", !signals.AutoApprovedWrite)},
+		model.Evidence{Key: "resolver_ref", Value: rel},
+	)
+	finding.Autonomy = autonomy.Classify(signals)
+	if signals.AutoApprovedWrite {
+		finding.Severity = model.SeverityMedium
+		finding.Remediation = "Remove write and exec tools from Kiro MCP autoApprove lists so each call needs review."
+	}
+	return finding
+}
+
+func parseSteeringFrontmatter(root, rel string) (steeringFrontmatter, *model.ParseError) {
+	var out steeringFrontmatter
+	if _, parseErr := detect.ParseYAMLFrontmatter(detectorID, root, rel, &out); parseErr != nil {
+		return steeringFrontmatter{}, parseErr
+	}
+	return out, nil
+}
+
+func baseFinding(scope detect.Scope, location string, permissions []string, extra ...model.Evidence) model.Finding {
+	evidence := []model.Evidence{{Key: "delivery_harness", Value: "kiro"}}
+	evidence = append(evidence, extra...)
+	return model.Finding{
+		FindingType: "tool_config",
+		Severity:    model.SeverityLow,
+		ToolType:    "kiro",
+		Location:    location,
+		Repo:        scope.Repo,
+		Org:         fallbackOrg(scope.Org),
+		Detector:    detectorID,
+		Permissions: permissions,
+		Evidence:    evidence,
+	}
+}
+
+func parseErrorFinding(scope detect.Scope, location string, parseErr *model.ParseError) model.Finding {
+	parseErr.Detector = detectorID
+	return model.Finding{
+		FindingType: "parse_error",
+		Severity:    model.SeverityMedium,
+		ToolType:    "kiro",
+		Location:    location,
+		Repo:        scope.Repo,
+		Org:         fallbackOrg(scope.Org),
+		Detector:    detectorID,
+		ParseError:  parseErr,
+		Remediation: "Fix malformed Kiro configuration so deterministic parsing can proceed.",
+	}
+}
+
+func fallback(value, fallbackValue string) string {
+	if strings.TrimSpace(value) == "" {
+		return fallbackValue
+	}
+	return value
+}
+
+func fallbackOrg(org string) string {
+	if strings.TrimSpace(org) == "" {
+		return "local"
+	}
+	return org
+}
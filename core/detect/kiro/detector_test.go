@@ -0,0 +1,178 @@
+package kiro
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/Clyra-AI/wrkr/core/detect"
+	"github.com/Clyra-AI/wrkr/core/model"
+	"github.com/Clyra-AI/wrkr/core/risk/autonomy"
+)
+
+func TestKiroDetectorFindsSteeringSpecsAndHooks(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeKiroFile(t, root, ".kiro/steering/tech.md", "---\ninclusion: fileMatch\nfileMatchPattern: \"**/*.go\"\n---\nUse Go 1.26.\n")
+	writeKiroFile(t, root, ".kiro/steering/product.md", "# Product\n")
+	writeKiroFile(t, root, ".kiro/specs/billing/requirements.md", "# Requirements\n")
+	writeKiroFile(t, root, ".kiro/specs/billing/tasks.md", "# Tasks\n")
+	writeKiroFile(t, root, ".kiro/hooks/lint-on-save.kiro.hook", `{
+  "enabled": true,
+  "name": "Lint on save",
+  "when": {"type": "fileEdited", "patterns": ["**/*.go"]},
+  "then": {"type": "runCommand", "command": "golangci-lint run"}
+}`)
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Root: root, Repo: "repo", Org: "local"}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect: %v", err)
+	}
+	byLocation := map[string]model.Finding{}
+	for _, finding := range findings {
+		if finding.FindingType != "tool_config" || finding.ToolType != "kiro" {
+			t.Fatalf("expected kiro tool_config findings, got %+v", finding)
+		}
+		byLocation[finding.Location] = finding
+	}
+	if len(byLocation) != 4 {
+		t.Fatalf("expected four kiro findings, got %+v", findings)
+	}
+	if got := evidenceValue(byLocation[".kiro/steering/tech.md"], "inclusion"); got != "filematch" {
+		t.Fatalf("expected fileMatch inclusion, got %q", got)
+	}
+	if got := evidenceValue(byLocation[".kiro/steering/product.md"], "inclusion"); got != "always" {
+		t.Fatalf("expected default always inclusion, got %q", got)
+	}
+	if got := evidenceValue(byLocation[".kiro/specs/billing"], "spec_documents"); got != "requirements.md,tasks.md" {
+		t.Fatalf("unexpected spec documents %q", got)
+	}
+	hook := byLocation[".kiro/hooks/lint-on-save.kiro.hook"]
+	if !reflect.DeepEqual(hook.Permissions, []string{"proc.exec"}) {
+		t.Fatalf("expected hook to be an exec surface, got %v", hook.Permissions)
+	}
+	for key, want := range map[string]string{
+		"trigger":                "fileEdited",
+		"action":                 "runCommand",
+		"command":                "golangci-lint run",
+		"runs_on_file_event":     "true",
+		"validation_requirement": "review_hook_execution",
+	} {
+		if got := evidenceValue(hook, key); got != want {
+			t.Fatalf("expected %s=%q, got %q", key, want, got)
+		}
+	}
+}
+
+func TestKiroDetectorGrantsExecOnlyToEnabledCommandHooks(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeKiroFile(t, root, ".kiro/hooks/review.kiro.hook", `{
+  "name": "Review on save",
+  "when": {"type": "fileEdited", "patterns": ["**/*.ts"]},
+  "then": {"type": "askAgent", "prompt": "Review the edited file."}
+}`)
+	writeKiroFile(t, root, ".kiro/hooks/disabled.kiro.hook", `{
+  "enabled": false,
+  "name": "Format on save",
+  "when": {"type": "fileEdited", "patterns": ["**/*.ts"]},
+  "then": {"type": "runCommand", "command": "npx prettier --write ."}
+}`)
+	writeKiroFile(t, root, ".kiro/hooks/manual.kiro.hook", `{
+  "name": "Summarize",
+  "when": {"type": "userTriggered"},
+  "then": {"type": "askAgent", "prompt": "Summarize the branch."}
+}`)
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Root: root, Repo: "repo", Org: "local"}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect: %v", err)
+	}
+	if len(findings) != 3 {
+		t.Fatalf("expected three hook findings, got %+v", findings)
+	}
+	want := map[string]struct {
+		autonomy string
+		review   string
+	}{
+		".kiro/hooks/review.kiro.hook":   {autonomy: autonomy.LevelHeadlessAuto, review: "review_hook_execution"},
+		".kiro/hooks/disabled.kiro.hook": {autonomy: autonomy.LevelInteractive},
+		".kiro/hooks/manual.kiro.hook":   {autonomy: autonomy.LevelInteractive},
+	}
+	for _, finding := range findings {
+		if len(finding.Permissions) != 0 {
+			t.Fatalf("expected %s to grant no permissions, got %v", finding.Location, finding.Permissions)
+		}
+		expected, ok := want[finding.Location]
+		if !ok {
+			t.Fatalf("unexpected finding %s", finding.Location)
+		}
+		if finding.Autonomy != expected.autonomy {
+			t.Fatalf("expected %s autonomy %q, got %q", finding.Location, expected.autonomy, finding.Autonomy)
+		}
+		if got := evidenceValue(finding, "validation_requirement"); got != expected.review {
+			t.Fatalf("expected %s review requirement %q, got %q", finding.Location, expected.review, got)
+		}
+	}
+}
+
+func TestKiroDetectorAutoApprovedMCPToolsRaiseAutonomy(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeKiroFile(t, root, ".kiro/settings/mcp.json", `{"mcpServers":{"fetch":{"command":"uvx","args":["mcp-server-fetch"],"autoApprove":["fetch"]},"git":{"command":"uvx","args":["mcp-server-git"],"autoApprove":["git_commit"]}}}`)
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Root: root, Repo: "repo", Org: "local"}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect: %v", err)
+	}
+	if len(findings) != 1 {
+		t.Fatalf("expected one mcp settings finding, got %+v", findings)
+	}
+	finding := findings[0]
+	if finding.Autonomy != autonomy.LevelHeadlessAuto || finding.Severity != model.SeverityMedium {
+		t.Fatalf("expected auto-approved git_commit to classify as headless_auto, got %+v", finding)
+	}
+	if got := evidenceValue(finding, "auto_approved_write_tools"); got != "git:git_commit" {
+		t.Fatalf("unexpected auto-approved write tools %q", got)
+	}
+}
+
+func TestKiroDetectorReportsMalformedHook(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeKiroFile(t, root, ".kiro/hooks/broken.kiro.hook", `{"when":`)
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Root: root, Repo: "repo", Org: "local"}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect: %v", err)
+	}
+	if len(findings) != 1 || findings[0].FindingType != "parse_error" || findings[0].ParseError == nil || findings[0].ParseError.Detector != detectorID {
+		t.Fatalf("expected kiro parse_error, got %+v", findings)
+	}
+}
+
+func evidenceValue(finding model.Finding, key string) string {
+	for _, item := range finding.Evidence {
+		if item.Key == key {
+			return item.Value
+		}
+	}
+	return ""
+}
+
+func writeKiroFile(t *testing.T, root, rel, content string) {
+	t.Helper()
+	path := filepath.Join(root, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir %s: %v", rel, err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write %s: %v", rel, err)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -46,8 +47,13 @@ type serverDef struct {
 	Command          string            `json:"command" yaml:"command" toml:"command"`
 	Args             []string          `json:"args" yaml:"args" toml:"args"`
 	URL              string            `json:"url" yaml:"url" toml:"url"`
+	HTTPURL          string            `json:"httpUrl" yaml:"httpUrl" toml:"http_url"`
+	ServerURL        string            `json:"serverUrl" yaml:"serverUrl" toml:"server_url"`
 	Description      string            `json:"description" yaml:"description" toml:"description"`
 	Transport        string            `json:"transport" yaml:"transport" toml:"transport"`
+	Type             string            `json:"type" yaml:"-" toml:"-"`
+	Headers          map[string]string `json:"headers" yaml:"-" toml:"http_headers"`
+	EnvFile          string            `json:"envFile" yaml:"-" toml:"-"`
 	Auth             string            `json:"auth" yaml:"auth" toml:"auth"`
 	AuthStrength     string            `json:"auth_strength" yaml:"auth_strength" toml:"auth_strength"`
 	Delegation       string            `json:"delegation" yaml:"delegation" toml:"delegation"`
@@ -59,15 +65,114 @@ type serverDef struct {
 	PrivilegeSurface []string          `json:"privilegeSurface" yaml:"privilegeSurface" toml:"privilege_surface"`
 	Access           string            `json:"access" yaml:"access" toml:"access"`
 	Mode             string            `json:"mode" yaml:"mode" toml:"mode"`
+	AutoApprove      []string          `json:"autoApprove" yaml:"autoApprove" toml:"auto_approve"`
+	AlwaysAllow      []string          `json:"alwaysAllow" yaml:"alwaysAllow" toml:"always_allow"`
+	// Codex forwards named environment variables and reads bearer tokens and
+	// HTTP headers from the environment instead of inlining values.
+	EnvVars           []string          `json:"-" yaml:"-" toml:"env_vars"`
+	BearerTokenEnvVar string            `json:"-" yaml:"-" toml:"bearer_token_env_var"`
+	EnvHTTPHeaders    map[string]string `json:"-" yaml:"-" toml:"env_http_headers"`
+	StartupTimeoutSec float64           `json:"-" yaml:"-" toml:"startup_timeout_sec"`
+	ToolTimeoutSec    float64           `json:"-" yaml:"-" toml:"tool_timeout_sec"`
 }
 
 type mcpDoc struct {
 	MCPServers map[string]serverDef `json:"mcpServers" yaml:"mcpServers" toml:"mcp_servers"`
+	// Servers is the native VS Code `servers` map.
+	Servers map[string]serverDef `json:"servers" yaml:"-" toml:"-"`
+	// Inputs declares the VS Code `${input:<id>}` prompts servers may read.
+	Inputs []vscodeInput `json:"inputs" yaml:"-" toml:"-"`
+}
+
+// continueServerDef is a Continue config.yaml or mcpServers block entry.
+// Continue declares servers as a named list and the transport as `type`.
+type continueServerDef struct {
+	serverDef `yaml:",inline"`
+	Name      string `yaml:"name"`
+	Type      string `yaml:"type"`
+}
+
+type continueDoc struct {
+	MCPServers []continueServerDef `yaml:"mcpServers"`
 }
 
 var pinRE = regexp.MustCompile(`@[0-9]+`)
 var packageRE = regexp.MustCompile(`(@[A-Za-z0-9._-]+/[A-Za-z0-9._-]+|[A-Za-z0-9._-]+)(?:@([A-Za-z0-9._-]+))?`)
 
+// staticConfigPaths are the fixed MCP declaration paths read by coding
+// assistants and MCP clients.
+var staticConfigPaths = []string{
+	".mcp.json",
+	".cursor/mcp.json",
+	".vscode/mcp.json",
+	"mcp.json",
+	"managed-mcp.json",
+	".claude/settings.json",
+	".claude/settings.local.json",
+	".codex/config.toml",
+	".codex/config.yaml",
+	".gemini/settings.json",
+	".codeium/windsurf/mcp_config.json",
+	".continue/config.yaml",
+	".amazonq/mcp.json",
+	".aws/amazonq/mcp.json",
+	".kiro/settings/mcp.json",
+	"cline_mcp_settings.json",
+	".config/Code/User/globalStorage/saoudrizwan.claude-dev/settings/cline_mcp_settings.json",
+	"Library/Application Support/Code/User/globalStorage/saoudrizwan.claude-dev/settings/cline_mcp_settings.json",
+	"AppData/Roaming/Code/User/globalStorage/saoudrizwan.claude-dev/settings/cline_mcp_settings.json",
+}
+
+// ConfigPaths returns the MCP declaration paths the detector reads under
+// root: the fixed client paths, Continue blocks, VS Code settings and
+// workspaces, Spring AI and Mastra sources, and Claude Code plugin configs.
+func ConfigPaths(root string) ([]string, error) {
+	paths := append([]string(nil), staticConfigPaths...)
+	continueBlocks, err := detect.Glob(root, ".continue/mcpServers/*.yaml")
+	if err != nil {
+		return nil, fmt.Errorf("glob continue mcp blocks: %w", err)
+	}
+	paths = append(paths, continueBlocks...)
+	workspaces, err := detect.Glob(root, "*.code-workspace")
+	if err != nil {
+		return nil, fmt.Errorf("glob code workspaces: %w", err)
+	}
+	paths = append(paths, ".vscode/settings.json")
+	paths = append(paths, workspaces...)
+	paths = append(paths, SpringMCPConfigPaths(root)...)
+	paths = append(paths, MastraMCPConfigPaths(root)...)
+	// Claude Code plugins install MCP servers from plugin.json or the plugin's
+	// own .mcp.json; the repository-root .mcp.json is already listed.
+	for _, rel := range detect.ClaudePluginMCPConfigPaths(root) {
+		if rel != ".mcp.json" {
+			paths = append(paths, rel)
+		}
+	}
+	return paths, nil
+}
+
+// IsConfigPath returns whether rel is an MCP declaration path the detector
+// reads, without consulting the filesystem.
+func IsConfigPath(rel string) bool {
+	trimmed := strings.TrimSpace(filepath.ToSlash(rel))
+	for _, candidate := range staticConfigPaths {
+		if trimmed == candidate {
+			return true
+		}
+	}
+	// Continue MCP blocks are one YAML file per server set, Claude Code
+	// plugins declare servers in plugin.json or their own .mcp.json, VS
+	// Code workspace files embed an mcp settings block, and Spring AI and
+	// Mastra declare client connections in application config and source.
+	return trimmed == ".vscode/settings.json" ||
+		strings.HasPrefix(trimmed, ".continue/mcpServers/") ||
+		strings.HasSuffix(trimmed, ".code-workspace") ||
+		strings.HasSuffix(trimmed, ".claude-plugin/plugin.json") ||
+		strings.HasSuffix(trimmed, "/.mcp.json") ||
+		detect.IsSpringApplicationConfigPath(trimmed) ||
+		isMastraSourcePath(trimmed)
+}
+
 func (d *Detector) Detect(ctx context.Context, scope detect.Scope, options detect.Options) ([]model.Finding, error) {
 	if err := detect.ValidateScopeRoot(scope.Root); err != nil {
 		return nil, err
@@ -86,16 +191,9 @@ func (d *Detector) Detect(ctx context.Context, scope detect.Scope, options detec
 	findings := make([]model.Finding, 0)
 	receipt := detect.SurfaceCoverage{Surface: "mcp_server", Org: scope.Org, Repo: scope.Repo, Detector: detectorID, ParserVersion: "2"}
 	processedLocations := map[string]struct{}{}
-	paths := []string{
-		".mcp.json",
-		".cursor/mcp.json",
-		".vscode/mcp.json",
-		"mcp.json",
-		"managed-mcp.json",
-		".claude/settings.json",
-		".claude/settings.local.json",
-		".codex/config.toml",
-		".codex/config.yaml",
+	paths, err := ConfigPaths(scope.Root)
+	if err != nil {
+		return nil, err
 	}
 	for _, rel := range paths {
 		exists, fileErr := detect.FileExistsWithinRoot(detectorID, scope.Root, rel)
@@ -164,6 +262,7 @@ func (d *Detector) Detect(ctx context.Context, scope detect.Scope, options detec
 			} else if trustDepthRequiresMediumSeverity(trustDepth) && severity == model.SeverityLow {
 				severity = model.SeverityMedium
 			}
+			credentialInputs, undeclaredInputs := inputRefs(server, doc.Inputs)
 			evidence := []model.Evidence{
 				{Key: "server", Value: name},
 				{Key: "server_description", Value: fallbackValue(server.Description, "")},
@@ -171,13 +270,28 @@ func (d *Detector) Detect(ctx context.Context, scope detect.Scope, options detec
 				{Key: "pinned", Value: fmt.Sprintf("%t", pinned)},
 				{Key: "lockfile", Value: fmt.Sprintf("%t", lockfilePresent)},
 				{Key: "credential_refs", Value: fmt.Sprintf("%d", credentialRefs)},
+				{Key: "credential_env_refs", Value: strings.Join(credentialEnvRefs(server), ",")},
+				{Key: "credential_input_refs", Value: strings.Join(credentialInputs, ",")},
 				{Key: "trust_score", Value: fmt.Sprintf("%.1f", trustScore)},
 				{Key: "declared_action_surface", Value: fallbackValue(strings.Join(actionSurface, ","), "unknown")},
+				{Key: "auto_approved_tools", Value: fmt.Sprintf("%d", len(server.AutoApprove)+len(server.AlwaysAllow))},
 				{Key: "package", Value: fallbackValue(pkg, "unknown")},
 				{Key: "version", Value: fallbackValue(version, "unknown")},
 				{Key: "version_source", Value: fallbackValue(versionSource, "unknown")},
 				{Key: "config_source", Value: rel},
 			}
+			if len(undeclaredInputs) > 0 {
+				evidence = append(evidence, model.Evidence{Key: "undeclared_input_refs", Value: strings.Join(undeclaredInputs, ",")})
+			}
+			if envFile := strings.TrimSpace(server.EnvFile); envFile != "" {
+				evidence = append(evidence, model.Evidence{Key: "env_file", Value: envFile})
+			}
+			if server.StartupTimeoutSec > 0 {
+				evidence = append(evidence, model.Evidence{Key: "startup_timeout_sec", Value: strconv.FormatFloat(server.StartupTimeoutSec, 'f', -1, 64)})
+			}
+			if server.ToolTimeoutSec > 0 {
+				evidence = append(evidence, model.Evidence{Key: "tool_timeout_sec", Value: strconv.FormatFloat(server.ToolTimeoutSec, 'f', -1, 64)})
+			}
 			evidence = append(evidence, trustDepthEvidence(trustDepth)...)
 			endpointSemantics := mutableendpoint.Classify("", name, fallbackValue(server.Description, ""), name, "mcp", "medium")
 			for _, encoded := range mutableendpoint.EncodeEvidenceValues(endpointSemantics) {
@@ -251,26 +365,87 @@ func (d *Detector) Detect(ctx context.Context, scope detect.Scope, options detec
 }
 
 func parseMCPDocument(root, rel string) (mcpDoc, *model.ParseError) {
+	if strings.HasPrefix(filepath.ToSlash(rel), ".continue/") {
+		return parseContinueMCPDocument(root, rel)
+	}
 	var parsed mcpDoc
-	switch strings.ToLower(filepath.Ext(rel)) {
-	case ".json":
+	switch {
+	case detect.IsSpringApplicationConfigPath(rel):
+		return parseSpringMCPDocument(root, rel)
+	case isMastraSourcePath(rel):
+		return parseMastraMCPDocument(root, rel)
+	case isVSCodePath(rel):
+		doc, parseErr := parseVSCodeMCPDocument(root, rel)
+		if parseErr != nil {
+			return mcpDoc{}, parseErr
+		}
+		parsed = doc
+	case strings.EqualFold(filepath.Ext(rel), ".json"):
 		if parseErr := detect.ParseJSONFileAllowUnknownFields(detectorID, root, rel, &parsed); parseErr != nil {
 			return mcpDoc{}, parseErr
 		}
-	case ".toml":
+	case strings.EqualFold(filepath.Ext(rel), ".toml"):
 		if parseErr := detect.ParseTOMLFileAllowUnknownFields(detectorID, root, rel, &parsed); parseErr != nil {
 			return mcpDoc{}, parseErr
 		}
-	case ".yaml", ".yml":
+	case strings.EqualFold(filepath.Ext(rel), ".yaml"), strings.EqualFold(filepath.Ext(rel), ".yml"):
 		if parseErr := detect.ParseYAMLFileAllowUnknownFields(detectorID, root, rel, &parsed); parseErr != nil {
 			return mcpDoc{}, parseErr
 		}
 	default:
 		return mcpDoc{}, nil
 	}
+	for name, server := range parsed.Servers {
+		if parsed.MCPServers == nil {
+			parsed.MCPServers = map[string]serverDef{}
+		}
+		if _, ok := parsed.MCPServers[name]; !ok {
+			parsed.MCPServers[name] = server
+		}
+	}
+	parsed.Servers = nil
+	for name, server := range parsed.MCPServers {
+		if strings.TrimSpace(server.Transport) == "" && strings.TrimSpace(server.Type) != "" {
+			// VS Code and Claude Code name the transport with `type`.
+			server.Transport = strings.ToLower(strings.TrimSpace(server.Type))
+		}
+		switch {
+		case strings.TrimSpace(server.URL) != "":
+		case strings.TrimSpace(server.HTTPURL) != "":
+			// Gemini CLI declares streamable HTTP servers with httpUrl.
+			server.URL = server.HTTPURL
+			if strings.TrimSpace(server.Transport) == "" {
+				server.Transport = "streamable_http"
+			}
+		case strings.TrimSpace(server.ServerURL) != "":
+			// Windsurf declares remote servers with serverUrl.
+			server.URL = server.ServerURL
+		}
+		parsed.MCPServers[name] = server
+	}
 	return parsed, nil
 }
 
+func parseContinueMCPDocument(root, rel string) (mcpDoc, *model.ParseError) {
+	var parsed continueDoc
+	if parseErr := detect.ParseYAMLFileAllowUnknownFields(detectorID, root, rel, &parsed); parseErr != nil {
+		return mcpDoc{}, parseErr
+	}
+	doc := mcpDoc{MCPServers: map[string]serverDef{}}
+	for index, entry := range parsed.MCPServers {
+		name := strings.TrimSpace(entry.Name)
+		if name == "" {
+			name = fmt.Sprintf("server-%d", index+1)
+		}
+		server := entry.serverDef
+		if strings.TrimSpace(server.Transport) == "" {
+			server.Transport = strings.ReplaceAll(strings.ToLower(strings.TrimSpace(entry.Type)), "-", "_")
+		}
+		doc.MCPServers[name] = server
+	}
+	return doc, nil
+}
+
 func inferTransport(server serverDef) string {
 	if strings.TrimSpace(server.Transport) != "" {
 		return strings.ToLower(strings.TrimSpace(server.Transport))
@@ -301,7 +476,54 @@ func countCredentialRefs(server serverDef) int {
 			count++
 		}
 	}
-	return count
+	for _, name := range server.EnvVars {
+		if containsCredentialRef(name) {
+			count++
+		}
+	}
+	for _, value := range server.Headers {
+		if containsCredentialRef(value) {
+			count++
+		}
+	}
+	if strings.TrimSpace(server.BearerTokenEnvVar) != "" {
+		count++
+	}
+	if strings.TrimSpace(server.EnvFile) != "" {
+		count++
+	}
+	return count + len(server.EnvHTTPHeaders)
+}
+
+// credentialEnvRefs names the environment variables that carry credentials
+// into a server. Only names are reported; values are never read.
+func credentialEnvRefs(server serverDef) []string {
+	refs := make([]string, 0)
+	for key, value := range server.Env {
+		if containsCredentialRef(key) || containsCredentialRef(value) {
+			refs = append(refs, strings.TrimSpace(key))
+		}
+	}
+	for _, name := range server.EnvVars {
+		if containsCredentialRef(name) {
+			refs = append(refs, strings.TrimSpace(name))
+		}
+	}
+	refs = append(refs, strings.TrimSpace(server.BearerTokenEnvVar))
+	for _, name := range server.EnvHTTPHeaders {
+		refs = append(refs, strings.TrimSpace(name))
+	}
+	set := map[string]struct{}{}
+	out := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		if _, seen := set[ref]; seen || ref == "" {
+			continue
+		}
+		set[ref] = struct{}{}
+		out = append(out, ref)
+	}
+	sort.Strings(out)
+	return out
 }
 
 func containsCredentialRef(in string) bool {
@@ -410,6 +632,10 @@ func deriveDeclaredActionSurface(server serverDef) []string {
 	addActionSurfaceTokens(set, server.Permissions)
 	addActionSurfaceTokens(set, server.PrivilegeSurface)
 	addActionSurfaceTokens(set, []string{server.Access, server.Mode})
+	// Cline autoApprove and Roo Code alwaysAllow name tools that run without
+	// a per-call prompt, so they are part of the declared surface.
+	addActionSurfaceTokens(set, server.AutoApprove)
+	addActionSurfaceTokens(set, server.AlwaysAllow)
 	if len(set) == 0 {
 		return nil
 	}
@@ -444,3 +444,539 @@ func evidenceValue(finding model.Finding, key string) string {
 	}
 	return ""
 }
+
+func TestDetectMCPReadsGeminiSettingsServers(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, ".gemini"), 0o755); err != nil {
+		t.Fatalf("mkdir .gemini: %v", err)
+	}
+	payload := []byte(`{
+  "autoAccept": true,
+  "mcpServers": {
+    "remote": {"httpUrl": "https://mcp.example.com/mcp", "trust": true},
+    "local": {"command": "npx", "args": ["-y", "@modelcontextprotocol/server-filesystem@1.0.0"]}
+  }
+}`)
+	if err := os.WriteFile(filepath.Join(root, ".gemini", "settings.json"), payload, 0o600); err != nil {
+		t.Fatalf("write gemini settings: %v", err)
+	}
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Org: "local", Repo: "repo", Root: root}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect mcp: %v", err)
+	}
+	byServer := map[string]model.Finding{}
+	for _, finding := range findings {
+		byServer[evidenceValueForServer(finding)] = finding
+	}
+	remote, ok := byServer["remote"]
+	if !ok || remote.Location != ".gemini/settings.json" {
+		t.Fatalf("expected remote gemini MCP server, got %+v", findings)
+	}
+	if transport := evidenceValue(remote, "transport"); transport != "streamable_http" {
+		t.Fatalf("expected httpUrl to map to streamable_http, got %q", transport)
+	}
+	if exposure := evidenceValue(remote, "exposure"); exposure != "public" {
+		t.Fatalf("expected public exposure for remote gemini server, got %q", exposure)
+	}
+	if transport := evidenceValue(byServer["local"], "transport"); transport != "stdio" {
+		t.Fatalf("expected stdio local gemini server, got %q", transport)
+	}
+}
+
+func TestDetectMCPReadsWindsurfServerURL(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, ".codeium", "windsurf"), 0o755); err != nil {
+		t.Fatalf("mkdir windsurf: %v", err)
+	}
+	payload := []byte(`{"mcpServers":{"remote":{"serverUrl":"https://mcp.example.com/sse"}}}`)
+	if err := os.WriteFile(filepath.Join(root, ".codeium", "windsurf", "mcp_config.json"), payload, 0o600); err != nil {
+		t.Fatalf("write windsurf mcp config: %v", err)
+	}
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Org: "local", Repo: "local-machine", Root: root, TargetMode: "my_setup"}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect mcp: %v", err)
+	}
+	if len(findings) != 1 || findings[0].Location != ".codeium/windsurf/mcp_config.json" {
+		t.Fatalf("expected one windsurf MCP server finding, got %+v", findings)
+	}
+	if transport := evidenceValue(findings[0], "transport"); transport != "http" {
+		t.Fatalf("expected serverUrl to map to http transport, got %q", transport)
+	}
+	if coverage := evidenceValue(findings[0], "gateway_coverage"); coverage == "" {
+		t.Fatalf("expected gateway coverage evidence, got %+v", findings[0].Evidence)
+	}
+}
+
+func TestDetectMCPReadsClineAutoApprovedTools(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	payload := []byte(`{"mcpServers":{"github":{"command":"npx","args":["-y","@modelcontextprotocol/server-filesystem@1.2.0"],"autoApprove":["write_file"],"alwaysAllow":["read_file"]}}}`)
+	if err := os.WriteFile(filepath.Join(root, "cline_mcp_settings.json"), payload, 0o600); err != nil {
+		t.Fatalf("write cline mcp settings: %v", err)
+	}
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Org: "local", Repo: "repo", Root: root}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect mcp: %v", err)
+	}
+	if len(findings) != 1 || findings[0].Location != "cline_mcp_settings.json" {
+		t.Fatalf("expected one cline MCP server finding, got %+v", findings)
+	}
+	if got := evidenceValue(findings[0], "auto_approved_tools"); got != "2" {
+		t.Fatalf("expected two auto-approved tools, got %q", got)
+	}
+	if got := evidenceValue(findings[0], "declared_action_surface"); got != "read,write" {
+		t.Fatalf("expected auto-approved tools to declare read,write surface, got %q", got)
+	}
+}
+
+func TestDetectMCPReadsContinueServerLists(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, ".continue", "mcpServers"), 0o755); err != nil {
+		t.Fatalf("mkdir continue: %v", err)
+	}
+	config := []byte("name: team\nmcpServers:\n  - name: sqlite\n    command: npx\n    args: [\"-y\", \"mcp-sqlite@1.0.0\"]\n")
+	if err := os.WriteFile(filepath.Join(root, ".continue", "config.yaml"), config, 0o600); err != nil {
+		t.Fatalf("write continue config: %v", err)
+	}
+	block := []byte("name: GitHub\nmcpServers:\n  - name: github\n    type: streamable-http\n    url: https://api.githubcopilot.com/mcp/\n")
+	if err := os.WriteFile(filepath.Join(root, ".continue", "mcpServers", "github.yaml"), block, 0o600); err != nil {
+		t.Fatalf("write continue block: %v", err)
+	}
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Org: "local", Repo: "repo", Root: root}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect mcp: %v", err)
+	}
+	transports := map[string]string{}
+	for _, finding := range findings {
+		if finding.FindingType != "mcp_server" {
+			t.Fatalf("unexpected finding %+v", finding)
+		}
+		transports[evidenceValue(finding, "server")+"@"+finding.Location] = evidenceValue(finding, "transport")
+	}
+	want := map[string]string{
+		"sqlite@.continue/config.yaml":            "stdio",
+		"github@.continue/mcpServers/github.yaml": "streamable_http",
+	}
+	if !reflect.DeepEqual(transports, want) {
+		t.Fatalf("unexpected continue servers: got %v want %v", transports, want)
+	}
+}
+
+func TestDetectMCPReadsAmazonQAndKiroConfigs(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	for rel, payload := range map[string]string{
+		".amazonq/mcp.json":       `{"mcpServers":{"aws-docs":{"command":"uvx","args":["awslabs.aws-documentation-mcp-server@1.0.0"]}}}`,
+		".aws/amazonq/mcp.json":   `{"mcpServers":{"global":{"url":"https://mcp.example.com/mcp"}}}`,
+		".kiro/settings/mcp.json": `{"mcpServers":{"git":{"command":"uvx","args":["mcp-server-git@1.0.0"],"autoApprove":["git_commit"]}}}`,
+	} {
+		path := filepath.Join(root, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("mkdir %s: %v", rel, err)
+		}
+		if err := os.WriteFile(path, []byte(payload), 0o600); err != nil {
+			t.Fatalf("write %s: %v", rel, err)
+		}
+	}
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Org: "local", Repo: "repo", Root: root}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect mcp: %v", err)
+	}
+	servers := map[string]string{}
+	for _, finding := range findings {
+		servers[finding.Location] = evidenceValue(finding, "server")
+	}
+	want := map[string]string{
+		".amazonq/mcp.json":       "aws-docs",
+		".aws/amazonq/mcp.json":   "global",
+		".kiro/settings/mcp.json": "git",
+	}
+	if !reflect.DeepEqual(servers, want) {
+		t.Fatalf("unexpected MCP servers: got %v want %v", servers, want)
+	}
+}
+
+func TestDetectMCPReadsClaudePluginServers(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	for rel, payload := range map[string]string{
+		".claude-plugin/marketplace.json":             `{"name":"acme","plugins":[{"name":"release","source":"./plugins/release"},{"name":"inline","source":"./plugins/inline"}]}`,
+		"plugins/release/.claude-plugin/plugin.json":  `{"name":"release"}`,
+		"plugins/release/.mcp.json":                   `{"mcpServers":{"deployer":{"command":"npx","args":["-y","acme-deployer@1.0.0"]}}}`,
+		"plugins/inline/.claude-plugin/plugin.json":   `{"name":"inline","mcpServers":{"search":{"url":"https://search.example.com/mcp"}}}`,
+		"plugins/unlisted/.claude-plugin/plugin.json": `{"name":"unlisted","mcpServers":{"ignored":{"command":"npx"}}}`,
+	} {
+		path := filepath.Join(root, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("mkdir %s: %v", rel, err)
+		}
+		if err := os.WriteFile(path, []byte(payload), 0o600); err != nil {
+			t.Fatalf("write %s: %v", rel, err)
+		}
+	}
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Org: "local", Repo: "repo", Root: root}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect mcp: %v", err)
+	}
+	servers := map[string]string{}
+	for _, finding := range findings {
+		if finding.FindingType == "mcp_server" {
+			servers[finding.Location] = evidenceValue(finding, "server")
+		}
+	}
+	want := map[string]string{
+		"plugins/release/.mcp.json":                 "deployer",
+		"plugins/inline/.claude-plugin/plugin.json": "search",
+	}
+	if !reflect.DeepEqual(servers, want) {
+		t.Fatalf("unexpected MCP servers: got %v want %v", servers, want)
+	}
+}
+
+func TestDetectMCPReportsCodexEnvCredentialRefs(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, ".codex"), 0o755); err != nil {
+		t.Fatalf("mkdir .codex: %v", err)
+	}
+	payload := `approval_policy = "on-request"
+
+[mcp_servers.github]
+command = "npx"
+args = ["-y", "@modelcontextprotocol/server-github@1.2.0"]
+env_vars = ["GITHUB_TOKEN", "LOG_LEVEL"]
+startup_timeout_sec = 20
+tool_timeout_sec = 60.5
+
+[mcp_servers.github.env]
+GITHUB_API_URL = "https://api.github.com"
+
+[mcp_servers.linear]
+url = "https://mcp.linear.app/mcp"
+bearer_token_env_var = "LINEAR_API_KEY"
+
+[mcp_servers.linear.env_http_headers]
+X-Workspace = "LINEAR_WORKSPACE"
+`
+	if err := os.WriteFile(filepath.Join(root, ".codex", "config.toml"), []byte(payload), 0o600); err != nil {
+		t.Fatalf("write config.toml: %v", err)
+	}
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Org: "local", Repo: "repo", Root: root}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect mcp: %v", err)
+	}
+	servers := map[string]model.Finding{}
+	for _, finding := range findings {
+		if finding.FindingType == "mcp_server" {
+			servers[evidenceValue(finding, "server")] = finding
+		}
+	}
+	if len(servers) != 2 {
+		t.Fatalf("expected 2 codex MCP servers, got %+v", findings)
+	}
+	github := servers["github"]
+	if got := evidenceValue(github, "credential_env_refs"); got != "GITHUB_TOKEN" {
+		t.Fatalf("unexpected github credential env refs %q", got)
+	}
+	if got := evidenceValue(github, "tool_timeout_sec"); got != "60.5" {
+		t.Fatalf("unexpected github tool timeout %q", got)
+	}
+	if got := evidenceValue(github, "startup_timeout_sec"); got != "20" {
+		t.Fatalf("unexpected github startup timeout %q", got)
+	}
+	linear := servers["linear"]
+	if got := evidenceValue(linear, "credential_env_refs"); got != "LINEAR_API_KEY,LINEAR_WORKSPACE" {
+		t.Fatalf("unexpected linear credential env refs %q", got)
+	}
+	if got := evidenceValue(linear, "credential_refs"); got != "2" {
+		t.Fatalf("unexpected linear credential ref count %q", got)
+	}
+}
+
+func TestDetectMCPReadsNativeVSCodeSchema(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	for rel, payload := range map[string]string{
+		".vscode/mcp.json": `{
+  // Native VS Code schema with prompted credentials.
+  "inputs": [
+    {"type": "promptString", "id": "gh-pat", "password": true},
+    {"type": "promptString", "id": "project"},
+  ],
+  "servers": {
+    "github": {"type": "http", "url": "https://api.githubcopilot.com/mcp/", "headers": {"Authorization": "Bearer ${input:gh-pat}"}},
+    "local": {"type": "stdio", "command": "node", "args": ["server.js"], "envFile": "${workspaceFolder}/.env"},
+    "events": {"type": "sse", "url": "http://localhost:3000/sse?project=${input:project}&key=${input:events-key}"},
+  },
+}`,
+		".vscode/settings.json": `{"mcp": {"servers": {"docs": {"command": "uvx", "args": ["docs-mcp==1.0.0"], "env": {"DOCS_TOKEN": "${input:docs-token}"}}}}}`,
+		"team.code-workspace":   `{"settings": {"mcp": {"servers": {"tickets": {"url": "https://tickets.example.com/mcp"}}}}}`,
+	} {
+		path := filepath.Join(root, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("mkdir %s: %v", rel, err)
+		}
+		if err := os.WriteFile(path, []byte(payload), 0o600); err != nil {
+			t.Fatalf("write %s: %v", rel, err)
+		}
+	}
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Org: "local", Repo: "repo", Root: root}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect mcp: %v", err)
+	}
+	servers := map[string]model.Finding{}
+	for _, finding := range findings {
+		if finding.FindingType == "mcp_server" {
+			servers[evidenceValue(finding, "server")] = finding
+		}
+	}
+	for name, location := range map[string]string{
+		"github":  ".vscode/mcp.json",
+		"local":   ".vscode/mcp.json",
+		"events":  ".vscode/mcp.json",
+		"docs":    ".vscode/settings.json",
+		"tickets": "team.code-workspace",
+	} {
+		if servers[name].Location != location {
+			t.Fatalf("expected %s at %s, got %+v", name, location, servers)
+		}
+	}
+	if got := evidenceValue(servers["events"], "transport"); got != "sse" {
+		t.Fatalf("expected type to set the transport, got %q", got)
+	}
+	github := servers["github"]
+	if got := evidenceValue(github, "credential_input_refs"); got != "gh-pat" {
+		t.Fatalf("unexpected input refs %q", got)
+	}
+	if got := evidenceValue(github, "credential_refs"); got != "1" {
+		t.Fatalf("expected prompted header to count as a credential ref, got %q", got)
+	}
+	local := servers["local"]
+	if got := evidenceValue(local, "env_file"); got != "${workspaceFolder}/.env" {
+		t.Fatalf("unexpected env file %q", got)
+	}
+	if got := evidenceValue(local, "credential_refs"); got != "1" {
+		t.Fatalf("expected envFile to count as a credential ref, got %q", got)
+	}
+	if got := evidenceValue(servers["docs"], "credential_env_refs"); got != "DOCS_TOKEN" {
+		t.Fatalf("unexpected docs env refs %q", got)
+	}
+	events := servers["events"]
+	if got := evidenceValue(events, "credential_input_refs"); got != "" {
+		t.Fatalf("expected a non-password prompt not to be a credential input, got %q", got)
+	}
+	if got := evidenceValue(events, "undeclared_input_refs"); got != "events-key" {
+		t.Fatalf("unexpected undeclared input refs %q", got)
+	}
+	docs := servers["docs"]
+	if got := evidenceValue(docs, "undeclared_input_refs"); got != "docs-token" {
+		t.Fatalf("expected settings input without an inputs entry to be undeclared, got %q", got)
+	}
+	if got := evidenceValue(github, "undeclared_input_refs"); got != "" {
+		t.Fatalf("expected declared input not to be undeclared, got %q", got)
+	}
+}
+
+func TestDetectMCPReadsSpringAIClientConnections(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	for rel, payload := range map[string]string{
+		"src/main/resources/application.yml": `server:
+  port: 8080
+spring:
+  ai:
+    mcp:
+      client:
+        stdio:
+          servers-configuration: classpath:mcp-servers.json
+          connections:
+            github:
+              command: npx
+              args:
+                - -y
+                - "@modelcontextprotocol/server-github@2025.4.8"
+              env:
+                GITHUB_PERSONAL_ACCESS_TOKEN: ${GITHUB_TOKEN}
+        sse:
+          connections:
+            weather:
+              url: http://localhost:8081
+              sse-endpoint: /mcp/sse
+---
+spring:
+  config:
+    activate:
+      on-profile: prod
+  ai:
+    mcp:
+      client:
+        streamableHttp:
+          connections:
+            tickets:
+              url: https://tickets.example.com
+              endpoint: /mcp
+`,
+		"src/main/resources/mcp-servers.json": `{"mcpServers": {"filesystem": {"command": "npx", "args": ["-y", "@modelcontextprotocol/server-filesystem@1.0.0", "/tmp"]}}}`,
+		"worker/src/main/resources/application-dev.properties": `# Local MCP tools
+spring.ai.mcp.client.stdio.connections.brave.command=npx
+spring.ai.mcp.client.stdio.connections.brave.args=-y,@modelcontextprotocol/server-brave-search
+spring.ai.mcp.client.stdio.connections.brave.env.BRAVE_API_KEY=${BRAVE_API_KEY}
+`,
+		"billing/src/main/resources/application.yml": "spring:\n  datasource:\n    url: jdbc:postgresql://db/billing\n",
+	} {
+		path := filepath.Join(root, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("mkdir %s: %v", rel, err)
+		}
+		if err := os.WriteFile(path, []byte(payload), 0o600); err != nil {
+			t.Fatalf("write %s: %v", rel, err)
+		}
+	}
+
+	detector := New()
+	findings, err := detector.Detect(context.Background(), detect.Scope{Org: "local", Repo: "repo", Root: root}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect mcp: %v", err)
+	}
+	servers := map[string]model.Finding{}
+	for _, finding := range findings {
+		if finding.FindingType == "mcp_server" {
+			servers[evidenceValue(finding, "server")] = finding
+		}
+		if finding.Location == "billing/src/main/resources/application.yml" {
+			t.Fatalf("expected config without MCP settings to be skipped, got %+v", finding)
+		}
+	}
+	for name, want := range map[string]struct{ location, transport string }{
+		"github":     {"src/main/resources/application.yml", "stdio"},
+		"filesystem": {"src/main/resources/application.yml", "stdio"},
+		"weather":    {"src/main/resources/application.yml", "sse"},
+		"tickets":    {"src/main/resources/application.yml", "streamable_http"},
+		"brave":      {"worker/src/main/resources/application-dev.properties", "stdio"},
+	} {
+		finding, ok := servers[name]
+		if !ok || finding.Location != want.location || evidenceValue(finding, "transport") != want.transport {
+			t.Fatalf("expected %s at %s over %s, got %+v", name, want.location, want.transport, servers)
+		}
+	}
+	if got := evidenceValue(servers["github"], "version"); got != "2025.4.8" {
+		t.Fatalf("expected pinned github server version, got %q", got)
+	}
+	if got := evidenceValue(servers["github"], "credential_env_refs"); got != "GITHUB_PERSONAL_ACCESS_TOKEN" {
+		t.Fatalf("unexpected github env refs %q", got)
+	}
+	if got := evidenceValue(servers["brave"], "credential_env_refs"); got != "BRAVE_API_KEY" {
+		t.Fatalf("unexpected brave env refs %q", got)
+	}
+	if got := evidenceValue(servers["brave"], "package"); got != "@modelcontextprotocol/server-brave-search" {
+		t.Fatalf("expected comma-separated args to bind as a list, got package %q", got)
+	}
+	if coverage := detector.SurfaceCoverage(detect.Scope{Root: root}, detect.Options{}); len(coverage) != 1 || coverage[0].Parsed != 2 {
+		t.Fatalf("expected two parsed Spring config files, got %+v", coverage)
+	}
+}
+
+func TestDetectMCPReadsMastraClientServers(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	for rel, payload := range map[string]string{
+		"src/mastra/mcp.ts": `import { MCPClient } from "@mastra/mcp";
+
+export const mcp = new MCPClient({
+  id: "ops-tools",
+  servers: {
+    github: {
+      command: "npx",
+      args: ["-y", "@modelcontextprotocol/server-github@2025.4.8"],
+      env: { GITHUB_PERSONAL_ACCESS_TOKEN: process.env.GITHUB_TOKEN ?? "" },
+    },
+    "weather-api": {
+      url: new URL("https://weather.example.com/mcp"),
+      requestInit: {
+        headers: { Authorization: ` + "`Bearer ${process.env.WEATHER_API_KEY}`" + ` },
+      },
+    },
+  },
+});
+`,
+		"src/mastra/tools/weather-tool.ts": `import { createTool } from "@mastra/core/tools";
+
+export const weatherTool = createTool({ id: "get-weather", description: "MCP-free tool" });
+`,
+	} {
+		path := filepath.Join(root, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("mkdir %s: %v", rel, err)
+		}
+		if err := os.WriteFile(path, []byte(payload), 0o600); err != nil {
+			t.Fatalf("write %s: %v", rel, err)
+		}
+	}
+
+	detector := New()
+	findings, err := detector.Detect(context.Background(), detect.Scope{Org: "local", Repo: "repo", Root: root}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect mcp: %v", err)
+	}
+	servers := map[string]model.Finding{}
+	for _, finding := range findings {
+		if finding.FindingType == "mcp_server" {
+			servers[evidenceValue(finding, "server")] = finding
+		}
+	}
+	for name, want := range map[string]string{"github": "stdio", "weather-api": "http"} {
+		finding, ok := servers[name]
+		if !ok || finding.Location != "src/mastra/mcp.ts" || evidenceValue(finding, "transport") != want {
+			t.Fatalf("expected %s in src/mastra/mcp.ts over %s, got %+v", name, want, servers)
+		}
+	}
+	if got := evidenceValue(servers["github"], "version"); got != "2025.4.8" {
+		t.Fatalf("expected pinned github server version, got %q", got)
+	}
+	if got := evidenceValue(servers["github"], "credential_env_refs"); got != "GITHUB_PERSONAL_ACCESS_TOKEN" {
+		t.Fatalf("unexpected github env refs %q", got)
+	}
+	if coverage := detector.SurfaceCoverage(detect.Scope{Root: root}, detect.Options{}); len(coverage) != 1 || coverage[0].Parsed != 1 {
+		t.Fatalf("expected one parsed Mastra source file, got %+v", coverage)
+	}
+}
+
+func TestIsConfigPathMatchesDetectorInputs(t *testing.T) {
+	t.Parallel()
+
+	for rel, want := range map[string]bool{
+		".kiro/settings/mcp.json":                   true,
+		".continue/mcpServers/github.yaml":          true,
+		"plugins/review/.claude-plugin/plugin.json": true,
+		"team.code-workspace":                       true,
+		"src/mastra/mcp.ts":                         true,
+		"src/main/resources/application.yml":        true,
+		".kiro/hooks/lint.kiro.hook":                false,
+		"docs/mcp.md":                               false,
+	} {
+		if got := IsConfigPath(rel); got != want {
+			t.Fatalf("IsConfigPath(%q) = %t, want %t", rel, got, want)
+		}
+	}
+}
@@ -0,0 +1,251 @@
+package mcp
+
+import (
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Clyra-AI/wrkr/core/detect"
+	"github.com/Clyra-AI/wrkr/core/model"
+)
+
+// mastraSourceGlobs are where Mastra projects keep agents, tools, and MCP
+// clients: `src/mastra/` in a single package or a workspace package.
+var mastraSourceGlobs = []string{
+	"src/mastra/*.*",
+	"src/mastra/*/*.*",
+	"*/src/mastra/*.*",
+	"*/src/mastra/*/*.*",
+	"*/*/src/mastra/*.*",
+	"*/*/src/mastra/*/*.*",
+}
+
+var (
+	mastraClientPattern      = regexp.MustCompile(`new\s+(?:MCPClient|MCPConfiguration)\s*\(`)
+	mastraProcessEnvPattern  = regexp.MustCompile(`^process\.env(?:\.([A-Za-z_][A-Za-z0-9_]*)|\[\s*["']([A-Za-z_][A-Za-z0-9_]*)["']\s*\])`)
+	mastraTemplateEnvPattern = regexp.MustCompile(`\$\{\s*process\.env\.([A-Za-z_][A-Za-z0-9_]*)\s*\}`)
+)
+
+// MastraMCPConfigPaths returns Mastra source files that construct an
+// `MCPClient` from `@mastra/mcp`.
+func MastraMCPConfigPaths(root string) []string {
+	seen := map[string]struct{}{}
+	out := make([]string, 0)
+	for _, pattern := range mastraSourceGlobs {
+		matches, err := detect.Glob(root, pattern)
+		if err != nil {
+			continue
+		}
+		for _, rel := range matches {
+			if _, ok := seen[rel]; ok || !isMastraSourcePath(rel) {
+				continue
+			}
+			seen[rel] = struct{}{}
+			payload, parseErr := detect.ReadFileWithinRoot(detectorID, root, rel)
+			// Unreadable files are kept so the caller reports the parse error.
+			if parseErr == nil && (!strings.Contains(string(payload), "@mastra/mcp") || !mastraClientPattern.Match(payload)) {
+				continue
+			}
+			out = append(out, rel)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+func isMastraSourcePath(rel string) bool {
+	if !strings.Contains(filepath.ToSlash(rel), "src/mastra/") {
+		return false
+	}
+	switch strings.ToLower(filepath.Ext(rel)) {
+	case ".ts", ".js", ".mts", ".mjs", ".cts", ".cjs":
+		return true
+	default:
+		return false
+	}
+}
+
+// parseMastraMCPDocument reads the `servers` map passed to
+// `new MCPClient({ servers: { name: { command, args, env } } })`. Remote
+// servers set `url`, often as `new URL(...)`, with headers under
+// `requestInit`. Environment reads become `${NAME}` references; values are
+// never resolved.
+func parseMastraMCPDocument(root, rel string) (mcpDoc, *model.ParseError) {
+	payload, parseErr := detect.ReadFileWithinRoot(detectorID, root, rel)
+	if parseErr != nil {
+		return mcpDoc{}, parseErr
+	}
+	content := string(payload)
+	doc := mcpDoc{MCPServers: map[string]serverDef{}}
+	for _, loc := range mastraClientPattern.FindAllStringIndex(content, -1) {
+		options := jsObjectEntries(strings.TrimSpace(jsBracketInner(content, loc[1]-1)))
+		for name, value := range jsObjectEntries(options["servers"]) {
+			fields := jsObjectEntries(value)
+			if len(fields) == 0 {
+				continue
+			}
+			server := serverDef{
+				Command: jsStringValue(fields["command"]),
+				URL:     jsStringValue(fields["url"]),
+			}
+			for _, arg := range jsArrayItems(fields["args"]) {
+				server.Args = append(server.Args, jsStringValue(arg))
+			}
+			if env := jsObjectEntries(fields["env"]); len(env) > 0 {
+				server.Env = map[string]string{}
+				for key, value := range env {
+					server.Env[key] = jsStringValue(value)
+				}
+			}
+			if headers := jsObjectEntries(jsObjectEntries(fields["requestInit"])["headers"]); len(headers) > 0 {
+				server.Headers = map[string]string{}
+				for key, value := range headers {
+					server.Headers[key] = jsStringValue(value)
+				}
+			}
+			doc.MCPServers[name] = server
+		}
+	}
+	return doc, nil
+}
+
+// jsObjectEntries splits a JS object literal into its top-level properties.
+// Shorthand, spread, and computed properties are skipped.
+func jsObjectEntries(literal string) map[string]string {
+	literal = strings.TrimSpace(literal)
+	if !strings.HasPrefix(literal, "{") {
+		return nil
+	}
+	entries := map[string]string{}
+	for _, item := range jsSplitTopLevel(jsBracketInner(literal, 0)) {
+		colon := jsTopLevelIndex(item, ':')
+		if colon <= 0 {
+			continue
+		}
+		key := strings.TrimSpace(item[:colon])
+		if unquoted, ok := jsQuoted(key); ok {
+			key = unquoted
+		}
+		if key == "" || strings.HasPrefix(key, "[") {
+			continue
+		}
+		entries[key] = strings.TrimSpace(item[colon+1:])
+	}
+	return entries
+}
+
+func jsArrayItems(literal string) []string {
+	literal = strings.TrimSpace(literal)
+	if !strings.HasPrefix(literal, "[") {
+		return nil
+	}
+	return jsSplitTopLevel(jsBracketInner(literal, 0))
+}
+
+// jsStringValue returns a literal string, the string passed to `new URL()`,
+// or an environment read as `${NAME}`. Template literals keep their text
+// with environment reads rewritten the same way.
+func jsStringValue(expr string) string {
+	expr = strings.TrimSpace(expr)
+	if strings.HasPrefix(expr, "new URL(") {
+		if args := jsSplitTopLevel(jsBracketInner(expr, strings.Index(expr, "("))); len(args) > 0 {
+			expr = args[0]
+		}
+	}
+	if match := mastraProcessEnvPattern.FindStringSubmatch(expr); len(match) == 3 {
+		return "${" + match[1] + match[2] + "}"
+	}
+	if value, ok := jsQuoted(expr); ok {
+		if expr[0] == '`' {
+			value = mastraTemplateEnvPattern.ReplaceAllString(value, "$${$1}")
+		}
+		return value
+	}
+	return expr
+}
+
+func jsQuoted(value string) (string, bool) {
+	if len(value) < 2 {
+		return "", false
+	}
+	first, last := value[0], value[len(value)-1]
+	if (first == '"' || first == '\'' || first == '`') && first == last {
+		return value[1 : len(value)-1], true
+	}
+	return "", false
+}
+
+// jsBracketInner returns the text inside the bracket opening at start,
+// skipping brackets inside string and template literals.
+func jsBracketInner(raw string, start int) string {
+	depth := 0
+	var quote byte
+	for idx := start; idx < len(raw); idx++ {
+		ch := raw[idx]
+		switch {
+		case quote != 0:
+			if ch == '\\' {
+				idx++
+			} else if ch == quote {
+				quote = 0
+			}
+		case ch == '"' || ch == '\'' || ch == '`':
+			quote = ch
+		case ch == '(' || ch == '[' || ch == '{':
+			depth++
+		case ch == ')' || ch == ']' || ch == '}':
+			depth--
+			if depth == 0 {
+				return raw[start+1 : idx]
+			}
+		}
+	}
+	return raw[start+1:]
+}
+
+func jsSplitTopLevel(raw string) []string {
+	out := make([]string, 0)
+	start := 0
+	for {
+		next := jsTopLevelIndex(raw[start:], ',')
+		if next < 0 {
+			break
+		}
+		if item := strings.TrimSpace(raw[start : start+next]); item != "" {
+			out = append(out, item)
+		}
+		start += next + 1
+	}
+	if item := strings.TrimSpace(raw[start:]); item != "" {
+		out = append(out, item)
+	}
+	return out
+}
+
+// jsTopLevelIndex returns the first index of sep outside brackets and
+// string literals, or -1.
+func jsTopLevelIndex(raw string, sep byte) int {
+	depth := 0
+	var quote byte
+	for idx := 0; idx < len(raw); idx++ {
+		ch := raw[idx]
+		switch {
+		case quote != 0:
+			if ch == '\\' {
+				idx++
+			} else if ch == quote {
+				quote = 0
+			}
+		case ch == '"' || ch == '\'' || ch == '`':
+			quote = ch
+		case ch == '(' || ch == '[' || ch == '{':
+			depth++
+		case ch == ')' || ch == ']' || ch == '}':
+			depth--
+		case ch == sep && depth == 0:
+			return idx
+		}
+	}
+	return -1
+}
@@ -0,0 +1,259 @@
+package mcp
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Clyra-AI/wrkr/core/detect"
+	"github.com/Clyra-AI/wrkr/core/model"
+	"gopkg.in/yaml.v3"
+)
+
+// springConfigGlobs are where Spring Boot loads application config from in
+// single-module and multi-module builds.
+var springConfigGlobs = []string{
+	"application*.yml",
+	"application*.yaml",
+	"application*.properties",
+	"config/application*.*",
+	"src/main/resources/application*.*",
+	"*/src/main/resources/application*.*",
+}
+
+// springTransports maps Spring AI MCP client connection groups to the
+// transport names used in MCP findings.
+var springTransports = map[string]string{
+	"stdio":          "stdio",
+	"sse":            "sse",
+	"streamablehttp": "streamable_http",
+}
+
+// SpringMCPConfigPaths returns Spring Boot config files that mention MCP, so
+// ordinary application config is not counted as an MCP declaration surface.
+func SpringMCPConfigPaths(root string) []string {
+	seen := map[string]struct{}{}
+	out := make([]string, 0)
+	for _, pattern := range springConfigGlobs {
+		matches, err := detect.Glob(root, pattern)
+		if err != nil {
+			continue
+		}
+		for _, rel := range matches {
+			if _, ok := seen[rel]; ok || !detect.IsSpringApplicationConfigPath(rel) {
+				continue
+			}
+			seen[rel] = struct{}{}
+			payload, parseErr := detect.ReadFileWithinRoot(detectorID, root, rel)
+			// Unreadable files are kept so the caller reports the parse error.
+			if parseErr == nil && !strings.Contains(strings.ToLower(string(payload)), "mcp") {
+				continue
+			}
+			out = append(out, rel)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// parseSpringMCPDocument reads Spring AI MCP client connections from
+// `spring.ai.mcp.client.{stdio,sse,streamable-http}.connections.<name>` in
+// application YAML or properties, plus the Claude Desktop style JSON file a
+// `stdio.servers-configuration` property points to.
+func parseSpringMCPDocument(root, rel string) (mcpDoc, *model.ParseError) {
+	payload, parseErr := detect.ReadFileWithinRoot(detectorID, root, rel)
+	if parseErr != nil {
+		return mcpDoc{}, parseErr
+	}
+	var flat map[string]string
+	if strings.HasSuffix(strings.ToLower(rel), ".properties") {
+		flat = parseSpringProperties(string(payload))
+	} else {
+		parsed, err := flattenSpringYAML(payload)
+		if err != nil {
+			return mcpDoc{}, &model.ParseError{Kind: "parse_error", Format: "yaml", Path: rel, Detector: detectorID, Message: err.Error()}
+		}
+		flat = parsed
+	}
+
+	doc := mcpDoc{MCPServers: map[string]serverDef{}}
+	keys := make([]string, 0, len(flat))
+	for key := range flat {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		value := strings.TrimSpace(flat[key])
+		segments := strings.Split(key, ".")
+		if len(segments) < 6 || relaxedKey(strings.Join(segments[:4], ".")) != "spring.ai.mcp.client" {
+			continue
+		}
+		transport, ok := springTransports[relaxedKey(segments[4])]
+		if !ok {
+			continue
+		}
+		if transport == "stdio" && relaxedKey(segments[5]) == "serversconfiguration" {
+			referenced, refErr := parseSpringServersConfiguration(root, rel, value)
+			if refErr != nil {
+				return mcpDoc{}, refErr
+			}
+			for name, server := range referenced.MCPServers {
+				if _, exists := doc.MCPServers[name]; !exists {
+					doc.MCPServers[name] = server
+				}
+			}
+			continue
+		}
+		if len(segments) < 8 || relaxedKey(segments[5]) != "connections" {
+			continue
+		}
+		name := segments[6]
+		server := doc.MCPServers[name]
+		server.Transport = transport
+		applySpringConnectionField(&server, segments[7:], value)
+		doc.MCPServers[name] = server
+	}
+	return doc, nil
+}
+
+func applySpringConnectionField(server *serverDef, field []string, value string) {
+	head, index := splitIndexedKey(field[0])
+	switch relaxedKey(head) {
+	case "command":
+		server.Command = value
+	case "args":
+		if index < 0 {
+			// Spring binds a comma-separated property value to a list.
+			for _, item := range strings.Split(value, ",") {
+				server.Args = append(server.Args, strings.TrimSpace(item))
+			}
+			return
+		}
+		for len(server.Args) <= index {
+			server.Args = append(server.Args, "")
+		}
+		server.Args[index] = value
+	case "env":
+		if len(field) < 2 {
+			return
+		}
+		if server.Env == nil {
+			server.Env = map[string]string{}
+		}
+		server.Env[strings.Join(field[1:], ".")] = value
+	case "url":
+		server.URL = strings.TrimSuffix(value, "/") + server.URL
+	case "sseendpoint", "endpoint":
+		server.URL += "/" + strings.TrimPrefix(value, "/")
+	}
+}
+
+// parseSpringServersConfiguration loads a `classpath:` or `file:` JSON
+// resource. Classpath resources resolve against the directory the
+// application config lives in.
+func parseSpringServersConfiguration(root, rel, location string) (mcpDoc, *model.ParseError) {
+	location = strings.TrimSpace(location)
+	var target string
+	switch {
+	case strings.HasPrefix(location, "classpath:"):
+		target = path.Join(path.Dir(rel), strings.TrimPrefix(strings.TrimPrefix(location, "classpath:"), "/"))
+	case strings.HasPrefix(location, "file:"):
+		target = path.Clean(strings.TrimPrefix(strings.TrimPrefix(location, "file:"), "./"))
+	default:
+		target = path.Clean(location)
+	}
+	var parsed mcpDoc
+	if parseErr := detect.ParseJSONFileAllowUnknownFields(detectorID, root, target, &parsed); parseErr != nil {
+		return mcpDoc{}, parseErr
+	}
+	return parsed, nil
+}
+
+func parseSpringProperties(content string) map[string]string {
+	flat := map[string]string{}
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, "!") {
+			continue
+		}
+		sep := strings.IndexAny(trimmed, "=:")
+		if sep <= 0 {
+			continue
+		}
+		flat[strings.TrimSpace(trimmed[:sep])] = strings.TrimSpace(trimmed[sep+1:])
+	}
+	return flat
+}
+
+// flattenSpringYAML flattens every document in a multi-document YAML file
+// into dotted property keys. Later documents override earlier ones.
+func flattenSpringYAML(payload []byte) (map[string]string, error) {
+	flat := map[string]string{}
+	decoder := yaml.NewDecoder(bytes.NewReader(payload))
+	for {
+		var doc any
+		err := decoder.Decode(&doc)
+		if errors.Is(err, io.EOF) {
+			return flat, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		flattenSpringValue("", doc, flat)
+	}
+}
+
+func flattenSpringValue(prefix string, value any, flat map[string]string) {
+	switch typed := value.(type) {
+	case map[string]any:
+		for key, item := range typed {
+			next := key
+			if prefix != "" {
+				next = prefix + "." + key
+			}
+			flattenSpringValue(next, item, flat)
+		}
+	case map[any]any:
+		for key, item := range typed {
+			next := fmt.Sprintf("%v", key)
+			if prefix != "" {
+				next = prefix + "." + next
+			}
+			flattenSpringValue(next, item, flat)
+		}
+	case []any:
+		for index, item := range typed {
+			flattenSpringValue(prefix+"["+strconv.Itoa(index)+"]", item, flat)
+		}
+	case nil:
+	default:
+		if prefix != "" {
+			flat[prefix] = fmt.Sprintf("%v", typed)
+		}
+	}
+}
+
+// relaxedKey applies Spring's relaxed binding so `streamable-http`,
+// `streamableHttp`, and `STREAMABLE_HTTP` compare equal.
+func relaxedKey(key string) string {
+	key = strings.ToLower(key)
+	key = strings.ReplaceAll(key, "-", "")
+	return strings.ReplaceAll(key, "_", "")
+}
+
+func splitIndexedKey(key string) (string, int) {
+	open := strings.Index(key, "[")
+	if open < 0 || !strings.HasSuffix(key, "]") {
+		return key, -1
+	}
+	index, err := strconv.Atoi(key[open+1 : len(key)-1])
+	if err != nil {
+		return key[:open], -1
+	}
+	return key[:open], index
+}
@@ -0,0 +1,102 @@
+package mcp
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Clyra-AI/wrkr/core/detect"
+	"github.com/Clyra-AI/wrkr/core/model"
+)
+
+// inputRefRE matches VS Code `${input:<id>}` prompts. The value is supplied
+// by the user at startup, so a password prompt is a credential without a
+// secret.
+var inputRefRE = regexp.MustCompile(`\$\{input:([^}]+)\}`)
+
+// vscodeInput is a `inputs` entry. Password prompts mask what the user types
+// and mark the value as a secret.
+type vscodeInput struct {
+	ID       string `json:"id"`
+	Password bool   `json:"password"`
+}
+
+// vscodeSettings holds the `mcp` block VS Code accepts in user and workspace
+// settings. `*.code-workspace` files nest settings under `settings`.
+type vscodeSettings struct {
+	MCP      mcpDoc `json:"mcp"`
+	Settings *struct {
+		MCP mcpDoc `json:"mcp"`
+	} `json:"settings"`
+}
+
+func isVSCodePath(rel string) bool {
+	return strings.HasPrefix(rel, ".vscode/") || strings.HasSuffix(rel, ".code-workspace")
+}
+
+// parseVSCodeMCPDocument decodes VS Code files, which allow comments and
+// trailing commas.
+func parseVSCodeMCPDocument(root, rel string) (mcpDoc, *model.ParseError) {
+	if rel == ".vscode/mcp.json" {
+		var parsed mcpDoc
+		if parseErr := detect.ParseJSONCFileAllowUnknownFields(detectorID, root, rel, &parsed); parseErr != nil {
+			return mcpDoc{}, parseErr
+		}
+		return parsed, nil
+	}
+	var parsed vscodeSettings
+	if parseErr := detect.ParseJSONCFileAllowUnknownFields(detectorID, root, rel, &parsed); parseErr != nil {
+		return mcpDoc{}, parseErr
+	}
+	if strings.HasSuffix(rel, ".code-workspace") {
+		if parsed.Settings == nil {
+			return mcpDoc{}, nil
+		}
+		return parsed.Settings.MCP, nil
+	}
+	return parsed.MCP, nil
+}
+
+// inputRefs splits the `${input:<id>}` prompts a server reads into declared
+// password prompts, which are credential refs, and ids no `inputs` entry
+// declares. Declared non-password prompts are neither.
+func inputRefs(server serverDef, inputs []vscodeInput) (credential []string, undeclared []string) {
+	declared := map[string]bool{}
+	for _, input := range inputs {
+		if id := strings.TrimSpace(input.ID); id != "" {
+			declared[id] = declared[id] || input.Password
+		}
+	}
+	values := []string{server.Command, server.URL}
+	values = append(values, server.Args...)
+	for _, value := range server.Env {
+		values = append(values, value)
+	}
+	for _, value := range server.Headers {
+		values = append(values, value)
+	}
+	credentialSet := map[string]struct{}{}
+	undeclaredSet := map[string]struct{}{}
+	for _, value := range values {
+		for _, match := range inputRefRE.FindAllStringSubmatch(value, -1) {
+			id := strings.TrimSpace(match[1])
+			password, ok := declared[id]
+			switch {
+			case !ok:
+				undeclaredSet[id] = struct{}{}
+			case password:
+				credentialSet[id] = struct{}{}
+			}
+		}
+	}
+	return sortedIDs(credentialSet), sortedIDs(undeclaredSet)
+}
+
+func sortedIDs(set map[string]struct{}) []string {
+	out := make([]string, 0, len(set))
+	for id := range set {
+		out = append(out, id)
+	}
+	sort.Strings(out)
+	return out
+}
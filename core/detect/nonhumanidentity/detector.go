@@ -266,7 +266,7 @@ func structuredParseError(rel, format string, err error) *model.ParseError {
 
 func structuredSurfaceRole(rel string, decoded any) string {
 	lower := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(rel), "\\", "/"))
-	if workflowloc.IsGitHubWorkflow(lower) || workflowloc.IsGitLabEntryPipeline(lower) || workflowloc.IsAzurePipelinePath(lower) {
+	if workflowloc.IsGitHubWorkflow(lower) || workflowloc.IsGitLabEntryPipeline(lower) || workflowloc.IsAzurePipelinePath(lower) || workflowloc.IsBitbucketPipeline(lower) {
 		return "workflow_reference"
 	}
 	if strings.Contains(lower, "/examples/") || strings.Contains(lower, "/fixtures/") || strings.Contains(lower, "/samples/") || strings.HasPrefix(lower, "examples/") || strings.HasPrefix(lower, "fixtures/") || strings.HasPrefix(lower, "scenarios/") {
@@ -275,7 +275,7 @@ func specExecutionRelationships(specRel string, generators, consumers []string,
 		relationships = append(relationships, newSpecRelationship("api_spec_generator", generator, specRel, "source_declared", "resolved_local", "high", []string{"spec_generator_ref:" + generator}))
 	}
 	for _, consumer := range consumers {
-		relationships = append(relationships, newSpecRelationship("api_spec_consumer", specRel, consumer, "source_declared", "resolved_local", "high", []string{"spec_consumer_ref:" + consumer}))
+		relationships = append(relationships, ConsumerRelationship(specRel, consumer))
 	}
 	if runtimeRelation != "" {
 		parts := strings.Split(runtimeRelation, "|")
@@ -286,6 +286,13 @@ func specExecutionRelationships(specRel string, generators, consumers []string,
 	return model.NormalizeExecutionRelationships(relationships)
 }
 
+// ConsumerRelationship is the relationship recorded between a spec and a
+// local file that consumes it. Detectors that resolve the consumer side use
+// it so both findings carry the same relationship ID.
+func ConsumerRelationship(specRel, consumerRel string) model.ExecutionRelationship {
+	return newSpecRelationship("api_spec_consumer", specRel, consumerRel, "source_declared", "resolved_local", "high", []string{"spec_consumer_ref:" + consumerRel})
+}
+
 func newSpecRelationship(kind, caller, callee, origin, state, confidence string, evidenceRefs []string) model.ExecutionRelationship {
 	digest := sha256.Sum256([]byte(strings.Join([]string{kind, caller, callee, state}, "\x00")))
 	return model.ExecutionRelationship{RelationshipID: "xrel-" + hex.EncodeToString(digest[:8]), Kind: kind, Caller: caller, Callee: callee, Origin: origin, ResolutionState: state, Confidence: confidence, EvidenceRefs: evidenceRefs}
@@ -705,6 +712,14 @@ func parseDocument(root, rel string) (document, *model.ParseError) {
 	return doc, nil
 }
 
+// IsSpecification reports whether rel is a file this detector reads as an
+// OpenAPI or Swagger specification, so detectors that find a consumer first
+// can link to the same spec.
+func IsSpecification(root, rel string) bool {
+	ok, parseErr := isOpenAPICandidate(root, rel)
+	return ok && parseErr == nil
+}
+
 func isOpenAPICandidate(root, rel string) (bool, *model.ParseError) {
 	lower := strings.ToLower(strings.TrimSpace(rel))
 	ext := strings.ToLower(filepath.Ext(lower))
@@ -805,13 +820,16 @@ func referencesArtifactPath(sourceRel, artifactRel string, lowerPayload []byte)
 	if artifact == "" || artifact == "." {
 		return false
 	}
-	relative, err := filepath.Rel(filepath.Dir(filepath.FromSlash(sourceRel)), filepath.FromSlash(artifact))
+	// Compare in lower case on both sides so mixed-case project layouts such
+	// as `src/Support/Plugins` still resolve relative references.
+	sourceDir := filepath.Dir(filepath.FromSlash(strings.ToLower(filepath.ToSlash(sourceRel))))
+	relative, err := filepath.Rel(sourceDir, filepath.FromSlash(artifact))
 	if err != nil {
 		return false
 	}
-	relative = strings.ToLower(filepath.ToSlash(relative))
+	relative = filepath.ToSlash(relative)
 	candidates := []string{artifact, relative, strings.TrimPrefix(relative, "./")}
-	if filepath.Dir(filepath.FromSlash(sourceRel)) == filepath.Dir(filepath.FromSlash(artifact)) {
+	if sourceDir == filepath.Dir(filepath.FromSlash(artifact)) {
 		candidates = append(candidates, filepath.Base(artifact))
 	}
 	if !strings.Contains(artifact, "/") {
@@ -841,7 +859,7 @@ func generatorCandidate(rel string) bool {
 
 func consumerCandidate(rel string) bool {
 	switch strings.ToLower(filepath.Ext(rel)) {
-	case ".yaml", ".yml", ".json", ".js", ".ts", ".tsx", ".jsx", ".html":
+	case ".yaml", ".yml", ".json", ".js", ".ts", ".tsx", ".jsx", ".html", ".cs":
 		return true
 	default:
 		return false
@@ -124,6 +124,27 @@ func TestLocalSwaggerConsumerIsCorrelatedWithoutPromotingRuntimeProof(t *testing
 	}
 }
 
+func TestSemanticKernelOpenAPIPluginImportIsLocalConsumer(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeOpenAPITestFile(t, root, "src/Support/Plugins/github.yaml", "openapi: 3.0.0\npaths:\n  /issues:\n    post:\n      operationId: createIssue\n")
+	writeOpenAPITestFile(t, root, "src/Support/Program.cs", `await kernel.ImportPluginFromOpenApiAsync("github", "Plugins/github.yaml");`)
+	findings := detectortest.RunFixture(t, root, "local", "support", New())
+	want := ConsumerRelationship("src/Support/Plugins/github.yaml", "src/Support/Program.cs")
+	for _, finding := range findings {
+		if finding.FindingType != "openapi_specification" {
+			continue
+		}
+		for _, relationship := range finding.ExecutionRelationships {
+			if relationship.RelationshipID == want.RelationshipID {
+				return
+			}
+		}
+	}
+	t.Fatalf("expected C# plugin import to be a spec consumer, got %+v", findings)
+}
+
 func TestConsumerLineageFanoutIsBoundedAndReported(t *testing.T) {
 	t.Parallel()
 
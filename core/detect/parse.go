@@ -61,6 +61,69 @@ func parseJSONFile(detectorID, root, rel string, dst any, allowUnknownFields boo
 	return nil
 }
 
+// ParseJSONCFileAllowUnknownFields parses editor-style JSON with comments and
+// trailing commas, as used by VS Code derived settings files.
+func ParseJSONCFileAllowUnknownFields(detectorID, root, rel string, dst any) *model.ParseError {
+	payload, parseErr := readFile(detectorID, root, rel)
+	if parseErr != nil {
+		parseErr.Format = "json"
+		return parseErr
+	}
+	if err := json.Unmarshal(StripJSONC(payload), dst); err != nil {
+		return newParseError(detectorID, rel, "json", err)
+	}
+	return nil
+}
+
+// StripJSONC removes line and block comments and trailing commas outside of
+// string literals so the payload can be decoded as standard JSON.
+func StripJSONC(payload []byte) []byte {
+	out := make([]byte, 0, len(payload))
+	inString, escaped := false, false
+	for i := 0; i < len(payload); i++ {
+		c := payload[i]
+		if inString {
+			out = append(out, c)
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == '"':
+				inString = false
+			}
+			continue
+		}
+		switch {
+		case c == '"':
+			inString = true
+			out = append(out, c)
+		case c == '/' && i+1 < len(payload) && payload[i+1] == '/':
+			for i < len(payload) && payload[i] != '\n' {
+				i++
+			}
+			if i < len(payload) {
+				out = append(out, '\n')
+			}
+		case c == '/' && i+1 < len(payload) && payload[i+1] == '*':
+			i += 2
+			for i+1 < len(payload) && (payload[i] != '*' || payload[i+1] != '/') {
+				i++
+			}
+			i++
+		case c == ']' || c == '}':
+			trimmed := bytes.TrimRight(out, " \t\r\n")
+			if len(trimmed) > 0 && trimmed[len(trimmed)-1] == ',' {
+				out = append(trimmed[:len(trimmed)-1], out[len(trimmed):]...)
+			}
+			out = append(out, c)
+		default:
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
 func ParseYAMLFile(detectorID, root, rel string, dst any) *model.ParseError {
 	return ParseYAMLFileStrict(detectorID, root, rel, dst)
 }
@@ -100,6 +163,36 @@ func parseYAMLFile(detectorID, root, rel string, dst any, allowUnknownFields boo
 	return nil
 }
 
+// ParseYAMLFrontmatter decodes the YAML frontmatter of a Markdown file into
+// dst and returns the body after it. CRLF line endings are normalized and the
+// closing `---` may end the file. Files without frontmatter leave dst
+// untouched and return their whole content.
+func ParseYAMLFrontmatter(detectorID, root, rel string, dst any) (string, *model.ParseError) {
+	payload, parseErr := readFile(detectorID, root, rel)
+	if parseErr != nil {
+		parseErr.Format = "yaml"
+		return "", parseErr
+	}
+	content := strings.ReplaceAll(string(payload), "\r\n", "\n")
+	if !strings.HasPrefix(content, "---\n") {
+		return content, nil
+	}
+	rest := content[4:]
+	offset := 0
+	for _, line := range strings.SplitAfter(rest, "\n") {
+		if strings.TrimRight(line, " \t\n") != "---" {
+			offset += len(line)
+			continue
+		}
+		decoder := yaml.NewDecoder(strings.NewReader(rest[:offset]))
+		if err := decoder.Decode(dst); err != nil && !errors.Is(err, io.EOF) {
+			return "", newParseError(detectorID, rel, "yaml", err)
+		}
+		return rest[offset+len(line):], nil
+	}
+	return "", newParseError(detectorID, rel, "yaml", errors.New("missing frontmatter terminator"))
+}
+
 func ParseTOMLFile(detectorID, root, rel string, dst any) *model.ParseError {
 	return ParseTOMLFileStrict(detectorID, root, rel, dst)
 }
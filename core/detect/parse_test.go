@@ -80,6 +80,36 @@ func TestParseJSONFileAllowUnknownFields(t *testing.T) {
 	}
 }
 
+func TestParseJSONCFileAllowsCommentsAndTrailingCommas(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	payload := `{
+  // editor comment
+  "name": "ok // not a comment", /* block
+  comment */
+  "extra": ["a", "b",],
+}
+`
+	if err := os.WriteFile(filepath.Join(root, "settings.json"), []byte(payload), 0o600); err != nil {
+		t.Fatalf("write fixture: %v", err)
+	}
+
+	var parsed jsonFixture
+	if parseErr := ParseJSONCFileAllowUnknownFields("detector", root, "settings.json", &parsed); parseErr != nil {
+		t.Fatalf("expected no parse error, got %#v", parseErr)
+	}
+	if parsed.Name != "ok // not a comment" {
+		t.Fatalf("unexpected parsed result: %#v", parsed)
+	}
+	if err := os.WriteFile(filepath.Join(root, "broken.json"), []byte(`{"name": }`), 0o600); err != nil {
+		t.Fatalf("write fixture: %v", err)
+	}
+	if parseErr := ParseJSONCFileAllowUnknownFields("detector", root, "broken.json", &parsed); parseErr == nil || parseErr.Format != "json" {
+		t.Fatalf("expected json parse error, got %#v", parseErr)
+	}
+}
+
 func TestParseYAMLFileStrictUnknownField(t *testing.T) {
 	t.Parallel()
 
@@ -120,6 +150,42 @@ func TestParseYAMLFileAllowUnknownFields(t *testing.T) {
 	}
 }
 
+func TestParseYAMLFrontmatterNormalizesLineEndingsAndEOFTerminator(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	for name, payload := range map[string]string{
+		"crlf.md":     "---\r\nenabled: true\r\n---\r\nbody\r\n",
+		"eof.md":      "---\nenabled: true\n---",
+		"plain.md":    "body\n",
+		"unclosed.md": "---\nenabled: true\nbody\n",
+	} {
+		if err := os.WriteFile(filepath.Join(root, name), []byte(payload), 0o600); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+
+	var crlf yamlFixture
+	body, parseErr := ParseYAMLFrontmatter("detector", root, "crlf.md", &crlf)
+	if parseErr != nil || !crlf.Enabled || body != "body\n" {
+		t.Fatalf("unexpected CRLF parse: %+v %q %v", crlf, body, parseErr)
+	}
+	var eof yamlFixture
+	body, parseErr = ParseYAMLFrontmatter("detector", root, "eof.md", &eof)
+	if parseErr != nil || !eof.Enabled || body != "" {
+		t.Fatalf("unexpected EOF terminator parse: %+v %q %v", eof, body, parseErr)
+	}
+	var plain yamlFixture
+	body, parseErr = ParseYAMLFrontmatter("detector", root, "plain.md", &plain)
+	if parseErr != nil || plain.Enabled || body != "body\n" {
+		t.Fatalf("unexpected plain parse: %+v %q %v", plain, body, parseErr)
+	}
+	var unclosed yamlFixture
+	if _, parseErr = ParseYAMLFrontmatter("detector", root, "unclosed.md", &unclosed); parseErr == nil || parseErr.Format != "yaml" {
+		t.Fatalf("expected missing terminator parse error, got %+v", parseErr)
+	}
+}
+
 func TestParseTOMLFileStrictUnknownField(t *testing.T) {
 	t.Parallel()
 
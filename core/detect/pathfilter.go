@@ -2,6 +2,7 @@ package detect
 
 import (
 	"path/filepath"
+	"regexp"
 	"strings"
 )
 
@@ -83,7 +84,33 @@ func IsHighSignalAgentFrameworkSourcePath(rel string) bool {
 	if hasPathFilterSegment(normalized, "agent", "agents", "crew", "crews", "assistant", "assistants", "orchestrator", "orchestrators", "bot", "bots", "handoff", "handoffs") {
 		return true
 	}
-	return baseNameContainsPathFilterToken(normalized, "agent", "crew", "assistant", "orchestrator", "handoff", "mcp")
+	tokens := []string{"agent", "crew", "assistant", "orchestrator", "handoff", "mcp"}
+	if baseNameContainsPathFilterToken(normalized, tokens...) {
+		return true
+	}
+	// Go names a package by its directory, so `cmd/greeter-mcp/main.go` is
+	// as specific as a Python `greeter_mcp.py`.
+	if strings.HasSuffix(normalized, ".go") {
+		return baseNameContainsPathFilterToken(filepath.Dir(normalized), tokens...)
+	}
+	// JVM files are named after their class, and Spring AI wiring lives in
+	// classes such as `ChatConfig` or `AiConfiguration`.
+	if strings.HasSuffix(normalized, ".java") || strings.HasSuffix(normalized, ".kt") {
+		return baseNameContainsPathFilterToken(normalized, "chat", "llm", "aiconfig", "aiservice", "tool")
+	}
+	// .NET wires Semantic Kernel in `Program.cs` top-level statements or in
+	// kernel and plugin setup classes.
+	if strings.HasSuffix(normalized, ".cs") {
+		return baseNameContainsPathFilterToken(normalized, "program", "kernel", "plugin", "chat", "llm")
+	}
+	// TypeScript agents live in a Mastra project's `src/mastra/` tree, in
+	// Next.js chat route handlers (`app/api/chat/route.ts`), and in
+	// LangGraph.js `graph.ts` modules.
+	switch filepath.Ext(normalized) {
+	case ".ts", ".tsx", ".js", ".jsx", ".mjs", ".cjs", ".mts", ".cts":
+		return hasPathFilterSegment(normalized, "mastra", "chat") || baseNameContainsPathFilterToken(normalized, "graph", "chat", "llm")
+	}
+	return false
 }
 
 func IsHighSignalMCPCandidateSourcePath(rel string) bool {
@@ -131,3 +158,11 @@ func baseNameContainsPathFilterToken(rel string, tokens ...string) bool {
 	}
 	return false
 }
+
+// springApplicationConfigRE matches Spring Boot application config files,
+// including profile-specific variants such as `application-prod.yml`.
+var springApplicationConfigRE = regexp.MustCompile(`^application(?:-[a-z0-9_.-]+)?\.(?:ya?ml|properties)$`)
+
+func IsSpringApplicationConfigPath(rel string) bool {
+	return springApplicationConfigRE.MatchString(filepath.Base(normalizePathFilterPath(rel)))
+}
@@ -59,6 +59,12 @@ func TestPathFilterHighSignalHelpers(t *testing.T) {
 		".wrkr/agents/langchain.yaml",
 		"agents/release_agent.ts",
 		"bots/runtime.py",
+		"cmd/greeter-mcp/main.go",
+		"src/main/java/com/acme/ChatConfig.java",
+		"src/Support/Program.cs",
+		"src/mastra/index.ts",
+		"app/api/chat/route.ts",
+		"src/graph.ts",
 	} {
 		if !IsHighSignalAgentFrameworkSourcePath(path) {
 			t.Fatalf("expected high-signal agent-framework path: %s", path)
@@ -67,6 +73,9 @@ func TestPathFilterHighSignalHelpers(t *testing.T) {
 	for _, path := range []string{
 		"src/runtime.ts",
 		"lib/helpers.py",
+		"cmd/server/main.go",
+		"src/main/java/com/acme/BillingService.java",
+		"src/Billing/InvoiceService.cs",
 	} {
 		if IsHighSignalAgentFrameworkSourcePath(path) {
 			t.Fatalf("did not expect low-signal agent-framework path: %s", path)
@@ -102,7 +102,7 @@ func isPromptSurface(rel string) bool {
 	base := filepath.Base(normalized)
 
 	switch base {
-	case "agents.md", "agents.override.md", "claude.md", ".cursorrules", "jenkinsfile", "skill.md":
+	case "agents.md", "agents.override.md", "claude.md", "gemini.md", ".cursorrules", ".windsurfrules", ".clinerules", "jenkinsfile", "skill.md":
 		return true
 	}
 	if strings.HasPrefix(normalized, ".github/workflows/") {
@@ -111,7 +111,7 @@ func isPromptSurface(rel string) bool {
 	if strings.Contains(normalized, "/skills/") && strings.HasSuffix(base, ".md") {
 		return true
 	}
-	if strings.HasPrefix(normalized, ".agents/") || strings.HasPrefix(normalized, ".claude/") || strings.HasPrefix(normalized, ".cursor/") || strings.HasPrefix(normalized, ".codex/") {
+	if strings.HasPrefix(normalized, ".agents/") || strings.HasPrefix(normalized, ".claude/") || strings.HasPrefix(normalized, ".cursor/") || strings.HasPrefix(normalized, ".codex/") || strings.HasPrefix(normalized, ".gemini/") || strings.HasPrefix(normalized, ".windsurf/") || strings.HasPrefix(normalized, ".clinerules/") || strings.HasPrefix(normalized, ".roo/") || strings.HasPrefix(normalized, ".continue/rules/") || strings.HasPrefix(normalized, ".amazonq/rules/") || strings.HasPrefix(normalized, ".kiro/steering/") {
 		return hasTextLikeExtension(normalized)
 	}
 	if strings.Contains(normalized, "prompt") || strings.Contains(normalized, "instruction") {
@@ -128,7 +128,7 @@ func classifyLocation(rel string) string {
 		return "workflow"
 	case strings.Contains(normalized, "/skills/") || base == "skill.md":
 		return "skill"
-	case base == "agents.md" || base == "agents.override.md" || base == "claude.md":
+	case base == "agents.md" || base == "agents.override.md" || base == "claude.md" || base == "gemini.md":
 		return "instruction_doc"
 	case strings.HasSuffix(normalized, ".json") || strings.HasSuffix(normalized, ".yaml") || strings.HasSuffix(normalized, ".yml") || strings.HasSuffix(normalized, ".toml"):
 		return "config"
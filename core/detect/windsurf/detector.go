@@ -0,0 +1,269 @@
+package windsurf
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Clyra-AI/wrkr/core/detect"
+	"github.com/Clyra-AI/wrkr/core/model"
+	"github.com/Clyra-AI/wrkr/core/risk/autonomy"
+)
+
+const detectorID = "windsurf"
+
+const mcpConfigPath = ".codeium/windsurf/mcp_config.json"
+
+// userSettingsPaths are the Windsurf editor settings files relative to the
+// user home directory. They are only read for --my-setup scans.
+var userSettingsPaths = []string{
+	".config/Windsurf/User/settings.json",
+	"Library/Application Support/Windsurf/User/settings.json",
+	"AppData/Roaming/Windsurf/User/settings.json",
+}
+
+type Detector struct{}
+
+func New() Detector { return Detector{} }
+
+func (Detector) ID() string { return detectorID }
+
+type mcpConfig struct {
+	MCPServers map[string]struct {
+		Disabled bool `json:"disabled"`
+	} `json:"mcpServers"`
+}
+
+type ruleFrontmatter struct {
+	Trigger     string   `yaml:"trigger"`
+	Description string   `yaml:"description"`
+	Globs       []string `yaml:"globs"`
+}
+
+func (Detector) Detect(_ context.Context, scope detect.Scope, _ detect.Options) ([]model.Finding, error) {
+	if err := detect.ValidateScopeRoot(scope.Root); err != nil {
+		return nil, err
+	}
+
+	findings := make([]model.Finding, 0)
+	if exists, parseErr := detect.FileExistsWithinRoot(detectorID, scope.Root, ".windsurfrules"); parseErr != nil {
+		findings = append(findings, parseErrorFinding(scope, ".windsurfrules", parseErr))
+	} else if exists {
+		findings = append(findings, baseFinding(scope, ".windsurfrules", nil,
+			model.Evidence{Key: "deprecated_surface", Value: "true"},
+			model.Evidence{Key: "resolver_ref", Value: ".windsurfrules"},
+		))
+	}
+
+	rules, globErr := detect.Glob(scope.Root, ".windsurf/rules/*.md")
+	if globErr != nil {
+		return nil, fmt.Errorf("glob windsurf rules: %w", globErr)
+	}
+	for _, rel := range rules {
+		frontmatter, parseErr := parseRuleFrontmatter(scope.Root, rel)
+		if parseErr != nil {
+			findings = append(findings, parseErrorFinding(scope, rel, parseErr))
+			continue
+		}
+		findings = append(findings, baseFinding(scope, rel, nil,
+			model.Evidence{Key: "trigger", Value: fallback(strings.ToLower(strings.TrimSpace(frontmatter.Trigger)), "unspecified")},
+			model.Evidence{Key: "glob_count", Value: fmt.Sprintf("%d", len(frontmatter.Globs))},
+			model.Evidence{Key: "resolver_ref", Value: rel},
+		))
+	}
+
+	if exists, parseErr := detect.FileExistsWithinRoot(detectorID, scope.Root, mcpConfigPath); parseErr != nil {
+		findings = append(findings, parseErrorFinding(scope, mcpConfigPath, parseErr))
+	} else if exists {
+		var parsed mcpConfig
+		if parseErr := detect.ParseJSONFileAllowUnknownFields(detectorID, scope.Root, mcpConfigPath, &parsed); parseErr != nil {
+			findings = append(findings, parseErrorFinding(scope, mcpConfigPath, parseErr))
+		} else {
+			enabled := 0
+			for _, server := range parsed.MCPServers {
+				if !server.Disabled {
+					enabled++
+				}
+			}
+			var permissions []string
+			if enabled > 0 {
+				permissions = []string{"mcp.access"}
+			}
+			findings = append(findings, baseFinding(scope, mcpConfigPath, permissions,
+				model.Evidence{Key: "mcp_server_count", Value: fmt.Sprintf("%d", len(parsed.MCPServers))},
+				model.Evidence{Key: "mcp_enabled_server_count", Value: fmt.Sprintf("%d", enabled)},
+				model.Evidence{Key: "resolver_ref", Value: mcpConfigPath},
+			))
+		}
+	}
+
+	if detect.IsLocalMachineScope(scope) {
+		for _, rel := range userSettingsPaths {
+			exists, parseErr := detect.FileExistsWithinRoot(detectorID, scope.Root, rel)
+			if parseErr != nil {
+				findings = append(findings, parseErrorFinding(scope, rel, parseErr))
+				continue
+			}
+			if !exists {
+				continue
+			}
+			finding, ok := settingsFinding(scope, rel)
+			if ok {
+				findings = append(findings, finding)
+			}
+		}
+	}
+
+	model.SortFindings(findings)
+	return findings, nil
+}
+
+// settingsFinding reports Cascade terminal auto-execution. Windsurf has
+// renamed these keys across releases, so any `windsurf.` or `codeium.` key
+// naming auto-execution, turbo mode, or the command allow/deny lists is read.
+func settingsFinding(scope detect.Scope, rel string) (model.Finding, bool) {
+	var parsed map[string]any
+	if parseErr := detect.ParseJSONCFileAllowUnknownFields(detectorID, scope.Root, rel, &parsed); parseErr != nil {
+		return parseErrorFinding(scope, rel, parseErr), true
+	}
+	policy := ""
+	allowList, denyList := 0, 0
+	keys := make([]string, 0, len(parsed))
+	for key := range parsed {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		normalized := strings.ToLower(strings.TrimSpace(key))
+		if !strings.HasPrefix(normalized, "windsurf.") && !strings.HasPrefix(normalized, "codeium.") {
+			continue
+		}
+		value := parsed[key]
+		switch {
+		case strings.Contains(normalized, "denylist"):
+			denyList += listLength(value)
+		case strings.Contains(normalized, "allowlist"):
+			allowList += listLength(value)
+		case strings.Contains(normalized, "turbo"):
+			if enabled, ok := value.(bool); ok && enabled {
+				policy = "turbo"
+			}
+		case strings.Contains(normalized, "autoexecution"):
+			if raw, ok := value.(string); ok && policy != "turbo" {
+				policy = normalizeAutoExecution(raw)
+			}
+		}
+	}
+	if policy == "" && allowList == 0 && denyList == 0 {
+		return model.Finding{}, false
+	}
+	policy = fallback(policy, "unspecified")
+
+	signals := autonomy.Signals{
+		Tool:            detectorID,
+		Headless:        policy == "turbo" || policy == "auto",
+		HasApprovalGate: policy == "auto",
+	}
+	var permissions []string
+	if signals.Headless {
+		permissions = []string{"proc.exec"}
+	}
+	finding := baseFinding(scope, rel, permissions,
+		model.Evidence{Key: "auto_execution", Value: policy},
+		model.Evidence{Key: "command_allow_list_count", Value: fmt.Sprintf("%d", allowList)},
+		model.Evidence{Key: "command_deny_list_count", Value: fmt.Sprintf("%d", denyList)},
+		model.Evidence{Key: "headless", Value: fmt.Sprintf("%t", signals.Headless)},
+		model.Evidence{Key: "approval_gate", Value: fmt.Sprintf("%t", signals.HasApprovalGate)},
+		model.Evidence{Key: "resolver_ref", Value: rel},
+	)
+	finding.Autonomy = autonomy.Classify(signals)
+	if finding.Autonomy == autonomy.LevelHeadlessAuto {
+		finding.Severity = model.SeverityMedium
+		finding.Remediation = "Turn off Cascade Turbo auto-execution or restrict it with a command allow list so terminal commands need review."
+	}
+	return finding, true
+}
+
+func normalizeAutoExecution(raw string) string {
+	normalized := strings.ToLower(strings.TrimSpace(raw))
+	switch {
+	case strings.Contains(normalized, "turbo"):
+		return "turbo"
+	case strings.Contains(normalized, "allow"):
+		return "allowlist"
+	case strings.Contains(normalized, "auto"):
+		return "auto"
+	case normalized == "off", strings.Contains(normalized, "disable"), strings.Contains(normalized, "never"), strings.Contains(normalized, "manual"):
+		return "off"
+	default:
+		return normalized
+	}
+}
+
+func listLength(value any) int {
+	switch typed := value.(type) {
+	case []any:
+		return len(typed)
+	case string:
+		if strings.TrimSpace(typed) == "" {
+			return 0
+		}
+		return len(strings.Split(typed, ","))
+	default:
+		return 0
+	}
+}
+
+func parseRuleFrontmatter(root, rel string) (ruleFrontmatter, *model.ParseError) {
+	var out ruleFrontmatter
+	if _, parseErr := detect.ParseYAMLFrontmatter(detectorID, root, rel, &out); parseErr != nil {
+		return ruleFrontmatter{}, parseErr
+	}
+	return out, nil
+}
+
+func baseFinding(scope detect.Scope, location string, permissions []string, extra ...model.Evidence) model.Finding {
+	evidence := []model.Evidence{{Key: "delivery_harness", Value: "windsurf_cascade"}}
+	evidence = append(evidence, extra...)
+	return model.Finding{
+		FindingType: "tool_config",
+		Severity:    model.SeverityLow,
+		ToolType:    "windsurf",
+		Location:    location,
+		Repo:        scope.Repo,
+		Org:         fallbackOrg(scope.Org),
+		Detector:    detectorID,
+		Permissions: permissions,
+		Evidence:    evidence,
+	}
+}
+
+func parseErrorFinding(scope detect.Scope, location string, parseErr *model.ParseError) model.Finding {
+	parseErr.Detector = detectorID
+	return model.Finding{
+		FindingType: "parse_error",
+		Severity:    model.SeverityMedium,
+		ToolType:    "windsurf",
+		Location:    location,
+		Repo:        scope.Repo,
+		Org:         fallbackOrg(scope.Org),
+		Detector:    detectorID,
+		ParseError:  parseErr,
+		Remediation: "Fix malformed Windsurf configuration so deterministic parsing can proceed.",
+	}
+}
+
+func fallback(value, fallbackValue string) string {
+	if strings.TrimSpace(value) == "" {
+		return fallbackValue
+	}
+	return value
+}
+
+func fallbackOrg(org string) string {
+	if strings.TrimSpace(org) == "" {
+		return "local"
+	}
+	return org
+}
@@ -0,0 +1,114 @@
+package windsurf
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/Clyra-AI/wrkr/core/detect"
+	"github.com/Clyra-AI/wrkr/core/model"
+	"github.com/Clyra-AI/wrkr/core/risk/autonomy"
+)
+
+func TestWindsurfDetectorFindsRulesAndMCPConfig(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeWindsurfFile(t, root, ".windsurfrules", "Always run the tests.\n")
+	writeWindsurfFile(t, root, ".windsurf/rules/deploy.md", "---\ntrigger: glob\nglobs: [\"deploy/**\"]\nowner: platform\n---\n\n# Deploy\n")
+	writeWindsurfFile(t, root, ".codeium/windsurf/mcp_config.json", `{"mcpServers":{"github":{"serverUrl":"https://api.githubcopilot.com/mcp/"},"old":{"command":"npx","disabled":true}}}`)
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Root: root, Repo: "repo", Org: "local"}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect: %v", err)
+	}
+	byLocation := map[string]model.Finding{}
+	for _, finding := range findings {
+		byLocation[finding.Location] = finding
+	}
+	if len(byLocation) != 3 {
+		t.Fatalf("expected three windsurf findings, got %+v", findings)
+	}
+	if got := evidenceValue(byLocation[".windsurf/rules/deploy.md"], "trigger"); got != "glob" {
+		t.Fatalf("expected glob trigger, got %q", got)
+	}
+	mcp := byLocation[".codeium/windsurf/mcp_config.json"]
+	if !reflect.DeepEqual(mcp.Permissions, []string{"mcp.access"}) || evidenceValue(mcp, "mcp_enabled_server_count") != "1" {
+		t.Fatalf("unexpected mcp config finding: %+v", mcp)
+	}
+}
+
+func TestWindsurfDetectorMapsTurboToHeadlessAutonomyForMySetup(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeWindsurfFile(t, root, ".config/Windsurf/User/settings.json", `{
+  // Cascade settings
+  "windsurf.cascadeCommandsAutoExecution": "turbo",
+  "windsurf.cascadeCommandsDenyList": ["rm", "git push"],
+  "editor.fontSize": 14,
+}`)
+
+	findings, err := New().Detect(context.Background(), detect.Scope{Root: root, Repo: "local-machine", Org: "local"}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect: %v", err)
+	}
+	if len(findings) != 0 {
+		t.Fatalf("expected user settings to be ignored outside --my-setup, got %+v", findings)
+	}
+
+	findings, err = New().Detect(context.Background(), detect.Scope{Root: root, Repo: "local-machine", Org: "local", TargetMode: "my_setup"}, detect.Options{})
+	if err != nil {
+		t.Fatalf("detect: %v", err)
+	}
+	if len(findings) != 1 {
+		t.Fatalf("expected one settings finding, got %+v", findings)
+	}
+	finding := findings[0]
+	if finding.Autonomy != autonomy.LevelHeadlessAuto {
+		t.Fatalf("expected turbo to classify as %s, got %q", autonomy.LevelHeadlessAuto, finding.Autonomy)
+	}
+	if !reflect.DeepEqual(finding.Permissions, []string{"proc.exec"}) || finding.Severity != model.SeverityMedium {
+		t.Fatalf("unexpected turbo finding: %+v", finding)
+	}
+	if got := evidenceValue(finding, "command_deny_list_count"); got != "2" {
+		t.Fatalf("expected deny list count 2, got %q", got)
+	}
+}
+
+func TestNormalizeAutoExecution(t *testing.T) {
+	t.Parallel()
+
+	for raw, want := range map[string]string{
+		"turbo":          "turbo",
+		"Auto":           "auto",
+		"allowlist_only": "allowlist",
+		"disabled":       "off",
+	} {
+		if got := normalizeAutoExecution(raw); got != want {
+			t.Fatalf("normalizeAutoExecution(%q)=%q want %q", raw, got, want)
+		}
+	}
+}
+
+func evidenceValue(finding model.Finding, key string) string {
+	for _, item := range finding.Evidence {
+		if item.Key == key {
+			return item.Value
+		}
+	}
+	return ""
+}
+
+func writeWindsurfFile(t *testing.T, root, rel, content string) {
+	t.Helper()
+	path := filepath.Join(root, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir %s: %v", rel, err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write %s: %v", rel, err)
+	}
+}
@@ -708,6 +708,71 @@ variables:
 	}
 }
 
+func TestAnalyzeBitbucketPipelineObservesStepsAcrossPipelineKinds(t *testing.T) {
+	t.Parallel()
+
+	result, parseErr := AnalyzeInRoot("", "bitbucket-pipelines.yml", []byte(`image: golang:1.26
+definitions:
+  steps:
+    - step: &test
+        name: Test
+        script:
+          - go test ./...
+pipelines:
+  pull-requests:
+    '**':
+      - step: *test
+  branches:
+    main:
+      - parallel:
+          - step: *test
+          - step:
+              name: Review
+              script:
+                - codex --full-auto --approval never
+      - step:
+          name: Deploy
+          deployment: production
+          trigger: manual
+          oidc: true
+          script:
+            - kubectl apply -f k8s/
+            - pipe: atlassian/slack-notify:2.1.0
+              variables:
+                WEBHOOK_URL: $SLACK_WEBHOOK
+`))
+	if parseErr != nil {
+		t.Fatalf("analyze bitbucket pipeline: %v", parseErr)
+	}
+	if !contains(result.Capabilities, "deploy.write") {
+		t.Fatalf("expected deploy.write capability in %v", result.Capabilities)
+	}
+	if result.Tool != "codex" || !result.Headless {
+		t.Fatalf("expected headless codex step, got tool=%q headless=%v", result.Tool, result.Headless)
+	}
+	if result.DeploymentGate != "approved" {
+		t.Fatalf("expected manual deployment step to be approved, got %q", result.DeploymentGate)
+	}
+	if evidenceValue(result, "ci_platform") != "bitbucket_pipelines" {
+		t.Fatalf("expected ci_platform=bitbucket_pipelines, got %q", evidenceValue(result, "ci_platform"))
+	}
+	if evidenceValue(result, "workflow_triggers") != "pull_request,push" {
+		t.Fatalf("expected pull_request and push triggers, got %q", evidenceValue(result, "workflow_triggers"))
+	}
+	if evidenceValue(result, "workflow_environment") != "production" {
+		t.Fatalf("expected production environment evidence, got %q", evidenceValue(result, "workflow_environment"))
+	}
+	if !strings.Contains(evidenceValue(result, "auth_surfaces"), "bitbucket_oidc") {
+		t.Fatalf("expected bitbucket oidc auth surface, got %q", evidenceValue(result, "auth_surfaces"))
+	}
+	if refs := evidenceValues(result, "workflow_secret_refs"); !contains(refs, "SLACK_WEBHOOK") || !contains(refs, "WEBHOOK_URL") {
+		t.Fatalf("expected pipe secret refs, got %v", refs)
+	}
+	if len(result.JobNames) != 4 {
+		t.Fatalf("expected four observed steps, got %v", result.JobNames)
+	}
+}
+
 func TestAnalyzeAzurePipelineDoesNotTreatOrdinaryRuntimeVariablesAsSecrets(t *testing.T) {
 	t.Parallel()
 
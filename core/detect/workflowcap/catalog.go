@@ -1,6 +1,10 @@
 package workflowcap
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
 	"path/filepath"
 	"sort"
 	"strings"
@@ -108,6 +112,24 @@ func CatalogFor(root string, options detect.Options) (*Catalog, error) {
 	return BuildCatalog(root, options)
 }
 
+// Digest returns a stable fingerprint of every resolved catalog entry so
+// callers can tell when cross-repo workflow inheritance changed a result.
+func (c *Catalog) Digest() (string, error) {
+	if c == nil {
+		return "", nil
+	}
+	entries := make([]CatalogEntry, 0, len(c.paths))
+	for _, path := range c.paths {
+		entries = append(entries, c.entries[path])
+	}
+	payload, err := json.Marshal(entries)
+	if err != nil {
+		return "", fmt.Errorf("marshal workflow catalog: %w", err)
+	}
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:]), nil
+}
+
 func (c *Catalog) Paths() []string {
 	if c == nil {
 		return nil
@@ -172,6 +194,8 @@ func platformForPath(path string) string {
 		return "gitlab_ci"
 	case workflowloc.IsAzurePipelinePath(path):
 		return "azure_pipelines"
+	case workflowloc.IsBitbucketPipeline(path):
+		return "bitbucket_pipelines"
 	default:
 		return "unsupported"
 	}
@@ -31,6 +31,8 @@ func AnalyzeInRoot(root, path string, payload []byte) (Result, *model.ParseError
 		result, parseErr = analyzeGitLabWorkflow(root, path, payload)
 	case workflowloc.IsAzurePipelinePath(path):
 		result, parseErr = analyzeAzureWorkflow(root, path, payload)
+	case workflowloc.IsBitbucketPipeline(path):
+		result, parseErr = analyzeBitbucketWorkflow(path, payload)
 	case workflowloc.IsGitHubWorkflow(path):
 		result, parseErr = analyzeGitHubWorkflow(root, path, payload)
 	case isCompositeAction(path):
@@ -1382,6 +1384,184 @@ func normalizeAzureTemplatePath(currentPath, templatePath string) string {
 	return filepath.ToSlash(filepath.Clean(filepath.Join(baseDir, templatePath)))
 }
 
+type bitbucketDocument struct {
+	Image     any                  `yaml:"image"`
+	Pipelines map[string]yaml.Node `yaml:"pipelines"`
+}
+
+type bitbucketStep struct {
+	Name        string `yaml:"name"`
+	Script      []any  `yaml:"script"`
+	AfterScript []any  `yaml:"after-script"`
+	Deployment  string `yaml:"deployment"`
+	Trigger     string `yaml:"trigger"`
+	Image       any    `yaml:"image"`
+	Services    []any  `yaml:"services"`
+	OIDC        bool   `yaml:"oidc"`
+}
+
+type bitbucketStage struct {
+	Name       string      `yaml:"name"`
+	Deployment string      `yaml:"deployment"`
+	Trigger    string      `yaml:"trigger"`
+	Steps      []yaml.Node `yaml:"steps"`
+}
+
+// bitbucketPipelineTriggers maps bitbucket-pipelines.yml start conditions to
+// the shared trigger vocabulary; custom pipelines only run when started by hand.
+var bitbucketPipelineTriggers = map[string]string{
+	"default":       "push",
+	"branches":      "push",
+	"tags":          "tag",
+	"pull-requests": "pull_request",
+	"custom":        "manual",
+}
+
+func analyzeBitbucketWorkflow(path string, payload []byte) (Result, *model.ParseError) {
+	obs := workflowObservation{
+		platform:     "bitbucket_pipelines",
+		workflowName: strings.TrimSpace(filepath.Base(path)),
+	}
+	var doc bitbucketDocument
+	if err := yaml.Unmarshal(payload, &doc); err != nil {
+		return analyzeObservation(obs), &model.ParseError{Kind: "parse_error", Format: "yaml", Path: path, Detector: detectorID, Message: err.Error()}
+	}
+	for _, kind := range sortedNodeMapKeys(doc.Pipelines) {
+		trigger, ok := bitbucketPipelineTriggers[kind]
+		if !ok {
+			continue
+		}
+		node := doc.Pipelines[kind]
+		obs.triggers = append(obs.triggers, trigger)
+		if kind == "default" {
+			obs.jobs = append(obs.jobs, observeBitbucketItems(kind, &node, doc.Image)...)
+			continue
+		}
+		children := mappingChildren(&node)
+		names := make([]string, 0, len(children))
+		for name := range children {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			obs.jobs = append(obs.jobs, observeBitbucketItems(kind+"/"+name, children[name], doc.Image)...)
+		}
+	}
+	for _, job := range obs.jobs {
+		obs.jobNames = append(obs.jobNames, job.name)
+		if strings.TrimSpace(job.environment) != "" {
+			obs.environments = append(obs.environments, job.environment)
+		}
+	}
+	return analyzeObservation(obs), nil
+}
+
+// observeBitbucketItems flattens a pipeline's step, parallel, and stage
+// entries into one job observation per step.
+func observeBitbucketItems(pipeline string, node *yaml.Node, defaultImage any) []jobObservation {
+	if node == nil || node.Kind != yaml.SequenceNode {
+		return nil
+	}
+	out := []jobObservation{}
+	for _, item := range node.Content {
+		entries := mappingChildren(item)
+		if stepNode := entries["step"]; stepNode != nil {
+			var step bitbucketStep
+			if err := stepNode.Decode(&step); err == nil {
+				out = append(out, observeBitbucketStep(pipeline, step, "", "", defaultImage))
+			}
+		}
+		if parallelNode := entries["parallel"]; parallelNode != nil {
+			if parallelNode.Kind == yaml.MappingNode {
+				parallelNode = mappingChildren(parallelNode)["steps"]
+			}
+			out = append(out, observeBitbucketItems(pipeline, parallelNode, defaultImage)...)
+		}
+		if stageNode := entries["stage"]; stageNode != nil {
+			var stage bitbucketStage
+			if err := stageNode.Decode(&stage); err != nil {
+				continue
+			}
+			for _, stepItem := range stage.Steps {
+				stepNode := mappingChildren(&stepItem)["step"]
+				if stepNode == nil {
+					continue
+				}
+				var step bitbucketStep
+				if err := stepNode.Decode(&step); err == nil {
+					out = append(out, observeBitbucketStep(pipeline, step, stage.Deployment, stage.Trigger, defaultImage))
+				}
+			}
+		}
+	}
+	return out
+}
+
+func observeBitbucketStep(pipeline string, step bitbucketStep, stageDeployment, stageTrigger string, defaultImage any) jobObservation {
+	name := pipeline + ":" + firstNonEmptyString(step.Name, "step")
+	environment := firstNonEmptyString(step.Deployment, stageDeployment)
+	values := []string{strings.ToLower(strings.TrimSpace(name)), strings.ToLower(strings.TrimSpace(environment))}
+	secretRefs := map[string]struct{}{}
+	scripts := []string{}
+	for _, entry := range append(append([]any(nil), step.Script...), step.AfterScript...) {
+		switch typed := entry.(type) {
+		case string:
+			scripts = append(scripts, typed)
+			values = append(values, strings.ToLower(strings.TrimSpace(typed)))
+		case map[string]any:
+			// Pipes run vendor containers whose variables carry the credentials.
+			values = append(values, normalizeDynamicValue(typed["pipe"])...)
+			if variables, ok := typed["variables"].(map[string]any); ok {
+				values = append(values, normalizeDynamicValues(variables)...)
+				for _, key := range sortedMapKeys(variables) {
+					if sensitiveCredentialName(key) {
+						secretRefs[key] = struct{}{}
+					}
+					scripts = append(scripts, fmt.Sprint(variables[key]))
+				}
+			}
+		}
+	}
+	for _, ref := range extractShellVariableRefs(scripts...) {
+		if sensitiveCredentialName(ref) {
+			secretRefs[ref] = struct{}{}
+		}
+	}
+	image := normalizeDynamicValue(step.Image)
+	if len(image) == 0 {
+		image = normalizeDynamicValue(defaultImage)
+	}
+	values = append(values, image...)
+	values = append(values, normalizeStringSlice(step.Services)...)
+	explicitAuth := []string{}
+	if step.OIDC {
+		explicitAuth = append(explicitAuth, "bitbucket_oidc")
+	}
+	manual := strings.EqualFold(strings.TrimSpace(step.Trigger), "manual") || strings.EqualFold(strings.TrimSpace(stageTrigger), "manual")
+	return jobObservation{
+		name:              name,
+		environment:       strings.TrimSpace(environment),
+		values:            dedupeSlice(values),
+		secretRefs:        sortedSet(secretRefs),
+		authSurfaces:      workflowAuthSurfacesFromValues(values, sortedSet(secretRefs), explicitAuth),
+		authorityBindings: workflowAuthorityBindingsFromValues(values, environment, nil),
+		manualStrong:      manual,
+		manualDeclared:    manual,
+		// Deployment environment permissions live in repository settings, not YAML.
+		ambiguousApproval: !manual && strings.TrimSpace(environment) != "",
+		stepCount:         len(step.Script) + len(step.AfterScript),
+	}
+}
+
+func sortedNodeMapKeys(values map[string]yaml.Node) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 func yamlDocumentNode(payload []byte) (*yaml.Node, error) {
 	var root yaml.Node
 	if err := yaml.Unmarshal(payload, &root); err != nil {
@@ -47,6 +47,10 @@ var homeMarkers = []markerSpec{
 	{Path: ".codex/config.yml", ToolType: "codex"},
 	{Path: ".claude/settings.json", ToolType: "claude"},
 	{Path: ".claude/settings.local.json", ToolType: "claude"},
+	{Path: ".gemini/settings.json", ToolType: "gemini"},
+	{Path: ".codeium/windsurf/mcp_config.json", ToolType: "windsurf"},
+	{Path: ".config/Code/User/globalStorage/saoudrizwan.claude-dev/settings/cline_mcp_settings.json", ToolType: "cline"},
+	{Path: "Library/Application Support/Code/User/globalStorage/saoudrizwan.claude-dev/settings/cline_mcp_settings.json", ToolType: "cline"},
 	{Path: ".cursor/mcp.json", ToolType: "cursor"},
 	{Path: ".mcp.json", ToolType: "mcp"},
 }
@@ -56,6 +60,17 @@ var projectMarkers = []markerSpec{
 	{Path: "AGENTS.override.md", ToolType: "codex"},
 	{Path: "CLAUDE.md", ToolType: "claude"},
 	{Path: ".claude", ToolType: "claude"},
+	{Path: "GEMINI.md", ToolType: "gemini"},
+	{Path: ".gemini", ToolType: "gemini"},
+	{Path: ".windsurfrules", ToolType: "windsurf"},
+	{Path: ".windsurf", ToolType: "windsurf"},
+	{Path: ".clinerules", ToolType: "cline"},
+	{Path: ".roomodes", ToolType: "roo_code"},
+	{Path: ".roo", ToolType: "roo_code"},
+	{Path: ".continue", ToolType: "continue"},
+	{Path: ".aider.conf.yml", ToolType: "aider"},
+	{Path: ".amazonq", ToolType: "amazon_q"},
+	{Path: ".kiro", ToolType: "kiro"},
 	{Path: ".agents", ToolType: "skill"},
 	{Path: ".agents/skills", ToolType: "agentic_factory", FindingType: "agentic_factory", Severity: model.SeverityMedium, Surface: "local_agentic_factory", Remediation: "Map this local agentic factory to PR review, branch protection, CI, and credential evidence before treating downstream actions as controlled."},
 	{Path: "factory/skills", ToolType: "agentic_factory", FindingType: "agentic_factory", Severity: model.SeverityMedium, Surface: "local_agentic_factory", Remediation: "Map this local agentic factory to PR review, branch protection, CI, and credential evidence before treating downstream actions as controlled."},
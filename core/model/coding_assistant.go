@@ -0,0 +1,166 @@
+package model
+
+import "strings"
+
+type codingAssistant struct {
+	detector string
+	harness  string
+}
+
+// codingAssistants maps each coding assistant tool type to the detector that
+// emits it and the delivery harness reported when a finding carries no
+// delivery_harness evidence. Copilot has no default harness.
+var codingAssistants = map[string]codingAssistant{
+	"aider":    {detector: "aider", harness: "aider_cli"},
+	"amazon_q": {detector: "amazonq", harness: "amazon_q_developer"},
+	"claude":   {detector: "claude", harness: "claude_code"},
+	"cline":    {detector: "cline", harness: "cline"},
+	"codex":    {detector: "codex", harness: "codex_cli"},
+	"continue": {detector: "continue", harness: "continue"},
+	"copilot":  {detector: "copilot"},
+	"cursor":   {detector: "cursor", harness: "cursor_rules"},
+	"gemini":   {detector: "gemini", harness: "gemini_cli"},
+	"kiro":     {detector: "kiro", harness: "kiro"},
+	"roo_code": {detector: "cline", harness: "roo_code"},
+	"windsurf": {detector: "windsurf", harness: "windsurf_cascade"},
+}
+
+// codingAssistantDirs are directories whose contents configure a coding
+// assistant.
+var codingAssistantDirs = map[string]struct{}{
+	".amazonq":       {},
+	".claude":        {},
+	".claude-plugin": {},
+	".clinerules":    {},
+	".codeium":       {},
+	".codex":         {},
+	".continue":      {},
+	".cursor":        {},
+	".gemini":        {},
+	".kiro":          {},
+	".roo":           {},
+	".windsurf":      {},
+}
+
+// codingAssistantFiles are file names coding assistants read configuration
+// or instructions from wherever they sit in the repository.
+var codingAssistantFiles = map[string]struct{}{
+	".aider.conf.yaml":        {},
+	".aider.conf.yml":         {},
+	".clinerules":             {},
+	".cursorrules":            {},
+	".roomodes":               {},
+	".windsurfrules":          {},
+	"agents.md":               {},
+	"agents.override.md":      {},
+	"claude.md":               {},
+	"cline_mcp_settings.json": {},
+	"copilot-instructions.md": {},
+	"gemini.md":               {},
+}
+
+// codingAssistantInstructionFiles are the standalone instruction files
+// within codingAssistantFiles.
+var codingAssistantInstructionFiles = map[string]struct{}{
+	".clinerules":             {},
+	".cursorrules":            {},
+	".windsurfrules":          {},
+	"agents.md":               {},
+	"agents.override.md":      {},
+	"claude.md":               {},
+	"copilot-instructions.md": {},
+	"gemini.md":               {},
+}
+
+// codingAssistantRuleDirs maps a configuration directory to the
+// subdirectory prefix holding its rules; Roo Code also reads per-mode
+// `rules-<mode>/` directories.
+var codingAssistantRuleDirs = map[string]string{
+	".amazonq":  "rules",
+	".continue": "rules",
+	".cursor":   "rules",
+	".kiro":     "steering",
+	".roo":      "rules",
+	".windsurf": "rules",
+}
+
+// IsCodingAssistantToolType returns whether toolType is emitted by a coding
+// assistant detector.
+func IsCodingAssistantToolType(toolType string) bool {
+	_, ok := codingAssistants[strings.ToLower(strings.TrimSpace(toolType))]
+	return ok
+}
+
+// IsCodingAssistantDetector returns whether detectorID names a coding
+// assistant detector.
+func IsCodingAssistantDetector(detectorID string) bool {
+	normalized := strings.ToLower(strings.TrimSpace(detectorID))
+	for _, assistant := range codingAssistants {
+		if assistant.detector == normalized {
+			return true
+		}
+	}
+	return false
+}
+
+// CodingAssistantHarness returns the default delivery harness for a coding
+// assistant tool type, or "" when it has none.
+func CodingAssistantHarness(toolType string) string {
+	return codingAssistants[strings.ToLower(strings.TrimSpace(toolType))].harness
+}
+
+// IsCodingAssistantLocation returns whether location sits in a coding
+// assistant configuration directory or names a coding assistant
+// configuration or instruction file. Whole path segments are compared, so
+// `.roo/` matches and `.rootfs/` does not.
+func IsCodingAssistantLocation(location string) bool {
+	segments := locationSegments(location)
+	for idx, segment := range segments {
+		if _, ok := codingAssistantDirs[segment]; ok && idx < len(segments)-1 {
+			return true
+		}
+	}
+	if len(segments) == 0 {
+		return false
+	}
+	_, ok := codingAssistantFiles[segments[len(segments)-1]]
+	return ok
+}
+
+// IsCodingAssistantInstructionLocation returns whether location is a coding
+// assistant instruction file or sits in a rules directory such as
+// `.cursor/rules/`, `.roo/rules-code/`, or `.kiro/steering/`.
+func IsCodingAssistantInstructionLocation(location string) bool {
+	segments := locationSegments(location)
+	if len(segments) == 0 {
+		return false
+	}
+	if _, ok := codingAssistantInstructionFiles[segments[len(segments)-1]]; ok {
+		return true
+	}
+	for idx := 0; idx < len(segments)-1; idx++ {
+		if segments[idx] == ".clinerules" {
+			return true
+		}
+		prefix, ok := codingAssistantRuleDirs[segments[idx]]
+		if !ok || idx+1 >= len(segments)-1 {
+			continue
+		}
+		next := segments[idx+1]
+		if next == prefix || (segments[idx] == ".roo" && strings.HasPrefix(next, prefix+"-")) {
+			return true
+		}
+	}
+	return false
+}
+
+func locationSegments(location string) []string {
+	normalized := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(location), "\\", "/"))
+	segments := make([]string, 0, 4)
+	for _, segment := range strings.Split(normalized, "/") {
+		if segment != "" && segment != "." {
+			segments = append(segments, segment)
+		}
+	}
+	return segments
+}
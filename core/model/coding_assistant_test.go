@@ -0,0 +1,82 @@
+package model
+
+import "testing"
+
+func TestCodingAssistantToolTypesAndDetectors(t *testing.T) {
+	t.Parallel()
+
+	for _, toolType := range []string{"claude", "Copilot", "roo_code", "amazon_q", " kiro "} {
+		if !IsCodingAssistantToolType(toolType) {
+			t.Fatalf("expected %q to be a coding assistant tool type", toolType)
+		}
+	}
+	for _, toolType := range []string{"", "mcp", "langchain", "ci_agent", "amazonq"} {
+		if IsCodingAssistantToolType(toolType) {
+			t.Fatalf("expected %q not to be a coding assistant tool type", toolType)
+		}
+	}
+	if !IsCodingAssistantDetector("amazonq") || IsCodingAssistantDetector("roo_code") || IsCodingAssistantDetector("mcp") {
+		t.Fatal("expected detector ids, not tool types, to match coding assistant detectors")
+	}
+	if got := CodingAssistantHarness("roo_code"); got != "roo_code" {
+		t.Fatalf("unexpected roo_code harness %q", got)
+	}
+	if got := CodingAssistantHarness("copilot"); got != "" {
+		t.Fatalf("expected no default copilot harness, got %q", got)
+	}
+}
+
+func TestIsCodingAssistantLocationMatchesWholeSegments(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]bool{
+		".roo/mcp.json":                      true,
+		".roomodes":                          true,
+		"services/api/.kiro/hooks/lint.json": true,
+		".windsurf/rules/style.md":           true,
+		".codeium/windsurf/mcp_config.json":  true,
+		`packages\web\AGENTS.md`:             true,
+		".github/copilot-instructions.md":    true,
+		".aider.conf.yml":                    true,
+		".rootfs/agent.yaml":                 false,
+		"docs/.roomba/notes.md":              false,
+		".kirov/config.json":                 false,
+		".windsurfing/agent.py":              false,
+		"src/my.claude/notes.txt":            false,
+		"docs/agents.md.bak":                 false,
+		".roo":                               false,
+		"":                                   false,
+	}
+	for location, want := range cases {
+		if got := IsCodingAssistantLocation(location); got != want {
+			t.Fatalf("IsCodingAssistantLocation(%q) = %t, want %t", location, got, want)
+		}
+	}
+}
+
+func TestIsCodingAssistantInstructionLocation(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]bool{
+		"AGENTS.md":                          true,
+		".cursor/rules/go.mdc":               true,
+		".roo/rules-code/style.md":           true,
+		".roo/rules/style.md":                true,
+		".clinerules/testing.md":             true,
+		".clinerules":                        true,
+		".kiro/steering/product.md":          true,
+		".amazonq/rules/security.md":         true,
+		".kiro/hooks/lint.json":              false,
+		".roo/mcp.json":                      false,
+		".roomodes":                          false,
+		".cursor/mcp.json":                   false,
+		"docs/rules/style.md":                false,
+		".rooster/rules/style.md":            false,
+		".windsurf/rulesets/legacy/style.md": false,
+	}
+	for location, want := range cases {
+		if got := IsCodingAssistantInstructionLocation(location); got != want {
+			t.Fatalf("IsCodingAssistantInstructionLocation(%q) = %t, want %t", location, got, want)
+		}
+	}
+}
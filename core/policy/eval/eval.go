@@ -392,11 +392,22 @@ func isPromptChannelFinding(finding model.Finding) bool {
 
 func agentFindings(findings []model.Finding) []model.Finding {
 	agentToolTypes := map[string]struct{}{
-		"langchain":     {},
-		"crewai":        {},
-		"openai_agents": {},
-		"autogen":       {},
-		"llamaindex":    {},
+		"langchain":       {},
+		"langgraph":       {},
+		"crewai":          {},
+		"openai_agents":   {},
+		"autogen":         {},
+		"llamaindex":      {},
+		"google_adk":      {},
+		"pydantic_ai":     {},
+		"smolagents":      {},
+		"dspy":            {},
+		"haystack":        {},
+		"strands":         {},
+		"vercel_ai":       {},
+		"mastra":          {},
+		"spring_ai":       {},
+		"semantic_kernel": {},
 	}
 	out := make([]model.Finding, 0)
 	for _, finding := range findings {
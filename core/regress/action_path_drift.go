@@ -837,6 +837,8 @@ func actionPathPlatform(path risk.ActionPath) string {
 		return "gitlab_ci"
 	case strings.HasSuffix(location, "azure-pipelines.yml"), strings.HasSuffix(location, "azure-pipelines.yaml"), strings.HasPrefix(location, ".azure/pipelines/"), strings.Contains(location, "/.azure/pipelines/"):
 		return "azure_devops"
+	case strings.HasSuffix(location, "bitbucket-pipelines.yml"), strings.HasSuffix(location, "bitbucket-pipelines.yaml"):
+		return "bitbucket_pipelines"
 	case strings.HasSuffix(location, "jenkinsfile"), strings.Contains(location, "/jenkinsfile"):
 		return "jenkins"
 	default:
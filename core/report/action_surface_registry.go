@@ -161,6 +161,7 @@ func actionSurfaceType(path risk.ActionPath) string {
 		strings.HasSuffix(location, ".gitlab-ci.yml") || strings.HasSuffix(location, ".gitlab-ci.yaml") ||
 		strings.Contains(location, "/.gitlab/ci/") || strings.HasSuffix(location, "azure-pipelines.yml") ||
 		strings.HasSuffix(location, "azure-pipelines.yaml") || strings.Contains(location, "/.azure/pipelines/") ||
+		strings.HasSuffix(location, "bitbucket-pipelines.yml") || strings.HasSuffix(location, "bitbucket-pipelines.yaml") ||
 		toolType == "ci_agent":
 		return "workflow"
 	case toolType == "openapi":
@@ -462,9 +462,11 @@ func scanScopeLabel(mode string, targetCount int) (string, string) {
 			return "local repo group", "repo_group"
 		}
 		return "local repository path", "local_path"
-	case "repo":
+	case "archive":
+		return "offline archive", "archive"
+	case "repo", "gitlab-project", "bitbucket-repo":
 		return "remote repository", "remote_repo"
-	case "org":
+	case "org", "enterprise", "gitlab-group", "bitbucket-workspace", "azdo":
 		return "remote organization", "remote_org"
 	case source.TargetModeMulti:
 		return "multi-target scan", "multi_target"
@@ -2128,6 +2130,7 @@ func sanitizeActionPathsPublicWithContractRefs(in []risk.ActionPath, proposedCon
 		copyItem.ResolverRefs = redactStringSlice(copyItem.ResolverRefs, "resolver")
 		copyItem.EvalConfigRefs = redactStringSlice(copyItem.EvalConfigRefs, "eval")
 		copyItem.SandboxGates = redactStringSlice(copyItem.SandboxGates, "sandbox")
+		copyItem.DenyRules = redactStringSlice(copyItem.DenyRules, "deny")
 		copyItem.TestGates = redactStringSlice(copyItem.TestGates, "test")
 		copyItem.ValidationRequirements = redactStringSlice(copyItem.ValidationRequirements, "validation")
 		copyItem.HighStakesPresets = sanitizeHighStakesPresetsPublic(copyItem.HighStakesPresets)
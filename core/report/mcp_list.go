@@ -10,6 +10,7 @@ import (
 
 	agginventory "github.com/Clyra-AI/wrkr/core/aggregate/inventory"
 	"github.com/Clyra-AI/wrkr/core/aggregate/scanquality"
+	"github.com/Clyra-AI/wrkr/core/detect/mcp"
 	"github.com/Clyra-AI/wrkr/core/model"
 	"github.com/Clyra-AI/wrkr/core/risk"
 	"github.com/Clyra-AI/wrkr/core/source"
@@ -45,6 +46,7 @@ type MCPListRow struct {
 	Transport            string                   `json:"transport"`
 	RequestedPermissions []string                 `json:"requested_permissions,omitempty"`
 	PrivilegeSurface     []string                 `json:"privilege_surface,omitempty"`
+	CredentialRefs       []string                 `json:"credential_refs,omitempty"`
 	GatewayCoverage      string                   `json:"gateway_coverage"`
 	TrustDepth           *agginventory.TrustDepth `json:"trust_depth,omitempty"`
 	TrustStatus          string                   `json:"trust_status"`
@@ -145,6 +147,7 @@ func BuildMCPListWithOptions(snapshot state.Snapshot, opts MCPListOptions) MCPLi
 			Transport:            fallbackString(evidence["transport"], "unknown"),
 			RequestedPermissions: append([]string(nil), finding.Permissions...),
 			PrivilegeSurface:     privilegeSurface,
+			CredentialRefs:       mcpRowCredentialRefs(evidence),
 			GatewayCoverage:      fallbackString(gatewayCoverage[rowKey], "unknown"),
 			TrustDepth:           agginventory.TrustDepthFromFinding(finding),
 			TrustStatus:          trustStatus,
@@ -627,6 +630,16 @@ func sortMCPDiagnostics(items []MCPMissDiagnostic) {
 	})
 }
 
+// mcpRowCredentialRefs lists environment variable names and VS Code
+// `${input:<id>}` prompts, prefixed `input:`, that supply server credentials.
+func mcpRowCredentialRefs(evidence map[string]string) []string {
+	refs := splitMCPListCSV(evidence["credential_env_refs"])
+	for _, id := range splitMCPListCSV(evidence["credential_input_refs"]) {
+		refs = append(refs, "input:"+id)
+	}
+	return uniqueMCPListStrings(refs)
+}
+
 func splitMCPListCSV(value string) []string {
 	if strings.TrimSpace(value) == "" {
 		return nil
@@ -693,21 +706,8 @@ func MCPVisibilityWarnings(findings []source.Finding) []string {
 }
 
 func isKnownMCPDeclarationPath(location string) bool {
-	switch strings.TrimSpace(location) {
-	case ".mcp.json",
-		".cursor/mcp.json",
-		".vscode/mcp.json",
-		"mcp.json",
-		"managed-mcp.json",
-		".claude/settings.json",
-		".claude/settings.local.json",
-		".codex/config.toml",
-		".codex/config.yaml",
-		".codex/config.yml":
-		return true
-	default:
-		return false
-	}
+	trimmed := strings.TrimSpace(location)
+	return mcp.IsConfigPath(trimmed) || trimmed == ".codex/config.yml"
 }
 
 func ResolveGeneratedAtForCLI(snapshot state.Snapshot, generatedAt time.Time) time.Time {
@@ -144,6 +144,7 @@ func sanitizeActionPathsWithConfigAndContractRefs(in []risk.ActionPath, config R
 		copyItem.ResolverRefs = maybeRedactLocationLikeSlice(copyItem.ResolverRefs, config)
 		copyItem.EvalConfigRefs = maybeRedactLocationLikeSlice(copyItem.EvalConfigRefs, config)
 		copyItem.SandboxGates = maybeRedactLocationLikeSlice(copyItem.SandboxGates, config)
+		copyItem.DenyRules = maybeRedactLocationLikeSlice(copyItem.DenyRules, config)
 		copyItem.TestGates = maybeRedactCompositeLabelSlice(copyItem.TestGates, config)
 		copyItem.ValidationRequirements = maybeRedactCompositeLabelSlice(copyItem.ValidationRequirements, config)
 		copyItem.HighStakesPresets = sanitizeHighStakesPresetsWithConfig(copyItem.HighStakesPresets, config)
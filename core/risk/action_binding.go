@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	agginventory "github.com/Clyra-AI/wrkr/core/aggregate/inventory"
+	"github.com/Clyra-AI/wrkr/core/model"
 )
 
 const (
@@ -90,8 +91,8 @@ func pathHasContextSurfaceCorrelation(path ActionPath) bool {
 		return true
 	}
 	if pathIsAgentInstructionControlSurface(path) {
-		switch strings.TrimSpace(strings.ToLower(path.ToolType)) {
-		case "codex", "claude", "cursor", "skill", "prompt_channel":
+		toolType := strings.TrimSpace(strings.ToLower(path.ToolType))
+		if (model.IsCodingAssistantToolType(toolType) && toolType != "copilot") || toolType == "skill" || toolType == "prompt_channel" {
 			return true
 		}
 	}
@@ -220,17 +221,7 @@ func pathIsAgentInstructionControlSurface(path ActionPath) bool {
 	switch {
 	case toolType == "skill":
 		return true
-	case strings.HasSuffix(location, "agents.md"),
-		strings.HasSuffix(location, "agents.override.md"),
-		strings.HasSuffix(location, "claude.md"),
-		strings.HasSuffix(location, ".cursorrules"),
-		strings.Contains(location, ".cursor/rules/"),
-		strings.HasSuffix(location, ".codex/config.toml"),
-		strings.HasSuffix(location, ".codex/config.yaml"),
-		strings.HasSuffix(location, ".codex/config.yml"),
-		strings.HasSuffix(location, ".cursor/mcp.json"),
-		strings.HasSuffix(location, ".claude/settings.json"),
-		strings.HasSuffix(location, ".claude/settings.local.json"),
+	case model.IsCodingAssistantLocation(location),
 		strings.HasSuffix(location, ".mcp.json"),
 		strings.HasSuffix(location, "/skill.md"):
 		return true
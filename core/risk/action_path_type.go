@@ -139,7 +139,7 @@ func actionPathTypeRank(value string) int {
 
 func isAgentFrameworkToolType(toolType string) bool {
 	switch strings.TrimSpace(toolType) {
-	case "langchain", "langgraph", "crewai", "autogen", "llamaindex", "openai_agents", "semantic_kernel", "haystack", "custom_agent":
+	case "langchain", "langgraph", "crewai", "autogen", "llamaindex", "openai_agents", "google_adk", "pydantic_ai", "smolagents", "dspy", "haystack", "strands", "vercel_ai", "mastra", "spring_ai", "semantic_kernel", "custom_agent":
 		return true
 	default:
 		return false
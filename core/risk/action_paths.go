@@ -229,6 +229,7 @@ type ActionPath struct {
 	EvalConfigRefs                      []string                                `json:"eval_config_refs,omitempty"`
 	DryRunRequired                      bool                                    `json:"dry_run_required,omitempty"`
 	SandboxGates                        []string                                `json:"sandbox_gates,omitempty"`
+	DenyRules                           []string                                `json:"deny_rules,omitempty"`
 	TestGates                           []string                                `json:"test_gates,omitempty"`
 	ValidationRequirements              []string                                `json:"validation_requirements,omitempty"`
 	ExecutionRelationships              []model.ExecutionRelationship           `json:"execution_relationships,omitempty"`
@@ -341,6 +342,7 @@ func buildActionPath(
 		EvalConfigRefs:              dedupeSortedStrings(entry.EvalConfigRefs),
 		DryRunRequired:              entry.DryRunRequired,
 		SandboxGates:                dedupeSortedStrings(entry.SandboxGates),
+		DenyRules:                   dedupeSortedStrings(entry.DenyRules),
 		TestGates:                   dedupeSortedStrings(entry.TestGates),
 		ValidationRequirements:      dedupeSortedStrings(entry.ValidationRequirements),
 		ExecutionRelationships:      model.NormalizeExecutionRelationships(entry.ExecutionRelationships),
@@ -670,6 +672,7 @@ func mergeActionPath(current, incoming ActionPath) ActionPath {
 	merged.EvalConfigRefs = dedupeSortedStrings(append(append([]string(nil), current.EvalConfigRefs...), incoming.EvalConfigRefs...))
 	merged.DryRunRequired = current.DryRunRequired || incoming.DryRunRequired
 	merged.SandboxGates = dedupeSortedStrings(append(append([]string(nil), current.SandboxGates...), incoming.SandboxGates...))
+	merged.DenyRules = dedupeSortedStrings(append(append([]string(nil), current.DenyRules...), incoming.DenyRules...))
 	merged.TestGates = dedupeSortedStrings(append(append([]string(nil), current.TestGates...), incoming.TestGates...))
 	merged.ValidationRequirements = dedupeSortedStrings(append(append([]string(nil), current.ValidationRequirements...), incoming.ValidationRequirements...))
 	merged.ExecutionRelationships = model.NormalizeExecutionRelationships(append(append([]model.ExecutionRelationship(nil), current.ExecutionRelationships...), incoming.ExecutionRelationships...))
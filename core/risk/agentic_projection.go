@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	agginventory "github.com/Clyra-AI/wrkr/core/aggregate/inventory"
+	"github.com/Clyra-AI/wrkr/core/model"
 )
 
 const (
@@ -613,9 +614,7 @@ func sensitiveInfraSurface(path ActionPath) bool {
 		strings.Contains(location, "payment") ||
 		strings.Contains(location, "publish") ||
 		strings.Contains(location, ".mcp") ||
-		strings.Contains(location, "agents.md") ||
-		strings.Contains(location, "claude.md") ||
-		strings.Contains(location, ".cursorrules") {
+		model.IsCodingAssistantInstructionLocation(location) {
 		return true
 	}
 	switch strings.TrimSpace(path.RiskZone) {
@@ -633,9 +632,7 @@ func workflowOrToolingControlSurface(path ActionPath) bool {
 	return strings.Contains(location, ".github/workflows") ||
 		strings.Contains(location, "jenkinsfile") ||
 		strings.Contains(location, ".mcp") ||
-		strings.Contains(location, "agents.md") ||
-		strings.Contains(location, "claude.md") ||
-		strings.Contains(location, ".cursorrules")
+		model.IsCodingAssistantInstructionLocation(location)
 }
 
 func hasClassificationReason(path ActionPath, reason string) bool {
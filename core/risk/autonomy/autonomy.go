@@ -1,6 +1,9 @@
 package autonomy
 
-import "strings"
+import (
+	"strings"
+	"unicode"
+)
 
 const (
 	LevelInteractive  = "interactive"
@@ -16,14 +19,19 @@ type Signals struct {
 	HasApprovalGate bool
 	HasSecretAccess bool
 	DangerousFlags  bool
+	// AutoApprovedWrite marks interactive tools configured to run write or
+	// exec tools without per-call approval, which is headless-equivalent.
+	AutoApprovedWrite bool
 }
 
 func Classify(signals Signals) string {
 	tool := strings.ToLower(strings.TrimSpace(signals.Tool))
-	if strings.Contains(tool, "copilot") {
+	// Copilot keeps its own level unless it is configured to auto-approve
+	// write or exec tools.
+	if strings.Contains(tool, "copilot") && !signals.AutoApprovedWrite {
 		return LevelCopilot
 	}
-	if !signals.Headless {
+	if !signals.Headless && !signals.AutoApprovedWrite {
 		return LevelInteractive
 	}
 	if signals.HasApprovalGate {
@@ -35,3 +43,81 @@ func Classify(signals Signals) string {
 func IsCritical(signals Signals) bool {
 	return Classify(signals) == LevelHeadlessAuto && signals.HasSecretAccess && signals.DangerousFlags
 }
+
+// writeOrExecVerbs and readOnlyVerbs are the verbs tool names are built
+// from; clauseJoiners start a new verb clause within one name.
+var (
+	writeOrExecVerbs = map[string]struct{}{
+		"write": {}, "edit": {}, "create": {}, "update": {}, "delete": {}, "remove": {},
+		"move": {}, "push": {}, "merge": {}, "commit": {}, "shell": {}, "exec": {},
+		"execute": {}, "run": {}, "command": {}, "deploy": {}, "replace": {}, "apply": {},
+		"insert": {}, "patch": {}, "append": {}, "rename": {}, "install": {}, "bash": {},
+		"terminal": {}, "post": {}, "put": {}, "send": {},
+	}
+	readOnlyVerbs = map[string]struct{}{
+		"get": {}, "list": {}, "search": {}, "read": {}, "fetch": {}, "find": {},
+		"query": {}, "describe": {}, "view": {}, "show": {}, "check": {},
+	}
+	clauseJoiners = map[string]struct{}{"and": {}, "or": {}, "then": {}}
+)
+
+// IsWriteOrExecTool classifies an auto-approved tool name so detectors can
+// set AutoApprovedWrite for write and exec tools. The name is split on `_`,
+// `-`, `.`, spaces, and camelCase boundaries after any `server/` or
+// `mcp__server__` namespace, and every segment is checked for a write or exec
+// verb. Once a read-only verb has appeared, later write verbs only count when
+// they start a new clause, so `search_and_replace` and `git_commit` match
+// while `get_workflow_run` and `getHTTPCommandHistory` stay read-only.
+func IsWriteOrExecTool(tool string) bool {
+	name := strings.TrimSpace(tool)
+	if idx := strings.LastIndex(name, "__"); idx >= 0 {
+		name = name[idx+2:]
+	}
+	if idx := strings.LastIndex(name, "/"); idx >= 0 {
+		name = name[idx+1:]
+	}
+	afterRead := false
+	newClause := false
+	for _, segment := range nameSegments(name) {
+		if _, ok := writeOrExecVerbs[segment]; ok && (!afterRead || newClause) {
+			return true
+		}
+		if _, ok := readOnlyVerbs[segment]; ok {
+			afterRead = true
+		}
+		_, newClause = clauseJoiners[segment]
+	}
+	return false
+}
+
+// nameSegments lowercases name and splits it into words on separators and
+// camelCase boundaries; `HTTPRequest` splits as `http`, `request`.
+func nameSegments(name string) []string {
+	runes := []rune(name)
+	segments := make([]string, 0, 4)
+	start := -1
+	flush := func(end int) {
+		if start >= 0 && end > start {
+			segments = append(segments, strings.ToLower(string(runes[start:end])))
+		}
+		start = -1
+	}
+	for idx, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			flush(idx)
+			continue
+		}
+		if start >= 0 && unicode.IsUpper(r) {
+			prev := runes[idx-1]
+			nextLower := idx+1 < len(runes) && unicode.IsLower(runes[idx+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				flush(idx)
+			}
+		}
+		if start < 0 {
+			start = idx
+		}
+	}
+	flush(len(runes))
+	return segments
+}
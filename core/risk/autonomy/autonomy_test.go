@@ -12,8 +12,10 @@ func TestClassify(t *testing.T) {
 	}{
 		{name: "interactive default", signal: Signals{}, want: LevelInteractive},
 		{name: "copilot override", signal: Signals{Tool: "copilot", Headless: true}, want: LevelCopilot},
+		{name: "copilot auto-approved write", signal: Signals{Tool: "copilot", AutoApprovedWrite: true}, want: LevelHeadlessAuto},
 		{name: "headless gated", signal: Signals{Headless: true, HasApprovalGate: true}, want: LevelHeadlessGate},
 		{name: "headless auto", signal: Signals{Headless: true}, want: LevelHeadlessAuto},
+		{name: "auto-approved write", signal: Signals{Tool: "cline", AutoApprovedWrite: true}, want: LevelHeadlessAuto},
 	}
 	for _, tc := range cases {
 		tc := tc
@@ -36,3 +38,67 @@ func TestIsCritical(t *testing.T) {
 		t.Fatal("did not expect critical without secret access")
 	}
 }
+
+func TestIsWriteOrExecTool(t *testing.T) {
+	t.Parallel()
+	for tool, want := range map[string]bool{
+		"create_pull_request":   true,
+		"run_command":           true,
+		"runCommand":            true,
+		"write_file":            true,
+		"execute-command":       true,
+		"github/push_files":     true,
+		"mcp__github__merge_pr": true,
+		"git_commit":            true,
+		"search_and_replace":    true,
+		"find_and_replace":      true,
+		"replace_in_file":       true,
+		"apply_diff":            true,
+		"insert_content":        true,
+		"patch_file":            true,
+		"append_to_file":        true,
+		"rename_file":           true,
+		"install_package":       true,
+		"bash":                  true,
+		"terminal":              true,
+		"run_in_terminal":       true,
+		"http_post":             true,
+		"put_object":            true,
+		"send_message":          true,
+		"get_terminal_output":   false,
+		"git_status":            false,
+		"read_file":             false,
+		"search_docs":           false,
+		"list_commits":          false,
+		"get_workflow_run":      false,
+		"list_commands":         false,
+		"get_updates":           false,
+		"search_created_issues": false,
+		"getHTTPCommandHistory": false,
+		"":                      false,
+	} {
+		if got := IsWriteOrExecTool(tool); got != want {
+			t.Fatalf("IsWriteOrExecTool(%q)=%t want %t", tool, got, want)
+		}
+	}
+}
+
+func TestRulePermission(t *testing.T) {
+	t.Parallel()
+	for rule, want := range map[string]string{
+		"Bash(git push:*)":       "proc.exec",
+		"Edit(/infra/**)":        "filesystem.write",
+		"MultiEdit":              "filesystem.write",
+		"Read(./.env)":           "filesystem.read",
+		"WebFetch(domain:x.com)": "network.access",
+		"mcp__github__merge_pr":  "mcp.access",
+		"TodoWrite":              "",
+	} {
+		if got := RulePermission(rule); got != want {
+			t.Fatalf("RulePermission(%q)=%q want %q", rule, got, want)
+		}
+	}
+	if rule := ParsePermissionRule(" Bash( git status ) "); rule.Tool != "Bash" || rule.Specifier != "git status" {
+		t.Fatalf("unexpected parsed rule %+v", rule)
+	}
+}
@@ -0,0 +1,52 @@
+package autonomy
+
+import "strings"
+
+// PermissionRule is a Claude Code permission rule such as `Bash(git push:*)`
+// split into its tool name and optional specifier.
+type PermissionRule struct {
+	Tool      string
+	Specifier string
+}
+
+// ParsePermissionRule splits raw into its tool name and the specifier in
+// parentheses, if any.
+func ParsePermissionRule(raw string) PermissionRule {
+	trimmed := strings.TrimSpace(raw)
+	open := strings.Index(trimmed, "(")
+	if open < 0 || !strings.HasSuffix(trimmed, ")") {
+		return PermissionRule{Tool: trimmed}
+	}
+	return PermissionRule{
+		Tool:      strings.TrimSpace(trimmed[:open]),
+		Specifier: strings.TrimSpace(trimmed[open+1 : len(trimmed)-1]),
+	}
+}
+
+// RulePermission maps a permission rule such as `Bash(git push:*)` or
+// `Read(./.env)` to the permission its tool grants, or "" when the tool has
+// no side effect.
+func RulePermission(rule string) string {
+	return ToolPermission(ParsePermissionRule(rule).Tool)
+}
+
+// ToolPermission maps Claude Code tool names used in permission rules and
+// frontmatter tool lists to permissions. Tools without a side effect, such as
+// Task or TodoWrite, map to no permission.
+func ToolPermission(tool string) string {
+	normalized := strings.ToLower(strings.TrimSpace(tool))
+	switch {
+	case strings.HasPrefix(normalized, "mcp__"):
+		return "mcp.access"
+	case normalized == "bash":
+		return "proc.exec"
+	case normalized == "edit", normalized == "write", normalized == "multiedit", normalized == "notebookedit":
+		return "filesystem.write"
+	case normalized == "read", normalized == "glob", normalized == "grep", normalized == "ls", normalized == "notebookread":
+		return "filesystem.read"
+	case normalized == "webfetch", normalized == "websearch":
+		return "network.access"
+	default:
+		return ""
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	agginventory "github.com/Clyra-AI/wrkr/core/aggregate/inventory"
 	"github.com/Clyra-AI/wrkr/core/evidencepolicy"
+	"github.com/Clyra-AI/wrkr/core/model"
 )
 
 const (
@@ -877,10 +878,7 @@ func pathIsPromptOrInstructionSurface(path ActionPath) bool {
 	switch {
 	case toolType == "prompt_channel":
 		return true
-	case strings.Contains(location, "agents.md"),
-		strings.Contains(location, "claude.md"),
-		strings.Contains(location, ".cursorrules"),
-		strings.Contains(location, ".cursor/rules"),
+	case model.IsCodingAssistantInstructionLocation(location),
 		strings.Contains(location, "prompt"),
 		strings.Contains(location, "instruction"):
 		return true
@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	agginventory "github.com/Clyra-AI/wrkr/core/aggregate/inventory"
+	"github.com/Clyra-AI/wrkr/core/model"
 )
 
 const (
@@ -97,8 +98,7 @@ func ciPathHasAgenticInfluence(path ActionPath) bool {
 	case ActionPathTypeAIAssistedWorkflow, ActionPathTypeAgentFramework, ActionPathTypeAgentInstruction, ActionPathTypeAutomationBot:
 		return true
 	}
-	switch strings.ToLower(strings.TrimSpace(path.ToolType)) {
-	case "claude", "codex", "cursor", "copilot", "openai_agents", "langchain", "langgraph", "crewai", "autogen", "llamaindex", "semantic_kernel", "custom_agent":
+	if toolType := strings.ToLower(strings.TrimSpace(path.ToolType)); model.IsCodingAssistantToolType(toolType) || isAgentFrameworkToolType(toolType) {
 		return true
 	}
 	switch strings.ToLower(strings.TrimSpace(path.AutonomyLevel)) {
@@ -25,6 +25,8 @@ func EndpointClass(finding model.Finding) string {
 			strings.Contains(deploymentArtifacts, "azure-pipelines.yml"),
 			strings.Contains(deploymentArtifacts, "azure-pipelines.yaml"),
 			strings.Contains(deploymentArtifacts, ".azure/pipelines/"),
+			strings.Contains(deploymentArtifacts, "bitbucket-pipelines.yml"),
+			strings.Contains(deploymentArtifacts, "bitbucket-pipelines.yaml"),
 			strings.Contains(deploymentArtifacts, "jenkinsfile"),
 			deploymentStatus == "deployed" || deploymentStatus == "ambiguous",
 			autoDeploy:
@@ -53,7 +55,7 @@ func EndpointClass(finding model.Finding) string {
 			return "network_service"
 		}
 		return "local_service"
-	case strings.Contains(location, ".claude/"), strings.Contains(location, ".cursor/"), strings.Contains(location, ".codex/"), strings.Contains(location, "agents.md"):
+	case model.IsCodingAssistantLocation(location):
 		return "repo_config"
 	default:
 		return "workspace"
@@ -70,6 +70,48 @@ func TestOwnerEvidenceAloneDoesNotResolveVisibleControl(t *testing.T) {
 	}
 }
 
+func TestDenyRulesResolveDetectedControl(t *testing.T) {
+	t.Parallel()
+
+	paths := DecorateEvidenceContext([]ActionPath{{
+		PathID:                "apc-deny-rules",
+		Org:                   "acme",
+		Repo:                  "platform",
+		ToolType:              "claude",
+		Location:              ".claude/settings.json",
+		WriteCapable:          true,
+		ActionClasses:         []string{agginventory.ActionClassExecute, agginventory.ActionClassWrite},
+		DenyRules:             []string{"bash(git push:*)"},
+		ApprovalEvidenceState: EvidenceStateUnknown,
+		ProofEvidenceState:    EvidenceStateUnknown,
+		RuntimeEvidenceState:  EvidenceStateUnknown,
+	}}, nil)
+	if got := paths[0].ControlResolutionState; got != ControlResolutionStateDetectedControl {
+		t.Fatalf("deny rules are control evidence; got %q", got)
+	}
+}
+
+func TestUnrelatedDenyRulesAreNotControlEvidence(t *testing.T) {
+	t.Parallel()
+
+	paths := DecorateEvidenceContext([]ActionPath{{
+		PathID:                "apc-read-deny",
+		Org:                   "acme",
+		Repo:                  "platform",
+		ToolType:              "claude",
+		Location:              ".claude/settings.json",
+		WriteCapable:          true,
+		ActionClasses:         []string{agginventory.ActionClassExecute, agginventory.ActionClassWrite},
+		DenyRules:             []string{"read(./.env)", "webfetch(domain:example.com)"},
+		ApprovalEvidenceState: EvidenceStateUnknown,
+		ProofEvidenceState:    EvidenceStateUnknown,
+		RuntimeEvidenceState:  EvidenceStateUnknown,
+	}}, nil)
+	if got := paths[0].ControlResolutionState; got != ControlResolutionStateNoVisibleControl {
+		t.Fatalf("a read deny does not control shell or write access; got %q", got)
+	}
+}
+
 func TestClosureRequirementsForExpiredEvidenceAndRuntimeGap(t *testing.T) {
 	t.Parallel()
 
@@ -7,6 +7,7 @@ import (
 	"github.com/Clyra-AI/wrkr/core/attribution"
 	"github.com/Clyra-AI/wrkr/core/evidencepolicy"
 	"github.com/Clyra-AI/wrkr/core/owners"
+	"github.com/Clyra-AI/wrkr/core/risk/autonomy"
 )
 
 const (
@@ -546,7 +547,12 @@ func pathNeedsVisibleControl(path ActionPath) bool {
 		actionPathHasWeakOwnership(path)
 }
 
+// pathHasVisibleControlEvidence also counts deny rules on the path's write or
+// exec tools, which block matching actions before they run.
 func pathHasVisibleControlEvidence(path ActionPath) bool {
+	if pathDenyRulesCoverWriteOrExec(path) {
+		return true
+	}
 	for _, state := range []string{
 		path.ApprovalEvidenceState,
 		path.ProofEvidenceState,
@@ -560,6 +566,24 @@ func pathHasVisibleControlEvidence(path ActionPath) bool {
 	return false
 }
 
+// pathDenyRulesCoverWriteOrExec ignores deny rules on tools the path does not
+// use to write or execute, so `Read(./.env)` is not a control on shell access.
+func pathDenyRulesCoverWriteOrExec(path ActionPath) bool {
+	for _, rule := range path.DenyRules {
+		switch autonomy.RulePermission(rule) {
+		case "filesystem.write":
+			if path.WriteCapable || containsAnyPathClass(path.ActionClasses, agginventory.ActionClassWrite, agginventory.ActionClassDelete) {
+				return true
+			}
+		case "proc.exec":
+			if containsPathValue(path.ActionClasses, agginventory.ActionClassExecute) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func mergeEvidenceState(derived, existing string) string {
 	derived = normalizeEvidenceState(derived)
 	existing = normalizeEvidenceState(existing)
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	agginventory "github.com/Clyra-AI/wrkr/core/aggregate/inventory"
+	"github.com/Clyra-AI/wrkr/core/model"
 	riskattack "github.com/Clyra-AI/wrkr/core/risk/attackpath"
 )
 
@@ -227,7 +228,7 @@ func pathLooksLikeCIWorkflow(path ActionPath) bool {
 		return true
 	}
 	toolType := strings.ToLower(strings.TrimSpace(path.ToolType))
-	if toolType == "ci_agent" || toolType == "compiled_action" || toolType == "github_actions" || toolType == "gitlab_ci" || toolType == "azure_pipelines" || toolType == "jenkins" {
+	if toolType == "ci_agent" || toolType == "compiled_action" || toolType == "github_actions" || toolType == "gitlab_ci" || toolType == "azure_pipelines" || toolType == "bitbucket_pipelines" || toolType == "jenkins" {
 		return true
 	}
 	location := strings.ToLower(strings.TrimSpace(path.Location))
@@ -238,6 +239,8 @@ func pathLooksLikeCIWorkflow(path ActionPath) bool {
 		return true
 	case strings.Contains(location, "azure-pipelines"):
 		return true
+	case strings.Contains(location, "bitbucket-pipelines"):
+		return true
 	case strings.HasSuffix(location, "jenkinsfile"):
 		return true
 	default:
@@ -250,8 +253,7 @@ func pathHasAgenticCIInfluence(path ActionPath) bool {
 	case ActionPathTypeAIAssistedWorkflow, ActionPathTypeAgentFramework, ActionPathTypeAgentInstruction:
 		return true
 	}
-	switch strings.ToLower(strings.TrimSpace(path.ToolType)) {
-	case "claude", "codex", "cursor", "copilot", "openai_agents", "langchain", "langgraph", "crewai", "autogen", "llamaindex", "semantic_kernel", "custom_agent":
+	if toolType := strings.ToLower(strings.TrimSpace(path.ToolType)); model.IsCodingAssistantToolType(toolType) || isAgentFrameworkToolType(toolType) {
 		return true
 	}
 	switch strings.ToLower(strings.TrimSpace(path.AutonomyLevel)) {
@@ -519,7 +521,14 @@ func actionPathDependencyOnly(path ActionPath) bool {
 		location == "poetry.lock",
 		location == "pyproject.toml",
 		location == "go.mod",
-		location == "cargo.toml":
+		location == "cargo.toml",
+		location == "pom.xml",
+		location == "build.gradle",
+		location == "build.gradle.kts",
+		location == "packages.config",
+		location == "directory.packages.props":
+		return true
+	case strings.HasSuffix(location, ".csproj"):
 		return true
 	default:
 		return false
@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	agginventory "github.com/Clyra-AI/wrkr/core/aggregate/inventory"
+	"github.com/Clyra-AI/wrkr/core/model"
 )
 
 const (
@@ -172,14 +173,9 @@ func pathIsDeveloperProductivity(path ActionPath) bool {
 	location := strings.ToLower(strings.TrimSpace(path.Location))
 	toolType := strings.ToLower(strings.TrimSpace(path.ToolType))
 	switch {
-	case strings.Contains(location, "agents.md"),
-		strings.Contains(location, "claude.md"),
-		strings.Contains(location, ".claude/"),
-		strings.Contains(location, ".cursor/"),
-		strings.Contains(location, ".codex/"),
-		strings.Contains(location, "copilot-instructions"):
+	case model.IsCodingAssistantLocation(location):
 		return true
-	case toolType == "claude" || toolType == "cursor" || toolType == "codex" || toolType == "copilot" || toolType == "prompt_channel" || toolType == "skill":
+	case model.IsCodingAssistantToolType(toolType) || toolType == "prompt_channel" || toolType == "skill":
 		return true
 	default:
 		return false
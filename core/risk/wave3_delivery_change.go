@@ -7,6 +7,7 @@ import (
 
 	agginventory "github.com/Clyra-AI/wrkr/core/aggregate/inventory"
 	"github.com/Clyra-AI/wrkr/core/attribution"
+	"github.com/Clyra-AI/wrkr/core/model"
 )
 
 const (
@@ -114,12 +115,11 @@ func classifyAgenticDeliverySurface(path ActionPath) string {
 	case toolType == "prompt_channel",
 		strings.Contains(location, "agents.md"),
 		strings.Contains(location, "claude.md"),
+		strings.Contains(location, "gemini.md"),
 		strings.Contains(location, "prompt"),
 		strings.Contains(location, "instruction"):
 		return AgenticDeliverySurfaceInstruction
-	case strings.Contains(location, ".cursor/rules/"),
-		strings.HasSuffix(location, ".cursorrules"),
-		strings.Contains(location, "copilot-instructions"):
+	case model.IsCodingAssistantInstructionLocation(location):
 		return AgenticDeliverySurfaceAgentRule
 	case strings.Contains(location, "mcp.json"),
 		strings.Contains(location, "/mcp."),
@@ -129,6 +129,7 @@ func classifyAgenticDeliverySurface(path ActionPath) string {
 		return AgenticDeliverySurfaceMCPConfig
 	case strings.Contains(location, ".codex/config."),
 		strings.Contains(location, ".claude/settings"),
+		strings.Contains(location, ".gemini/settings"),
 		strings.Contains(location, "settings.local.json"),
 		strings.Contains(location, "managed-mcp.json"):
 		return AgenticDeliverySurfaceToolConfig
@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	agginventory "github.com/Clyra-AI/wrkr/core/aggregate/inventory"
+	"github.com/Clyra-AI/wrkr/core/model"
 )
 
 func deriveHighStakesPresets(path ActionPath) []HighStakesPreset {
@@ -47,7 +48,7 @@ func deriveHighStakesPresets(path ActionPath) []HighStakesPreset {
 	if strings.TrimSpace(path.RiskZone) == RiskZoneExternalEgress || containsPathValue(path.ActionClasses, "egress") {
 		add(HighStakesPresetExternalEgress, []string{"external_egress:detected"}, append([]string(nil), path.PolicyEvidenceRefs...)...)
 	}
-	if strings.Contains(location, ".mcp.json") || strings.Contains(location, "/mcp.") || strings.Contains(location, ".codex/") || strings.Contains(location, ".claude/") || strings.Contains(location, ".cursor/") || strings.HasSuffix(location, "agents.md") || strings.HasSuffix(location, "agents.override.md") {
+	if strings.Contains(location, ".mcp.json") || strings.Contains(location, "/mcp.") || model.IsCodingAssistantLocation(location) {
 		add(HighStakesPresetMCPToolConfig, []string{"tool_or_mcp_config:detected"}, []string{strings.TrimSpace(path.Location)}...)
 	}
 	if pathHasAnyMutableEndpoint(path) {
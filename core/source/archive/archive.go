@@ -0,0 +1,404 @@
+// Package archive acquires repositories from offline archive files: gzip or
+// plain tarballs, zip files, and git bundles.
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Clyra-AI/wrkr/core/source"
+	"github.com/Clyra-AI/wrkr/core/source/github"
+	"github.com/Clyra-AI/wrkr/core/source/local"
+	"github.com/Clyra-AI/wrkr/core/workflowloc"
+)
+
+// Source marks repos materialized from an archive target.
+const Source = "archive"
+
+const (
+	formatTar    = "tar"
+	formatTarGz  = "tar.gz"
+	formatZip    = "zip"
+	formatBundle = "git bundle"
+
+	// maxRepoPathDepth bounds how many leading directories may sit above a
+	// repo root inside an archive: one wrapper directory plus the nested
+	// owner/repo depth that local path discovery accepts.
+	maxRepoPathDepth = 5
+	sniffBytes       = 512
+)
+
+// Options controls archive acquisition.
+type Options struct {
+	// MaterializedRoot receives the extracted tree; it must be a scan-managed
+	// directory because the archive subdirectory is replaced on every call.
+	MaterializedRoot string
+	// AllowSourceMaterialization permits broad source-code extension
+	// extraction for explicit deep scans, matching hosted acquisition.
+	AllowSourceMaterialization bool
+}
+
+// Acquire safely extracts the detector-relevant entries of archivePath under
+// opts.MaterializedRoot and classifies the result with the same repo-root and
+// immediate-child rules as local path scans. Git bundles always yield one
+// repo materialized from the bundle's default ref.
+func Acquire(ctx context.Context, archivePath string, opts Options) ([]source.RepoManifest, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	archivePath = strings.TrimSpace(archivePath)
+	if archivePath == "" {
+		return nil, errors.New("archive target is required")
+	}
+	if strings.TrimSpace(opts.MaterializedRoot) == "" {
+		return nil, errors.New("materialized source root is required for archive acquisition")
+	}
+	archivePath = filepath.Clean(archivePath)
+	info, err := os.Stat(archivePath)
+	if err != nil {
+		return nil, fmt.Errorf("read archive target: %w", err)
+	}
+	if !info.Mode().IsRegular() {
+		return nil, fmt.Errorf("archive target must be a regular file: %s", archivePath)
+	}
+	format, err := detectFormat(archivePath)
+	if err != nil {
+		return nil, err
+	}
+	name := archiveRepoName(archivePath)
+
+	if format == formatBundle {
+		manifest, bundleErr := local.AcquireBundle(ctx, archivePath, name, local.RefOptions{
+			MaterializedRoot:           opts.MaterializedRoot,
+			AllowSourceMaterialization: opts.AllowSourceMaterialization,
+		})
+		if bundleErr != nil {
+			return nil, fmt.Errorf("archive %s: %w", filepath.Base(archivePath), bundleErr)
+		}
+		manifest.Source = Source
+		return []source.RepoManifest{manifest}, nil
+	}
+
+	extractRoot := filepath.Join(filepath.Clean(opts.MaterializedRoot), name)
+	if err := os.RemoveAll(extractRoot); err != nil {
+		return nil, fmt.Errorf("clean materialized archive root: %w", err)
+	}
+	if err := os.MkdirAll(extractRoot, 0o750); err != nil {
+		return nil, fmt.Errorf("create materialized archive root: %w", err)
+	}
+	rootFS, err := os.OpenRoot(extractRoot)
+	if err != nil {
+		return nil, fmt.Errorf("open materialized archive root: %w", err)
+	}
+	defer func() { _ = rootFS.Close() }()
+
+	extractor := &extractor{
+		ctx:                        ctx,
+		root:                       rootFS,
+		allowSourceMaterialization: opts.AllowSourceMaterialization,
+		gitDirs:                    map[string]struct{}{},
+	}
+	switch format {
+	case formatZip:
+		err = extractor.extractZip(archivePath)
+	default:
+		err = extractor.extractTar(archivePath, format == formatTarGz)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("archive %s: %w", filepath.Base(archivePath), err)
+	}
+	if err := extractor.writeGitMarkers(); err != nil {
+		return nil, fmt.Errorf("archive %s: %w", filepath.Base(archivePath), err)
+	}
+
+	repos, err := local.AcquireWithOptions(ctx, extractRoot, local.AcquireOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("classify archive %s: %w", filepath.Base(archivePath), err)
+	}
+	out := make([]source.RepoManifest, 0, len(repos))
+	for _, repo := range repos {
+		rel, relErr := filepath.Rel(extractRoot, filepath.FromSlash(repo.Location))
+		if relErr != nil || !(rel == "." || filepath.IsLocal(rel)) {
+			return nil, fmt.Errorf("classify archive %s: repo %s escaped the extraction root", filepath.Base(archivePath), repo.Repo)
+		}
+		repo.ScanRoot = repo.Location
+		repo.Location = archiveLocation(archivePath, rel)
+		repo.Source = Source
+		repo.ContentStatus = source.RepoContentStatusAvailable
+		out = append(out, repo)
+	}
+	return out, nil
+}
+
+func detectFormat(archivePath string) (string, error) {
+	file, err := os.Open(archivePath) // #nosec G304 -- archive path is an explicit user-selected scan input.
+	if err != nil {
+		return "", fmt.Errorf("open archive target: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+	header := make([]byte, sniffBytes)
+	n, err := io.ReadFull(file, header)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("read archive target: %w", err)
+	}
+	header = header[:n]
+	switch {
+	case bytes.HasPrefix(header, []byte{0x1f, 0x8b}):
+		return formatTarGz, nil
+	case bytes.HasPrefix(header, []byte("PK\x03\x04")) || bytes.HasPrefix(header, []byte("PK\x05\x06")):
+		return formatZip, nil
+	case local.IsGitBundle(header):
+		return formatBundle, nil
+	case len(header) >= 262 && bytes.Equal(header[257:262], []byte("ustar")):
+		return formatTar, nil
+	default:
+		return "", fmt.Errorf("archive target %s is not a tar.gz, tar, zip, or git bundle file", filepath.Base(archivePath))
+	}
+}
+
+// archiveRepoName derives a stable directory and repo name from the archive
+// file name without its archive extensions.
+func archiveRepoName(archivePath string) string {
+	name := filepath.Base(archivePath)
+	lower := strings.ToLower(name)
+	for _, suffix := range []string{".tar.gz", ".tgz", ".tar", ".zip", ".bundle", ".git"} {
+		if strings.HasSuffix(lower, suffix) {
+			name = name[:len(name)-len(suffix)]
+			break
+		}
+	}
+	name = strings.TrimSpace(name)
+	if name == "" || name == "." || name == ".." || strings.HasPrefix(name, ".") {
+		return "archive"
+	}
+	return name
+}
+
+// archiveLocation is the customer-facing location of a repo inside an
+// archive; nested repos use the archive!/path convention so locations never
+// point at the internal extraction root.
+func archiveLocation(archivePath, rel string) string {
+	location := filepath.ToSlash(archivePath)
+	if rel == "." {
+		return location
+	}
+	return location + "!/" + filepath.ToSlash(rel)
+}
+
+type extractor struct {
+	ctx                        context.Context
+	root                       *os.Root
+	allowSourceMaterialization bool
+	budget                     github.ArchiveBudget
+	gitDirs                    map[string]struct{}
+}
+
+func (e *extractor) extractTar(archivePath string, gzipped bool) error {
+	file, err := os.Open(archivePath) // #nosec G304 -- archive path is an explicit user-selected scan input.
+	if err != nil {
+		return fmt.Errorf("open archive: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+	var stream io.Reader = file
+	if gzipped {
+		gz, gzErr := gzip.NewReader(file)
+		if gzErr != nil {
+			return fmt.Errorf("open gzip stream: %w", gzErr)
+		}
+		defer func() { _ = gz.Close() }()
+		stream = gz
+	}
+
+	reader := tar.NewReader(stream)
+	for {
+		header, nextErr := reader.Next()
+		if errors.Is(nextErr, io.EOF) {
+			return nil
+		}
+		if nextErr != nil {
+			return fmt.Errorf("read tar entry: %w", nextErr)
+		}
+		if err := e.ctx.Err(); err != nil {
+			return err
+		}
+		if err := e.budget.AddEntry(); err != nil {
+			return err
+		}
+		rel, ok, err := e.entryPath(header.Name)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		switch header.Typeflag {
+		case tar.TypeReg:
+			if err := e.writeFile(rel, header.Size, reader); err != nil {
+				return err
+			}
+		case tar.TypeDir:
+			e.noteGitDir(rel)
+		}
+	}
+}
+
+func (e *extractor) extractZip(archivePath string) error {
+	reader, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return fmt.Errorf("open zip: %w", err)
+	}
+	defer func() { _ = reader.Close() }()
+	for _, entry := range reader.File {
+		if err := e.ctx.Err(); err != nil {
+			return err
+		}
+		if err := e.budget.AddEntry(); err != nil {
+			return err
+		}
+		rel, ok, err := e.entryPath(entry.Name)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		mode := entry.Mode()
+		if mode.IsDir() {
+			e.noteGitDir(rel)
+			continue
+		}
+		if !mode.IsRegular() {
+			continue
+		}
+		if entry.UncompressedSize64 > math.MaxInt64 {
+			return fmt.Errorf("zip entry %s has an invalid size", rel)
+		}
+		if err := e.writeZipFile(rel, entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *extractor) writeZipFile(rel string, entry *zip.File) error {
+	body, err := entry.Open()
+	if err != nil {
+		return fmt.Errorf("open zip entry %s: %w", rel, err)
+	}
+	defer func() { _ = body.Close() }()
+	return e.writeFile(rel, int64(entry.UncompressedSize64), body)
+}
+
+// entryPath validates an entry name with the hosted archive rules. Leading
+// "./" segments written by `tar -C dir .` and the trailing slash of directory
+// entries are dropped; the bare "./" entry is skipped.
+func (e *extractor) entryPath(name string) (string, bool, error) {
+	trimmed := strings.TrimSuffix(strings.TrimSpace(name), "/")
+	for strings.HasPrefix(trimmed, "./") {
+		trimmed = strings.TrimPrefix(trimmed, "./")
+	}
+	if trimmed == "" || trimmed == "." {
+		return "", false, nil
+	}
+	rel, err := github.ArchiveEntryPath(trimmed)
+	if err != nil {
+		return "", false, err
+	}
+	if !filepath.IsLocal(filepath.FromSlash(rel)) {
+		return "", false, fmt.Errorf("archive contains a non-local entry %q", name)
+	}
+	return rel, true, nil
+}
+
+func (e *extractor) writeFile(rel string, size int64, body io.Reader) error {
+	e.noteGitDir(rel)
+	materialize := shouldMaterializeArchivePath(rel, e.allowSourceMaterialization)
+	if err := e.budget.AddFile(rel, size, materialize); err != nil {
+		return err
+	}
+	if !materialize {
+		return nil
+	}
+	localRel := filepath.FromSlash(rel)
+	if err := e.root.MkdirAll(filepath.Dir(localRel), 0o750); err != nil {
+		return fmt.Errorf("create materialized parent: %w", err)
+	}
+	file, err := e.root.OpenFile(localRel, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("create materialized file %s: %w", rel, err)
+	}
+	written, copyErr := io.Copy(file, io.LimitReader(body, size+1))
+	closeErr := file.Close()
+	if copyErr != nil {
+		return fmt.Errorf("write materialized file %s: %w", rel, copyErr)
+	}
+	if closeErr != nil {
+		return fmt.Errorf("close materialized file %s: %w", rel, closeErr)
+	}
+	if written != size {
+		return fmt.Errorf("archive entry %s does not match its declared size", rel)
+	}
+	return nil
+}
+
+// noteGitDir records the parent of any .git directory so the extracted tree
+// keeps the strong repo-root signal local path classification relies on.
+// Git metadata itself is never extracted.
+func (e *extractor) noteGitDir(rel string) {
+	parts := strings.Split(rel, "/")
+	for i, part := range parts {
+		if part != ".git" {
+			continue
+		}
+		parent := strings.Join(parts[:i], "/")
+		if parent != "" && github.IsBlockedMaterializedPath(parent) {
+			return
+		}
+		e.gitDirs[parent] = struct{}{}
+		return
+	}
+}
+
+func (e *extractor) writeGitMarkers() error {
+	parents := make([]string, 0, len(e.gitDirs))
+	for parent := range e.gitDirs {
+		parents = append(parents, parent)
+	}
+	sort.Strings(parents)
+	for _, parent := range parents {
+		marker := filepath.Join(filepath.FromSlash(parent), ".git")
+		if err := e.root.MkdirAll(marker, 0o750); err != nil {
+			return fmt.Errorf("create repo marker for %q: %w", parent, err)
+		}
+	}
+	return nil
+}
+
+// shouldMaterializeArchivePath applies the hosted sparse predicates to every
+// candidate repo-relative suffix of rel, because repo roots inside an archive
+// are only known after extraction. Blocked directories such as node_modules
+// are rejected anywhere in the path.
+func shouldMaterializeArchivePath(rel string, allowSourceMaterialization bool) bool {
+	if github.IsBlockedMaterializedPath(rel) {
+		return false
+	}
+	parts := strings.Split(rel, "/")
+	for i := 0; i < len(parts) && i <= maxRepoPathDepth; i++ {
+		candidate := strings.Join(parts[i:], "/")
+		if workflowloc.IsCIWorkflow(candidate) || github.ShouldMaterializePath(candidate, allowSourceMaterialization) {
+			return true
+		}
+	}
+	return false
+}
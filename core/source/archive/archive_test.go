@@ -0,0 +1,304 @@
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Clyra-AI/wrkr/core/source"
+)
+
+type archiveEntry struct {
+	name     string
+	body     string
+	typeflag byte
+	linkname string
+}
+
+func TestAcquireTarGzSingleRepoWithWrapperDirectory(t *testing.T) {
+	t.Parallel()
+
+	archivePath := writeTarGz(t, "backend.tar.gz", []archiveEntry{
+		{name: "backend-main/", typeflag: tar.TypeDir},
+		{name: "backend-main/AGENTS.md", body: "# agents\n"},
+		{name: "backend-main/.github/workflows/ci.yml", body: "on: push\n"},
+		{name: "backend-main/.codex/config.toml", body: "approval_policy = \"never\"\n"},
+		{name: "backend-main/src/app.py", body: "print('hi')\n"},
+		{name: "backend-main/node_modules/pkg/AGENTS.md", body: "vendored\n"},
+	})
+	materialized := t.TempDir()
+
+	repos, err := Acquire(context.Background(), archivePath, Options{MaterializedRoot: materialized})
+	if err != nil {
+		t.Fatalf("acquire archive: %v", err)
+	}
+	if len(repos) != 1 {
+		t.Fatalf("expected one repo, got %+v", repos)
+	}
+	repo := repos[0]
+	if repo.Repo != "backend-main" || repo.Source != Source || repo.Location != filepath.ToSlash(archivePath)+"!/backend-main" {
+		t.Fatalf("unexpected repo identity: %+v", repo)
+	}
+	if repo.ContentStatus != source.RepoContentStatusAvailable {
+		t.Fatalf("expected available content, got %q", repo.ContentStatus)
+	}
+	scanRoot := filepath.FromSlash(repo.ScanRoot)
+	if !strings.HasPrefix(scanRoot, materialized) {
+		t.Fatalf("expected scan root under materialized root, got %s", scanRoot)
+	}
+	for _, rel := range []string{"AGENTS.md", ".github/workflows/ci.yml", ".codex/config.toml"} {
+		if _, err := os.Stat(filepath.Join(scanRoot, filepath.FromSlash(rel))); err != nil {
+			t.Fatalf("expected %s to be extracted: %v", rel, err)
+		}
+	}
+	for _, rel := range []string{"src/app.py", "node_modules/pkg/AGENTS.md"} {
+		if _, err := os.Stat(filepath.Join(scanRoot, filepath.FromSlash(rel))); !os.IsNotExist(err) {
+			t.Fatalf("expected %s to stay unextracted, got %v", rel, err)
+		}
+	}
+}
+
+func TestAcquireTarGzRootLevelEntriesUseArchiveName(t *testing.T) {
+	t.Parallel()
+
+	archivePath := writeTarGz(t, "payments.tgz", []archiveEntry{
+		{name: "./", typeflag: tar.TypeDir},
+		{name: "./.git/", typeflag: tar.TypeDir},
+		{name: "./.git/HEAD", body: "ref: refs/heads/main\n"},
+		{name: "./CLAUDE.md", body: "rules\n"},
+	})
+	repos, err := Acquire(context.Background(), archivePath, Options{MaterializedRoot: t.TempDir()})
+	if err != nil {
+		t.Fatalf("acquire archive: %v", err)
+	}
+	if len(repos) != 1 || repos[0].Repo != "payments" || repos[0].Location != filepath.ToSlash(archivePath) {
+		t.Fatalf("expected root-level repo named after archive, got %+v", repos)
+	}
+	if _, err := os.Stat(filepath.Join(filepath.FromSlash(repos[0].ScanRoot), ".git", "HEAD")); !os.IsNotExist(err) {
+		t.Fatalf("git metadata must not be extracted, got %v", err)
+	}
+}
+
+func TestAcquireZipMultiRepoBundleUsesImmediateChildren(t *testing.T) {
+	t.Parallel()
+
+	archivePath := writeZip(t, "bundle.zip", []archiveEntry{
+		{name: "alpha/AGENTS.md", body: "alpha\n"},
+		{name: "alpha/package.json", body: "{}\n"},
+		{name: "beta/.mcp.json", body: "{\"mcpServers\":{}}\n"},
+		{name: "beta/go.mod", body: "module beta\n"},
+		{name: "gamma/.git/config", body: "[core]\n"},
+	})
+	repos, err := Acquire(context.Background(), archivePath, Options{MaterializedRoot: t.TempDir()})
+	if err != nil {
+		t.Fatalf("acquire archive: %v", err)
+	}
+	names := []string{}
+	for _, repo := range repos {
+		names = append(names, repo.Repo)
+		if repo.Location != filepath.ToSlash(archivePath)+"!/"+repo.Repo {
+			t.Fatalf("unexpected nested location %q", repo.Location)
+		}
+	}
+	if strings.Join(names, ",") != "alpha,beta,gamma" {
+		t.Fatalf("expected immediate-child repos alpha,beta,gamma, got %v", names)
+	}
+}
+
+func TestAcquireRejectsUnsafeEntries(t *testing.T) {
+	t.Parallel()
+
+	for name, entries := range map[string][]archiveEntry{
+		"traversal": {{name: "repo/../../escape/AGENTS.md", body: "x"}},
+		"absolute":  {{name: "/etc/AGENTS.md", body: "x"}},
+	} {
+		archivePath := writeTarGz(t, name+".tar.gz", entries)
+		if _, err := Acquire(context.Background(), archivePath, Options{MaterializedRoot: t.TempDir()}); err == nil || !strings.Contains(err.Error(), "unsafe archive entry") {
+			t.Fatalf("%s: expected unsafe archive entry error, got %v", name, err)
+		}
+	}
+
+	zipPath := writeZip(t, "traversal.zip", []archiveEntry{{name: "../AGENTS.md", body: "x"}})
+	if _, err := Acquire(context.Background(), zipPath, Options{MaterializedRoot: t.TempDir()}); err == nil || !strings.Contains(err.Error(), "unsafe archive entry") {
+		t.Fatalf("expected unsafe zip entry error, got %v", err)
+	}
+}
+
+func TestAcquireSkipsSymlinkEntries(t *testing.T) {
+	t.Parallel()
+
+	outside := filepath.Join(t.TempDir(), "secret.md")
+	archivePath := writeTarGz(t, "links.tar.gz", []archiveEntry{
+		{name: "repo/AGENTS.md", typeflag: tar.TypeSymlink, linkname: outside},
+		{name: "repo/CLAUDE.md", body: "rules\n"},
+	})
+	repos, err := Acquire(context.Background(), archivePath, Options{MaterializedRoot: t.TempDir()})
+	if err != nil {
+		t.Fatalf("acquire archive: %v", err)
+	}
+	if _, err := os.Lstat(filepath.Join(filepath.FromSlash(repos[0].ScanRoot), "AGENTS.md")); !os.IsNotExist(err) {
+		t.Fatalf("expected symlink entry to be skipped, got %v", err)
+	}
+}
+
+func TestAcquireRejectsUnknownFormat(t *testing.T) {
+	t.Parallel()
+
+	archivePath := filepath.Join(t.TempDir(), "notes.txt")
+	if err := os.WriteFile(archivePath, []byte("plain text"), 0o600); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	if _, err := Acquire(context.Background(), archivePath, Options{MaterializedRoot: t.TempDir()}); err == nil || !strings.Contains(err.Error(), "not a tar.gz, tar, zip, or git bundle") {
+		t.Fatalf("expected unsupported format error, got %v", err)
+	}
+}
+
+func TestAcquireGitBundleMaterializesDefaultBranch(t *testing.T) {
+	t.Parallel()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is required to build bundle fixtures")
+	}
+
+	repoRoot := filepath.Join(t.TempDir(), "src")
+	if err := os.MkdirAll(repoRoot, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	runGit(t, repoRoot, "init", "--quiet", "--initial-branch=main")
+	writeRepoFile(t, repoRoot, "AGENTS.md", strings.Repeat("use the gateway\n", 50)+"v1\n")
+	runGit(t, repoRoot, "add", "-A")
+	runGit(t, repoRoot, "commit", "--quiet", "-m", "v1")
+	writeRepoFile(t, repoRoot, "AGENTS.md", strings.Repeat("use the gateway\n", 50)+"v2\n")
+	writeRepoFile(t, repoRoot, "app/main.go", "package main\n")
+	runGit(t, repoRoot, "add", "-A")
+	runGit(t, repoRoot, "commit", "--quiet", "-m", "v2")
+	head := gitOutput(t, repoRoot, "rev-parse", "HEAD")
+	bundlePath := filepath.Join(t.TempDir(), "incident.bundle")
+	runGit(t, repoRoot, "bundle", "create", bundlePath, "--all")
+
+	repos, err := Acquire(context.Background(), bundlePath, Options{MaterializedRoot: t.TempDir()})
+	if err != nil {
+		t.Fatalf("acquire bundle: %v", err)
+	}
+	if len(repos) != 1 {
+		t.Fatalf("expected one repo, got %+v", repos)
+	}
+	repo := repos[0]
+	if repo.Repo != "incident" || repo.Source != Source || repo.Commit != head || repo.Ref != "HEAD" {
+		t.Fatalf("unexpected bundle manifest: %+v", repo)
+	}
+	payload, err := os.ReadFile(filepath.Join(filepath.FromSlash(repo.ScanRoot), "AGENTS.md")) // #nosec G304 -- test fixture path.
+	if err != nil || !strings.HasSuffix(string(payload), "v2\n") {
+		t.Fatalf("expected head revision of AGENTS.md, got %q err=%v", payload, err)
+	}
+	if _, err := os.Stat(filepath.Join(filepath.FromSlash(repo.ScanRoot), "app", "main.go")); !os.IsNotExist(err) {
+		t.Fatalf("expected sparse bundle materialization, got %v", err)
+	}
+
+	thinPath := filepath.Join(t.TempDir(), "thin.bundle")
+	runGit(t, repoRoot, "bundle", "create", thinPath, "HEAD~1..main")
+	if _, err := Acquire(context.Background(), thinPath, Options{MaterializedRoot: t.TempDir()}); err == nil || !strings.Contains(err.Error(), "prerequisite") {
+		t.Fatalf("expected thin bundle to be rejected, got %v", err)
+	}
+}
+
+func writeTarGz(t *testing.T, name string, entries []archiveEntry) string {
+	t.Helper()
+	var buffer bytes.Buffer
+	gz := gzip.NewWriter(&buffer)
+	writer := tar.NewWriter(gz)
+	for _, entry := range entries {
+		header := &tar.Header{Name: entry.name, Mode: 0o644, Typeflag: entry.typeflag, Linkname: entry.linkname}
+		if header.Typeflag == 0 {
+			header.Typeflag = tar.TypeReg
+		}
+		if header.Typeflag == tar.TypeReg {
+			header.Size = int64(len(entry.body))
+		}
+		if err := writer.WriteHeader(header); err != nil {
+			t.Fatalf("write tar header: %v", err)
+		}
+		if header.Typeflag == tar.TypeReg {
+			if _, err := writer.Write([]byte(entry.body)); err != nil {
+				t.Fatalf("write tar body: %v", err)
+			}
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("close tar: %v", err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatalf("close gzip: %v", err)
+	}
+	return writeArchiveFile(t, name, buffer.Bytes())
+}
+
+func writeZip(t *testing.T, name string, entries []archiveEntry) string {
+	t.Helper()
+	var buffer bytes.Buffer
+	writer := zip.NewWriter(&buffer)
+	for _, entry := range entries {
+		file, err := writer.Create(entry.name)
+		if err != nil {
+			t.Fatalf("create zip entry: %v", err)
+		}
+		if _, err := file.Write([]byte(entry.body)); err != nil {
+			t.Fatalf("write zip entry: %v", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("close zip: %v", err)
+	}
+	return writeArchiveFile(t, name, buffer.Bytes())
+}
+
+func writeArchiveFile(t *testing.T, name string, payload []byte) string {
+	t.Helper()
+	archivePath := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(archivePath, payload, 0o600); err != nil {
+		t.Fatalf("write archive: %v", err)
+	}
+	return archivePath
+}
+
+func writeRepoFile(t *testing.T, root, rel, content string) {
+	t.Helper()
+	target := filepath.Join(root, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		t.Fatalf("mkdir %s: %v", rel, err)
+	}
+	if err := os.WriteFile(target, []byte(content), 0o600); err != nil {
+		t.Fatalf("write %s: %v", rel, err)
+	}
+}
+
+func runGit(t *testing.T, repoRoot string, args ...string) {
+	t.Helper()
+	gitOutput(t, repoRoot, args...)
+}
+
+func gitOutput(t *testing.T, repoRoot string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-C", repoRoot, "-c", "commit.gpgsign=false"}, args...)...) // #nosec G204 -- deterministic test fixture setup.
+	cmd.Env = append(os.Environ(),
+		"GIT_CONFIG_NOSYSTEM=1",
+		"GIT_CONFIG_GLOBAL="+os.DevNull,
+		"GIT_AUTHOR_NAME=wrkr",
+		"GIT_AUTHOR_EMAIL=wrkr@example.com",
+		"GIT_COMMITTER_NAME=wrkr",
+		"GIT_COMMITTER_EMAIL=wrkr@example.com",
+		"GIT_AUTHOR_DATE=2026-01-02T03:04:05Z",
+		"GIT_COMMITTER_DATE=2026-01-02T03:04:05Z",
+	)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v failed: %v\n%s", args, err, output)
+	}
+	return strings.TrimSpace(string(output))
+}
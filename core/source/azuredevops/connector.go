@@ -0,0 +1,720 @@
+// Package azuredevops acquires Azure Repos Git repositories for an Azure
+// DevOps organization or project through the REST API with the same sparse
+// materialization contract as the GitHub connector.
+package azuredevops
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Clyra-AI/wrkr/core/source"
+	"github.com/Clyra-AI/wrkr/core/source/github"
+	"github.com/Clyra-AI/wrkr/core/workflowloc"
+	"github.com/Clyra-AI/wrkr/internal/githubendpoint"
+	"github.com/Clyra-AI/wrkr/internal/reponame"
+	"github.com/Clyra-AI/wrkr/internal/sourceconn"
+)
+
+const (
+	// Provider names Azure DevOps sources in manifests, checkpoints, and locations.
+	Provider = "azdo"
+	// DefaultBaseURL is the Azure DevOps Services root; Azure DevOps Server
+	// deployments pass their collection URL instead.
+	DefaultBaseURL = "https://dev.azure.com"
+
+	apiVersion   = "7.1"
+	pageSize     = 100
+	maxBlobBytes = 10 << 20
+)
+
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Connector acquires Azure DevOps project and repository lists and sparse
+// repository trees.
+type Connector struct {
+	BaseURL    string
+	Token      string
+	HTTPClient HTTPClient
+	MaxRetries int
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	// AllowSourceMaterialization permits broad source-code extension fetching for
+	// explicit deep/debug scans. Default hosted scans keep this false.
+	AllowSourceMaterialization bool
+	endpointOptions            githubendpoint.Options
+
+	mu           sync.Mutex
+	nowFn        func() time.Time
+	sleepFn      func(context.Context, time.Duration) error
+	onRetry      func(github.RetryEvent)
+	requestStats source.AcquisitionTelemetry
+	projectTeams map[string][]string
+}
+
+// ConnectorOptions controls explicit development-only connector behavior.
+type ConnectorOptions struct {
+	AllowInsecureLoopback bool
+}
+
+func NewConnector(baseURL, token string, client HTTPClient) *Connector {
+	return NewConnectorWithOptions(baseURL, token, client, ConnectorOptions{})
+}
+
+// NewConnectorWithOptions permits loopback HTTP only when explicitly requested
+// for local development or tests. Production callers must use NewConnector.
+func NewConnectorWithOptions(baseURL, token string, client HTTPClient, options ConnectorOptions) *Connector {
+	endpointOptions := githubendpoint.Options{AllowInsecureLoopback: options.AllowInsecureLoopback}
+	if configured, ok := client.(*http.Client); ok {
+		client = sourceconn.NewHTTPClient(baseURL, endpointOptions, configured)
+	} else if client == nil {
+		client = sourceconn.NewHTTPClient(baseURL, endpointOptions, nil)
+	}
+	return &Connector{
+		BaseURL:         strings.TrimRight(baseURL, "/"),
+		Token:           token,
+		HTTPClient:      client,
+		MaxRetries:      2,
+		Backoff:         25 * time.Millisecond,
+		MaxBackoff:      2 * time.Second,
+		endpointOptions: endpointOptions,
+		nowFn:           time.Now,
+		sleepFn:         sourceconn.SleepWithContext,
+		projectTeams:    map[string][]string{},
+	}
+}
+
+func (c *Connector) validateEndpoint() error {
+	if c == nil {
+		return errors.New("azure devops connector is required")
+	}
+	if c.BaseURL == "" {
+		return errors.New("azure devops api base url is required for hosted acquisition")
+	}
+	_, err := githubendpoint.Parse(c.BaseURL, c.endpointOptions)
+	return err
+}
+
+func (c *Connector) SetRetryHandler(fn func(github.RetryEvent)) {
+	if c == nil {
+		return
+	}
+	c.onRetry = fn
+}
+
+func (c *Connector) SetAllowSourceMaterialization(allow bool) {
+	if c == nil {
+		return
+	}
+	c.AllowSourceMaterialization = allow
+}
+
+func (c *Connector) AcquisitionTelemetry() source.AcquisitionTelemetry {
+	if c == nil {
+		return source.AcquisitionTelemetry{}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	out := c.requestStats
+	out.Warnings = append([]string(nil), c.requestStats.Warnings...)
+	out.Mode = "azdo_sparse_api"
+	return out
+}
+
+// RateLimitedError reports exhausted Azure DevOps throttling after bounded retries.
+type RateLimitedError struct {
+	StatusCode int
+	Attempts   int
+	Message    string
+}
+
+func (e *RateLimitedError) Error() string {
+	if e == nil {
+		return ""
+	}
+	parts := []string{fmt.Sprintf("azure devops API rate limit exhausted after %d attempt(s)", e.Attempts)}
+	if e.StatusCode != 0 {
+		parts = append(parts, fmt.Sprintf("status=%d", e.StatusCode))
+	}
+	if message := strings.TrimSpace(e.Message); message != "" {
+		parts = append(parts, "upstream_message="+message)
+	}
+	return strings.Join(parts, "; ")
+}
+
+// IsRateLimitedError reports whether err represents exhausted hosted throttling.
+func IsRateLimitedError(err error) bool {
+	var target *RateLimitedError
+	return errors.As(err, &target)
+}
+
+// NormalizeScope validates an org or org/project target value.
+func NormalizeScope(scope string) (string, error) {
+	normalized, err := reponame.NormalizeNamespacePath(scope, "azure devops scope", 1)
+	if err != nil {
+		return "", err
+	}
+	if strings.Count(normalized, "/") > 1 {
+		return "", fmt.Errorf("azure devops scope must be org or org/project, got %q", scope)
+	}
+	return normalized, nil
+}
+
+// NormalizeRepo validates an org/project/repo identifier.
+func NormalizeRepo(repo string) (string, error) {
+	normalized, err := reponame.NormalizeNamespacePath(repo, "azure devops repo", 3)
+	if err != nil {
+		return "", err
+	}
+	if strings.Count(normalized, "/") != 2 {
+		return "", fmt.Errorf("azure devops repo must be org/project/repo, got %q", repo)
+	}
+	return normalized, nil
+}
+
+type repoMeta struct {
+	FullName      string
+	Org           string
+	Project       string
+	ID            string
+	DefaultBranch string
+	Commit        string
+}
+
+// ListOrgRepos lists every enabled Git repository in an Azure DevOps project,
+// or in every project of an organization when scope names only the org. The
+// name satisfies org.RepoLister so Azure DevOps targets reuse org
+// checkpointing.
+func (c *Connector) ListOrgRepos(ctx context.Context, scope string) ([]string, error) {
+	scope, err := NormalizeScope(scope)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.validateEndpoint(); err != nil {
+		return nil, err
+	}
+
+	organization, project, hasProject := strings.Cut(scope, "/")
+	projects := []string{project}
+	if !hasProject {
+		projects, err = c.listProjects(ctx, organization)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	repos := make([]string, 0, 128)
+	seen := map[string]struct{}{}
+	for _, projectName := range projects {
+		endpoint := c.BaseURL + "/" + url.PathEscape(organization) + "/" + url.PathEscape(projectName) + "/_apis/git/repositories?" + apiQuery().Encode()
+		var payload struct {
+			Value []struct {
+				Name       string `json:"name"`
+				IsDisabled bool   `json:"isDisabled"`
+				Project    struct {
+					Name string `json:"name"`
+				} `json:"project"`
+			} `json:"value"`
+		}
+		if err := c.getJSON(ctx, endpoint, &payload); err != nil {
+			return nil, fmt.Errorf("list repos for project %s: %w", projectName, err)
+		}
+		for _, item := range payload.Value {
+			if item.IsDisabled {
+				continue
+			}
+			owner := strings.TrimSpace(item.Project.Name)
+			if owner == "" {
+				owner = projectName
+			}
+			repo, err := NormalizeRepo(organization + "/" + owner + "/" + item.Name)
+			if err != nil {
+				return nil, fmt.Errorf("list repos for project %s: %w", projectName, err)
+			}
+			if _, ok := seen[repo]; ok {
+				continue
+			}
+			seen[repo] = struct{}{}
+			repos = append(repos, repo)
+		}
+	}
+	sort.Strings(repos)
+	return repos, nil
+}
+
+// listProjects pages the organization project list with continuation tokens.
+func (c *Connector) listProjects(ctx context.Context, organization string) ([]string, error) {
+	projects := make([]string, 0, 32)
+	continuation := ""
+	for page := 1; ; page++ {
+		query := apiQuery()
+		query.Set("$top", strconv.Itoa(pageSize))
+		if continuation != "" {
+			query.Set("continuationToken", continuation)
+		}
+		endpoint := c.BaseURL + "/" + url.PathEscape(organization) + "/_apis/projects?" + query.Encode()
+		body, headers, err := c.doGETWithRetry(ctx, endpoint, 0)
+		if err != nil {
+			return nil, fmt.Errorf("list org projects page %d: %w", page, err)
+		}
+		var payload struct {
+			Value []struct {
+				Name string `json:"name"`
+			} `json:"value"`
+		}
+		if err := json.Unmarshal(body, &payload); err != nil {
+			return nil, fmt.Errorf("parse azure devops response: %w", err)
+		}
+		for _, item := range payload.Value {
+			if name := strings.TrimSpace(item.Name); name != "" {
+				projects = append(projects, name)
+			}
+		}
+		next := strings.TrimSpace(headers.Get("X-Ms-Continuationtoken"))
+		if next == "" || next == continuation || len(payload.Value) == 0 {
+			break
+		}
+		continuation = next
+	}
+	sort.Strings(projects)
+	return projects, nil
+}
+
+// AcquireRepo resolves one repository by org/project/repo.
+func (c *Connector) AcquireRepo(ctx context.Context, repo string) (source.RepoManifest, error) {
+	repo, err := NormalizeRepo(repo)
+	if err != nil {
+		return source.RepoManifest{}, err
+	}
+	if err := c.validateEndpoint(); err != nil {
+		return source.RepoManifest{}, err
+	}
+	meta, err := c.repoMetadata(ctx, repo)
+	if err != nil {
+		return source.RepoManifest{}, err
+	}
+	teams, err := c.teamsForProject(ctx, meta.Org, meta.Project)
+	if err != nil {
+		return source.RepoManifest{}, err
+	}
+	return source.RepoManifest{
+		Repo:              meta.FullName,
+		Location:          meta.FullName,
+		Source:            Provider + "_repo",
+		OwnershipMetadata: ownershipMetadata(teams),
+	}, nil
+}
+
+// MaterializeRepo fetches detector-relevant repository files through the
+// Azure DevOps API and writes them into a deterministic local workspace under
+// materializedRoot.
+func (c *Connector) MaterializeRepo(ctx context.Context, repo string, materializedRoot string) (source.RepoManifest, error) {
+	repo, err := NormalizeRepo(repo)
+	if err != nil {
+		return source.RepoManifest{}, err
+	}
+	if err := c.validateEndpoint(); err != nil {
+		return source.RepoManifest{}, err
+	}
+	meta, err := c.repoMetadata(ctx, repo)
+	if err != nil {
+		return source.RepoManifest{}, err
+	}
+	teams, err := c.teamsForProject(ctx, meta.Org, meta.Project)
+	if err != nil {
+		return source.RepoManifest{}, err
+	}
+
+	repoRoot, err := sourceconn.SafeJoin(materializedRoot, meta.FullName)
+	if err != nil {
+		return source.RepoManifest{}, fmt.Errorf("materialize repo root: %w", err)
+	}
+	if err := os.RemoveAll(repoRoot); err != nil {
+		return source.RepoManifest{}, fmt.Errorf("clean materialized repo root: %w", err)
+	}
+	if err := os.MkdirAll(repoRoot, 0o750); err != nil {
+		return source.RepoManifest{}, fmt.Errorf("create materialized repo root: %w", err)
+	}
+
+	emptyRepo := meta.Commit == ""
+	if !emptyRepo {
+		items, treeErr := c.repoItems(ctx, meta)
+		if treeErr != nil {
+			return source.RepoManifest{}, treeErr
+		}
+		emptyRepo = len(items) == 0
+		for _, item := range items {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return source.RepoManifest{}, ctxErr
+			}
+			dest, pathErr := sourceconn.SafeJoin(repoRoot, item.Path)
+			if pathErr != nil {
+				return source.RepoManifest{}, pathErr
+			}
+			if !shouldMaterializePath(item.Path, c.AllowSourceMaterialization) {
+				continue
+			}
+			content, blobErr := c.blob(ctx, meta, item)
+			if blobErr != nil {
+				return source.RepoManifest{}, blobErr
+			}
+			if err := os.MkdirAll(filepath.Dir(dest), 0o750); err != nil {
+				return source.RepoManifest{}, fmt.Errorf("create materialized parent: %w", err)
+			}
+			if err := os.WriteFile(dest, content, 0o600); err != nil {
+				return source.RepoManifest{}, fmt.Errorf("write materialized file %s: %w", item.Path, err)
+			}
+		}
+	}
+
+	contentStatus := source.RepoContentStatusAvailable
+	if emptyRepo {
+		contentStatus = source.RepoContentStatusEmpty
+	}
+	return source.RepoManifest{
+		Repo:              meta.FullName,
+		Location:          Provider + "://" + meta.FullName,
+		ScanRoot:          filepath.ToSlash(repoRoot),
+		Source:            Provider + "_repo_materialized",
+		ContentStatus:     contentStatus,
+		OwnershipMetadata: ownershipMetadata(teams),
+	}, nil
+}
+
+// shouldMaterializePath reuses the GitHub sparse detector predicates and adds
+// Azure Pipelines definitions, which are the native workflow surface here.
+func shouldMaterializePath(rel string, allowSourceMaterialization bool) bool {
+	if workflowloc.IsAzurePipelinePath(rel) {
+		return true
+	}
+	return github.ShouldMaterializePath(rel, allowSourceMaterialization)
+}
+
+// repoMetadata resolves the canonical repository name, repository ID, default
+// branch, and the commit that pins every later item and blob request. An
+// empty Commit means the repository has no default branch yet.
+func (c *Connector) repoMetadata(ctx context.Context, repo string) (repoMeta, error) {
+	parts := strings.Split(repo, "/")
+	organization, project, name := parts[0], parts[1], parts[2]
+	endpoint := c.BaseURL + "/" + url.PathEscape(organization) + "/" + url.PathEscape(project) + "/_apis/git/repositories/" + url.PathEscape(name) + "?" + apiQuery().Encode()
+	var payload struct {
+		ID            string `json:"id"`
+		Name          string `json:"name"`
+		DefaultBranch string `json:"defaultBranch"`
+		IsDisabled    bool   `json:"isDisabled"`
+		Project       struct {
+			Name string `json:"name"`
+		} `json:"project"`
+	}
+	if err := c.getJSON(ctx, endpoint, &payload); err != nil {
+		return repoMeta{}, err
+	}
+	if payload.IsDisabled {
+		return repoMeta{}, fmt.Errorf("azure devops repo %s is disabled", repo)
+	}
+	if strings.TrimSpace(payload.ID) == "" {
+		return repoMeta{}, fmt.Errorf("repo metadata: missing repository id for %s", repo)
+	}
+	meta := repoMeta{Org: organization, Project: project, ID: strings.TrimSpace(payload.ID)}
+	if strings.TrimSpace(payload.Project.Name) != "" && strings.TrimSpace(payload.Name) != "" {
+		meta.Project = strings.TrimSpace(payload.Project.Name)
+		name = strings.TrimSpace(payload.Name)
+	}
+	fullName, err := NormalizeRepo(organization + "/" + meta.Project + "/" + name)
+	if err != nil {
+		return repoMeta{}, fmt.Errorf("repo metadata: %w", err)
+	}
+	meta.FullName = fullName
+
+	defaultRef := strings.TrimSpace(payload.DefaultBranch)
+	if defaultRef == "" {
+		return meta, nil
+	}
+	meta.DefaultBranch = strings.TrimPrefix(defaultRef, "refs/heads/")
+	query := apiQuery()
+	query.Set("filter", "heads/"+meta.DefaultBranch)
+	var refs struct {
+		Value []struct {
+			Name     string `json:"name"`
+			ObjectID string `json:"objectId"`
+		} `json:"value"`
+	}
+	if err := c.getJSON(ctx, c.repoEndpoint(meta)+"/refs?"+query.Encode(), &refs); err != nil {
+		return repoMeta{}, fmt.Errorf("resolve default branch %s for %s: %w", meta.DefaultBranch, fullName, err)
+	}
+	for _, ref := range refs.Value {
+		if ref.Name == defaultRef {
+			meta.Commit = strings.TrimSpace(ref.ObjectID)
+			break
+		}
+	}
+	return meta, nil
+}
+
+type repoItem struct {
+	ObjectID string
+	Path     string
+}
+
+// repoItems lists every blob at the pinned commit in sorted path order.
+// Folders and submodule commits are skipped.
+func (c *Connector) repoItems(ctx context.Context, meta repoMeta) ([]repoItem, error) {
+	query := apiQuery()
+	query.Set("scopePath", "/")
+	query.Set("recursionLevel", "Full")
+	query.Set("versionDescriptor.version", meta.Commit)
+	query.Set("versionDescriptor.versionType", "commit")
+	var payload struct {
+		Value []struct {
+			ObjectID      string `json:"objectId"`
+			GitObjectType string `json:"gitObjectType"`
+			Path          string `json:"path"`
+			IsFolder      bool   `json:"isFolder"`
+		} `json:"value"`
+	}
+	if err := c.getJSON(ctx, c.repoEndpoint(meta)+"/items?"+query.Encode(), &payload); err != nil {
+		return nil, fmt.Errorf("load repo items for %s@%s: %w", meta.FullName, meta.Commit, err)
+	}
+	items := make([]repoItem, 0, len(payload.Value))
+	for _, item := range payload.Value {
+		rel := strings.TrimPrefix(strings.TrimSpace(item.Path), "/")
+		if item.IsFolder || !strings.EqualFold(item.GitObjectType, "blob") || rel == "" || strings.TrimSpace(item.ObjectID) == "" {
+			continue
+		}
+		items = append(items, repoItem{ObjectID: strings.TrimSpace(item.ObjectID), Path: rel})
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].Path < items[j].Path })
+	return items, nil
+}
+
+func (c *Connector) blob(ctx context.Context, meta repoMeta, item repoItem) ([]byte, error) {
+	query := apiQuery()
+	query.Set("$format", "octetstream")
+	body, _, err := c.doGETWithRetry(ctx, c.repoEndpoint(meta)+"/blobs/"+url.PathEscape(item.ObjectID)+"?"+query.Encode(), maxBlobBytes)
+	if err != nil {
+		return nil, fmt.Errorf("load blob %s for %s: %w", item.Path, meta.FullName, err)
+	}
+	return body, nil
+}
+
+// teamsForProject loads project team names once per project. Tokens without
+// the Project and Team (Read) scope get a 401/403 here; that only removes the
+// ownership hint and does not fail acquisition.
+func (c *Connector) teamsForProject(ctx context.Context, organization, project string) ([]string, error) {
+	key := organization + "/" + project
+	c.mu.Lock()
+	cached, ok := c.projectTeams[key]
+	c.mu.Unlock()
+	if ok {
+		return cached, nil
+	}
+
+	teams := make([]string, 0, 8)
+	for skip := 0; ; skip += pageSize {
+		query := apiQuery()
+		query.Set("$top", strconv.Itoa(pageSize))
+		query.Set("$skip", strconv.Itoa(skip))
+		endpoint := c.BaseURL + "/" + url.PathEscape(organization) + "/_apis/projects/" + url.PathEscape(project) + "/teams?" + query.Encode()
+		var payload struct {
+			Value []struct {
+				Name string `json:"name"`
+			} `json:"value"`
+		}
+		if err := c.getJSON(ctx, endpoint, &payload); err != nil {
+			if isForbidden(err) {
+				teams = nil
+				break
+			}
+			return nil, fmt.Errorf("load teams for project %s: %w", key, err)
+		}
+		for _, item := range payload.Value {
+			if name := strings.TrimSpace(item.Name); name != "" {
+				teams = append(teams, name)
+			}
+		}
+		if len(payload.Value) < pageSize {
+			break
+		}
+	}
+	sort.Strings(teams)
+
+	c.mu.Lock()
+	c.projectTeams[key] = teams
+	c.mu.Unlock()
+	return teams, nil
+}
+
+func ownershipMetadata(teams []string) *source.RepoOwnershipMetadata {
+	if len(teams) == 0 {
+		return nil
+	}
+	return &source.RepoOwnershipMetadata{Teams: append([]string(nil), teams...)}
+}
+
+func (c *Connector) repoEndpoint(meta repoMeta) string {
+	return c.BaseURL + "/" + url.PathEscape(meta.Org) + "/" + url.PathEscape(meta.Project) + "/_apis/git/repositories/" + url.PathEscape(meta.ID)
+}
+
+func apiQuery() url.Values {
+	query := url.Values{}
+	query.Set("api-version", apiVersion)
+	return query
+}
+
+func (c *Connector) getJSON(ctx context.Context, endpoint string, out any) error {
+	body, _, err := c.doGETWithRetry(ctx, endpoint, 0)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("parse azure devops response: %w", err)
+	}
+	return nil
+}
+
+type statusError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *statusError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("azure devops API status %d", e.StatusCode)
+	}
+	return fmt.Sprintf("azure devops API status %d: %s", e.StatusCode, e.Message)
+}
+
+func isForbidden(err error) bool {
+	var target *statusError
+	return errors.As(err, &target) && (target.StatusCode == http.StatusUnauthorized || target.StatusCode == http.StatusForbidden)
+}
+
+// authorizationHeader sends Microsoft Entra access tokens (JWTs) as bearer
+// tokens and personal access tokens as basic auth with an empty username.
+func (c *Connector) authorizationHeader() string {
+	token := strings.TrimSpace(c.Token)
+	if token == "" {
+		return ""
+	}
+	if strings.HasPrefix(token, "eyJ") && strings.Count(token, ".") == 2 {
+		return "Bearer " + token
+	}
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(":"+token))
+}
+
+func (c *Connector) doGETWithRetry(ctx context.Context, endpoint string, maxBytes int64) ([]byte, http.Header, error) {
+	var lastErr error
+	for attempt := 0; attempt <= c.MaxRetries; attempt++ {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, nil, ctxErr
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+		if err != nil {
+			return nil, nil, fmt.Errorf("build request: %w", err)
+		}
+		if authorization := c.authorizationHeader(); authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		req.Header.Set("Accept", "application/json")
+
+		resp, err := c.HTTPClient.Do(req)
+		retryDelay := sourceconn.JitteredBackoff(c.Backoff, c.MaxBackoff, attempt)
+		statusCode := 0
+		if err != nil {
+			if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+				lastErr = fmt.Errorf("request timed out before the scan deadline: %v", err)
+			} else {
+				lastErr = fmt.Errorf("request failed: %w", err)
+			}
+		} else {
+			c.recordResponse()
+			reader := io.Reader(resp.Body)
+			if maxBytes > 0 {
+				reader = io.LimitReader(resp.Body, maxBytes+1)
+			}
+			body, readErr := io.ReadAll(reader)
+			_ = resp.Body.Close()
+			if readErr != nil {
+				return nil, nil, fmt.Errorf("read response body: %w", readErr)
+			}
+			// Azure DevOps answers unauthenticated requests with a 203 sign-in page.
+			if resp.StatusCode == http.StatusNonAuthoritativeInfo {
+				return nil, nil, &statusError{StatusCode: http.StatusUnauthorized, Message: "authentication required"}
+			}
+			if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+				if maxBytes > 0 && int64(len(body)) > maxBytes {
+					return nil, nil, fmt.Errorf("azure devops response exceeds the %d-byte limit", maxBytes)
+				}
+				return body, resp.Header, nil
+			}
+			message := sourceconn.APIMessage(body)
+			statusCode = resp.StatusCode
+			switch {
+			case resp.StatusCode == http.StatusTooManyRequests:
+				if wait, ok := sourceconn.RetryAfter(resp.Header, "", c.now()); ok {
+					retryDelay = wait
+				}
+				lastErr = &RateLimitedError{StatusCode: resp.StatusCode, Attempts: attempt + 1, Message: message}
+			case resp.StatusCode >= 500:
+				lastErr = fmt.Errorf("azure devops API transient status %d", resp.StatusCode)
+			default:
+				return nil, nil, &statusError{StatusCode: resp.StatusCode, Message: message}
+			}
+		}
+		if attempt == c.MaxRetries {
+			break
+		}
+		c.emitRetry(attempt+1, retryDelay, statusCode)
+		if sleepErr := c.sleep(ctx, retryDelay); sleepErr != nil {
+			return nil, nil, sleepErr
+		}
+	}
+	if lastErr == nil {
+		lastErr = errors.New("request failed")
+	}
+	return nil, nil, lastErr
+}
+
+// recordResponse counts requests only; Azure DevOps throttles on a sliding
+// resource-usage window rather than a request count a preflight budget could
+// be checked against.
+func (c *Connector) recordResponse() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.requestStats.Requests++
+}
+
+func (c *Connector) now() time.Time {
+	if c.nowFn != nil {
+		return c.nowFn()
+	}
+	return time.Now()
+}
+
+func (c *Connector) sleep(ctx context.Context, duration time.Duration) error {
+	if c.sleepFn != nil {
+		return c.sleepFn(ctx, duration)
+	}
+	return sourceconn.SleepWithContext(ctx, duration)
+}
+
+func (c *Connector) emitRetry(attempt int, delay time.Duration, statusCode int) {
+	if c == nil || c.onRetry == nil {
+		return
+	}
+	c.onRetry(github.RetryEvent{Attempt: attempt, StatusCode: statusCode, Delay: delay})
+}
@@ -0,0 +1,340 @@
+package azuredevops
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Clyra-AI/wrkr/core/source"
+)
+
+func TestConnectorRequiresBaseURL(t *testing.T) {
+	t.Parallel()
+
+	connector := NewConnector("", "", nil)
+	if _, err := connector.AcquireRepo(context.Background(), "contoso/payments/ledger"); err == nil || !strings.Contains(err.Error(), "azure devops api base url is required") {
+		t.Fatalf("expected missing base url error, got %v", err)
+	}
+}
+
+func TestConnectorRejectsInsecureEndpointBeforeSendingToken(t *testing.T) {
+	t.Parallel()
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	connector := NewConnector(server.URL, "pat-secret", server.Client())
+	if _, err := connector.ListOrgRepos(context.Background(), "contoso"); err == nil {
+		t.Fatal("expected insecure endpoint rejection")
+	}
+	if requests != 0 {
+		t.Fatalf("expected no requests to insecure endpoint, got %d", requests)
+	}
+}
+
+func TestAuthorizationHeaderSupportsPATAndEntraTokens(t *testing.T) {
+	t.Parallel()
+
+	expectedBasic := "Basic " + base64.StdEncoding.EncodeToString([]byte(":pat-secret"))
+	if got := (&Connector{Token: "pat-secret"}).authorizationHeader(); got != expectedBasic {
+		t.Fatalf("expected basic PAT header, got %q", got)
+	}
+	if got := (&Connector{Token: "eyJhbGciOi.eyJzdWIiOi.c2lnbmF0dXJl"}).authorizationHeader(); got != "Bearer eyJhbGciOi.eyJzdWIiOi.c2lnbmF0dXJl" {
+		t.Fatalf("expected bearer header for entra token, got %q", got)
+	}
+}
+
+func TestListOrgReposEnumeratesProjectsWithContinuation(t *testing.T) {
+	t.Parallel()
+
+	expectedAuth := "Basic " + base64.StdEncoding.EncodeToString([]byte(":pat-test"))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != expectedAuth {
+			t.Errorf("expected basic PAT header, got %q", got)
+		}
+		if r.URL.Query().Get("api-version") != apiVersion {
+			t.Fatalf("expected api-version %s, got %q", apiVersion, r.URL.RawQuery)
+		}
+		switch r.URL.Path {
+		case "/contoso/_apis/projects":
+			switch r.URL.Query().Get("continuationToken") {
+			case "":
+				w.Header().Set("X-MS-ContinuationToken", "page-2")
+				_, _ = fmt.Fprint(w, `{"value":[{"name":"Payments Platform"}]}`)
+			case "page-2":
+				_, _ = fmt.Fprint(w, `{"value":[{"name":"infra"}]}`)
+			default:
+				t.Fatalf("unexpected continuation token: %s", r.URL.RawQuery)
+			}
+		case "/contoso/Payments Platform/_apis/git/repositories":
+			_, _ = fmt.Fprint(w, `{"value":[{"name":"ledger","project":{"name":"Payments Platform"}},{"name":"archived","isDisabled":true,"project":{"name":"Payments Platform"}}]}`)
+		case "/contoso/infra/_apis/git/repositories":
+			_, _ = fmt.Fprint(w, `{"value":[{"name":"agents","project":{"name":"infra"}}]}`)
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	connector := NewConnectorWithOptions(server.URL, "pat-test", server.Client(), ConnectorOptions{AllowInsecureLoopback: true})
+	repos, err := connector.ListOrgRepos(context.Background(), "contoso")
+	if err != nil {
+		t.Fatalf("list org repos: %v", err)
+	}
+	expected := []string{"contoso/Payments Platform/ledger", "contoso/infra/agents"}
+	if !reflect.DeepEqual(repos, expected) {
+		t.Fatalf("unexpected repos: %v", repos)
+	}
+	if telemetry := connector.AcquisitionTelemetry(); telemetry.Requests != 4 || telemetry.Mode != "azdo_sparse_api" {
+		t.Fatalf("unexpected telemetry: %+v", telemetry)
+	}
+}
+
+func TestListOrgReposScopedToProjectSkipsProjectEnumeration(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/contoso/payments/_apis/git/repositories" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		_, _ = fmt.Fprint(w, `{"value":[{"name":"web","project":{"name":"payments"}},{"name":"api","project":{"name":"payments"}}]}`)
+	}))
+	defer server.Close()
+
+	connector := NewConnectorWithOptions(server.URL, "", server.Client(), ConnectorOptions{AllowInsecureLoopback: true})
+	repos, err := connector.ListOrgRepos(context.Background(), "/contoso/payments/")
+	if err != nil {
+		t.Fatalf("list project repos: %v", err)
+	}
+	if !reflect.DeepEqual(repos, []string{"contoso/payments/api", "contoso/payments/web"}) {
+		t.Fatalf("unexpected repos: %v", repos)
+	}
+}
+
+func TestMaterializeRepoWritesSparseTreeWithTeamOwnership(t *testing.T) {
+	t.Parallel()
+
+	tmp := t.TempDir()
+	var teamRequests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/contoso/payments/_apis/git/repositories/ledger":
+			_, _ = fmt.Fprint(w, `{"id":"repo-1","name":"ledger","defaultBranch":"refs/heads/main","project":{"name":"payments"}}`)
+		case "/contoso/payments/_apis/git/repositories/repo-1/refs":
+			if r.URL.Query().Get("filter") != "heads/main" {
+				t.Fatalf("unexpected refs filter: %s", r.URL.RawQuery)
+			}
+			_, _ = fmt.Fprint(w, `{"value":[{"name":"refs/heads/main-old","objectId":"stale"},{"name":"refs/heads/main","objectId":"c0ffee"}]}`)
+		case "/contoso/payments/_apis/git/repositories/repo-1/items":
+			if r.URL.Query().Get("versionDescriptor.version") != "c0ffee" || r.URL.Query().Get("versionDescriptor.versionType") != "commit" || r.URL.Query().Get("recursionLevel") != "Full" {
+				t.Fatalf("unexpected items query: %s", r.URL.RawQuery)
+			}
+			_, _ = fmt.Fprint(w, `{"value":[{"objectId":"tree-root","gitObjectType":"tree","path":"/","isFolder":true},{"objectId":"sha-agents","gitObjectType":"blob","path":"/AGENTS.md"},{"objectId":"sha-pipe","gitObjectType":"blob","path":"/azure-pipelines.yml"},{"objectId":"sha-mcp","gitObjectType":"blob","path":"/.vscode/mcp.json"},{"objectId":"sha-sub","gitObjectType":"commit","path":"/vendor/lib"},{"objectId":"sha-source","gitObjectType":"blob","path":"/src/main.py"},{"objectId":"sha-skip","gitObjectType":"blob","path":"/docs/changelog.txt"}]}`)
+		case "/contoso/payments/_apis/git/repositories/repo-1/blobs/sha-agents":
+			_, _ = fmt.Fprint(w, "# agents\n")
+		case "/contoso/payments/_apis/git/repositories/repo-1/blobs/sha-pipe":
+			_, _ = fmt.Fprint(w, "trigger: [main]\n")
+		case "/contoso/payments/_apis/git/repositories/repo-1/blobs/sha-mcp":
+			_, _ = fmt.Fprint(w, `{"servers":{}}`)
+		case "/contoso/_apis/projects/payments/teams":
+			atomic.AddInt32(&teamRequests, 1)
+			_, _ = fmt.Fprint(w, `{"value":[{"name":"payments Team"},{"name":"Ledger Owners"}]}`)
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	connector := NewConnectorWithOptions(server.URL, "", server.Client(), ConnectorOptions{AllowInsecureLoopback: true})
+	manifest, err := connector.MaterializeRepo(context.Background(), "contoso/payments/ledger", tmp)
+	if err != nil {
+		t.Fatalf("materialize repo: %v", err)
+	}
+	if manifest.Source != "azdo_repo_materialized" {
+		t.Fatalf("unexpected source: %s", manifest.Source)
+	}
+	if manifest.Location != "azdo://contoso/payments/ledger" {
+		t.Fatalf("expected logical hosted location, got %s", manifest.Location)
+	}
+	if manifest.ContentStatus != source.RepoContentStatusAvailable {
+		t.Fatalf("expected available content status, got %q", manifest.ContentStatus)
+	}
+	if manifest.OwnershipMetadata == nil || !reflect.DeepEqual(manifest.OwnershipMetadata.Teams, []string{"Ledger Owners", "payments Team"}) {
+		t.Fatalf("expected sorted project teams, got %+v", manifest.OwnershipMetadata)
+	}
+
+	for _, rel := range []string{"AGENTS.md", "azure-pipelines.yml", ".vscode/mcp.json"} {
+		if _, err := os.Stat(filepath.Join(tmp, "contoso", "payments", "ledger", filepath.FromSlash(rel))); err != nil {
+			t.Fatalf("expected materialized %s: %v", rel, err)
+		}
+	}
+	for _, rel := range []string{"vendor/lib", "src/main.py", "docs/changelog.txt"} {
+		if _, err := os.Stat(filepath.Join(tmp, "contoso", "payments", "ledger", filepath.FromSlash(rel))); !os.IsNotExist(err) {
+			t.Fatalf("expected %s to be skipped, got %v", rel, err)
+		}
+	}
+
+	if _, err := connector.AcquireRepo(context.Background(), "contoso/payments/ledger"); err != nil {
+		t.Fatalf("acquire repo: %v", err)
+	}
+	if got := atomic.LoadInt32(&teamRequests); got != 1 {
+		t.Fatalf("expected project teams to be loaded once, got %d", got)
+	}
+}
+
+func TestMaterializeRepoToleratesForbiddenTeamsAndEmptyRepo(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/contoso/payments/_apis/git/repositories/empty":
+			_, _ = fmt.Fprint(w, `{"id":"repo-2","name":"empty","project":{"name":"payments"}}`)
+		case "/contoso/_apis/projects/payments/teams":
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = fmt.Fprint(w, `{"message":"TF400813: The user is not authorized to access this resource."}`)
+		default:
+			t.Fatalf("empty repo should not request %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	connector := NewConnectorWithOptions(server.URL, "", server.Client(), ConnectorOptions{AllowInsecureLoopback: true})
+	manifest, err := connector.MaterializeRepo(context.Background(), "contoso/payments/empty", t.TempDir())
+	if err != nil {
+		t.Fatalf("materialize empty repo: %v", err)
+	}
+	if manifest.ContentStatus != source.RepoContentStatusEmpty {
+		t.Fatalf("expected empty content status, got %q", manifest.ContentStatus)
+	}
+	if manifest.OwnershipMetadata != nil {
+		t.Fatalf("expected no ownership metadata without team access, got %+v", manifest.OwnershipMetadata)
+	}
+}
+
+func TestMaterializeRepoRejectsTraversalItemPath(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/contoso/payments/_apis/git/repositories/ledger":
+			_, _ = fmt.Fprint(w, `{"id":"repo-1","name":"ledger","defaultBranch":"refs/heads/main","project":{"name":"payments"}}`)
+		case "/contoso/payments/_apis/git/repositories/repo-1/refs":
+			_, _ = fmt.Fprint(w, `{"value":[{"name":"refs/heads/main","objectId":"c0ffee"}]}`)
+		case "/contoso/payments/_apis/git/repositories/repo-1/items":
+			_, _ = fmt.Fprint(w, `{"value":[{"objectId":"sha-1","gitObjectType":"blob","path":"/../AGENTS.md"}]}`)
+		case "/contoso/_apis/projects/payments/teams":
+			_, _ = fmt.Fprint(w, `{"value":[]}`)
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	connector := NewConnectorWithOptions(server.URL, "", server.Client(), ConnectorOptions{AllowInsecureLoopback: true})
+	if _, err := connector.MaterializeRepo(context.Background(), "contoso/payments/ledger", t.TempDir()); err == nil {
+		t.Fatal("expected traversal path to fail materialization")
+	}
+}
+
+func TestConnectorTreatsSignInRedirectAsUnauthorized(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNonAuthoritativeInfo)
+		_, _ = fmt.Fprint(w, "<html>sign in</html>")
+	}))
+	defer server.Close()
+
+	connector := NewConnectorWithOptions(server.URL, "", server.Client(), ConnectorOptions{AllowInsecureLoopback: true})
+	if _, err := connector.ListOrgRepos(context.Background(), "contoso/payments"); err == nil || !strings.Contains(err.Error(), "status 401") {
+		t.Fatalf("expected unauthorized error, got %v", err)
+	}
+}
+
+func TestConnectorHonorsRetryAfter429(t *testing.T) {
+	t.Parallel()
+
+	var attempts int32
+	var slept []time.Duration
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.Header().Set("Retry-After", "3")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = fmt.Fprint(w, `{"message":"Request was blocked due to exceeding usage of resource"}`)
+			return
+		}
+		_, _ = fmt.Fprint(w, `{"value":[{"name":"ledger","project":{"name":"payments"}}]}`)
+	}))
+	defer server.Close()
+
+	connector := NewConnectorWithOptions(server.URL, "", server.Client(), ConnectorOptions{AllowInsecureLoopback: true})
+	connector.sleepFn = func(_ context.Context, duration time.Duration) error {
+		slept = append(slept, duration)
+		return nil
+	}
+
+	repos, err := connector.ListOrgRepos(context.Background(), "contoso/payments")
+	if err != nil {
+		t.Fatalf("list project repos: %v", err)
+	}
+	if !reflect.DeepEqual(repos, []string{"contoso/payments/ledger"}) {
+		t.Fatalf("unexpected repos: %v", repos)
+	}
+	if attempts != 2 {
+		t.Fatalf("expected two attempts, got %d", attempts)
+	}
+	if len(slept) == 0 || slept[0] != 3*time.Second {
+		t.Fatalf("expected retry-after sleep of 3s, got %v", slept)
+	}
+}
+
+func TestConnectorReturnsRateLimitedErrorAfterRetries(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Retry-After", "1")
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = fmt.Fprint(w, `{"message":"Request was blocked due to exceeding usage of resource"}`)
+	}))
+	defer server.Close()
+
+	connector := NewConnectorWithOptions(server.URL, "", server.Client(), ConnectorOptions{AllowInsecureLoopback: true})
+	connector.MaxRetries = 1
+	connector.sleepFn = func(context.Context, time.Duration) error { return nil }
+
+	_, err := connector.ListOrgRepos(context.Background(), "contoso/payments")
+	if !IsRateLimitedError(err) {
+		t.Fatalf("expected rate limited error, got %v", err)
+	}
+}
+
+func TestNormalizeScopeAndRepo(t *testing.T) {
+	t.Parallel()
+
+	for _, value := range []string{"", "contoso/payments/ledger", "contoso//payments", "contoso/../payments"} {
+		if _, err := NormalizeScope(value); err == nil {
+			t.Fatalf("expected scope %q to be rejected", value)
+		}
+	}
+	for _, value := range []string{"contoso/payments", "contoso/payments/ledger/extra", `contoso\payments\ledger`} {
+		if _, err := NormalizeRepo(value); err == nil {
+			t.Fatalf("expected repo %q to be rejected", value)
+		}
+	}
+	if got, err := NormalizeRepo("/contoso/Payments Platform/ledger/"); err != nil || got != "contoso/Payments Platform/ledger" {
+		t.Fatalf("unexpected normalized repo %q err=%v", got, err)
+	}
+}
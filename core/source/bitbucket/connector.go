@@ -0,0 +1,714 @@
+// Package bitbucket acquires Bitbucket Cloud workspaces and Bitbucket Data
+// Center projects through their REST APIs with the same sparse materialization
+// contract as the GitHub connector.
+package bitbucket
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Clyra-AI/wrkr/core/source"
+	"github.com/Clyra-AI/wrkr/core/source/github"
+	"github.com/Clyra-AI/wrkr/core/workflowloc"
+	"github.com/Clyra-AI/wrkr/internal/githubendpoint"
+	"github.com/Clyra-AI/wrkr/internal/reponame"
+	"github.com/Clyra-AI/wrkr/internal/sourceconn"
+)
+
+const (
+	// Provider names Bitbucket-hosted sources in manifests, checkpoints, and locations.
+	Provider = "bitbucket"
+
+	// FlavorCloud targets the bitbucket.org 2.0 API.
+	FlavorCloud = "cloud"
+	// FlavorDataCenter targets the self-hosted /rest/api/1.0 API.
+	FlavorDataCenter = "datacenter"
+
+	pageSize     = 100
+	maxBlobBytes = 10 << 20
+	// maxTreeDepth bounds the recursive Cloud source listing.
+	maxTreeDepth = 32
+)
+
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Connector acquires Bitbucket repository lists and sparse repository trees.
+type Connector struct {
+	BaseURL    string
+	Token      string
+	Flavor     string
+	HTTPClient HTTPClient
+	MaxRetries int
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	// AllowSourceMaterialization permits broad source-code extension fetching for
+	// explicit deep/debug scans. Default hosted scans keep this false.
+	AllowSourceMaterialization bool
+	endpointOptions            githubendpoint.Options
+
+	mu           sync.Mutex
+	nowFn        func() time.Time
+	sleepFn      func(context.Context, time.Duration) error
+	onRetry      func(github.RetryEvent)
+	requestStats source.AcquisitionTelemetry
+}
+
+// ConnectorOptions controls explicit development-only connector behavior.
+type ConnectorOptions struct {
+	AllowInsecureLoopback bool
+}
+
+func NewConnector(baseURL, token string, client HTTPClient) *Connector {
+	return NewConnectorWithOptions(baseURL, token, client, ConnectorOptions{})
+}
+
+// NewConnectorWithOptions permits loopback HTTP only when explicitly requested
+// for local development or tests. Production callers must use NewConnector.
+func NewConnectorWithOptions(baseURL, token string, client HTTPClient, options ConnectorOptions) *Connector {
+	endpointOptions := githubendpoint.Options{AllowInsecureLoopback: options.AllowInsecureLoopback}
+	if configured, ok := client.(*http.Client); ok {
+		client = sourceconn.NewHTTPClient(baseURL, endpointOptions, configured)
+	} else if client == nil {
+		client = sourceconn.NewHTTPClient(baseURL, endpointOptions, nil)
+	}
+	trimmed := strings.TrimRight(baseURL, "/")
+	return &Connector{
+		BaseURL:         trimmed,
+		Token:           token,
+		Flavor:          DetectFlavor(trimmed),
+		HTTPClient:      client,
+		MaxRetries:      2,
+		Backoff:         25 * time.Millisecond,
+		MaxBackoff:      2 * time.Second,
+		endpointOptions: endpointOptions,
+		nowFn:           time.Now,
+		sleepFn:         sourceconn.SleepWithContext,
+	}
+}
+
+// DetectFlavor selects the Data Center API when the base URL points at a
+// /rest/api/1.0 or /rest/api/latest root and Bitbucket Cloud otherwise.
+func DetectFlavor(baseURL string) string {
+	path := strings.TrimRight(strings.ToLower(strings.TrimSpace(baseURL)), "/")
+	if parsed, err := url.Parse(path); err == nil {
+		path = strings.TrimRight(parsed.Path, "/")
+	}
+	if strings.HasSuffix(path, "/rest/api/1.0") || strings.HasSuffix(path, "/rest/api/latest") {
+		return FlavorDataCenter
+	}
+	return FlavorCloud
+}
+
+func (c *Connector) validateEndpoint() error {
+	if c == nil {
+		return errors.New("bitbucket connector is required")
+	}
+	if c.BaseURL == "" {
+		return errors.New("bitbucket api base url is required for hosted acquisition")
+	}
+	_, err := githubendpoint.Parse(c.BaseURL, c.endpointOptions)
+	return err
+}
+
+func (c *Connector) dataCenter() bool {
+	return c.Flavor == FlavorDataCenter
+}
+
+func (c *Connector) SetRetryHandler(fn func(github.RetryEvent)) {
+	if c == nil {
+		return
+	}
+	c.onRetry = fn
+}
+
+func (c *Connector) SetAllowSourceMaterialization(allow bool) {
+	if c == nil {
+		return
+	}
+	c.AllowSourceMaterialization = allow
+}
+
+func (c *Connector) AcquisitionTelemetry() source.AcquisitionTelemetry {
+	if c == nil {
+		return source.AcquisitionTelemetry{}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	out := c.requestStats
+	out.Warnings = append([]string(nil), c.requestStats.Warnings...)
+	out.Mode = "bitbucket_" + c.Flavor + "_sparse_api"
+	return out
+}
+
+// RateLimitedError reports exhausted Bitbucket throttling after bounded retries.
+type RateLimitedError struct {
+	StatusCode int
+	Attempts   int
+	Message    string
+}
+
+func (e *RateLimitedError) Error() string {
+	if e == nil {
+		return ""
+	}
+	parts := []string{fmt.Sprintf("bitbucket API rate limit exhausted after %d attempt(s)", e.Attempts)}
+	if e.StatusCode != 0 {
+		parts = append(parts, fmt.Sprintf("status=%d", e.StatusCode))
+	}
+	if message := strings.TrimSpace(e.Message); message != "" {
+		parts = append(parts, "upstream_message="+message)
+	}
+	return strings.Join(parts, "; ")
+}
+
+// IsRateLimitedError reports whether err represents exhausted hosted throttling.
+func IsRateLimitedError(err error) bool {
+	var target *RateLimitedError
+	return errors.As(err, &target)
+}
+
+// NormalizeWorkspace validates a Bitbucket Cloud workspace slug or Data
+// Center project key.
+func NormalizeWorkspace(workspace string) (string, error) {
+	return reponame.NormalizeOrg(workspace)
+}
+
+// NormalizeRepo validates a workspace/repo-slug or PROJECT/repo-slug identifier.
+func NormalizeRepo(repo string) (string, error) {
+	return reponame.NormalizeRepo(repo)
+}
+
+type repoMeta struct {
+	FullName      string
+	DefaultBranch string
+	Commit        string
+}
+
+// AcquireRepo resolves one repository by workspace/repo-slug (Cloud) or
+// PROJECT/repo-slug (Data Center).
+func (c *Connector) AcquireRepo(ctx context.Context, repo string) (source.RepoManifest, error) {
+	repo, err := NormalizeRepo(repo)
+	if err != nil {
+		return source.RepoManifest{}, err
+	}
+	if err := c.validateEndpoint(); err != nil {
+		return source.RepoManifest{}, err
+	}
+	meta, err := c.repoMetadata(ctx, repo)
+	if err != nil {
+		return source.RepoManifest{}, err
+	}
+	return source.RepoManifest{
+		Repo:     meta.FullName,
+		Location: meta.FullName,
+		Source:   Provider + "_repo",
+	}, nil
+}
+
+// ListOrgRepos lists every repository in a Cloud workspace or Data Center
+// project. The name satisfies org.RepoLister so workspaces reuse org
+// checkpointing.
+func (c *Connector) ListOrgRepos(ctx context.Context, workspace string) ([]string, error) {
+	workspace, err := NormalizeWorkspace(workspace)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.validateEndpoint(); err != nil {
+		return nil, err
+	}
+
+	repos := make([]string, 0, 128)
+	seen := map[string]struct{}{}
+	add := func(owner, slug string) error {
+		repo, err := NormalizeRepo(owner + "/" + slug)
+		if err != nil {
+			return err
+		}
+		if _, ok := seen[repo]; ok {
+			return nil
+		}
+		seen[repo] = struct{}{}
+		repos = append(repos, repo)
+		return nil
+	}
+
+	if c.dataCenter() {
+		start := 0
+		for page := 1; ; page++ {
+			endpoint := c.BaseURL + "/projects/" + url.PathEscape(workspace) + "/repos?" + dataCenterPageQuery(start).Encode()
+			var payload struct {
+				Values []struct {
+					Slug    string `json:"slug"`
+					Project struct {
+						Key string `json:"key"`
+					} `json:"project"`
+				} `json:"values"`
+				IsLastPage    bool `json:"isLastPage"`
+				NextPageStart int  `json:"nextPageStart"`
+			}
+			if err := c.getJSON(ctx, endpoint, &payload); err != nil {
+				return nil, fmt.Errorf("list project repos page %d: %w", page, err)
+			}
+			for _, item := range payload.Values {
+				owner := strings.TrimSpace(item.Project.Key)
+				if owner == "" {
+					owner = workspace
+				}
+				if err := add(owner, item.Slug); err != nil {
+					return nil, fmt.Errorf("list project repos page %d: %w", page, err)
+				}
+			}
+			if payload.IsLastPage || payload.NextPageStart <= start {
+				break
+			}
+			start = payload.NextPageStart
+		}
+	} else {
+		for page := 1; ; page++ {
+			query := url.Values{}
+			query.Set("pagelen", strconv.Itoa(pageSize))
+			query.Set("page", strconv.Itoa(page))
+			query.Set("sort", "slug")
+			endpoint := c.BaseURL + "/repositories/" + url.PathEscape(workspace) + "?" + query.Encode()
+			var payload struct {
+				Values []struct {
+					FullName string `json:"full_name"`
+					Slug     string `json:"slug"`
+				} `json:"values"`
+				Next string `json:"next"`
+			}
+			if err := c.getJSON(ctx, endpoint, &payload); err != nil {
+				return nil, fmt.Errorf("list workspace repos page %d: %w", page, err)
+			}
+			for _, item := range payload.Values {
+				slug := strings.TrimSpace(item.Slug)
+				if slug == "" {
+					_, slug, _ = strings.Cut(item.FullName, "/")
+				}
+				if err := add(workspace, slug); err != nil {
+					return nil, fmt.Errorf("list workspace repos page %d: %w", page, err)
+				}
+			}
+			if strings.TrimSpace(payload.Next) == "" || len(payload.Values) == 0 {
+				break
+			}
+		}
+	}
+	sort.Strings(repos)
+	return repos, nil
+}
+
+// MaterializeRepo fetches detector-relevant repository files through the
+// Bitbucket API and writes them into a deterministic local workspace under
+// materializedRoot.
+func (c *Connector) MaterializeRepo(ctx context.Context, repo string, materializedRoot string) (source.RepoManifest, error) {
+	repo, err := NormalizeRepo(repo)
+	if err != nil {
+		return source.RepoManifest{}, err
+	}
+	if err := c.validateEndpoint(); err != nil {
+		return source.RepoManifest{}, err
+	}
+	meta, err := c.repoMetadata(ctx, repo)
+	if err != nil {
+		return source.RepoManifest{}, err
+	}
+
+	repoRoot, err := sourceconn.SafeJoin(materializedRoot, meta.FullName)
+	if err != nil {
+		return source.RepoManifest{}, fmt.Errorf("materialize repo root: %w", err)
+	}
+	if err := os.RemoveAll(repoRoot); err != nil {
+		return source.RepoManifest{}, fmt.Errorf("clean materialized repo root: %w", err)
+	}
+	if err := os.MkdirAll(repoRoot, 0o750); err != nil {
+		return source.RepoManifest{}, fmt.Errorf("create materialized repo root: %w", err)
+	}
+
+	emptyRepo := meta.Commit == ""
+	if !emptyRepo {
+		files, treeErr := c.repoFiles(ctx, meta)
+		if treeErr != nil {
+			return source.RepoManifest{}, treeErr
+		}
+		emptyRepo = len(files) == 0
+		for _, rel := range files {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return source.RepoManifest{}, ctxErr
+			}
+			dest, pathErr := sourceconn.SafeJoin(repoRoot, rel)
+			if pathErr != nil {
+				return source.RepoManifest{}, pathErr
+			}
+			if !shouldMaterializePath(rel, c.AllowSourceMaterialization) {
+				continue
+			}
+			content, blobErr := c.repoFile(ctx, meta, rel)
+			if blobErr != nil {
+				return source.RepoManifest{}, blobErr
+			}
+			if err := os.MkdirAll(filepath.Dir(dest), 0o750); err != nil {
+				return source.RepoManifest{}, fmt.Errorf("create materialized parent: %w", err)
+			}
+			if err := os.WriteFile(dest, content, 0o600); err != nil {
+				return source.RepoManifest{}, fmt.Errorf("write materialized file %s: %w", rel, err)
+			}
+		}
+	}
+
+	contentStatus := source.RepoContentStatusAvailable
+	if emptyRepo {
+		contentStatus = source.RepoContentStatusEmpty
+	}
+	return source.RepoManifest{
+		Repo:          meta.FullName,
+		Location:      Provider + "://" + meta.FullName,
+		ScanRoot:      filepath.ToSlash(repoRoot),
+		Source:        Provider + "_repo_materialized",
+		ContentStatus: contentStatus,
+	}, nil
+}
+
+// shouldMaterializePath reuses the GitHub sparse detector predicates and adds
+// Bitbucket Pipelines definitions, which are the native workflow surface here.
+func shouldMaterializePath(rel string, allowSourceMaterialization bool) bool {
+	if workflowloc.IsBitbucketPipeline(rel) {
+		return true
+	}
+	return github.ShouldMaterializePath(rel, allowSourceMaterialization)
+}
+
+// repoMetadata resolves the canonical repository name, default branch, and
+// the commit that pins every later tree and file request. An empty Commit
+// means the repository has no default branch yet.
+func (c *Connector) repoMetadata(ctx context.Context, repo string) (repoMeta, error) {
+	owner, slug, _ := strings.Cut(repo, "/")
+	if c.dataCenter() {
+		repoEndpoint := c.BaseURL + "/projects/" + url.PathEscape(owner) + "/repos/" + url.PathEscape(slug)
+		var payload struct {
+			Slug    string `json:"slug"`
+			Project struct {
+				Key string `json:"key"`
+			} `json:"project"`
+		}
+		if err := c.getJSON(ctx, repoEndpoint, &payload); err != nil {
+			return repoMeta{}, err
+		}
+		fullName, err := metadataName(payload.Project.Key, payload.Slug, repo)
+		if err != nil {
+			return repoMeta{}, fmt.Errorf("repo metadata: %w", err)
+		}
+		var branch struct {
+			DisplayID    string `json:"displayId"`
+			LatestCommit string `json:"latestCommit"`
+		}
+		if err := c.getJSON(ctx, repoEndpoint+"/default-branch", &branch); err != nil {
+			if isNotFound(err) {
+				return repoMeta{FullName: fullName}, nil
+			}
+			return repoMeta{}, fmt.Errorf("load default branch for %s: %w", fullName, err)
+		}
+		return repoMeta{FullName: fullName, DefaultBranch: strings.TrimSpace(branch.DisplayID), Commit: strings.TrimSpace(branch.LatestCommit)}, nil
+	}
+
+	repoEndpoint := c.BaseURL + "/repositories/" + url.PathEscape(owner) + "/" + url.PathEscape(slug)
+	var payload struct {
+		FullName   string `json:"full_name"`
+		MainBranch *struct {
+			Name string `json:"name"`
+		} `json:"mainbranch"`
+	}
+	if err := c.getJSON(ctx, repoEndpoint, &payload); err != nil {
+		return repoMeta{}, err
+	}
+	payloadOwner, payloadSlug, _ := strings.Cut(strings.TrimSpace(payload.FullName), "/")
+	fullName, err := metadataName(payloadOwner, payloadSlug, repo)
+	if err != nil {
+		return repoMeta{}, fmt.Errorf("repo metadata: %w", err)
+	}
+	if payload.MainBranch == nil || strings.TrimSpace(payload.MainBranch.Name) == "" {
+		return repoMeta{FullName: fullName}, nil
+	}
+	defaultBranch := strings.TrimSpace(payload.MainBranch.Name)
+	var ref struct {
+		Target struct {
+			Hash string `json:"hash"`
+		} `json:"target"`
+	}
+	if err := c.getJSON(ctx, repoEndpoint+"/refs/branches/"+url.PathEscape(defaultBranch), &ref); err != nil {
+		if isNotFound(err) {
+			return repoMeta{FullName: fullName, DefaultBranch: defaultBranch}, nil
+		}
+		return repoMeta{}, fmt.Errorf("resolve default branch %s for %s: %w", defaultBranch, fullName, err)
+	}
+	return repoMeta{FullName: fullName, DefaultBranch: defaultBranch, Commit: strings.TrimSpace(ref.Target.Hash)}, nil
+}
+
+// repoFiles lists every regular file at the pinned commit in sorted order.
+// Cloud symlinks and submodules are skipped; Data Center only lists files.
+func (c *Connector) repoFiles(ctx context.Context, meta repoMeta) ([]string, error) {
+	owner, slug, _ := strings.Cut(meta.FullName, "/")
+	files := make([]string, 0, 256)
+	if c.dataCenter() {
+		start := 0
+		for page := 1; ; page++ {
+			query := dataCenterPageQuery(start)
+			query.Set("at", meta.Commit)
+			endpoint := c.BaseURL + "/projects/" + url.PathEscape(owner) + "/repos/" + url.PathEscape(slug) + "/files?" + query.Encode()
+			var payload struct {
+				Values        []string `json:"values"`
+				IsLastPage    bool     `json:"isLastPage"`
+				NextPageStart int      `json:"nextPageStart"`
+			}
+			if err := c.getJSON(ctx, endpoint, &payload); err != nil {
+				return nil, fmt.Errorf("load repo files for %s@%s page %d: %w", meta.FullName, meta.Commit, page, err)
+			}
+			for _, item := range payload.Values {
+				if strings.TrimSpace(item) != "" {
+					files = append(files, item)
+				}
+			}
+			if payload.IsLastPage || payload.NextPageStart <= start {
+				break
+			}
+			start = payload.NextPageStart
+		}
+	} else {
+		for page := 1; ; page++ {
+			query := url.Values{}
+			query.Set("max_depth", strconv.Itoa(maxTreeDepth))
+			query.Set("pagelen", strconv.Itoa(pageSize))
+			query.Set("page", strconv.Itoa(page))
+			endpoint := c.BaseURL + "/repositories/" + url.PathEscape(owner) + "/" + url.PathEscape(slug) + "/src/" + url.PathEscape(meta.Commit) + "/?" + query.Encode()
+			var payload struct {
+				Values []struct {
+					Type       string   `json:"type"`
+					Path       string   `json:"path"`
+					Attributes []string `json:"attributes"`
+				} `json:"values"`
+				Next string `json:"next"`
+			}
+			if err := c.getJSON(ctx, endpoint, &payload); err != nil {
+				return nil, fmt.Errorf("load repo tree for %s@%s page %d: %w", meta.FullName, meta.Commit, page, err)
+			}
+			for _, item := range payload.Values {
+				if item.Type != "commit_file" || strings.TrimSpace(item.Path) == "" || hasAttribute(item.Attributes, "link", "subrepository") {
+					continue
+				}
+				files = append(files, item.Path)
+			}
+			if strings.TrimSpace(payload.Next) == "" || len(payload.Values) == 0 {
+				break
+			}
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+func (c *Connector) repoFile(ctx context.Context, meta repoMeta, rel string) ([]byte, error) {
+	owner, slug, _ := strings.Cut(meta.FullName, "/")
+	var endpoint string
+	if c.dataCenter() {
+		query := url.Values{}
+		query.Set("at", meta.Commit)
+		endpoint = c.BaseURL + "/projects/" + url.PathEscape(owner) + "/repos/" + url.PathEscape(slug) + "/raw/" + escapePath(rel) + "?" + query.Encode()
+	} else {
+		endpoint = c.BaseURL + "/repositories/" + url.PathEscape(owner) + "/" + url.PathEscape(slug) + "/src/" + url.PathEscape(meta.Commit) + "/" + escapePath(rel)
+	}
+	body, _, err := c.doGETWithRetry(ctx, endpoint, maxBlobBytes)
+	if err != nil {
+		return nil, fmt.Errorf("load repo file %s for %s: %w", rel, meta.FullName, err)
+	}
+	return body, nil
+}
+
+func (c *Connector) getJSON(ctx context.Context, endpoint string, out any) error {
+	body, _, err := c.doGETWithRetry(ctx, endpoint, 0)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("parse bitbucket response: %w", err)
+	}
+	return nil
+}
+
+func dataCenterPageQuery(start int) url.Values {
+	query := url.Values{}
+	query.Set("start", strconv.Itoa(start))
+	query.Set("limit", strconv.Itoa(pageSize))
+	return query
+}
+
+func escapePath(rel string) string {
+	parts := strings.Split(filepath.ToSlash(rel), "/")
+	for idx, part := range parts {
+		parts[idx] = url.PathEscape(part)
+	}
+	return strings.Join(parts, "/")
+}
+
+func hasAttribute(attributes []string, names ...string) bool {
+	for _, attribute := range attributes {
+		for _, name := range names {
+			if strings.EqualFold(strings.TrimSpace(attribute), name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func metadataName(owner, slug, requested string) (string, error) {
+	if strings.TrimSpace(owner) == "" || strings.TrimSpace(slug) == "" {
+		return NormalizeRepo(requested)
+	}
+	return NormalizeRepo(owner + "/" + slug)
+}
+
+type statusError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *statusError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("bitbucket API status %d", e.StatusCode)
+	}
+	return fmt.Sprintf("bitbucket API status %d: %s", e.StatusCode, e.Message)
+}
+
+func isNotFound(err error) bool {
+	var target *statusError
+	return errors.As(err, &target) && (target.StatusCode == http.StatusNotFound || target.StatusCode == http.StatusNoContent)
+}
+
+// authorizationHeader sends username:secret tokens (Cloud app passwords and
+// API tokens) as basic auth and everything else as a bearer token.
+func (c *Connector) authorizationHeader() string {
+	token := strings.TrimSpace(c.Token)
+	if token == "" {
+		return ""
+	}
+	if user, secret, ok := strings.Cut(token, ":"); ok && user != "" && secret != "" {
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(token))
+	}
+	return "Bearer " + token
+}
+
+func (c *Connector) doGETWithRetry(ctx context.Context, endpoint string, maxBytes int64) ([]byte, http.Header, error) {
+	var lastErr error
+	for attempt := 0; attempt <= c.MaxRetries; attempt++ {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, nil, ctxErr
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+		if err != nil {
+			return nil, nil, fmt.Errorf("build request: %w", err)
+		}
+		if authorization := c.authorizationHeader(); authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		req.Header.Set("Accept", "application/json")
+
+		resp, err := c.HTTPClient.Do(req)
+		retryDelay := sourceconn.JitteredBackoff(c.Backoff, c.MaxBackoff, attempt)
+		statusCode := 0
+		if err != nil {
+			if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+				lastErr = fmt.Errorf("request timed out before the scan deadline: %v", err)
+			} else {
+				lastErr = fmt.Errorf("request failed: %w", err)
+			}
+		} else {
+			c.recordResponse()
+			reader := io.Reader(resp.Body)
+			if maxBytes > 0 {
+				reader = io.LimitReader(resp.Body, maxBytes+1)
+			}
+			body, readErr := io.ReadAll(reader)
+			_ = resp.Body.Close()
+			if readErr != nil {
+				return nil, nil, fmt.Errorf("read response body: %w", readErr)
+			}
+			if resp.StatusCode == http.StatusNoContent {
+				return nil, nil, &statusError{StatusCode: resp.StatusCode}
+			}
+			if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+				if maxBytes > 0 && int64(len(body)) > maxBytes {
+					return nil, nil, fmt.Errorf("bitbucket response exceeds the %d-byte limit", maxBytes)
+				}
+				return body, resp.Header, nil
+			}
+			message := sourceconn.APIMessage(body)
+			statusCode = resp.StatusCode
+			switch {
+			case resp.StatusCode == http.StatusTooManyRequests:
+				if wait, ok := sourceconn.RetryAfter(resp.Header, "", c.now()); ok {
+					retryDelay = wait
+				}
+				lastErr = &RateLimitedError{StatusCode: resp.StatusCode, Attempts: attempt + 1, Message: message}
+			case resp.StatusCode >= 500:
+				lastErr = fmt.Errorf("bitbucket API transient status %d", resp.StatusCode)
+			default:
+				return nil, nil, &statusError{StatusCode: resp.StatusCode, Message: message}
+			}
+		}
+		if attempt == c.MaxRetries {
+			break
+		}
+		c.emitRetry(attempt+1, retryDelay, statusCode)
+		if sleepErr := c.sleep(ctx, retryDelay); sleepErr != nil {
+			return nil, nil, sleepErr
+		}
+	}
+	if lastErr == nil {
+		lastErr = errors.New("request failed")
+	}
+	return nil, nil, lastErr
+}
+
+// recordResponse counts requests only; Bitbucket does not publish a
+// remaining-request window that a preflight budget could be checked against.
+func (c *Connector) recordResponse() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.requestStats.Requests++
+}
+
+func (c *Connector) now() time.Time {
+	if c.nowFn != nil {
+		return c.nowFn()
+	}
+	return time.Now()
+}
+
+func (c *Connector) sleep(ctx context.Context, duration time.Duration) error {
+	if c.sleepFn != nil {
+		return c.sleepFn(ctx, duration)
+	}
+	return sourceconn.SleepWithContext(ctx, duration)
+}
+
+func (c *Connector) emitRetry(attempt int, delay time.Duration, statusCode int) {
+	if c == nil || c.onRetry == nil {
+		return
+	}
+	c.onRetry(github.RetryEvent{Attempt: attempt, StatusCode: statusCode, Delay: delay})
+}
@@ -0,0 +1,341 @@
+package bitbucket
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Clyra-AI/wrkr/core/source"
+)
+
+func TestConnectorRequiresBaseURL(t *testing.T) {
+	t.Parallel()
+
+	connector := NewConnector("", "", nil)
+	if _, err := connector.AcquireRepo(context.Background(), "acme/backend"); err == nil || !strings.Contains(err.Error(), "bitbucket api base url is required") {
+		t.Fatalf("expected missing base url error, got %v", err)
+	}
+}
+
+func TestConnectorRejectsInsecureEndpointBeforeSendingToken(t *testing.T) {
+	t.Parallel()
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	connector := NewConnector(server.URL, "bb-secret", server.Client())
+	if _, err := connector.ListOrgRepos(context.Background(), "acme"); err == nil {
+		t.Fatal("expected insecure endpoint rejection")
+	}
+	if requests != 0 {
+		t.Fatalf("expected no requests to insecure endpoint, got %d", requests)
+	}
+}
+
+func TestDetectFlavor(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]string{
+		"https://api.bitbucket.org/2.0":                      FlavorCloud,
+		"https://bitbucket.example.com/rest/api/1.0":         FlavorDataCenter,
+		"https://bitbucket.example.com/scm/rest/api/latest/": FlavorDataCenter,
+		"https://bitbucket.example.com/":                     FlavorCloud,
+	}
+	for baseURL, expected := range cases {
+		if got := DetectFlavor(baseURL); got != expected {
+			t.Fatalf("DetectFlavor(%q) = %q, want %q", baseURL, got, expected)
+		}
+	}
+}
+
+func TestAuthorizationHeaderSupportsBearerAndBasicTokens(t *testing.T) {
+	t.Parallel()
+
+	if got := (&Connector{Token: "bb-token"}).authorizationHeader(); got != "Bearer bb-token" {
+		t.Fatalf("expected bearer header, got %q", got)
+	}
+	expectedBasic := "Basic " + base64.StdEncoding.EncodeToString([]byte("robot:app-password"))
+	if got := (&Connector{Token: "robot:app-password"}).authorizationHeader(); got != expectedBasic {
+		t.Fatalf("expected basic header, got %q", got)
+	}
+	if got := (&Connector{}).authorizationHeader(); got != "" {
+		t.Fatalf("expected no header without token, got %q", got)
+	}
+}
+
+func TestListOrgReposPagesCloudWorkspace(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer bb-test" {
+			t.Errorf("expected bearer token header, got %q", got)
+		}
+		if r.URL.Path != "/repositories/acme" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		switch r.URL.Query().Get("page") {
+		case "1":
+			_, _ = fmt.Fprintf(w, `{"values":[{"slug":"web","full_name":"acme/web"},{"slug":"api","full_name":"acme/api"}],"next":"%s/repositories/acme?page=2"}`, "https://api.bitbucket.org/2.0")
+		case "2":
+			_, _ = fmt.Fprint(w, `{"values":[{"full_name":"acme/agent"},{"slug":"api","full_name":"acme/api"}]}`)
+		default:
+			t.Fatalf("unexpected page: %s", r.URL.Query().Get("page"))
+		}
+	}))
+	defer server.Close()
+
+	connector := NewConnectorWithOptions(server.URL, "bb-test", server.Client(), ConnectorOptions{AllowInsecureLoopback: true})
+	repos, err := connector.ListOrgRepos(context.Background(), "acme")
+	if err != nil {
+		t.Fatalf("list workspace repos: %v", err)
+	}
+	expected := []string{"acme/agent", "acme/api", "acme/web"}
+	if !reflect.DeepEqual(repos, expected) {
+		t.Fatalf("unexpected repos: %v", repos)
+	}
+	if telemetry := connector.AcquisitionTelemetry(); telemetry.Requests != 2 || telemetry.Mode != "bitbucket_cloud_sparse_api" {
+		t.Fatalf("unexpected telemetry: %+v", telemetry)
+	}
+}
+
+func TestListOrgReposPagesDataCenterProject(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/1.0/projects/PLAT/repos" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		switch r.URL.Query().Get("start") {
+		case "0":
+			_, _ = fmt.Fprint(w, `{"values":[{"slug":"web","project":{"key":"PLAT"}}],"isLastPage":false,"nextPageStart":1}`)
+		case "1":
+			_, _ = fmt.Fprint(w, `{"values":[{"slug":"agent","project":{"key":"PLAT"}}],"isLastPage":true}`)
+		default:
+			t.Fatalf("unexpected start: %s", r.URL.Query().Get("start"))
+		}
+	}))
+	defer server.Close()
+
+	connector := NewConnectorWithOptions(server.URL+"/rest/api/1.0", "", server.Client(), ConnectorOptions{AllowInsecureLoopback: true})
+	repos, err := connector.ListOrgRepos(context.Background(), "PLAT")
+	if err != nil {
+		t.Fatalf("list project repos: %v", err)
+	}
+	if !reflect.DeepEqual(repos, []string{"PLAT/agent", "PLAT/web"}) {
+		t.Fatalf("unexpected repos: %v", repos)
+	}
+}
+
+func TestMaterializeRepoWritesSparseCloudTree(t *testing.T) {
+	t.Parallel()
+
+	tmp := t.TempDir()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repositories/acme/backend":
+			_, _ = fmt.Fprint(w, `{"full_name":"acme/backend","mainbranch":{"name":"main"}}`)
+		case "/repositories/acme/backend/refs/branches/main":
+			_, _ = fmt.Fprint(w, `{"target":{"hash":"abc123"}}`)
+		case "/repositories/acme/backend/src/abc123/":
+			if r.URL.Query().Get("max_depth") == "" {
+				t.Fatalf("expected recursive listing, got %s", r.URL.RawQuery)
+			}
+			_, _ = fmt.Fprint(w, `{"values":[{"type":"commit_directory","path":".codex"},{"type":"commit_file","path":"AGENTS.md"},{"type":"commit_file","path":".codex/config.toml"},{"type":"commit_file","path":"bitbucket-pipelines.yml"},{"type":"commit_file","path":".mcp.json","attributes":["link"]},{"type":"commit_file","path":"src/main.py"},{"type":"commit_file","path":"docs/changelog.txt"}]}`)
+		case "/repositories/acme/backend/src/abc123/AGENTS.md":
+			_, _ = fmt.Fprint(w, "# agents\n")
+		case "/repositories/acme/backend/src/abc123/.codex/config.toml":
+			_, _ = fmt.Fprint(w, "sandbox_mode = \"read-only\"\n")
+		case "/repositories/acme/backend/src/abc123/bitbucket-pipelines.yml":
+			_, _ = fmt.Fprint(w, "pipelines: {}\n")
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	connector := NewConnectorWithOptions(server.URL, "", server.Client(), ConnectorOptions{AllowInsecureLoopback: true})
+	manifest, err := connector.MaterializeRepo(context.Background(), "acme/backend", tmp)
+	if err != nil {
+		t.Fatalf("materialize repo: %v", err)
+	}
+	if manifest.Source != "bitbucket_repo_materialized" {
+		t.Fatalf("unexpected source: %s", manifest.Source)
+	}
+	if manifest.Location != "bitbucket://acme/backend" {
+		t.Fatalf("expected logical hosted location, got %s", manifest.Location)
+	}
+	if manifest.ContentStatus != source.RepoContentStatusAvailable {
+		t.Fatalf("expected available content status, got %q", manifest.ContentStatus)
+	}
+
+	for _, rel := range []string{"AGENTS.md", ".codex/config.toml", "bitbucket-pipelines.yml"} {
+		if _, err := os.Stat(filepath.Join(tmp, "acme", "backend", filepath.FromSlash(rel))); err != nil {
+			t.Fatalf("expected materialized %s: %v", rel, err)
+		}
+	}
+	for _, rel := range []string{".mcp.json", "src/main.py", "docs/changelog.txt"} {
+		if _, err := os.Stat(filepath.Join(tmp, "acme", "backend", filepath.FromSlash(rel))); !os.IsNotExist(err) {
+			t.Fatalf("expected %s to be skipped, got %v", rel, err)
+		}
+	}
+}
+
+func TestMaterializeRepoWritesSparseDataCenterTree(t *testing.T) {
+	t.Parallel()
+
+	tmp := t.TempDir()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/1.0/projects/PLAT/repos/backend":
+			_, _ = fmt.Fprint(w, `{"slug":"backend","project":{"key":"PLAT"}}`)
+		case "/rest/api/1.0/projects/PLAT/repos/backend/default-branch":
+			_, _ = fmt.Fprint(w, `{"displayId":"main","latestCommit":"def456"}`)
+		case "/rest/api/1.0/projects/PLAT/repos/backend/files":
+			if r.URL.Query().Get("at") != "def456" {
+				t.Fatalf("expected pinned commit, got %s", r.URL.RawQuery)
+			}
+			_, _ = fmt.Fprint(w, `{"values":["AGENTS.md","bitbucket-pipelines.yaml","src/main.go"],"isLastPage":true}`)
+		case "/rest/api/1.0/projects/PLAT/repos/backend/raw/AGENTS.md", "/rest/api/1.0/projects/PLAT/repos/backend/raw/bitbucket-pipelines.yaml":
+			if r.URL.Query().Get("at") != "def456" {
+				t.Fatalf("expected pinned commit, got %s", r.URL.RawQuery)
+			}
+			_, _ = fmt.Fprint(w, "content\n")
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	connector := NewConnectorWithOptions(server.URL+"/rest/api/1.0", "", server.Client(), ConnectorOptions{AllowInsecureLoopback: true})
+	manifest, err := connector.MaterializeRepo(context.Background(), "PLAT/backend", tmp)
+	if err != nil {
+		t.Fatalf("materialize repo: %v", err)
+	}
+	if manifest.Repo != "PLAT/backend" || manifest.ContentStatus != source.RepoContentStatusAvailable {
+		t.Fatalf("unexpected manifest: %+v", manifest)
+	}
+	for _, rel := range []string{"AGENTS.md", "bitbucket-pipelines.yaml"} {
+		if _, err := os.Stat(filepath.Join(tmp, "PLAT", "backend", rel)); err != nil {
+			t.Fatalf("expected materialized %s: %v", rel, err)
+		}
+	}
+}
+
+func TestMaterializeRepoMarksEmptyRepository(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/1.0/projects/PLAT/repos/empty":
+			_, _ = fmt.Fprint(w, `{"slug":"empty","project":{"key":"PLAT"}}`)
+		case "/rest/api/1.0/projects/PLAT/repos/empty/default-branch":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = fmt.Fprint(w, `{"errors":[{"message":"Repository has no branches"}]}`)
+		default:
+			t.Fatalf("empty repo should not request %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	connector := NewConnectorWithOptions(server.URL+"/rest/api/1.0", "", server.Client(), ConnectorOptions{AllowInsecureLoopback: true})
+	manifest, err := connector.MaterializeRepo(context.Background(), "PLAT/empty", t.TempDir())
+	if err != nil {
+		t.Fatalf("materialize empty repo: %v", err)
+	}
+	if manifest.ContentStatus != source.RepoContentStatusEmpty {
+		t.Fatalf("expected empty content status, got %q", manifest.ContentStatus)
+	}
+}
+
+func TestMaterializeRepoRejectsTraversalTreePath(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repositories/acme/backend":
+			_, _ = fmt.Fprint(w, `{"full_name":"acme/backend","mainbranch":{"name":"main"}}`)
+		case "/repositories/acme/backend/refs/branches/main":
+			_, _ = fmt.Fprint(w, `{"target":{"hash":"abc123"}}`)
+		case "/repositories/acme/backend/src/abc123/":
+			_, _ = fmt.Fprint(w, `{"values":[{"type":"commit_file","path":"../AGENTS.md"}]}`)
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	connector := NewConnectorWithOptions(server.URL, "", server.Client(), ConnectorOptions{AllowInsecureLoopback: true})
+	if _, err := connector.MaterializeRepo(context.Background(), "acme/backend", t.TempDir()); err == nil {
+		t.Fatal("expected traversal path to fail materialization")
+	}
+}
+
+func TestConnectorHonorsRetryAfter429(t *testing.T) {
+	t.Parallel()
+
+	var attempts int32
+	var slept []time.Duration
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.Header().Set("Retry-After", "3")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = fmt.Fprint(w, `{"type":"error","error":{"message":"Rate limit for this resource has been exceeded"}}`)
+			return
+		}
+		_, _ = fmt.Fprint(w, `{"full_name":"acme/backend"}`)
+	}))
+	defer server.Close()
+
+	connector := NewConnectorWithOptions(server.URL, "", server.Client(), ConnectorOptions{AllowInsecureLoopback: true})
+	connector.sleepFn = func(_ context.Context, duration time.Duration) error {
+		slept = append(slept, duration)
+		return nil
+	}
+
+	manifest, err := connector.AcquireRepo(context.Background(), "acme/backend")
+	if err != nil {
+		t.Fatalf("acquire repo: %v", err)
+	}
+	if manifest.Source != "bitbucket_repo" {
+		t.Fatalf("unexpected source: %s", manifest.Source)
+	}
+	if attempts != 2 {
+		t.Fatalf("expected two attempts, got %d", attempts)
+	}
+	if len(slept) == 0 || slept[0] != 3*time.Second {
+		t.Fatalf("expected retry-after sleep of 3s, got %v", slept)
+	}
+}
+
+func TestConnectorReturnsRateLimitedErrorAfterRetries(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Retry-After", "1")
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = fmt.Fprint(w, `{"type":"error","error":{"message":"Rate limit for this resource has been exceeded"}}`)
+	}))
+	defer server.Close()
+
+	connector := NewConnectorWithOptions(server.URL, "", server.Client(), ConnectorOptions{AllowInsecureLoopback: true})
+	connector.MaxRetries = 1
+	connector.sleepFn = func(context.Context, time.Duration) error { return nil }
+
+	_, err := connector.AcquireRepo(context.Background(), "acme/backend")
+	if !IsRateLimitedError(err) {
+		t.Fatalf("expected rate limited error, got %v", err)
+	}
+}
@@ -10,7 +10,6 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"math"
 	"net/http"
 	"net/url"
 	"os"
@@ -22,9 +21,11 @@ import (
 	"sync"
 	"time"
 
+	"github.com/Clyra-AI/wrkr/core/detect"
 	"github.com/Clyra-AI/wrkr/core/source"
 	"github.com/Clyra-AI/wrkr/internal/githubendpoint"
 	"github.com/Clyra-AI/wrkr/internal/reponame"
+	"github.com/Clyra-AI/wrkr/internal/sourceconn"
 )
 
 type HTTPClient interface {
@@ -55,6 +56,10 @@ type Connector struct {
 	onCooldown          func(CooldownEvent)
 	cooldownErr         error
 	requestStats        source.AcquisitionTelemetry
+	responseCache       *ResponseCache
+	apiMode             string
+	graphQLRateLimit    int
+	repoMetadataCache   map[string]repoMeta
 }
 
 // ConnectorOptions controls explicit development-only connector behavior.
@@ -82,9 +87,9 @@ func NewConnector(baseURL, token string, client HTTPClient) *Connector {
 func NewConnectorWithOptions(baseURL, token string, client HTTPClient, options ConnectorOptions) *Connector {
 	endpointOptions := githubendpoint.Options{AllowInsecureLoopback: options.AllowInsecureLoopback}
 	if configured, ok := client.(*http.Client); ok {
-		client = newSafeHTTPClient(baseURL, endpointOptions, configured)
+		client = sourceconn.NewHTTPClient(baseURL, endpointOptions, configured)
 	} else if client == nil {
-		client = newSafeHTTPClient(baseURL, endpointOptions, nil)
+		client = sourceconn.NewHTTPClient(baseURL, endpointOptions, nil)
 	}
 	return &Connector{
 		BaseURL:          strings.TrimRight(baseURL, "/"),
@@ -97,21 +102,10 @@ func NewConnectorWithOptions(baseURL, token string, client HTTPClient, options C
 		Cooldown:         10 * time.Second,
 		endpointOptions:  endpointOptions,
 		nowFn:            time.Now,
-		sleepFn:          sleepWithContext,
+		sleepFn:          sourceconn.SleepWithContext,
 	}
 }
 
-func newSafeHTTPClient(baseURL string, options githubendpoint.Options, source *http.Client) *http.Client {
-	client := http.Client{Timeout: 10 * time.Second}
-	if source != nil {
-		client = *source
-	}
-	if endpoint, err := githubendpoint.Parse(baseURL, options); err == nil {
-		client.CheckRedirect = githubendpoint.RedirectPolicy(endpoint)
-	}
-	return &client
-}
-
 func (c *Connector) validateEndpoint() error {
 	if c == nil {
 		return errors.New("github connector is required")
@@ -141,6 +135,15 @@ func (c *Connector) SetAllowSourceMaterialization(allow bool) {
 	c.AllowSourceMaterialization = allow
 }
 
+// SetResponseCache enables conditional GET requests backed by cache. A nil
+// cache disables response caching.
+func (c *Connector) SetResponseCache(cache *ResponseCache) {
+	if c == nil {
+		return
+	}
+	c.responseCache = cache
+}
+
 func (c *Connector) AcquisitionTelemetry() source.AcquisitionTelemetry {
 	if c == nil {
 		return source.AcquisitionTelemetry{}
@@ -154,6 +157,9 @@ func (c *Connector) AcquisitionTelemetry() source.AcquisitionTelemetry {
 	} else {
 		out.Mode = "sparse_api"
 	}
+	if c.apiMode == APIModeGraphQL {
+		out.APIMode = APIModeGraphQL
+	}
 	return out
 }
 
@@ -161,22 +167,42 @@ func (c *Connector) EnsureRequestBudget(repoCount int) error {
 	if c == nil || repoCount <= 0 {
 		return nil
 	}
+	graphQL := c.graphQLMode()
 	perRepo := 50
-	if c.AllowSourceMaterialization {
-		perRepo = 2
+	switch {
+	case c.AllowSourceMaterialization && graphQL:
+		perRepo = 1
+	case c.AllowSourceMaterialization:
+		// Metadata, default-branch ref, and archive.
+		perRepo = 3
+	case graphQL:
+		perRepo = graphQLRESTCallsPerRepo
 	}
 	estimate := repoCount*perRepo + 25
+	points := 0
+	if graphQL && !c.AllowSourceMaterialization {
+		points = repoCount * graphQLPointsPerRepo
+	}
 	c.mu.Lock()
 	c.requestStats.EstimatedRequests = estimate
+	c.requestStats.EstimatedGraphQLPoints = points
 	remaining := c.requestStats.RateLimitRemaining
 	limit := c.requestStats.RateLimitLimit
+	pointsRemaining := c.requestStats.GraphQLRateLimitRemaining
+	pointsLimit := c.graphQLRateLimit
 	if limit > 0 && remaining < estimate {
 		c.requestStats.Warnings = append(c.requestStats.Warnings, fmt.Sprintf("estimated GitHub requests %d exceed remaining budget %d", estimate, remaining))
 	}
+	if pointsLimit > 0 && pointsRemaining < points {
+		c.requestStats.Warnings = append(c.requestStats.Warnings, fmt.Sprintf("estimated GitHub GraphQL points %d exceed remaining budget %d", points, pointsRemaining))
+	}
 	c.mu.Unlock()
 	if limit > 0 && remaining < estimate {
 		return fmt.Errorf("github request budget is insufficient before materialization: estimated=%d remaining=%d; wait for reset, scan pre-cloned repositories with --path, or reduce the org scope", estimate, remaining)
 	}
+	if pointsLimit > 0 && pointsRemaining < points {
+		return fmt.Errorf("github graphql point budget is insufficient before materialization: estimated=%d remaining=%d; wait for reset, use --github-api-mode rest, or reduce the org scope", points, pointsRemaining)
+	}
 	return nil
 }
 
@@ -287,6 +313,9 @@ func (c *Connector) ListOrgRepos(ctx context.Context, org string) ([]string, err
 	if err := c.validateEndpoint(); err != nil {
 		return nil, err
 	}
+	if c.graphQLMode() {
+		return c.listOrgReposGraphQL(ctx, normalizedOrg)
+	}
 
 	u, err := url.Parse(c.BaseURL)
 	if err != nil {
@@ -345,23 +374,148 @@ func (c *Connector) ListOrgRepos(ctx context.Context, org string) ([]string, err
 	return repos, nil
 }
 
+const enterpriseOrgsQuery = `query($slug: String!, $after: String) {
+  enterprise(slug: $slug) {
+    organizations(first: 100, after: $after) {
+      nodes { login }
+      pageInfo { hasNextPage endCursor }
+    }
+  }
+}`
+
+// ListEnterpriseOrgs returns the sorted logins of every member organization of
+// an enterprise account. GitHub only exposes enterprise membership through the
+// GraphQL API, so this is the connector's one non-REST call.
+func (c *Connector) ListEnterpriseOrgs(ctx context.Context, enterprise string) ([]string, error) {
+	slug, err := reponame.NormalizeOrg(enterprise)
+	if err != nil {
+		return nil, fmt.Errorf("enterprise %w", err)
+	}
+	if c.BaseURL == "" {
+		return nil, errors.New("github api base url is required for enterprise acquisition")
+	}
+	if err := c.validateEndpoint(); err != nil {
+		return nil, err
+	}
+	endpoint, err := c.graphQLEndpoint()
+	if err != nil {
+		return nil, err
+	}
+
+	orgs := make([]string, 0, 16)
+	seen := map[string]struct{}{}
+	after := ""
+	for page := 1; ; page++ {
+		variables := map[string]any{"slug": slug}
+		if after != "" {
+			variables["after"] = after
+		}
+		requestBody, err := json.Marshal(map[string]any{"query": enterpriseOrgsQuery, "variables": variables})
+		if err != nil {
+			return nil, fmt.Errorf("encode enterprise orgs query: %w", err)
+		}
+		respBody, err := c.doRequestWithRetry(ctx, http.MethodPost, endpoint, requestBody, 0, c.HTTPClient)
+		if err != nil {
+			return nil, fmt.Errorf("list enterprise orgs page %d: %w", page, err)
+		}
+
+		var payload struct {
+			Data struct {
+				Enterprise *struct {
+					Organizations struct {
+						Nodes []struct {
+							Login string `json:"login"`
+						} `json:"nodes"`
+						PageInfo struct {
+							HasNextPage bool   `json:"hasNextPage"`
+							EndCursor   string `json:"endCursor"`
+						} `json:"pageInfo"`
+					} `json:"organizations"`
+				} `json:"enterprise"`
+			} `json:"data"`
+			Errors []struct {
+				Message string `json:"message"`
+			} `json:"errors"`
+		}
+		if err := json.Unmarshal(respBody, &payload); err != nil {
+			return nil, fmt.Errorf("parse enterprise orgs response page %d: %w", page, err)
+		}
+		if len(payload.Errors) > 0 {
+			return nil, fmt.Errorf("list enterprise orgs page %d: %s", page, sourceconn.SanitizeMessage(payload.Errors[0].Message))
+		}
+		if payload.Data.Enterprise == nil {
+			return nil, fmt.Errorf("enterprise %s was not found or is not visible to this token", slug)
+		}
+
+		connection := payload.Data.Enterprise.Organizations
+		for _, node := range connection.Nodes {
+			login, err := reponame.NormalizeOrg(node.Login)
+			if err != nil {
+				return nil, fmt.Errorf("list enterprise orgs page %d: %w", page, err)
+			}
+			if _, ok := seen[login]; ok {
+				continue
+			}
+			seen[login] = struct{}{}
+			orgs = append(orgs, login)
+		}
+		if !connection.PageInfo.HasNextPage || connection.PageInfo.EndCursor == "" || connection.PageInfo.EndCursor == after {
+			break
+		}
+		after = connection.PageInfo.EndCursor
+	}
+	sort.Strings(orgs)
+	return orgs, nil
+}
+
+// graphQLEndpoint derives the GraphQL URL from the REST base URL. GitHub
+// Enterprise Server serves REST under /api/v3 and GraphQL under /api/graphql.
+func (c *Connector) graphQLEndpoint() (string, error) {
+	u, err := url.Parse(c.BaseURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid base url: %w", err)
+	}
+	basePath := strings.TrimSuffix(u.Path, "/")
+	if strings.HasSuffix(basePath, "/api/v3") {
+		u.Path = strings.TrimSuffix(basePath, "/v3") + "/graphql"
+	} else {
+		u.Path = path.Join("/", basePath, "graphql")
+	}
+	u.RawQuery = ""
+	return u.String(), nil
+}
+
 // MaterializeRepo fetches repository file contents through the GitHub API and writes
-// them into a deterministic local workspace under materializedRoot.
+// them into a deterministic local workspace under materializedRoot. The
+// manifest records the default-branch commit the contents were read from.
 func (c *Connector) MaterializeRepo(ctx context.Context, repo string, materializedRoot string) (source.RepoManifest, error) {
+	manifest, _, err := c.materializeRepo(ctx, repo, materializedRoot, false, source.RepoRevision{})
+	return manifest, err
+}
+
+// MaterializeRepoIncremental materializes repo pinned to its default-branch
+// commit. When the commit and the acquisition source match the previous scan
+// and the earlier tree is still on disk, the tree is reused without fetching
+// repository contents and keeps the previous content status.
+func (c *Connector) MaterializeRepoIncremental(ctx context.Context, repo, materializedRoot string, previous source.RepoRevision) (source.RepoManifest, bool, error) {
+	return c.materializeRepo(ctx, repo, materializedRoot, true, previous)
+}
+
+func (c *Connector) materializeRepo(ctx context.Context, repo, materializedRoot string, pinCommit bool, previous source.RepoRevision) (source.RepoManifest, bool, error) {
 	repo, err := normalizeRepo(repo)
 	if err != nil {
-		return source.RepoManifest{}, err
+		return source.RepoManifest{}, false, err
 	}
 	if c.BaseURL == "" {
-		return source.RepoManifest{}, errors.New("github api base url is required for repository materialization")
+		return source.RepoManifest{}, false, errors.New("github api base url is required for repository materialization")
 	}
 	if err := c.validateEndpoint(); err != nil {
-		return source.RepoManifest{}, err
+		return source.RepoManifest{}, false, err
 	}
 
 	meta, err := c.repoMetadata(ctx, repo)
 	if err != nil {
-		return source.RepoManifest{}, err
+		return source.RepoManifest{}, false, err
 	}
 	fullName := strings.TrimSpace(meta.FullName)
 	if fullName == "" {
@@ -369,28 +523,62 @@ func (c *Connector) MaterializeRepo(ctx context.Context, repo string, materializ
 	}
 	fullName, err = normalizeRepo(fullName)
 	if err != nil {
-		return source.RepoManifest{}, fmt.Errorf("materialize repo metadata: %w", err)
+		return source.RepoManifest{}, false, fmt.Errorf("materialize repo metadata: %w", err)
 	}
 	defaultBranch := strings.TrimSpace(meta.DefaultBranch)
 	if defaultBranch == "" {
 		defaultBranch = "main"
 	}
 
-	repoRoot, err := safeJoin(materializedRoot, fullName)
+	repoRoot, err := sourceconn.SafeJoin(materializedRoot, fullName)
 	if err != nil {
-		return source.RepoManifest{}, fmt.Errorf("materialize repo root: %w", err)
+		return source.RepoManifest{}, false, fmt.Errorf("materialize repo root: %w", err)
+	}
+	sourceName := "github_repo_materialized"
+	if c.AllowSourceMaterialization {
+		sourceName = "github_repo_archive"
+	}
+	ref := defaultBranch
+	commit := meta.headCommit
+	if commit == "" {
+		commit, err = c.defaultBranchCommit(ctx, fullName, defaultBranch)
+		if err != nil {
+			return source.RepoManifest{}, false, err
+		}
+	}
+	if meta.headCommit != "" && c.graphQLMode() {
+		// Pin GraphQL file lookups to the listed head so they match the tree.
+		ref = meta.headCommit
+	}
+	if pinCommit && commit != "" {
+		ref = commit
+		previousStatus := strings.TrimSpace(previous.ContentStatus)
+		if previousStatus == "" {
+			previousStatus = source.RepoContentStatusAvailable
+		}
+		if commit == strings.ToLower(strings.TrimSpace(previous.Commit)) && sourceName == strings.TrimSpace(previous.Source) && isRetainedRepoRoot(repoRoot) {
+			return source.RepoManifest{
+				Repo:              fullName,
+				Location:          "github://" + fullName,
+				ScanRoot:          filepath.ToSlash(repoRoot),
+				Source:            sourceName,
+				ContentStatus:     previousStatus,
+				OwnershipMetadata: repoOwnershipMetadata(meta),
+				Commit:            commit,
+			}, true, nil
+		}
 	}
 	if err := os.RemoveAll(repoRoot); err != nil {
-		return source.RepoManifest{}, fmt.Errorf("clean materialized repo root: %w", err)
+		return source.RepoManifest{}, false, fmt.Errorf("clean materialized repo root: %w", err)
 	}
 	if err := os.MkdirAll(repoRoot, 0o750); err != nil {
-		return source.RepoManifest{}, fmt.Errorf("create materialized repo root: %w", err)
+		return source.RepoManifest{}, false, fmt.Errorf("create materialized repo root: %w", err)
 	}
 
 	if c.AllowSourceMaterialization {
-		emptyRepo, archiveErr := c.materializeRepoArchive(ctx, fullName, defaultBranch, repoRoot, meta.Size != nil && *meta.Size == 0)
+		emptyRepo, archiveErr := c.materializeRepoArchive(ctx, fullName, ref, repoRoot, meta.Size != nil && *meta.Size == 0)
 		if archiveErr != nil {
-			return source.RepoManifest{}, archiveErr
+			return source.RepoManifest{}, false, archiveErr
 		}
 		contentStatus := source.RepoContentStatusAvailable
 		if emptyRepo {
@@ -400,60 +588,135 @@ func (c *Connector) MaterializeRepo(ctx context.Context, repo string, materializ
 			Repo:              fullName,
 			Location:          "github://" + fullName,
 			ScanRoot:          filepath.ToSlash(repoRoot),
-			Source:            "github_repo_archive",
+			Source:            sourceName,
 			ContentStatus:     contentStatus,
 			OwnershipMetadata: repoOwnershipMetadata(meta),
-		}, nil
+			Commit:            commit,
+		}, false, nil
 	}
 
-	tree, emptyRepo, err := c.repoTree(ctx, fullName, defaultBranch)
+	tree, emptyRepo, err := c.repoTree(ctx, fullName, ref)
 	if err != nil {
-		return source.RepoManifest{}, err
+		return source.RepoManifest{}, false, err
 	}
 	sort.Slice(tree, func(i, j int) bool { return tree[i].Path < tree[j].Path })
 
+	selected := func(rel string) bool { return shouldMaterializeBlobWithSource(rel, c.AllowSourceMaterialization) }
+	if err := c.materializeTree(ctx, fullName, ref, repoRoot, tree, selected); err != nil {
+		return source.RepoManifest{}, false, err
+	}
+	// Plugin roots come from the root plugin.json and the marketplace file
+	// written above, so their components are fetched in a second pass.
+	if roots := sparseClaudePluginRoots(repoRoot, tree); len(roots) > 0 {
+		pluginComponent := func(rel string) bool { return !selected(rel) && isClaudePluginComponentPath(rel, roots) }
+		if err := c.materializeTree(ctx, fullName, ref, repoRoot, tree, pluginComponent); err != nil {
+			return source.RepoManifest{}, false, err
+		}
+	}
+
+	contentStatus := source.RepoContentStatusAvailable
+	if emptyRepo {
+		contentStatus = source.RepoContentStatusEmpty
+	}
+	return source.RepoManifest{
+		Repo:              fullName,
+		Location:          "github://" + fullName,
+		ScanRoot:          filepath.ToSlash(repoRoot),
+		Source:            sourceName,
+		ContentStatus:     contentStatus,
+		OwnershipMetadata: repoOwnershipMetadata(meta),
+		Commit:            commit,
+	}, false, nil
+}
+
+func (c *Connector) materializeTree(ctx context.Context, repo, ref, repoRoot string, tree []treeItem, include func(string) bool) error {
+	if c.graphQLMode() {
+		return c.materializeTreeGraphQL(ctx, repo, ref, repoRoot, tree, include)
+	}
+	return c.materializeTreeREST(ctx, repo, repoRoot, tree, include)
+}
+
+func (c *Connector) materializeTreeREST(ctx context.Context, repo, repoRoot string, tree []treeItem, include func(string) bool) error {
 	for _, item := range tree {
 		if ctxErr := ctx.Err(); ctxErr != nil {
-			return source.RepoManifest{}, ctxErr
+			return ctxErr
 		}
 		if item.Type != "blob" || strings.TrimSpace(item.Path) == "" {
 			continue
 		}
-		dest, pathErr := safeJoin(repoRoot, item.Path)
+		dest, pathErr := sourceconn.SafeJoin(repoRoot, item.Path)
 		if pathErr != nil {
-			return source.RepoManifest{}, pathErr
+			return pathErr
 		}
-		if !shouldMaterializeBlobWithSource(item.Path, c.AllowSourceMaterialization) {
+		if !include(item.Path) {
 			continue
 		}
-		blob, blobErr := c.repoBlob(ctx, fullName, item.SHA)
-		if blobErr != nil {
-			return source.RepoManifest{}, blobErr
-		}
-		decoded, decodeErr := decodeBlob(blob.Content, blob.Encoding)
-		if decodeErr != nil {
-			return source.RepoManifest{}, fmt.Errorf("decode blob %s: %w", item.SHA, decodeErr)
+		if err := c.materializeRESTBlob(ctx, repo, item, dest); err != nil {
+			return err
 		}
-		if err := os.MkdirAll(filepath.Dir(dest), 0o750); err != nil {
-			return source.RepoManifest{}, fmt.Errorf("create materialized parent: %w", err)
-		}
-		if err := os.WriteFile(dest, decoded, 0o600); err != nil {
-			return source.RepoManifest{}, fmt.Errorf("write materialized file %s: %w", item.Path, err)
+	}
+	return nil
+}
+
+func (c *Connector) materializeRESTBlob(ctx context.Context, repo string, item treeItem, dest string) error {
+	blob, err := c.repoBlob(ctx, repo, item.SHA)
+	if err != nil {
+		return err
+	}
+	decoded, err := decodeBlob(blob.Content, blob.Encoding)
+	if err != nil {
+		return fmt.Errorf("decode blob %s: %w", item.SHA, err)
+	}
+	return writeMaterializedFile(dest, item.Path, decoded)
+}
+
+// defaultBranchCommit resolves branch to its head commit SHA. Empty
+// repositories have no branch ref yet and resolve to "".
+func (c *Connector) defaultBranchCommit(ctx context.Context, repo, branch string) (string, error) {
+	u, err := url.Parse(c.BaseURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid base url: %w", err)
+	}
+	u.Path = path.Join(u.Path, "repos", repo, "git", "ref", "heads", branch)
+	respBody, err := c.doGETWithRetry(ctx, u.String())
+	if err != nil {
+		if isEmptyRepositoryTreeError(err) || isMissingRepositoryArchiveError(err) {
+			return "", nil
 		}
+		return "", fmt.Errorf("resolve default branch %s for %s: %w", branch, repo, err)
 	}
+	var payload struct {
+		Object struct {
+			SHA string `json:"sha"`
+		} `json:"object"`
+	}
+	if err := json.Unmarshal(respBody, &payload); err != nil {
+		return "", fmt.Errorf("parse default branch ref response: %w", err)
+	}
+	commit := strings.ToLower(strings.TrimSpace(payload.Object.SHA))
+	if !isGitObjectID(commit) {
+		return "", fmt.Errorf("resolve default branch %s for %s: unexpected commit id %q", branch, repo, payload.Object.SHA)
+	}
+	return commit, nil
+}
 
-	contentStatus := source.RepoContentStatusAvailable
-	if emptyRepo {
-		contentStatus = source.RepoContentStatusEmpty
+func isGitObjectID(value string) bool {
+	if len(value) != 40 {
+		return false
 	}
-	return source.RepoManifest{
-		Repo:              fullName,
-		Location:          "github://" + fullName,
-		ScanRoot:          filepath.ToSlash(repoRoot),
-		Source:            "github_repo_materialized",
-		ContentStatus:     contentStatus,
-		OwnershipMetadata: repoOwnershipMetadata(meta),
-	}, nil
+	for _, r := range value {
+		if (r < '0' || r > '9') && (r < 'a' || r > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// isRetainedRepoRoot reports whether an earlier scan left a real directory at
+// repoRoot that can be reused instead of re-fetching the repository.
+func isRetainedRepoRoot(repoRoot string) bool {
+	info, err := os.Lstat(repoRoot)
+	return err == nil && info.Mode()&os.ModeSymlink == 0 && info.IsDir()
 }
 
 type repoMeta struct {
@@ -462,9 +725,20 @@ type repoMeta struct {
 	Size          *int     `json:"size"`
 	Topics        []string `json:"topics"`
 	Teams         []string `json:"teams"`
+	Archived      bool     `json:"archived"`
+	PushedAt      string   `json:"pushed_at"`
+	Owner         struct {
+		Login string `json:"login"`
+	} `json:"owner"`
+
+	// headCommit is the default-branch head reported by GraphQL org listings.
+	headCommit string
 }
 
 func (c *Connector) repoMetadata(ctx context.Context, repo string) (repoMeta, error) {
+	if meta, ok := c.cachedRepoMetadata(repo); ok {
+		return meta, nil
+	}
 	endpoint := c.BaseURL + "/repos/" + repo
 	respBody, err := c.doGETWithRetry(ctx, endpoint)
 	if err != nil {
@@ -548,7 +822,9 @@ const (
 
 func (c *Connector) materializeRepoArchive(ctx context.Context, repo, ref, repoRoot string, knownEmpty bool) (bool, error) {
 	endpoint := c.BaseURL + "/repos/" + repo + "/tarball/" + url.PathEscape(ref)
-	body, err := c.doGETWithRetryClient(ctx, endpoint, maxArchiveResponseBytes, c.archiveHTTPClient())
+	// Archives bypass the response cache; they are too large to keep on disk
+	// and the materialized tree is already retained when reuse is wanted.
+	body, err := c.doRequestWithRetry(ctx, http.MethodGet, endpoint, nil, maxArchiveResponseBytes, c.archiveHTTPClient())
 	if err != nil {
 		if isEmptyRepositoryTreeError(err) || (knownEmpty && isMissingRepositoryArchiveError(err)) {
 			return true, nil
@@ -596,7 +872,9 @@ func (c *Connector) materializeRepoArchive(ctx context.Context, repo, ref, repoR
 		if !filepath.IsLocal(localRel) {
 			return false, fmt.Errorf("repository archive for %s contains a non-local entry %q", repo, header.Name)
 		}
-		materialize := shouldMaterializeBlobWithSource(rel, true)
+		// The archive streams once, so plugin components are kept wherever a
+		// plugin could be rooted instead of waiting for the marketplace file.
+		materialize := shouldMaterializeBlobWithSource(rel, true) || isClaudePluginComponentPath(rel, nil)
 		expandedBytes, materializedBytes, err = addArchiveEntryBytes(expandedBytes, materializedBytes, header.Size, materialize)
 		if err != nil {
 			return false, fmt.Errorf("repository archive for %s: %w", repo, err)
@@ -640,7 +918,46 @@ func addArchiveEntryBytes(expanded, materialized, entryBytes int64, materialize
 	return expanded, materialized + entryBytes, nil
 }
 
-func archiveRelativePath(name string) (string, error) {
+// ArchiveBudget applies the hosted archive extraction limits to archives
+// from other sources, so offline inputs are bounded exactly like tarballs
+// downloaded from GitHub. The zero value is ready to use.
+type ArchiveBudget struct {
+	entries      int
+	expanded     int64
+	materialized int64
+}
+
+// AddEntry counts one archive entry of any type against the entry limit.
+func (b *ArchiveBudget) AddEntry() error {
+	b.entries++
+	if b.entries > maxArchiveFiles {
+		return fmt.Errorf("exceeds %d entries", maxArchiveFiles)
+	}
+	return nil
+}
+
+// AddFile accounts for a regular file of size bytes. Every file consumes the
+// expanded budget; only files that will be written consume the materialized
+// budget and must fit the per-file limit.
+func (b *ArchiveBudget) AddFile(rel string, size int64, materialize bool) error {
+	if size < 0 {
+		return fmt.Errorf("contains an invalid negative-size entry %q", rel)
+	}
+	expanded, materialized, err := addArchiveEntryBytes(b.expanded, b.materialized, size, materialize)
+	if err != nil {
+		return err
+	}
+	if materialize && size > maxArchiveFileBytes {
+		return fmt.Errorf("file %s exceeds the %d-byte limit", rel, maxArchiveFileBytes)
+	}
+	b.expanded, b.materialized = expanded, materialized
+	return nil
+}
+
+// ArchiveEntryPath validates an archive entry name and returns it as a clean
+// slash-separated relative path. Absolute names, NUL bytes, empty, "." and
+// ".." segments are rejected rather than cleaned away.
+func ArchiveEntryPath(name string) (string, error) {
 	raw := strings.ReplaceAll(strings.TrimSpace(name), "\\", "/")
 	if raw == "" || strings.HasPrefix(raw, "/") || strings.ContainsRune(raw, '\x00') {
 		return "", fmt.Errorf("unsafe archive entry %q", name)
@@ -654,6 +971,14 @@ func archiveRelativePath(name string) (string, error) {
 	if normalized == "." || normalized == ".." || strings.HasPrefix(normalized, "../") {
 		return "", fmt.Errorf("unsafe archive entry %q", name)
 	}
+	return normalized, nil
+}
+
+func archiveRelativePath(name string) (string, error) {
+	normalized, err := ArchiveEntryPath(name)
+	if err != nil {
+		return "", err
+	}
 	parts := strings.Split(normalized, "/")
 	if len(parts) < 2 {
 		return "", fmt.Errorf("invalid archive entry %q", name)
@@ -704,6 +1029,13 @@ func repoOwnershipMetadata(meta repoMeta) *source.RepoOwnershipMetadata {
 	return &source.RepoOwnershipMetadata{Topics: topics, Teams: teams}
 }
 
+// ShouldMaterializePath reports whether a repository-relative path is a
+// detector input that hosted connectors should fetch. Generic source files are
+// only selected when allowSourceMaterialization is set.
+func ShouldMaterializePath(rel string, allowSourceMaterialization bool) bool {
+	return shouldMaterializeBlobWithSource(rel, allowSourceMaterialization)
+}
+
 func shouldMaterializeBlobWithSource(rel string, allowSourceMaterialization bool) bool {
 	normalized := strings.Trim(strings.ToLower(filepath.ToSlash(strings.TrimSpace(rel))), "/")
 	if normalized == "" {
@@ -722,11 +1054,11 @@ func shouldMaterializeBlobWithSource(rel string, allowSourceMaterialization bool
 	}
 
 	switch base {
-	case "agents.md", "agents.override.md", "claude.md", ".cursorrules", ".mcp.json", "mcp.json", "managed-mcp.json",
+	case "agents.md", "agents.override.md", "claude.md", "gemini.md", ".cursorrules", ".windsurfrules", ".clinerules", ".roomodes", "cline_mcp_settings.json", ".aider.conf.yml", ".mcp.json", "mcp.json", "managed-mcp.json",
 		"codeowners",
 		"jenkinsfile", "go.mod", "go.sum", "package.json", "package-lock.json", "yarn.lock", "pnpm-lock.yaml",
 		"pyproject.toml", "poetry.lock", "uv.lock", "cargo.toml", "gemfile", "pom.xml",
-		"build.gradle", "build.gradle.kts", "composer.json", "dockerfile", "gait.yaml",
+		"build.gradle", "build.gradle.kts", "packages.config", "directory.packages.props", "composer.json", "dockerfile", "gait.yaml",
 		"owners.yaml", "owners.yml", "wrkr-owners.yaml", "wrkr-owners.yml",
 		"service-catalog.yaml", "service-catalog.yml", "catalog-info.yaml", "catalog-info.yml":
 		return true
@@ -734,6 +1066,9 @@ func shouldMaterializeBlobWithSource(rel string, allowSourceMaterialization bool
 	if strings.HasPrefix(base, "requirements") && strings.HasSuffix(base, ".txt") {
 		return true
 	}
+	if strings.HasSuffix(base, ".csproj") {
+		return true
+	}
 	if strings.HasPrefix(base, "readme") {
 		return true
 	}
@@ -769,10 +1104,22 @@ func isSparseDetectorCandidate(normalized, base string) bool {
 		return true
 	}
 
+	if isSparseClaudePluginPath(normalized, base) {
+		return true
+	}
+
 	for _, prefix := range []string{
 		".claude/",
+		".claude-plugin/",
 		".cursor/",
 		".codex/",
+		".gemini/",
+		".windsurf/",
+		".clinerules/",
+		".roo/",
+		".continue/",
+		".amazonq/",
+		".kiro/",
 		".agents/",
 		".github/workflows/",
 		".gait/",
@@ -788,7 +1135,21 @@ func isSparseDetectorCandidate(normalized, base string) bool {
 	if normalized == ".wrkr/owners.json" || normalized == ".wrkr/service-catalog.json" {
 		return true
 	}
-	if strings.HasPrefix(normalized, ".vscode/") && strings.Contains(base, "mcp") {
+	if strings.HasPrefix(normalized, ".vscode/") && (strings.Contains(base, "mcp") || base == "settings.json") {
+		return true
+	}
+	if !strings.Contains(normalized, "/") && strings.HasSuffix(base, ".code-workspace") {
+		return true
+	}
+	// Spring AI reads MCP client connections from application config and
+	// the servers-configuration JSON kept beside it on the classpath.
+	if strings.HasPrefix(base, "application") {
+		switch path.Ext(base) {
+		case ".yml", ".yaml", ".properties":
+			return true
+		}
+	}
+	if strings.Contains(normalized, "src/main/resources/") && path.Ext(base) == ".json" {
 		return true
 	}
 	if strings.HasPrefix(normalized, ".github/") {
@@ -796,6 +1157,9 @@ func isSparseDetectorCandidate(normalized, base string) bool {
 		if ext == ".json" || ext == ".yaml" || ext == ".yml" || strings.Contains(base, "copilot") {
 			return true
 		}
+		if strings.HasSuffix(base, ".instructions.md") || strings.HasSuffix(base, ".prompt.md") {
+			return true
+		}
 	}
 	return false
 }
@@ -866,7 +1230,7 @@ func isSparseEnvCandidate(rel string) bool {
 func isSparsePromptSurface(rel string) bool {
 	base := path.Base(rel)
 	switch base {
-	case "agents.md", "agents.override.md", "claude.md", ".cursorrules", "jenkinsfile", "skill.md":
+	case "agents.md", "agents.override.md", "claude.md", "gemini.md", ".cursorrules", ".windsurfrules", ".clinerules", ".roomodes", "jenkinsfile", "skill.md":
 		return true
 	}
 	if strings.HasPrefix(rel, ".github/workflows/") {
@@ -878,7 +1242,14 @@ func isSparsePromptSurface(rel string) bool {
 	if strings.HasPrefix(rel, ".agents/") ||
 		strings.HasPrefix(rel, ".claude/") ||
 		strings.HasPrefix(rel, ".cursor/") ||
-		strings.HasPrefix(rel, ".codex/") {
+		strings.HasPrefix(rel, ".codex/") ||
+		strings.HasPrefix(rel, ".gemini/") ||
+		strings.HasPrefix(rel, ".windsurf/") ||
+		strings.HasPrefix(rel, ".clinerules/") ||
+		strings.HasPrefix(rel, ".roo/") ||
+		strings.HasPrefix(rel, ".continue/rules/") ||
+		strings.HasPrefix(rel, ".amazonq/rules/") ||
+		strings.HasPrefix(rel, ".kiro/steering/") {
 		return hasSparseTextLikeExtension(rel)
 	}
 	if strings.Contains(rel, "prompt") || strings.Contains(rel, "instruction") {
@@ -887,6 +1258,80 @@ func isSparsePromptSurface(rel string) bool {
 	return false
 }
 
+// isSparseClaudePluginPath keeps nested plugin manifests and the components
+// of marketplace plugins, which are conventionally kept under plugins/.
+func isSparseClaudePluginPath(rel, base string) bool {
+	if strings.Contains(rel, "/.claude-plugin/") {
+		return true
+	}
+	if !strings.HasPrefix(rel, "plugins/") {
+		return false
+	}
+	if base == ".mcp.json" || base == "hooks.json" {
+		return true
+	}
+	return (strings.Contains(rel, "/agents/") || strings.Contains(rel, "/commands/")) && path.Ext(rel) == ".md"
+}
+
+// sparseClaudePluginRoots returns the plugin roots of a sparsely
+// materialized repo: the repository itself when the tree has a root
+// `.claude-plugin/plugin.json`, plus the local marketplace sources.
+func sparseClaudePluginRoots(repoRoot string, tree []treeItem) []string {
+	roots := make([]string, 0)
+	for _, item := range tree {
+		if item.Type == "blob" && item.Path == detect.ClaudePluginManifestPath {
+			roots = append(roots, ".")
+			break
+		}
+	}
+	var marketplace detect.ClaudePluginMarketplace
+	if exists, _ := detect.FileExistsWithinRoot("claude", repoRoot, detect.ClaudePluginMarketplacePath); exists {
+		if parseErr := detect.ParseJSONFileAllowUnknownFields("claude", repoRoot, detect.ClaudePluginMarketplacePath, &marketplace); parseErr == nil {
+			roots = append(roots, marketplace.LocalSources()...)
+		}
+	}
+	return roots
+}
+
+// isClaudePluginComponentPath reports whether rel is a plugin manifest,
+// agent, command, hooks file, or MCP config under one of roots. A nil roots
+// matches those components under any directory.
+func isClaudePluginComponentPath(rel string, roots []string) bool {
+	rel = strings.Trim(filepath.ToSlash(strings.TrimSpace(rel)), "/")
+	isComponent := func(sub string) bool {
+		switch {
+		case sub == detect.ClaudePluginManifestPath, sub == "hooks/hooks.json", sub == ".mcp.json":
+			return true
+		case path.Ext(sub) != ".md":
+			return false
+		case path.Dir(sub) == "agents", strings.HasPrefix(sub, "commands/"):
+			return true
+		}
+		return false
+	}
+	if roots == nil {
+		segments := strings.Split(rel, "/")
+		for idx := range segments {
+			if isComponent(strings.Join(segments[idx:], "/")) {
+				return true
+			}
+		}
+		return false
+	}
+	for _, root := range roots {
+		if root == "." {
+			if isComponent(rel) {
+				return true
+			}
+			continue
+		}
+		if sub, ok := strings.CutPrefix(rel, strings.Trim(root, "/")+"/"); ok && isComponent(sub) {
+			return true
+		}
+	}
+	return false
+}
+
 func isSparseWellKnownPath(rel string) bool {
 	return strings.HasPrefix(rel, ".well-known/") || strings.Contains(rel, "/.well-known/")
 }
@@ -924,6 +1369,12 @@ func isSparseMCPGatewayCandidate(rel string) bool {
 	}
 }
 
+// IsBlockedMaterializedPath reports whether rel passes through a directory
+// that hosted materialization never enters, such as .git or node_modules.
+func IsBlockedMaterializedPath(rel string) bool {
+	return hasBlockedMaterializedTraversal(strings.Trim(strings.ToLower(filepath.ToSlash(strings.TrimSpace(rel))), "/"))
+}
+
 func hasBlockedMaterializedTraversal(rel string) bool {
 	parts := strings.Split(rel, "/")
 	for _, part := range parts {
@@ -948,26 +1399,13 @@ func shouldSkipMaterializedTraversal(rel string) bool {
 
 func isSparseSourceExtension(ext string) bool {
 	switch strings.ToLower(strings.TrimSpace(ext)) {
-	case ".go", ".html", ".htm", ".java", ".js", ".jsx", ".kt", ".mjs", ".cjs", ".mts", ".cts", ".php", ".py", ".rb", ".rs", ".ts", ".tsx":
+	case ".cs", ".go", ".html", ".htm", ".java", ".js", ".jsx", ".kt", ".mjs", ".cjs", ".mts", ".cts", ".php", ".py", ".rb", ".rs", ".ts", ".tsx":
 		return true
 	default:
 		return false
 	}
 }
 
-func safeJoin(root, rel string) (string, error) {
-	cleanRoot := filepath.Clean(root)
-	cleanRel := filepath.Clean(filepath.FromSlash(rel))
-	if cleanRel == "." || cleanRel == string(os.PathSeparator) {
-		return "", fmt.Errorf("invalid relative path %q", rel)
-	}
-	target := filepath.Join(cleanRoot, cleanRel)
-	if target != cleanRoot && !strings.HasPrefix(target, cleanRoot+string(os.PathSeparator)) {
-		return "", fmt.Errorf("refusing to materialize path outside root: %s", rel)
-	}
-	return target, nil
-}
-
 func (c *Connector) doGETWithRetry(ctx context.Context, endpoint string) ([]byte, error) {
 	return c.doGETWithRetryLimit(ctx, endpoint, 0)
 }
@@ -977,28 +1415,75 @@ func (c *Connector) doGETWithRetryLimit(ctx context.Context, endpoint string, ma
 }
 
 func (c *Connector) doGETWithRetryClient(ctx context.Context, endpoint string, maxBytes int64, client HTTPClient) ([]byte, error) {
+	cache := c.responseCache
+	if cache == nil {
+		return c.doRequestWithRetry(ctx, http.MethodGet, endpoint, nil, maxBytes, client)
+	}
+	if sha, ok := blobSHAFromEndpoint(endpoint); ok {
+		if body, hit := cache.blob(sha); hit && (maxBytes <= 0 || int64(len(body)) <= maxBytes) {
+			c.recordCacheHit()
+			return body, nil
+		}
+	}
+	body, header, err := c.doConditionalRequestWithRetry(ctx, http.MethodGet, endpoint, nil, maxBytes, client, cache.lookup(endpoint))
+	if err != nil {
+		return nil, err
+	}
+	if header != nil {
+		cache.store(endpoint, header, body)
+	}
+	return body, nil
+}
+
+// doRequestWithRetry sends one API request with the connector's retry,
+// rate-limit, and degradation policy. A non-nil payload is sent as a JSON body
+// and rebuilt for every attempt.
+func (c *Connector) doRequestWithRetry(ctx context.Context, method, endpoint string, payload []byte, maxBytes int64, client HTTPClient) ([]byte, error) {
+	body, _, err := c.doConditionalRequestWithRetry(ctx, method, endpoint, payload, maxBytes, client, nil)
+	return body, err
+}
+
+// doConditionalRequestWithRetry revalidates cached with If-None-Match and
+// If-Modified-Since when it is non-nil. A 304 returns the cached body and a
+// nil header; other successes return the response header for caching.
+func (c *Connector) doConditionalRequestWithRetry(ctx context.Context, method, endpoint string, payload []byte, maxBytes int64, client HTTPClient, cached *cachedResponse) ([]byte, http.Header, error) {
 	if degradeErr := c.checkDegraded(); degradeErr != nil {
 		c.emitCooldown(0, degradeErr)
-		return nil, degradeErr
+		return nil, nil, degradeErr
 	}
 
 	var lastErr error
 	for attempt := 0; attempt <= c.MaxRetries; attempt++ {
 		if ctxErr := ctx.Err(); ctxErr != nil {
-			return nil, ctxErr
+			return nil, nil, ctxErr
 		}
 
-		req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+		var reqBody io.Reader
+		if payload != nil {
+			reqBody = bytes.NewReader(payload)
+		}
+		req, err := http.NewRequestWithContext(ctx, method, endpoint, reqBody)
 		if err != nil {
-			return nil, fmt.Errorf("build request: %w", err)
+			return nil, nil, fmt.Errorf("build request: %w", err)
 		}
 		if c.Token != "" {
 			req.Header.Set("Authorization", "Bearer "+c.Token)
 		}
 		req.Header.Set("Accept", "application/vnd.github+json")
+		if payload != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		if cached != nil {
+			if cached.ETag != "" {
+				req.Header.Set("If-None-Match", cached.ETag)
+			}
+			if cached.LastModified != "" {
+				req.Header.Set("If-Modified-Since", cached.LastModified)
+			}
+		}
 
 		resp, err := client.Do(req)
-		retryDelay := c.jitteredBackoff(attempt)
+		retryDelay := sourceconn.JitteredBackoff(c.Backoff, c.MaxBackoff, attempt)
 		statusCode := 0
 		if err != nil {
 			if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
@@ -1015,19 +1500,24 @@ func (c *Connector) doGETWithRetryClient(ctx context.Context, endpoint string, m
 			body, readErr := io.ReadAll(reader)
 			_ = resp.Body.Close()
 			if readErr != nil {
-				return nil, fmt.Errorf("read response body: %w", readErr)
+				return nil, nil, fmt.Errorf("read response body: %w", readErr)
+			}
+			if resp.StatusCode == http.StatusNotModified && cached != nil {
+				c.recordSuccess()
+				c.recordNotModified()
+				return cached.Body, nil, nil
 			}
 			if resp.StatusCode >= 200 && resp.StatusCode < 300 {
 				if maxBytes > 0 && int64(len(body)) > maxBytes {
-					return nil, fmt.Errorf("github response exceeds the %d-byte limit", maxBytes)
+					return nil, nil, fmt.Errorf("github response exceeds the %d-byte limit", maxBytes)
 				}
 				c.recordSuccess()
-				return body, nil
+				return body, resp.Header, nil
 			}
 			classification := classifyResponse(resp, body)
 			if !classification.Retryable {
 				c.resetFailureStreak()
-				return nil, formatStatusError(resp.StatusCode, classification.Message)
+				return nil, nil, formatStatusError(resp.StatusCode, classification.Message)
 			}
 			statusCode = resp.StatusCode
 			retryDelay = c.retryDelayForResponse(resp, classification.RateLimited, attempt)
@@ -1048,7 +1538,7 @@ func (c *Connector) doGETWithRetryClient(ctx context.Context, endpoint string, m
 		}
 		c.emitRetry(attempt+1, retryDelay, statusCode)
 		if sleepErr := c.sleep(ctx, retryDelay); sleepErr != nil {
-			return nil, sleepErr
+			return nil, nil, sleepErr
 		}
 	}
 	if lastErr == nil {
@@ -1056,7 +1546,7 @@ func (c *Connector) doGETWithRetryClient(ctx context.Context, endpoint string, m
 	}
 	recordedErr := c.recordFailure(lastErr)
 	c.emitCooldown(0, recordedErr)
-	return nil, recordedErr
+	return nil, nil, recordedErr
 }
 
 func (c *Connector) archiveHTTPClient() HTTPClient {
@@ -1081,6 +1571,10 @@ func (c *Connector) recordResponseHeaders(header http.Header) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	c.requestStats.Requests++
+	if strings.EqualFold(strings.TrimSpace(header.Get("X-RateLimit-Resource")), "graphql") {
+		// GraphQL points are a separate budget; see recordGraphQLRateLimit.
+		return
+	}
 	if value, err := strconv.Atoi(strings.TrimSpace(header.Get("X-RateLimit-Limit"))); err == nil && value > 0 {
 		c.requestStats.RateLimitLimit = value
 	}
@@ -1094,6 +1588,18 @@ func (c *Connector) recordResponseHeaders(header http.Header) {
 	}
 }
 
+func (c *Connector) recordNotModified() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.requestStats.NotModified++
+}
+
+func (c *Connector) recordCacheHit() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.requestStats.CacheHits++
+}
+
 func (c *Connector) now() time.Time {
 	if c.nowFn != nil {
 		return c.nowFn()
@@ -1105,101 +1611,16 @@ func (c *Connector) sleep(ctx context.Context, duration time.Duration) error {
 	if c.sleepFn != nil {
 		return c.sleepFn(ctx, duration)
 	}
-	return sleepWithContext(ctx, duration)
-}
-
-func (c *Connector) jitteredBackoff(attempt int) time.Duration {
-	backoff := c.Backoff
-	if backoff <= 0 {
-		backoff = 25 * time.Millisecond
-	}
-	maxBackoff := c.MaxBackoff
-	if maxBackoff <= 0 {
-		maxBackoff = 2 * time.Second
-	}
-
-	shift := attempt
-	if shift > 8 {
-		shift = 8
-	}
-	base := float64(backoff) * math.Pow(2, float64(shift))
-	delay := time.Duration(base)
-	if delay > maxBackoff {
-		delay = maxBackoff
-	}
-
-	// Deterministic bounded jitter in [-20%, +20%].
-	jitterPct := (attempt*37)%41 - 20
-	jitter := delay * time.Duration(jitterPct) / 100
-	delay += jitter
-
-	minDelay := backoff / 2
-	if minDelay <= 0 {
-		minDelay = time.Millisecond
-	}
-	if delay < minDelay {
-		delay = minDelay
-	}
-	if delay > maxBackoff {
-		delay = maxBackoff
-	}
-	return delay
+	return sourceconn.SleepWithContext(ctx, duration)
 }
 
 func (c *Connector) retryDelayForResponse(resp *http.Response, rateLimited bool, attempt int) time.Duration {
-	if resp == nil {
-		return c.jitteredBackoff(attempt)
-	}
-	if !rateLimited {
-		return c.jitteredBackoff(attempt)
-	}
-
-	now := c.now()
-	if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After"), now); ok {
-		return wait
-	}
-	if wait, ok := parseRateLimitReset(resp.Header.Get("X-RateLimit-Reset"), now); ok {
-		return wait
-	}
-	return c.jitteredBackoff(attempt)
-}
-
-func parseRetryAfter(raw string, now time.Time) (time.Duration, bool) {
-	value := strings.TrimSpace(raw)
-	if value == "" {
-		return 0, false
-	}
-	if seconds, err := strconv.Atoi(value); err == nil {
-		if seconds < 0 {
-			return 0, false
+	if resp != nil && rateLimited {
+		if wait, ok := sourceconn.RetryAfter(resp.Header, "X-RateLimit-Reset", c.now()); ok {
+			return wait
 		}
-		return time.Duration(seconds) * time.Second, true
-	}
-	when, err := http.ParseTime(value)
-	if err != nil {
-		return 0, false
-	}
-	wait := when.Sub(now)
-	if wait < 0 {
-		return 0, false
 	}
-	return wait, true
-}
-
-func parseRateLimitReset(raw string, now time.Time) (time.Duration, bool) {
-	value := strings.TrimSpace(raw)
-	if value == "" {
-		return 0, false
-	}
-	epoch, err := strconv.ParseInt(value, 10, 64)
-	if err != nil || epoch <= 0 {
-		return 0, false
-	}
-	wait := time.Unix(epoch, 0).Sub(now)
-	if wait < 0 {
-		return 0, false
-	}
-	return wait, true
+	return sourceconn.JitteredBackoff(c.Backoff, c.MaxBackoff, attempt)
 }
 
 func (c *Connector) checkDegraded() error {
@@ -1308,20 +1729,6 @@ func (c *Connector) emitCooldown(delay time.Duration, err error) {
 	})
 }
 
-func sleepWithContext(ctx context.Context, duration time.Duration) error {
-	if duration <= 0 {
-		return nil
-	}
-	timer := time.NewTimer(duration)
-	defer timer.Stop()
-	select {
-	case <-ctx.Done():
-		return ctx.Err()
-	case <-timer.C:
-		return nil
-	}
-}
-
 type responseClassification struct {
 	Retryable   bool
 	RateLimited bool
@@ -1330,7 +1737,7 @@ type responseClassification struct {
 }
 
 func classifyResponse(resp *http.Response, body []byte) responseClassification {
-	message := extractAPIMessage(body)
+	message := sourceconn.APIMessage(body)
 	if resp == nil {
 		return responseClassification{Message: message}
 	}
@@ -1400,33 +1807,6 @@ func matchedRateLimitPhrase(body []byte) string {
 	return ""
 }
 
-func extractAPIMessage(body []byte) string {
-	trimmed := strings.TrimSpace(string(body))
-	if trimmed == "" {
-		return ""
-	}
-
-	var payload struct {
-		Message string `json:"message"`
-	}
-	if err := json.Unmarshal(body, &payload); err == nil && strings.TrimSpace(payload.Message) != "" {
-		return sanitizeErrorMessage(payload.Message)
-	}
-	return sanitizeErrorMessage(trimmed)
-}
-
-func sanitizeErrorMessage(raw string) string {
-	parts := strings.Fields(strings.TrimSpace(raw))
-	if len(parts) == 0 {
-		return ""
-	}
-	message := strings.Join(parts, " ")
-	if len(message) > 240 {
-		return message[:240] + "..."
-	}
-	return message
-}
-
 type statusError struct {
 	StatusCode int
 	Message    string
@@ -306,6 +306,79 @@ func TestListOrgReposAllowsEmptyResultWithoutSyntheticFallback(t *testing.T) {
 	}
 }
 
+func TestListEnterpriseOrgsPaginatesGraphQL(t *testing.T) {
+	t.Parallel()
+
+	var cursors []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/graphql" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Bearer test-token" || r.Header.Get("Content-Type") != "application/json" {
+			t.Fatalf("unexpected headers: %v", r.Header)
+		}
+		var request struct {
+			Query     string         `json:"query"`
+			Variables map[string]any `json:"variables"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Fatalf("decode graphql request: %v", err)
+		}
+		if request.Variables["slug"] != "big-co" || !strings.Contains(request.Query, "enterprise(slug: $slug)") {
+			t.Fatalf("unexpected graphql request: %+v", request)
+		}
+		after, _ := request.Variables["after"].(string)
+		cursors = append(cursors, after)
+		switch after {
+		case "":
+			_, _ = fmt.Fprint(w, `{"data":{"enterprise":{"organizations":{"nodes":[{"login":"zeta"},{"login":"acme"}],"pageInfo":{"hasNextPage":true,"endCursor":"c1"}}}}}`)
+		case "c1":
+			_, _ = fmt.Fprint(w, `{"data":{"enterprise":{"organizations":{"nodes":[{"login":"acme"},{"login":"beta"}],"pageInfo":{"hasNextPage":false,"endCursor":"c2"}}}}}`)
+		default:
+			t.Fatalf("unexpected cursor %q", after)
+		}
+	}))
+	defer server.Close()
+
+	connector := NewConnectorWithOptions(server.URL+"/api/v3", "test-token", server.Client(), ConnectorOptions{AllowInsecureLoopback: true})
+	orgs, err := connector.ListEnterpriseOrgs(context.Background(), "big-co")
+	if err != nil {
+		t.Fatalf("list enterprise orgs: %v", err)
+	}
+	if strings.Join(orgs, ",") != "acme,beta,zeta" {
+		t.Fatalf("unexpected enterprise orgs: %v", orgs)
+	}
+	if strings.Join(cursors, ",") != ",c1" {
+		t.Fatalf("unexpected cursor sequence: %v", cursors)
+	}
+}
+
+func TestListEnterpriseOrgsFailsClosedOnGraphQLErrors(t *testing.T) {
+	t.Parallel()
+
+	for name, body := range map[string]string{
+		"errors":    `{"data":null,"errors":[{"message":"Resource not accessible by integration"}]}`,
+		"not_found": `{"data":{"enterprise":null}}`,
+		"bad_login": `{"data":{"enterprise":{"organizations":{"nodes":[{"login":"../escape"}],"pageInfo":{"hasNextPage":false}}}}}`,
+	} {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/graphql" {
+				t.Fatalf("unexpected path: %s", r.URL.Path)
+			}
+			_, _ = fmt.Fprint(w, body)
+		}))
+		connector := NewConnectorWithOptions(server.URL, "", server.Client(), ConnectorOptions{AllowInsecureLoopback: true})
+		if _, err := connector.ListEnterpriseOrgs(context.Background(), "big-co"); err == nil {
+			t.Fatalf("%s: expected enterprise listing to fail", name)
+		}
+		server.Close()
+	}
+
+	if _, err := NewConnector("https://api.github.com", "", nil).ListEnterpriseOrgs(context.Background(), "big/co"); err == nil {
+		t.Fatal("expected unsafe enterprise slug to fail")
+	}
+}
+
 func TestMaterializeRepoWritesRepositoryTree(t *testing.T) {
 	t.Parallel()
 
@@ -314,6 +387,8 @@ func TestMaterializeRepoWritesRepositoryTree(t *testing.T) {
 		switch r.URL.Path {
 		case "/repos/acme/backend":
 			_, _ = fmt.Fprint(w, `{"full_name":"acme/backend","default_branch":"main"}`)
+		case "/repos/acme/backend/git/ref/heads/main":
+			_, _ = fmt.Fprint(w, `{"object":{"sha":"0123456789abcdef0123456789abcdef01234567"}}`)
 		case "/repos/acme/backend/git/trees/main":
 			if r.URL.Query().Get("recursive") != "1" {
 				t.Fatalf("expected recursive=1, got %q", r.URL.Query().Get("recursive"))
@@ -395,6 +470,9 @@ func TestMaterializeRepoWritesRepositoryTree(t *testing.T) {
 	if manifest.Source != "github_repo_materialized" {
 		t.Fatalf("unexpected source: %s", manifest.Source)
 	}
+	if manifest.Commit != "0123456789abcdef0123456789abcdef01234567" {
+		t.Fatalf("expected full materialization to record the default-branch commit, got %q", manifest.Commit)
+	}
 	if manifest.Repo != "acme/backend" {
 		t.Fatalf("unexpected repo: %s", manifest.Repo)
 	}
@@ -491,6 +569,8 @@ func TestMaterializeRepoAllowsGenericSourceWhenExplicitlyEnabled(t *testing.T) {
 		switch r.URL.Path {
 		case "/repos/acme/backend":
 			_, _ = fmt.Fprint(w, `{"full_name":"acme/backend","default_branch":"main"}`)
+		case "/repos/acme/backend/git/ref/heads/main":
+			_, _ = fmt.Fprint(w, `{"object":{"sha":"0123456789abcdef0123456789abcdef01234567"}}`)
 		case "/repos/acme/backend/tarball/main":
 			w.Header().Set("Content-Type", "application/gzip")
 			_, _ = w.Write(testTarGz(t, map[string]string{
@@ -567,6 +647,8 @@ func TestMaterializeRepoArchiveRejectsTraversal(t *testing.T) {
 		switch r.URL.Path {
 		case "/repos/acme/backend":
 			_, _ = fmt.Fprint(w, `{"full_name":"acme/backend","default_branch":"main"}`)
+		case "/repos/acme/backend/git/ref/heads/main":
+			_, _ = fmt.Fprint(w, `{"object":{"sha":"0123456789abcdef0123456789abcdef01234567"}}`)
 		case "/repos/acme/backend/tarball/main":
 			_, _ = w.Write(testTarGz(t, map[string]string{"root/../escape.py": "unsafe\n"}))
 		default:
@@ -651,6 +733,8 @@ func TestMaterializeRepoRejectsPathTraversal(t *testing.T) {
 		switch r.URL.Path {
 		case "/repos/acme/backend":
 			_, _ = fmt.Fprint(w, `{"full_name":"acme/backend","default_branch":"main"}`)
+		case "/repos/acme/backend/git/ref/heads/main":
+			_, _ = fmt.Fprint(w, `{"object":{"sha":"0123456789abcdef0123456789abcdef01234567"}}`)
 		case "/repos/acme/backend/git/trees/main":
 			_, _ = fmt.Fprint(w, `{"tree":[{"path":"../outside","type":"blob","sha":"sha-1"}]}`)
 		case "/repos/acme/backend/git/blobs/sha-1":
@@ -725,6 +809,8 @@ func TestMaterializeRepoFailsClosedOnTruncatedTree(t *testing.T) {
 		switch r.URL.Path {
 		case "/repos/acme/backend":
 			_, _ = fmt.Fprint(w, `{"full_name":"acme/backend","default_branch":"main"}`)
+		case "/repos/acme/backend/git/ref/heads/main":
+			_, _ = fmt.Fprint(w, `{"object":{"sha":"0123456789abcdef0123456789abcdef01234567"}}`)
 		case "/repos/acme/backend/git/trees/main":
 			_, _ = fmt.Fprint(w, `{"truncated":true,"tree":[{"path":"AGENTS.md","type":"blob","sha":"sha-1"}]}`)
 		default:
@@ -751,6 +837,9 @@ func TestMaterializeRepoTreatsEmptyRepositoryAsSuccessfulNoContent(t *testing.T)
 		switch r.URL.Path {
 		case "/repos/acme/empty":
 			_, _ = fmt.Fprint(w, `{"full_name":"acme/empty","default_branch":"main","size":0}`)
+		case "/repos/acme/empty/git/ref/heads/main":
+			w.WriteHeader(http.StatusConflict)
+			_, _ = fmt.Fprint(w, `{"message":"Git Repository is empty."}`)
 		case "/repos/acme/empty/git/trees/main":
 			w.WriteHeader(http.StatusConflict)
 			_, _ = fmt.Fprint(w, `{"message":"Git Repository is empty."}`)
@@ -774,6 +863,150 @@ func TestMaterializeRepoTreatsEmptyRepositoryAsSuccessfulNoContent(t *testing.T)
 	}
 }
 
+func TestMaterializeRepoFetchesClaudePluginComponents(t *testing.T) {
+	t.Parallel()
+
+	tmp := t.TempDir()
+	blobs := map[string]string{
+		"sha-plugin":      `{"name":"root-plugin"}`,
+		"sha-marketplace": `{"name":"acme","plugins":[{"name":"lint","source":"./tools/lint"},{"name":"remote","source":{"source":"github","repo":"acme/remote"}}]}`,
+		"sha-agent":       "---\nname: reviewer\n---\n",
+		"sha-command":     "Deploy the service.\n",
+		"sha-hooks":       `{"hooks":{}}`,
+		"sha-lint-agent":  "---\nname: linter\n---\n",
+		"sha-lint-mcp":    `{"mcpServers":{}}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/acme/backend":
+			_, _ = fmt.Fprint(w, `{"full_name":"acme/backend","default_branch":"main"}`)
+		case "/repos/acme/backend/git/ref/heads/main":
+			_, _ = fmt.Fprint(w, `{"object":{"sha":"0123456789abcdef0123456789abcdef01234567"}}`)
+		case "/repos/acme/backend/git/trees/main":
+			_, _ = fmt.Fprint(w, `{"tree":[`+
+				`{"path":".claude-plugin/plugin.json","type":"blob","sha":"sha-plugin"},`+
+				`{"path":".claude-plugin/marketplace.json","type":"blob","sha":"sha-marketplace"},`+
+				`{"path":"agents/reviewer.md","type":"blob","sha":"sha-agent"},`+
+				`{"path":"commands/ops/deploy.md","type":"blob","sha":"sha-command"},`+
+				`{"path":"hooks/hooks.json","type":"blob","sha":"sha-hooks"},`+
+				`{"path":"tools/lint/agents/linter.md","type":"blob","sha":"sha-lint-agent"},`+
+				`{"path":"tools/lint/.mcp.json","type":"blob","sha":"sha-lint-mcp"},`+
+				`{"path":"tools/other/agents/skip.md","type":"blob","sha":"sha-skip"},`+
+				`{"path":"docs/guide.md","type":"blob","sha":"sha-skip"}]}`)
+		default:
+			sha := strings.TrimPrefix(r.URL.Path, "/repos/acme/backend/git/blobs/")
+			payload, ok := blobs[sha]
+			if !ok {
+				t.Fatalf("unexpected path: %s", r.URL.Path)
+			}
+			_, _ = fmt.Fprintf(w, `{"content":%q,"encoding":"base64"}`, base64.StdEncoding.EncodeToString([]byte(payload)))
+		}
+	}))
+	defer server.Close()
+
+	connector := NewConnectorWithOptions(server.URL, "", server.Client(), ConnectorOptions{AllowInsecureLoopback: true})
+	if _, err := connector.MaterializeRepo(context.Background(), "acme/backend", tmp); err != nil {
+		t.Fatalf("materialize repo: %v", err)
+	}
+	for _, rel := range []string{"agents/reviewer.md", "commands/ops/deploy.md", "hooks/hooks.json", "tools/lint/agents/linter.md", "tools/lint/.mcp.json"} {
+		if _, err := os.Stat(filepath.Join(tmp, "acme", "backend", filepath.FromSlash(rel))); err != nil {
+			t.Fatalf("expected plugin component %s to be materialized: %v", rel, err)
+		}
+	}
+}
+
+func TestMaterializeRepoIncrementalPinsAndReusesDefaultBranchCommit(t *testing.T) {
+	t.Parallel()
+
+	tmp := t.TempDir()
+	commit := "0123456789abcdef0123456789abcdef01234567"
+	var contentRequests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/acme/api":
+			_, _ = fmt.Fprint(w, `{"full_name":"acme/api","default_branch":"main"}`)
+		case "/repos/acme/api/git/ref/heads/main":
+			_, _ = fmt.Fprintf(w, `{"ref":"refs/heads/main","object":{"sha":%q,"type":"commit"}}`, strings.ToUpper(commit))
+		case "/repos/acme/api/git/trees/" + commit:
+			contentRequests.Add(1)
+			_, _ = fmt.Fprint(w, `{"truncated":false,"tree":[{"path":"AGENTS.md","type":"blob","sha":"b1","size":5}]}`)
+		case "/repos/acme/api/git/blobs/b1":
+			contentRequests.Add(1)
+			_, _ = fmt.Fprintf(w, `{"content":%q,"encoding":"base64"}`, base64.StdEncoding.EncodeToString([]byte("hello")))
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	connector := NewConnectorWithOptions(server.URL, "", server.Client(), ConnectorOptions{AllowInsecureLoopback: true})
+	first, reused, err := connector.MaterializeRepoIncremental(context.Background(), "acme/api", tmp, source.RepoRevision{})
+	if err != nil {
+		t.Fatalf("materialize repo incrementally: %v", err)
+	}
+	if reused || first.Commit != commit || first.Source != "github_repo_materialized" {
+		t.Fatalf("expected fresh materialization pinned to commit, reused=%v manifest=%+v", reused, first)
+	}
+	if contentRequests.Load() != 2 {
+		t.Fatalf("expected tree and blob requests, got %d", contentRequests.Load())
+	}
+
+	second, reused, err := connector.MaterializeRepoIncremental(context.Background(), "acme/api", tmp, source.RepoRevision{Commit: first.Commit, Source: first.Source, ContentStatus: first.ContentStatus})
+	if err != nil {
+		t.Fatalf("rematerialize unchanged repo: %v", err)
+	}
+	if !reused || second.Commit != commit || second.ScanRoot != first.ScanRoot {
+		t.Fatalf("expected unchanged repo to be reused, reused=%v manifest=%+v", reused, second)
+	}
+	if contentRequests.Load() != 2 {
+		t.Fatalf("expected no content requests for unchanged repo, got %d", contentRequests.Load())
+	}
+	if payload, err := os.ReadFile(filepath.Join(tmp, "acme", "api", "AGENTS.md")); err != nil || string(payload) != "hello" {
+		t.Fatalf("expected retained tree to stay in place, payload=%q err=%v", payload, err)
+	}
+
+	if _, reused, err := connector.MaterializeRepoIncremental(context.Background(), "acme/api", tmp, source.RepoRevision{Commit: first.Commit, Source: "github_repo_archive"}); err != nil || reused {
+		t.Fatalf("expected a source change to force rematerialization, reused=%v err=%v", reused, err)
+	}
+}
+
+func TestMaterializeRepoIncrementalReuseKeepsEmptyContentStatus(t *testing.T) {
+	t.Parallel()
+
+	tmp := t.TempDir()
+	commit := "0123456789abcdef0123456789abcdef01234567"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/acme/empty":
+			_, _ = fmt.Fprint(w, `{"full_name":"acme/empty","default_branch":"main"}`)
+		case "/repos/acme/empty/git/ref/heads/main":
+			_, _ = fmt.Fprintf(w, `{"object":{"sha":%q}}`, commit)
+		case "/repos/acme/empty/git/trees/" + commit:
+			w.WriteHeader(http.StatusConflict)
+			_, _ = fmt.Fprint(w, `{"message":"Git Repository is empty."}`)
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	connector := NewConnectorWithOptions(server.URL, "", server.Client(), ConnectorOptions{AllowInsecureLoopback: true})
+	first, _, err := connector.MaterializeRepoIncremental(context.Background(), "acme/empty", tmp, source.RepoRevision{})
+	if err != nil {
+		t.Fatalf("materialize empty repo: %v", err)
+	}
+	if first.ContentStatus != source.RepoContentStatusEmpty {
+		t.Fatalf("expected content_status=empty, got %+v", first)
+	}
+	second, reused, err := connector.MaterializeRepoIncremental(context.Background(), "acme/empty", tmp, source.RepoRevision{Commit: first.Commit, Source: first.Source, ContentStatus: first.ContentStatus})
+	if err != nil {
+		t.Fatalf("rematerialize empty repo: %v", err)
+	}
+	if !reused || second.ContentStatus != source.RepoContentStatusEmpty {
+		t.Fatalf("expected reused repo to stay empty, reused=%v manifest=%+v", reused, second)
+	}
+}
+
 func TestMaterializeRepoArchiveTreatsNotFoundAsEmptyAfterMetadataLookup(t *testing.T) {
 	t.Parallel()
 
@@ -782,6 +1015,9 @@ func TestMaterializeRepoArchiveTreatsNotFoundAsEmptyAfterMetadataLookup(t *testi
 		switch r.URL.Path {
 		case "/repos/acme/empty":
 			_, _ = fmt.Fprint(w, `{"full_name":"acme/empty","default_branch":"main","size":0}`)
+		case "/repos/acme/empty/git/ref/heads/main":
+			w.WriteHeader(http.StatusConflict)
+			_, _ = fmt.Fprint(w, `{"message":"Git Repository is empty."}`)
 		case "/repos/acme/empty/tarball/main":
 			w.WriteHeader(http.StatusNotFound)
 			_, _ = fmt.Fprint(w, `{"message":"Not Found"}`)
@@ -809,6 +1045,8 @@ func TestMaterializeRepoArchiveDoesNotHideNotFoundForNonEmptyRepo(t *testing.T)
 		switch r.URL.Path {
 		case "/repos/acme/backend":
 			_, _ = fmt.Fprint(w, `{"full_name":"acme/backend","default_branch":"main","size":1}`)
+		case "/repos/acme/backend/git/ref/heads/main":
+			_, _ = fmt.Fprint(w, `{"object":{"sha":"0123456789abcdef0123456789abcdef01234567"}}`)
 		case "/repos/acme/backend/tarball/main":
 			w.WriteHeader(http.StatusNotFound)
 			_, _ = fmt.Fprint(w, `{"message":"Not Found"}`)
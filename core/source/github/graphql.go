@@ -0,0 +1,378 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Clyra-AI/wrkr/internal/sourceconn"
+)
+
+const (
+	// APIModeREST acquires repositories through the GitHub REST API only.
+	APIModeREST = "rest"
+	// APIModeGraphQL lists org repositories with their metadata and fetches
+	// detector files through batched GraphQL queries. Trees and files GraphQL
+	// cannot return as text still use REST.
+	APIModeGraphQL = "graphql"
+
+	graphQLRepoPageSize     = 100
+	graphQLBlobBatchSize    = 50
+	graphQLPointsPerRepo    = 1
+	graphQLRESTCallsPerRepo = 3
+)
+
+// ParseAPIMode normalizes an API mode flag value. The empty string selects REST.
+func ParseAPIMode(value string) (string, error) {
+	switch mode := strings.ToLower(strings.TrimSpace(value)); mode {
+	case "", APIModeREST:
+		return APIModeREST, nil
+	case APIModeGraphQL:
+		return APIModeGraphQL, nil
+	default:
+		return "", fmt.Errorf("unsupported github api mode %q (expected %s or %s)", value, APIModeREST, APIModeGraphQL)
+	}
+}
+
+// SetAPIMode selects REST or GraphQL acquisition. Unknown modes select REST.
+func (c *Connector) SetAPIMode(mode string) {
+	if c == nil {
+		return
+	}
+	normalized, err := ParseAPIMode(mode)
+	if err != nil {
+		normalized = APIModeREST
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.apiMode = normalized
+}
+
+func (c *Connector) graphQLMode() bool {
+	if c == nil {
+		return false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.apiMode == APIModeGraphQL
+}
+
+type graphQLRateLimit struct {
+	Cost      int `json:"cost"`
+	Remaining int `json:"remaining"`
+	Limit     int `json:"limit"`
+}
+
+// doGraphQL posts query and decodes its data object into out. Every query is
+// expected to select rateLimit { cost remaining limit } so point usage can be
+// accounted for separately from the REST budget.
+func (c *Connector) doGraphQL(ctx context.Context, query string, variables map[string]any, out any) error {
+	endpoint, err := c.graphQLEndpoint()
+	if err != nil {
+		return err
+	}
+	requestBody, err := json.Marshal(map[string]any{"query": query, "variables": variables})
+	if err != nil {
+		return fmt.Errorf("encode graphql query: %w", err)
+	}
+	respBody, err := c.doRequestWithRetry(ctx, http.MethodPost, endpoint, requestBody, 0, c.HTTPClient)
+	if err != nil {
+		return err
+	}
+	var payload struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(respBody, &payload); err != nil {
+		return fmt.Errorf("parse graphql response: %w", err)
+	}
+	if len(payload.Data) > 0 && string(payload.Data) != "null" {
+		var usage struct {
+			RateLimit *graphQLRateLimit `json:"rateLimit"`
+		}
+		if err := json.Unmarshal(payload.Data, &usage); err == nil && usage.RateLimit != nil {
+			c.recordGraphQLRateLimit(*usage.RateLimit)
+		}
+	}
+	if len(payload.Errors) > 0 {
+		return errors.New(sourceconn.SanitizeMessage(payload.Errors[0].Message))
+	}
+	if len(payload.Data) == 0 || string(payload.Data) == "null" {
+		return errors.New("graphql response has no data")
+	}
+	if err := json.Unmarshal(payload.Data, out); err != nil {
+		return fmt.Errorf("parse graphql response: %w", err)
+	}
+	return nil
+}
+
+func (c *Connector) recordGraphQLRateLimit(rateLimit graphQLRateLimit) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if rateLimit.Cost > 0 {
+		c.requestStats.GraphQLPoints += rateLimit.Cost
+	}
+	if rateLimit.Limit > 0 {
+		c.graphQLRateLimit = rateLimit.Limit
+	}
+	if rateLimit.Remaining >= 0 {
+		c.requestStats.GraphQLRateLimitRemaining = rateLimit.Remaining
+	}
+}
+
+const orgRepositoriesQuery = `query($login: String!, $after: String) {
+  organization(login: $login) {
+    repositories(first: 100, after: $after, orderBy: {field: NAME, direction: ASC}) {
+      nodes {
+        nameWithOwner
+        isArchived
+        isEmpty
+        diskUsage
+        pushedAt
+        owner { login }
+        defaultBranchRef { name target { oid } }
+        repositoryTopics(first: 25) { nodes { topic { name } } }
+      }
+      pageInfo { hasNextPage endCursor }
+    }
+  }
+  rateLimit { cost remaining limit }
+}`
+
+// listOrgReposGraphQL lists org repositories 100 per query and caches each
+// repository's metadata so materialization does not repeat per-repo lookups.
+func (c *Connector) listOrgReposGraphQL(ctx context.Context, org string) ([]string, error) {
+	repos := make([]string, 0, graphQLRepoPageSize)
+	seen := map[string]struct{}{}
+	after := ""
+	for page := 1; ; page++ {
+		variables := map[string]any{"login": org}
+		if after != "" {
+			variables["after"] = after
+		}
+		var data struct {
+			Organization *struct {
+				Repositories struct {
+					Nodes []struct {
+						NameWithOwner string `json:"nameWithOwner"`
+						IsArchived    bool   `json:"isArchived"`
+						IsEmpty       bool   `json:"isEmpty"`
+						DiskUsage     *int   `json:"diskUsage"`
+						PushedAt      string `json:"pushedAt"`
+						Owner         struct {
+							Login string `json:"login"`
+						} `json:"owner"`
+						DefaultBranchRef *struct {
+							Name   string `json:"name"`
+							Target struct {
+								OID string `json:"oid"`
+							} `json:"target"`
+						} `json:"defaultBranchRef"`
+						RepositoryTopics struct {
+							Nodes []struct {
+								Topic struct {
+									Name string `json:"name"`
+								} `json:"topic"`
+							} `json:"nodes"`
+						} `json:"repositoryTopics"`
+					} `json:"nodes"`
+					PageInfo struct {
+						HasNextPage bool   `json:"hasNextPage"`
+						EndCursor   string `json:"endCursor"`
+					} `json:"pageInfo"`
+				} `json:"repositories"`
+			} `json:"organization"`
+		}
+		if err := c.doGraphQL(ctx, orgRepositoriesQuery, variables, &data); err != nil {
+			return nil, fmt.Errorf("list org repos page %d: %w", page, err)
+		}
+		if data.Organization == nil {
+			return nil, fmt.Errorf("organization %s was not found or is not visible to this token", org)
+		}
+
+		connection := data.Organization.Repositories
+		for _, node := range connection.Nodes {
+			repo, err := normalizeRepo(node.NameWithOwner)
+			if err != nil {
+				return nil, fmt.Errorf("list org repos page %d: %w", page, err)
+			}
+			if _, ok := seen[repo]; ok {
+				continue
+			}
+			seen[repo] = struct{}{}
+			repos = append(repos, repo)
+
+			meta := repoMeta{
+				FullName: repo,
+				Size:     node.DiskUsage,
+				Archived: node.IsArchived,
+				PushedAt: node.PushedAt,
+			}
+			meta.Owner.Login = node.Owner.Login
+			if node.IsEmpty {
+				empty := 0
+				meta.Size = &empty
+			}
+			if ref := node.DefaultBranchRef; ref != nil {
+				meta.DefaultBranch = ref.Name
+				if oid := strings.ToLower(strings.TrimSpace(ref.Target.OID)); isGitObjectID(oid) {
+					meta.headCommit = oid
+				}
+			}
+			for _, topic := range node.RepositoryTopics.Nodes {
+				meta.Topics = append(meta.Topics, topic.Topic.Name)
+			}
+			c.cacheRepoMetadata(meta)
+		}
+		if !connection.PageInfo.HasNextPage || connection.PageInfo.EndCursor == "" || connection.PageInfo.EndCursor == after {
+			break
+		}
+		after = connection.PageInfo.EndCursor
+	}
+	sort.Strings(repos)
+	return repos, nil
+}
+
+func (c *Connector) cacheRepoMetadata(meta repoMeta) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.repoMetadataCache == nil {
+		c.repoMetadataCache = map[string]repoMeta{}
+	}
+	c.repoMetadataCache[strings.ToLower(meta.FullName)] = meta
+}
+
+func (c *Connector) cachedRepoMetadata(repo string) (repoMeta, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	meta, ok := c.repoMetadataCache[strings.ToLower(repo)]
+	return meta, ok
+}
+
+type graphQLBlob struct {
+	Text        *string `json:"text"`
+	IsBinary    bool    `json:"isBinary"`
+	IsTruncated bool    `json:"isTruncated"`
+}
+
+// graphQLBlobTexts fetches the text of each "<ref>:<path>" expression in one
+// query. Entries that are missing, binary, or truncated are left out so the
+// caller can fall back to the REST blob endpoint.
+func (c *Connector) graphQLBlobTexts(ctx context.Context, repo, ref string, paths []string) (map[int]string, error) {
+	owner, name, ok := strings.Cut(repo, "/")
+	if !ok {
+		return nil, fmt.Errorf("invalid repo %q", repo)
+	}
+	variables := map[string]any{"owner": owner, "name": name}
+	declarations := []string{"$owner: String!", "$name: String!"}
+	var selections strings.Builder
+	for i, filePath := range paths {
+		variables[fmt.Sprintf("e%d", i)] = ref + ":" + filePath
+		declarations = append(declarations, fmt.Sprintf("$e%d: String!", i))
+		fmt.Fprintf(&selections, "    f%d: object(expression: $e%d) { ... on Blob { text isBinary isTruncated } }\n", i, i)
+	}
+	query := "query(" + strings.Join(declarations, ", ") + ") {\n  repository(owner: $owner, name: $name) {\n" + selections.String() + "  }\n  rateLimit { cost remaining limit }\n}"
+
+	var data struct {
+		Repository map[string]*graphQLBlob `json:"repository"`
+	}
+	if err := c.doGraphQL(ctx, query, variables, &data); err != nil {
+		return nil, fmt.Errorf("load repo files for %s@%s: %w", repo, ref, err)
+	}
+	if data.Repository == nil {
+		return nil, fmt.Errorf("load repo files for %s@%s: repository was not found or is not visible to this token", repo, ref)
+	}
+	texts := make(map[int]string, len(paths))
+	for i := range paths {
+		blob := data.Repository[fmt.Sprintf("f%d", i)]
+		if blob == nil || blob.Text == nil || blob.IsBinary || blob.IsTruncated {
+			continue
+		}
+		texts[i] = *blob.Text
+	}
+	return texts, nil
+}
+
+type selectedBlob struct {
+	item treeItem
+	dest string
+}
+
+// materializeTreeGraphQL writes the tree files include selects using
+// batched GraphQL object lookups at ref. Blobs already in the response cache,
+// and files GraphQL cannot return as text, are loaded through REST.
+func (c *Connector) materializeTreeGraphQL(ctx context.Context, repo, ref, repoRoot string, tree []treeItem, include func(string) bool) error {
+	selected := make([]selectedBlob, 0, len(tree))
+	for _, item := range tree {
+		if item.Type != "blob" || strings.TrimSpace(item.Path) == "" {
+			continue
+		}
+		dest, pathErr := sourceconn.SafeJoin(repoRoot, item.Path)
+		if pathErr != nil {
+			return pathErr
+		}
+		if !include(item.Path) {
+			continue
+		}
+		selected = append(selected, selectedBlob{item: item, dest: dest})
+	}
+
+	pending := make([]selectedBlob, 0, len(selected))
+	for _, blob := range selected {
+		if c.responseCache != nil {
+			if _, hit := c.responseCache.blob(strings.ToLower(blob.item.SHA)); hit {
+				if err := c.materializeRESTBlob(ctx, repo, blob.item, blob.dest); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		pending = append(pending, blob)
+	}
+
+	for start := 0; start < len(pending); start += graphQLBlobBatchSize {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		batch := pending[start:min(start+graphQLBlobBatchSize, len(pending))]
+		paths := make([]string, 0, len(batch))
+		for _, blob := range batch {
+			paths = append(paths, blob.item.Path)
+		}
+		texts, err := c.graphQLBlobTexts(ctx, repo, ref, paths)
+		if err != nil {
+			return err
+		}
+		for i, blob := range batch {
+			text, ok := texts[i]
+			if !ok {
+				if err := c.materializeRESTBlob(ctx, repo, blob.item, blob.dest); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := writeMaterializedFile(blob.dest, blob.item.Path, []byte(text)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func writeMaterializedFile(dest, rel string, content []byte) error {
+	if err := os.MkdirAll(filepath.Dir(dest), 0o750); err != nil {
+		return fmt.Errorf("create materialized parent: %w", err)
+	}
+	if err := os.WriteFile(dest, content, 0o600); err != nil {
+		return fmt.Errorf("write materialized file %s: %w", rel, err)
+	}
+	return nil
+}
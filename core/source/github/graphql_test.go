@@ -0,0 +1,138 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestGraphQLModeBatchesOrgMetadataAndDetectorFiles(t *testing.T) {
+	t.Parallel()
+
+	tmp := t.TempDir()
+	commit := "0123456789abcdef0123456789abcdef01234567"
+	var listQueries, fileQueries, restBlobs atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/graphql":
+			var request struct {
+				Query     string         `json:"query"`
+				Variables map[string]any `json:"variables"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+				t.Fatalf("decode graphql request: %v", err)
+			}
+			w.Header().Set("X-RateLimit-Resource", "graphql")
+			w.Header().Set("X-RateLimit-Remaining", "4990")
+			if strings.Contains(request.Query, "organization(") {
+				listQueries.Add(1)
+				if request.Variables["after"] == nil {
+					_, _ = fmt.Fprintf(w, `{"data":{"organization":{"repositories":{"nodes":[{"nameWithOwner":"acme/api","isArchived":false,"isEmpty":false,"diskUsage":12,"pushedAt":"2026-01-02T03:04:05Z","owner":{"login":"acme"},"defaultBranchRef":{"name":"main","target":{"oid":%q}},"repositoryTopics":{"nodes":[{"topic":{"name":"agents"}}]}}],"pageInfo":{"hasNextPage":true,"endCursor":"c1"}}},"rateLimit":{"cost":1,"remaining":4999,"limit":5000}}}`, commit)
+					return
+				}
+				_, _ = fmt.Fprint(w, `{"data":{"organization":{"repositories":{"nodes":[{"nameWithOwner":"acme/web","isArchived":true,"isEmpty":true,"diskUsage":0,"owner":{"login":"acme"},"defaultBranchRef":null,"repositoryTopics":{"nodes":[]}}],"pageInfo":{"hasNextPage":false,"endCursor":"c2"}}},"rateLimit":{"cost":1,"remaining":4998,"limit":5000}}}`)
+				return
+			}
+			fileQueries.Add(1)
+			if request.Variables["e0"] != commit+":.mcp.json" || request.Variables["e1"] != commit+":AGENTS.md" {
+				t.Fatalf("expected expressions pinned to the listed head commit, got %v", request.Variables)
+			}
+			_, _ = fmt.Fprint(w, `{"data":{"repository":{"f0":{"text":null,"isBinary":true,"isTruncated":false},"f1":{"text":"# agents\n","isBinary":false,"isTruncated":false}},"rateLimit":{"cost":1,"remaining":4997,"limit":5000}}}`)
+		case "/repos/acme/api/git/trees/" + commit:
+			_, _ = fmt.Fprint(w, `{"truncated":false,"tree":[{"path":".mcp.json","type":"blob","sha":"b1"},{"path":"AGENTS.md","type":"blob","sha":"b2"},{"path":"main.go","type":"blob","sha":"b3"}]}`)
+		case "/repos/acme/api/git/blobs/b1":
+			restBlobs.Add(1)
+			_, _ = fmt.Fprintf(w, `{"content":%q,"encoding":"base64"}`, base64.StdEncoding.EncodeToString([]byte(`{"mcpServers":{}}`)))
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	connector := NewConnectorWithOptions(server.URL, "", server.Client(), ConnectorOptions{AllowInsecureLoopback: true})
+	connector.SetAPIMode(APIModeGraphQL)
+	repos, err := connector.ListOrgRepos(context.Background(), "acme")
+	if err != nil {
+		t.Fatalf("list org repos: %v", err)
+	}
+	if strings.Join(repos, ",") != "acme/api,acme/web" || listQueries.Load() != 2 {
+		t.Fatalf("expected both pages to be listed, repos=%v queries=%d", repos, listQueries.Load())
+	}
+
+	manifest, err := connector.MaterializeRepo(context.Background(), "acme/api", tmp)
+	if err != nil {
+		t.Fatalf("materialize repo: %v", err)
+	}
+	if manifest.OwnershipMetadata == nil || strings.Join(manifest.OwnershipMetadata.Topics, ",") != "agents" {
+		t.Fatalf("expected topics from the GraphQL listing, got %+v", manifest.OwnershipMetadata)
+	}
+	if fileQueries.Load() != 1 || restBlobs.Load() != 1 {
+		t.Fatalf("expected one batched file query and one REST fallback, got queries=%d blobs=%d", fileQueries.Load(), restBlobs.Load())
+	}
+	for name, want := range map[string]string{"AGENTS.md": "# agents\n", ".mcp.json": `{"mcpServers":{}}`} {
+		payload, err := os.ReadFile(filepath.Join(tmp, "acme", "api", name))
+		if err != nil || string(payload) != want {
+			t.Fatalf("unexpected %s content %q err=%v", name, payload, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(tmp, "acme", "api", "main.go")); !os.IsNotExist(err) {
+		t.Fatalf("expected non-detector source to stay unmaterialized, err=%v", err)
+	}
+
+	telemetry := connector.AcquisitionTelemetry()
+	if telemetry.APIMode != APIModeGraphQL || telemetry.Mode != "sparse_api" {
+		t.Fatalf("unexpected acquisition modes %+v", telemetry)
+	}
+	if telemetry.GraphQLPoints != 3 || telemetry.GraphQLRateLimitRemaining != 4997 {
+		t.Fatalf("expected GraphQL point usage from rateLimit, got %+v", telemetry)
+	}
+	if telemetry.RateLimitRemaining != 0 {
+		t.Fatalf("expected GraphQL headers to leave the REST budget untouched, got %+v", telemetry)
+	}
+}
+
+func TestEnsureRequestBudgetAccountsForGraphQLPoints(t *testing.T) {
+	t.Parallel()
+
+	connector := NewConnector("https://api.github.com", "", nil)
+	connector.SetAPIMode(APIModeGraphQL)
+	connector.recordGraphQLRateLimit(graphQLRateLimit{Cost: 1, Remaining: 40, Limit: 5000})
+
+	if err := connector.EnsureRequestBudget(40); err != nil {
+		t.Fatalf("expected budget to fit, got %v", err)
+	}
+	if err := connector.EnsureRequestBudget(41); err == nil || !strings.Contains(err.Error(), "graphql point budget") {
+		t.Fatalf("expected insufficient GraphQL point budget, got %v", err)
+	}
+	telemetry := connector.AcquisitionTelemetry()
+	if telemetry.EstimatedGraphQLPoints != 41 || telemetry.EstimatedRequests != 41*graphQLRESTCallsPerRepo+25 {
+		t.Fatalf("unexpected estimates %+v", telemetry)
+	}
+
+	connector.SetAllowSourceMaterialization(true)
+	if err := connector.EnsureRequestBudget(41); err != nil {
+		t.Fatalf("expected archive mode to spend no GraphQL points per repo, got %v", err)
+	}
+}
+
+func TestParseAPIMode(t *testing.T) {
+	t.Parallel()
+
+	for input, want := range map[string]string{"": APIModeREST, "REST": APIModeREST, " graphql ": APIModeGraphQL} {
+		got, err := ParseAPIMode(input)
+		if err != nil || got != want {
+			t.Fatalf("ParseAPIMode(%q) = %q, %v; want %q", input, got, err, want)
+		}
+	}
+	if _, err := ParseAPIMode("soap"); err == nil {
+		t.Fatal("expected unsupported api mode to fail")
+	}
+}
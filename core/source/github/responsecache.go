@@ -0,0 +1,180 @@
+package github
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/Clyra-AI/wrkr/internal/atomicwrite"
+)
+
+const (
+	responseCacheVersion  = "v1"
+	responseCacheRootName = "github-http-cache"
+	responseCacheBlobDir  = "blobs"
+	responseCacheEntryDir = "responses"
+)
+
+// ResponseCache stores GitHub API responses on disk so repeated scans can send
+// conditional requests. Responses carrying an ETag or Last-Modified validator
+// are revalidated with If-None-Match / If-Modified-Since; git blobs are
+// content-addressed by SHA and are served from disk without a request.
+//
+// Cache failures never fail a scan: unreadable or unsafe entries are treated
+// as misses and write errors leave the cache unchanged.
+type ResponseCache struct {
+	root string
+	mu   sync.Mutex
+}
+
+type cachedResponse struct {
+	Version      string `json:"version"`
+	Endpoint     string `json:"endpoint"`
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"last_modified,omitempty"`
+	Body         []byte `json:"body"`
+}
+
+// NewResponseCache opens the response cache under the scan-state directory.
+// The cache root must be a real directory; symlinked roots are rejected.
+func NewResponseCache(statePath string) (*ResponseCache, error) {
+	cleanState := filepath.Clean(strings.TrimSpace(statePath))
+	if cleanState == "" || cleanState == "." {
+		return nil, fmt.Errorf("state path is required for the github response cache")
+	}
+	root := filepath.Join(filepath.Dir(cleanState), responseCacheRootName)
+	info, err := os.Lstat(root)
+	switch {
+	case err == nil:
+		if info.Mode()&os.ModeSymlink != 0 || !info.IsDir() {
+			return nil, fmt.Errorf("github response cache root must be a directory, not a symlink or file: %s", root)
+		}
+	case os.IsNotExist(err):
+		if err := os.MkdirAll(root, 0o750); err != nil {
+			return nil, fmt.Errorf("create github response cache root: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("stat github response cache root: %w", err)
+	}
+	return &ResponseCache{root: root}, nil
+}
+
+// Root returns the cache directory.
+func (c *ResponseCache) Root() string {
+	if c == nil {
+		return ""
+	}
+	return c.root
+}
+
+func (c *ResponseCache) lookup(endpoint string) *cachedResponse {
+	entry, ok := c.read(c.entryPath(endpoint))
+	if !ok || entry.Endpoint != endpoint || (entry.ETag == "" && entry.LastModified == "") {
+		return nil
+	}
+	return entry
+}
+
+func (c *ResponseCache) blob(sha string) ([]byte, bool) {
+	entry, ok := c.read(c.blobPath(sha))
+	if !ok {
+		return nil, false
+	}
+	return entry.Body, true
+}
+
+// store records a successful response. Endpoints without validators are
+// cached only when they address an immutable blob.
+func (c *ResponseCache) store(endpoint string, header http.Header, body []byte) {
+	if c == nil {
+		return
+	}
+	if sha, ok := blobSHAFromEndpoint(endpoint); ok {
+		c.write(c.blobPath(sha), cachedResponse{Version: responseCacheVersion, Endpoint: endpoint, Body: body})
+		return
+	}
+	etag := strings.TrimSpace(header.Get("ETag"))
+	lastModified := strings.TrimSpace(header.Get("Last-Modified"))
+	if etag == "" && lastModified == "" {
+		return
+	}
+	c.write(c.entryPath(endpoint), cachedResponse{
+		Version:      responseCacheVersion,
+		Endpoint:     endpoint,
+		ETag:         etag,
+		LastModified: lastModified,
+		Body:         body,
+	})
+}
+
+func (c *ResponseCache) entryPath(endpoint string) string {
+	sum := sha256.Sum256([]byte(endpoint))
+	return filepath.Join(c.root, responseCacheEntryDir, hex.EncodeToString(sum[:])+".json")
+}
+
+func (c *ResponseCache) blobPath(sha string) string {
+	return filepath.Join(c.root, responseCacheBlobDir, sha+".json")
+}
+
+func (c *ResponseCache) read(file string) (*cachedResponse, bool) {
+	if c == nil {
+		return nil, false
+	}
+	info, err := os.Lstat(file)
+	if err != nil || !info.Mode().IsRegular() {
+		return nil, false
+	}
+	payload, err := os.ReadFile(file) // #nosec G304 -- cache paths are derived from hashes under the cache root.
+	if err != nil {
+		return nil, false
+	}
+	var entry cachedResponse
+	if err := json.Unmarshal(payload, &entry); err != nil || entry.Version != responseCacheVersion {
+		return nil, false
+	}
+	return &entry, true
+}
+
+func (c *ResponseCache) write(file string, entry cachedResponse) {
+	payload, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	dir := filepath.Dir(file)
+	if info, err := os.Lstat(dir); err == nil {
+		if info.Mode()&os.ModeSymlink != 0 || !info.IsDir() {
+			return
+		}
+	} else if err := os.MkdirAll(dir, 0o750); err != nil {
+		return
+	}
+	_ = atomicwrite.WriteFile(file, payload, 0o600)
+}
+
+// blobSHAFromEndpoint reports the git object id of a /repos/{owner}/{repo}/git/blobs/{sha}
+// endpoint. Only full SHA-1 object ids are treated as content-addressed.
+func blobSHAFromEndpoint(endpoint string) (string, bool) {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.RawQuery != "" {
+		return "", false
+	}
+	dir, sha := path.Split(u.Path)
+	if !strings.HasSuffix(dir, "/git/blobs/") {
+		return "", false
+	}
+	sha = strings.ToLower(sha)
+	if !isGitObjectID(sha) {
+		return "", false
+	}
+	return sha, true
+}
@@ -0,0 +1,86 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+)
+
+func TestResponseCacheRevalidatesMetadataAndReusesBlobs(t *testing.T) {
+	t.Parallel()
+
+	blobSHA := "89abcdef0123456789abcdef0123456789abcdef"
+	var metadataRequests, notModified, blobRequests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/acme/api":
+			metadataRequests.Add(1)
+			if r.Header.Get("If-None-Match") == `"meta-v1"` {
+				notModified.Add(1)
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			w.Header().Set("ETag", `"meta-v1"`)
+			_, _ = fmt.Fprint(w, `{"full_name":"acme/api","default_branch":"main"}`)
+		case "/repos/acme/api/git/blobs/" + blobSHA:
+			blobRequests.Add(1)
+			_, _ = fmt.Fprintf(w, `{"content":%q,"encoding":"base64"}`, base64.StdEncoding.EncodeToString([]byte("hello")))
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	cache, err := NewResponseCache(filepath.Join(t.TempDir(), "state.json"))
+	if err != nil {
+		t.Fatalf("open response cache: %v", err)
+	}
+	scan := func() *Connector {
+		connector := NewConnectorWithOptions(server.URL, "", server.Client(), ConnectorOptions{AllowInsecureLoopback: true})
+		connector.SetResponseCache(cache)
+		if _, err := connector.AcquireRepo(context.Background(), "acme/api"); err != nil {
+			t.Fatalf("acquire repo: %v", err)
+		}
+		blob, err := connector.repoBlob(context.Background(), "acme/api", blobSHA)
+		if err != nil {
+			t.Fatalf("load blob: %v", err)
+		}
+		if decoded, _ := base64.StdEncoding.DecodeString(blob.Content); string(decoded) != "hello" {
+			t.Fatalf("unexpected blob content %q", blob.Content)
+		}
+		return connector
+	}
+
+	first := scan().AcquisitionTelemetry()
+	if first.NotModified != 0 || first.CacheHits != 0 {
+		t.Fatalf("expected a cold cache, got %+v", first)
+	}
+	second := scan().AcquisitionTelemetry()
+	if second.NotModified != 1 || second.CacheHits != 1 {
+		t.Fatalf("expected one 304 and one blob cache hit, got %+v", second)
+	}
+	if second.Requests != 1 {
+		t.Fatalf("expected only the revalidation request on the warm scan, got %d", second.Requests)
+	}
+	if metadataRequests.Load() != 2 || notModified.Load() != 1 || blobRequests.Load() != 1 {
+		t.Fatalf("unexpected request counts metadata=%d not_modified=%d blobs=%d", metadataRequests.Load(), notModified.Load(), blobRequests.Load())
+	}
+}
+
+func TestNewResponseCacheRejectsSymlinkRoot(t *testing.T) {
+	t.Parallel()
+
+	tmp := t.TempDir()
+	if err := os.Symlink(t.TempDir(), filepath.Join(tmp, responseCacheRootName)); err != nil {
+		t.Skipf("symlinks unavailable: %v", err)
+	}
+	if _, err := NewResponseCache(filepath.Join(tmp, "state.json")); err == nil {
+		t.Fatal("expected symlinked response cache root to be rejected")
+	}
+}
@@ -0,0 +1,571 @@
+// Package gitlab acquires GitLab group and project sources through the GitLab
+// REST API with the same sparse materialization contract as the GitHub connector.
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Clyra-AI/wrkr/core/source"
+	"github.com/Clyra-AI/wrkr/core/source/github"
+	"github.com/Clyra-AI/wrkr/core/workflowloc"
+	"github.com/Clyra-AI/wrkr/internal/githubendpoint"
+	"github.com/Clyra-AI/wrkr/internal/reponame"
+	"github.com/Clyra-AI/wrkr/internal/sourceconn"
+)
+
+const (
+	// Provider names GitLab-hosted sources in manifests, checkpoints, and locations.
+	Provider = "gitlab"
+
+	pageSize     = 100
+	maxBlobBytes = 10 << 20
+	// symlinkMode is the git tree mode GitLab reports for symbolic links.
+	symlinkMode = "120000"
+)
+
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Connector acquires GitLab group project lists and sparse project trees.
+type Connector struct {
+	BaseURL    string
+	Token      string
+	HTTPClient HTTPClient
+	MaxRetries int
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	// AllowSourceMaterialization permits broad source-code extension fetching for
+	// explicit deep/debug scans. Default hosted scans keep this false.
+	AllowSourceMaterialization bool
+	endpointOptions            githubendpoint.Options
+
+	mu           sync.Mutex
+	nowFn        func() time.Time
+	sleepFn      func(context.Context, time.Duration) error
+	onRetry      func(github.RetryEvent)
+	requestStats source.AcquisitionTelemetry
+}
+
+// ConnectorOptions controls explicit development-only connector behavior.
+type ConnectorOptions struct {
+	AllowInsecureLoopback bool
+}
+
+func NewConnector(baseURL, token string, client HTTPClient) *Connector {
+	return NewConnectorWithOptions(baseURL, token, client, ConnectorOptions{})
+}
+
+// NewConnectorWithOptions permits loopback HTTP only when explicitly requested
+// for local development or tests. Production callers must use NewConnector.
+func NewConnectorWithOptions(baseURL, token string, client HTTPClient, options ConnectorOptions) *Connector {
+	endpointOptions := githubendpoint.Options{AllowInsecureLoopback: options.AllowInsecureLoopback}
+	if configured, ok := client.(*http.Client); ok {
+		client = sourceconn.NewHTTPClient(baseURL, endpointOptions, configured)
+	} else if client == nil {
+		client = sourceconn.NewHTTPClient(baseURL, endpointOptions, nil)
+	}
+	return &Connector{
+		BaseURL:         strings.TrimRight(baseURL, "/"),
+		Token:           token,
+		HTTPClient:      client,
+		MaxRetries:      2,
+		Backoff:         25 * time.Millisecond,
+		MaxBackoff:      2 * time.Second,
+		endpointOptions: endpointOptions,
+		nowFn:           time.Now,
+		sleepFn:         sourceconn.SleepWithContext,
+	}
+}
+
+func (c *Connector) validateEndpoint() error {
+	if c == nil {
+		return errors.New("gitlab connector is required")
+	}
+	if c.BaseURL == "" {
+		return errors.New("gitlab api base url is required for hosted acquisition")
+	}
+	_, err := githubendpoint.Parse(c.BaseURL, c.endpointOptions)
+	return err
+}
+
+func (c *Connector) SetRetryHandler(fn func(github.RetryEvent)) {
+	if c == nil {
+		return
+	}
+	c.onRetry = fn
+}
+
+func (c *Connector) SetAllowSourceMaterialization(allow bool) {
+	if c == nil {
+		return
+	}
+	c.AllowSourceMaterialization = allow
+}
+
+func (c *Connector) AcquisitionTelemetry() source.AcquisitionTelemetry {
+	if c == nil {
+		return source.AcquisitionTelemetry{}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	out := c.requestStats
+	out.Warnings = append([]string(nil), c.requestStats.Warnings...)
+	out.Mode = "gitlab_sparse_api"
+	return out
+}
+
+// EnsureRequestBudget fails before materialization when the last observed
+// GitLab rate-limit window cannot cover the estimated project fetches.
+func (c *Connector) EnsureRequestBudget(repoCount int) error {
+	if c == nil || repoCount <= 0 {
+		return nil
+	}
+	estimate := repoCount*50 + 25
+	c.mu.Lock()
+	c.requestStats.EstimatedRequests = estimate
+	remaining := c.requestStats.RateLimitRemaining
+	limit := c.requestStats.RateLimitLimit
+	if limit > 0 && remaining < estimate {
+		c.requestStats.Warnings = append(c.requestStats.Warnings, fmt.Sprintf("estimated GitLab requests %d exceed remaining budget %d", estimate, remaining))
+	}
+	c.mu.Unlock()
+	if limit > 0 && remaining < estimate {
+		return fmt.Errorf("gitlab request budget is insufficient before materialization: estimated=%d remaining=%d; wait for reset, scan pre-cloned repositories with --path, or reduce the group scope", estimate, remaining)
+	}
+	return nil
+}
+
+// RateLimitedError reports exhausted GitLab throttling after bounded retries.
+type RateLimitedError struct {
+	StatusCode int
+	Attempts   int
+	Message    string
+}
+
+func (e *RateLimitedError) Error() string {
+	if e == nil {
+		return ""
+	}
+	parts := []string{fmt.Sprintf("gitlab API rate limit exhausted after %d attempt(s)", e.Attempts)}
+	if e.StatusCode != 0 {
+		parts = append(parts, fmt.Sprintf("status=%d", e.StatusCode))
+	}
+	if message := strings.TrimSpace(e.Message); message != "" {
+		parts = append(parts, "upstream_message="+message)
+	}
+	return strings.Join(parts, "; ")
+}
+
+// IsRateLimitedError reports whether err represents exhausted hosted throttling.
+func IsRateLimitedError(err error) bool {
+	var target *RateLimitedError
+	return errors.As(err, &target)
+}
+
+type projectMeta struct {
+	PathWithNamespace string   `json:"path_with_namespace"`
+	DefaultBranch     string   `json:"default_branch"`
+	EmptyRepo         bool     `json:"empty_repo"`
+	Topics            []string `json:"topics"`
+	TagList           []string `json:"tag_list"`
+}
+
+// AcquireRepo resolves one project by its full group/subgroup/project path.
+func (c *Connector) AcquireRepo(ctx context.Context, project string) (source.RepoManifest, error) {
+	project, err := NormalizeProject(project)
+	if err != nil {
+		return source.RepoManifest{}, err
+	}
+	if err := c.validateEndpoint(); err != nil {
+		return source.RepoManifest{}, err
+	}
+	meta, err := c.projectMetadata(ctx, project)
+	if err != nil {
+		return source.RepoManifest{}, err
+	}
+	fullPath, err := metadataPath(meta, project)
+	if err != nil {
+		return source.RepoManifest{}, fmt.Errorf("acquire project metadata: %w", err)
+	}
+	return source.RepoManifest{
+		Repo:              fullPath,
+		Location:          fullPath,
+		Source:            Provider + "_repo",
+		OwnershipMetadata: ownershipMetadata(meta),
+	}, nil
+}
+
+// ListOrgRepos lists every project under a group and its subgroups. The name
+// satisfies org.RepoLister so GitLab groups reuse org checkpointing.
+func (c *Connector) ListOrgRepos(ctx context.Context, group string) ([]string, error) {
+	normalizedGroup, err := NormalizeGroup(group)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.validateEndpoint(); err != nil {
+		return nil, err
+	}
+
+	projects := make([]string, 0, 128)
+	seen := map[string]struct{}{}
+	for page := 1; ; page++ {
+		query := url.Values{}
+		query.Set("include_subgroups", "true")
+		query.Set("order_by", "path")
+		query.Set("sort", "asc")
+		query.Set("per_page", strconv.Itoa(pageSize))
+		query.Set("page", strconv.Itoa(page))
+		endpoint := c.BaseURL + "/groups/" + url.PathEscape(normalizedGroup) + "/projects?" + query.Encode()
+
+		respBody, header, err := c.doGETWithRetry(ctx, endpoint, 0)
+		if err != nil {
+			return nil, fmt.Errorf("list group projects page %d: %w", page, err)
+		}
+		var payload []projectMeta
+		if err := json.Unmarshal(respBody, &payload); err != nil {
+			return nil, fmt.Errorf("parse group projects response page %d: %w", page, err)
+		}
+		for _, item := range payload {
+			if strings.TrimSpace(item.PathWithNamespace) == "" {
+				continue
+			}
+			project, err := NormalizeProject(item.PathWithNamespace)
+			if err != nil {
+				return nil, fmt.Errorf("list group projects page %d: %w", page, err)
+			}
+			if _, ok := seen[project]; ok {
+				continue
+			}
+			seen[project] = struct{}{}
+			projects = append(projects, project)
+		}
+		if !hasNextPage(header, len(payload)) {
+			break
+		}
+	}
+	sort.Strings(projects)
+	return projects, nil
+}
+
+// MaterializeRepo fetches detector-relevant project files through the GitLab
+// API and writes them into a deterministic local workspace under materializedRoot.
+func (c *Connector) MaterializeRepo(ctx context.Context, project string, materializedRoot string) (source.RepoManifest, error) {
+	project, err := NormalizeProject(project)
+	if err != nil {
+		return source.RepoManifest{}, err
+	}
+	if err := c.validateEndpoint(); err != nil {
+		return source.RepoManifest{}, err
+	}
+	meta, err := c.projectMetadata(ctx, project)
+	if err != nil {
+		return source.RepoManifest{}, err
+	}
+	fullPath, err := metadataPath(meta, project)
+	if err != nil {
+		return source.RepoManifest{}, fmt.Errorf("materialize project metadata: %w", err)
+	}
+
+	repoRoot, err := sourceconn.SafeJoin(materializedRoot, fullPath)
+	if err != nil {
+		return source.RepoManifest{}, fmt.Errorf("materialize project root: %w", err)
+	}
+	if err := os.RemoveAll(repoRoot); err != nil {
+		return source.RepoManifest{}, fmt.Errorf("clean materialized project root: %w", err)
+	}
+	if err := os.MkdirAll(repoRoot, 0o750); err != nil {
+		return source.RepoManifest{}, fmt.Errorf("create materialized project root: %w", err)
+	}
+
+	defaultBranch := strings.TrimSpace(meta.DefaultBranch)
+	emptyRepo := meta.EmptyRepo || defaultBranch == ""
+	if !emptyRepo {
+		tree, treeErr := c.projectTree(ctx, fullPath, defaultBranch)
+		if treeErr != nil {
+			return source.RepoManifest{}, treeErr
+		}
+		emptyRepo = len(tree) == 0
+		for _, item := range tree {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return source.RepoManifest{}, ctxErr
+			}
+			if item.Type != "blob" || item.Mode == symlinkMode || strings.TrimSpace(item.Path) == "" {
+				continue
+			}
+			dest, pathErr := sourceconn.SafeJoin(repoRoot, item.Path)
+			if pathErr != nil {
+				return source.RepoManifest{}, pathErr
+			}
+			if !shouldMaterializePath(item.Path, c.AllowSourceMaterialization) {
+				continue
+			}
+			content, blobErr := c.projectBlob(ctx, fullPath, item.ID)
+			if blobErr != nil {
+				return source.RepoManifest{}, blobErr
+			}
+			if err := os.MkdirAll(filepath.Dir(dest), 0o750); err != nil {
+				return source.RepoManifest{}, fmt.Errorf("create materialized parent: %w", err)
+			}
+			if err := os.WriteFile(dest, content, 0o600); err != nil {
+				return source.RepoManifest{}, fmt.Errorf("write materialized file %s: %w", item.Path, err)
+			}
+		}
+	}
+
+	contentStatus := source.RepoContentStatusAvailable
+	if emptyRepo {
+		contentStatus = source.RepoContentStatusEmpty
+	}
+	return source.RepoManifest{
+		Repo:              fullPath,
+		Location:          Provider + "://" + fullPath,
+		ScanRoot:          filepath.ToSlash(repoRoot),
+		Source:            Provider + "_repo_materialized",
+		ContentStatus:     contentStatus,
+		OwnershipMetadata: ownershipMetadata(meta),
+	}, nil
+}
+
+// NormalizeGroup validates a GitLab group or subgroup full path.
+func NormalizeGroup(group string) (string, error) {
+	return reponame.NormalizeNamespacePath(group, "gitlab group", 1)
+}
+
+// NormalizeProject validates a GitLab group[/subgroup]/project full path.
+func NormalizeProject(project string) (string, error) {
+	return reponame.NormalizeNamespacePath(project, "gitlab project", 2)
+}
+
+func (c *Connector) projectMetadata(ctx context.Context, project string) (projectMeta, error) {
+	endpoint := c.BaseURL + "/projects/" + url.PathEscape(project)
+	respBody, _, err := c.doGETWithRetry(ctx, endpoint, 0)
+	if err != nil {
+		return projectMeta{}, err
+	}
+	var payload projectMeta
+	if err := json.Unmarshal(respBody, &payload); err != nil {
+		return projectMeta{}, fmt.Errorf("parse project response: %w", err)
+	}
+	return payload, nil
+}
+
+type treeItem struct {
+	ID   string `json:"id"`
+	Path string `json:"path"`
+	Type string `json:"type"`
+	Mode string `json:"mode"`
+}
+
+func (c *Connector) projectTree(ctx context.Context, project, ref string) ([]treeItem, error) {
+	items := make([]treeItem, 0, 256)
+	for page := 1; ; page++ {
+		query := url.Values{}
+		query.Set("recursive", "true")
+		query.Set("ref", ref)
+		query.Set("per_page", strconv.Itoa(pageSize))
+		query.Set("page", strconv.Itoa(page))
+		endpoint := c.BaseURL + "/projects/" + url.PathEscape(project) + "/repository/tree?" + query.Encode()
+
+		respBody, header, err := c.doGETWithRetry(ctx, endpoint, 0)
+		if err != nil {
+			return nil, fmt.Errorf("load project tree for %s@%s page %d: %w", project, ref, page, err)
+		}
+		var payload []treeItem
+		if err := json.Unmarshal(respBody, &payload); err != nil {
+			return nil, fmt.Errorf("parse project tree response page %d: %w", page, err)
+		}
+		items = append(items, payload...)
+		if !hasNextPage(header, len(payload)) {
+			break
+		}
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].Path < items[j].Path })
+	return items, nil
+}
+
+func (c *Connector) projectBlob(ctx context.Context, project, sha string) ([]byte, error) {
+	endpoint := c.BaseURL + "/projects/" + url.PathEscape(project) + "/repository/blobs/" + url.PathEscape(sha) + "/raw"
+	body, _, err := c.doGETWithRetry(ctx, endpoint, maxBlobBytes)
+	if err != nil {
+		return nil, fmt.Errorf("load project blob %s for %s: %w", sha, project, err)
+	}
+	return body, nil
+}
+
+// shouldMaterializePath reuses the GitHub sparse detector predicates and adds
+// GitLab CI pipeline files, which are the native workflow surface here.
+func shouldMaterializePath(rel string, allowSourceMaterialization bool) bool {
+	if workflowloc.IsGitLabCIPath(rel) {
+		return true
+	}
+	return github.ShouldMaterializePath(rel, allowSourceMaterialization)
+}
+
+// hasNextPage prefers GitLab's X-Next-Page header and falls back to the page
+// size when the header is absent, as it is for very large offset listings.
+func hasNextPage(header http.Header, count int) bool {
+	if header != nil {
+		if _, ok := header["X-Next-Page"]; ok {
+			return strings.TrimSpace(header.Get("X-Next-Page")) != ""
+		}
+	}
+	return count >= pageSize
+}
+
+func metadataPath(meta projectMeta, requested string) (string, error) {
+	fullPath := strings.TrimSpace(meta.PathWithNamespace)
+	if fullPath == "" {
+		fullPath = requested
+	}
+	return NormalizeProject(fullPath)
+}
+
+func ownershipMetadata(meta projectMeta) *source.RepoOwnershipMetadata {
+	topics := cloneSortedStrings(append(append([]string(nil), meta.Topics...), meta.TagList...))
+	if len(topics) == 0 {
+		return nil
+	}
+	return &source.RepoOwnershipMetadata{Topics: topics}
+}
+
+func cloneSortedStrings(values []string) []string {
+	seen := map[string]struct{}{}
+	out := make([]string, 0, len(values))
+	for _, value := range values {
+		trimmed := strings.TrimSpace(value)
+		if trimmed == "" {
+			continue
+		}
+		if _, ok := seen[trimmed]; ok {
+			continue
+		}
+		seen[trimmed] = struct{}{}
+		out = append(out, trimmed)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func (c *Connector) doGETWithRetry(ctx context.Context, endpoint string, maxBytes int64) ([]byte, http.Header, error) {
+	var lastErr error
+	for attempt := 0; attempt <= c.MaxRetries; attempt++ {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, nil, ctxErr
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+		if err != nil {
+			return nil, nil, fmt.Errorf("build request: %w", err)
+		}
+		if c.Token != "" {
+			req.Header.Set("Authorization", "Bearer "+c.Token)
+		}
+		req.Header.Set("Accept", "application/json")
+
+		resp, err := c.HTTPClient.Do(req)
+		retryDelay := sourceconn.JitteredBackoff(c.Backoff, c.MaxBackoff, attempt)
+		statusCode := 0
+		if err != nil {
+			if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+				lastErr = fmt.Errorf("request timed out before the scan deadline: %v", err)
+			} else {
+				lastErr = fmt.Errorf("request failed: %w", err)
+			}
+		} else {
+			c.recordResponseHeaders(resp.Header)
+			reader := io.Reader(resp.Body)
+			if maxBytes > 0 {
+				reader = io.LimitReader(resp.Body, maxBytes+1)
+			}
+			body, readErr := io.ReadAll(reader)
+			_ = resp.Body.Close()
+			if readErr != nil {
+				return nil, nil, fmt.Errorf("read response body: %w", readErr)
+			}
+			if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+				if maxBytes > 0 && int64(len(body)) > maxBytes {
+					return nil, nil, fmt.Errorf("gitlab response exceeds the %d-byte limit", maxBytes)
+				}
+				return body, resp.Header, nil
+			}
+			message := sourceconn.APIMessage(body)
+			statusCode = resp.StatusCode
+			switch {
+			case resp.StatusCode == http.StatusTooManyRequests:
+				if wait, ok := sourceconn.RetryAfter(resp.Header, "RateLimit-Reset", c.now()); ok {
+					retryDelay = wait
+				}
+				lastErr = &RateLimitedError{StatusCode: resp.StatusCode, Attempts: attempt + 1, Message: message}
+			case resp.StatusCode >= 500:
+				lastErr = fmt.Errorf("gitlab API transient status %d", resp.StatusCode)
+			default:
+				if message == "" {
+					return nil, nil, fmt.Errorf("gitlab API status %d", resp.StatusCode)
+				}
+				return nil, nil, fmt.Errorf("gitlab API status %d: %s", resp.StatusCode, message)
+			}
+		}
+		if attempt == c.MaxRetries {
+			break
+		}
+		c.emitRetry(attempt+1, retryDelay, statusCode)
+		if sleepErr := c.sleep(ctx, retryDelay); sleepErr != nil {
+			return nil, nil, sleepErr
+		}
+	}
+	if lastErr == nil {
+		lastErr = errors.New("request failed")
+	}
+	return nil, nil, lastErr
+}
+
+func (c *Connector) recordResponseHeaders(header http.Header) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.requestStats.Requests++
+	if value, err := strconv.Atoi(strings.TrimSpace(header.Get("RateLimit-Limit"))); err == nil && value > 0 {
+		c.requestStats.RateLimitLimit = value
+	}
+	if value, err := strconv.Atoi(strings.TrimSpace(header.Get("RateLimit-Remaining"))); err == nil && value >= 0 {
+		c.requestStats.RateLimitRemaining = value
+	}
+	if raw := strings.TrimSpace(header.Get("RateLimit-Reset")); raw != "" {
+		if epoch, err := strconv.ParseInt(raw, 10, 64); err == nil && epoch > 0 {
+			c.requestStats.RateLimitReset = time.Unix(epoch, 0).UTC().Format(time.RFC3339)
+		}
+	}
+}
+
+func (c *Connector) now() time.Time {
+	if c.nowFn != nil {
+		return c.nowFn()
+	}
+	return time.Now()
+}
+
+func (c *Connector) sleep(ctx context.Context, duration time.Duration) error {
+	if c.sleepFn != nil {
+		return c.sleepFn(ctx, duration)
+	}
+	return sourceconn.SleepWithContext(ctx, duration)
+}
+
+func (c *Connector) emitRetry(attempt int, delay time.Duration, statusCode int) {
+	if c == nil || c.onRetry == nil {
+		return
+	}
+	c.onRetry(github.RetryEvent{Attempt: attempt, StatusCode: statusCode, Delay: delay})
+}
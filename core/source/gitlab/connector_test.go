@@ -0,0 +1,264 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Clyra-AI/wrkr/core/source"
+)
+
+func TestConnectorRequiresBaseURL(t *testing.T) {
+	t.Parallel()
+
+	connector := NewConnector("", "", nil)
+	if _, err := connector.AcquireRepo(context.Background(), "acme/platform/backend"); err == nil || !strings.Contains(err.Error(), "gitlab api base url is required") {
+		t.Fatalf("expected missing base url error, got %v", err)
+	}
+}
+
+func TestConnectorRejectsInsecureEndpointBeforeSendingToken(t *testing.T) {
+	t.Parallel()
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	connector := NewConnector(server.URL, "glpat-secret", server.Client())
+	if _, err := connector.ListOrgRepos(context.Background(), "acme"); err == nil {
+		t.Fatal("expected insecure endpoint rejection")
+	}
+	if requests != 0 {
+		t.Fatalf("expected no requests to insecure endpoint, got %d", requests)
+	}
+}
+
+func TestListOrgReposPagesNestedGroupProjects(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer glpat-test" {
+			t.Errorf("expected bearer token header, got %q", got)
+		}
+		if r.URL.EscapedPath() != "/groups/acme%2Fplatform/projects" {
+			t.Fatalf("unexpected path: %s", r.URL.EscapedPath())
+		}
+		if r.URL.Query().Get("include_subgroups") != "true" {
+			t.Fatalf("expected include_subgroups=true, got %q", r.URL.RawQuery)
+		}
+		switch r.URL.Query().Get("page") {
+		case "1":
+			w.Header().Set("X-Next-Page", "2")
+			_, _ = fmt.Fprint(w, `[{"path_with_namespace":"acme/platform/web"},{"path_with_namespace":"acme/platform/api"}]`)
+		case "2":
+			w.Header().Set("X-Next-Page", "")
+			_, _ = fmt.Fprint(w, `[{"path_with_namespace":"acme/platform/tools/agent"},{"path_with_namespace":"acme/platform/api"}]`)
+		default:
+			t.Fatalf("unexpected page: %s", r.URL.Query().Get("page"))
+		}
+	}))
+	defer server.Close()
+
+	connector := NewConnectorWithOptions(server.URL, "glpat-test", server.Client(), ConnectorOptions{AllowInsecureLoopback: true})
+	projects, err := connector.ListOrgRepos(context.Background(), "/acme/platform/")
+	if err != nil {
+		t.Fatalf("list group projects: %v", err)
+	}
+	expected := []string{"acme/platform/api", "acme/platform/tools/agent", "acme/platform/web"}
+	if !reflect.DeepEqual(projects, expected) {
+		t.Fatalf("unexpected projects: %v", projects)
+	}
+	if telemetry := connector.AcquisitionTelemetry(); telemetry.Requests != 2 {
+		t.Fatalf("expected two counted requests, got %+v", telemetry)
+	}
+}
+
+func TestMaterializeRepoWritesSparseProjectTree(t *testing.T) {
+	t.Parallel()
+
+	tmp := t.TempDir()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/projects/acme%2Fplatform%2Fbackend":
+			_, _ = fmt.Fprint(w, `{"path_with_namespace":"acme/platform/backend","default_branch":"main","topics":["team-payments","ai"]}`)
+		case "/projects/acme%2Fplatform%2Fbackend/repository/tree":
+			if r.URL.Query().Get("ref") != "main" || r.URL.Query().Get("recursive") != "true" {
+				t.Fatalf("unexpected tree query: %s", r.URL.RawQuery)
+			}
+			_, _ = fmt.Fprint(w, `[{"id":"sha-agents","path":"AGENTS.md","type":"blob","mode":"100644"},{"id":"sha-codex","path":".codex/config.toml","type":"blob","mode":"100644"},{"id":"sha-ci","path":".gitlab-ci.yml","type":"blob","mode":"100644"},{"id":"tree-1","path":".codex","type":"tree","mode":"040000"},{"id":"sha-link","path":".mcp.json","type":"blob","mode":"120000"},{"id":"sha-source","path":"src/main.py","type":"blob","mode":"100644"},{"id":"sha-skip","path":"docs/changelog.txt","type":"blob","mode":"100644"}]`)
+		case "/projects/acme%2Fplatform%2Fbackend/repository/blobs/sha-agents/raw":
+			_, _ = fmt.Fprint(w, "# agents\n")
+		case "/projects/acme%2Fplatform%2Fbackend/repository/blobs/sha-codex/raw":
+			_, _ = fmt.Fprint(w, "sandbox_mode = \"read-only\"\n")
+		case "/projects/acme%2Fplatform%2Fbackend/repository/blobs/sha-ci/raw":
+			_, _ = fmt.Fprint(w, "stages: [test]\n")
+		case "/projects/acme%2Fplatform%2Fbackend/repository/blobs/sha-link/raw":
+			t.Fatalf("sparse materializer should not fetch symlink blob %s", r.URL.Path)
+		case "/projects/acme%2Fplatform%2Fbackend/repository/blobs/sha-source/raw":
+			t.Fatalf("default sparse materializer should not fetch generic source blob %s", r.URL.Path)
+		case "/projects/acme%2Fplatform%2Fbackend/repository/blobs/sha-skip/raw":
+			t.Fatalf("sparse materializer should not fetch unrelated blob %s", r.URL.Path)
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.EscapedPath())
+		}
+	}))
+	defer server.Close()
+
+	connector := NewConnectorWithOptions(server.URL, "", server.Client(), ConnectorOptions{AllowInsecureLoopback: true})
+	manifest, err := connector.MaterializeRepo(context.Background(), "acme/platform/backend", tmp)
+	if err != nil {
+		t.Fatalf("materialize project: %v", err)
+	}
+	if manifest.Source != "gitlab_repo_materialized" {
+		t.Fatalf("unexpected source: %s", manifest.Source)
+	}
+	if manifest.Location != "gitlab://acme/platform/backend" {
+		t.Fatalf("expected logical hosted location, got %s", manifest.Location)
+	}
+	if manifest.ContentStatus != source.RepoContentStatusAvailable {
+		t.Fatalf("expected available content status, got %q", manifest.ContentStatus)
+	}
+	expectedScanRoot := filepath.ToSlash(filepath.Join(tmp, "acme", "platform", "backend"))
+	if manifest.ScanRoot != expectedScanRoot {
+		t.Fatalf("expected scan root %s, got %s", expectedScanRoot, manifest.ScanRoot)
+	}
+	if manifest.OwnershipMetadata == nil || !reflect.DeepEqual(manifest.OwnershipMetadata.Topics, []string{"ai", "team-payments"}) {
+		t.Fatalf("expected sorted ownership topics, got %+v", manifest.OwnershipMetadata)
+	}
+
+	for _, rel := range []string{"AGENTS.md", ".codex/config.toml", ".gitlab-ci.yml"} {
+		if _, err := os.Stat(filepath.Join(tmp, "acme", "platform", "backend", filepath.FromSlash(rel))); err != nil {
+			t.Fatalf("expected materialized %s: %v", rel, err)
+		}
+	}
+	for _, rel := range []string{".mcp.json", "src/main.py", "docs/changelog.txt"} {
+		if _, err := os.Stat(filepath.Join(tmp, "acme", "platform", "backend", filepath.FromSlash(rel))); !os.IsNotExist(err) {
+			t.Fatalf("expected %s to be skipped, got %v", rel, err)
+		}
+	}
+}
+
+func TestMaterializeRepoMarksEmptyProject(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/projects/acme%2Fempty":
+			_, _ = fmt.Fprint(w, `{"path_with_namespace":"acme/empty","empty_repo":true}`)
+		default:
+			t.Fatalf("empty project should not request %s", r.URL.EscapedPath())
+		}
+	}))
+	defer server.Close()
+
+	connector := NewConnectorWithOptions(server.URL, "", server.Client(), ConnectorOptions{AllowInsecureLoopback: true})
+	manifest, err := connector.MaterializeRepo(context.Background(), "acme/empty", t.TempDir())
+	if err != nil {
+		t.Fatalf("materialize empty project: %v", err)
+	}
+	if manifest.ContentStatus != source.RepoContentStatusEmpty {
+		t.Fatalf("expected empty content status, got %q", manifest.ContentStatus)
+	}
+}
+
+func TestMaterializeRepoRejectsTraversalTreePath(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/projects/acme%2Fbackend":
+			_, _ = fmt.Fprint(w, `{"path_with_namespace":"acme/backend","default_branch":"main"}`)
+		case "/projects/acme%2Fbackend/repository/tree":
+			_, _ = fmt.Fprint(w, `[{"id":"sha-1","path":"../AGENTS.md","type":"blob","mode":"100644"}]`)
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.EscapedPath())
+		}
+	}))
+	defer server.Close()
+
+	connector := NewConnectorWithOptions(server.URL, "", server.Client(), ConnectorOptions{AllowInsecureLoopback: true})
+	if _, err := connector.MaterializeRepo(context.Background(), "acme/backend", t.TempDir()); err == nil {
+		t.Fatal("expected traversal path to fail materialization")
+	}
+}
+
+func TestConnectorHonorsRetryAfter429(t *testing.T) {
+	t.Parallel()
+
+	var attempts int32
+	var slept []time.Duration
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.Header().Set("Retry-After", "3")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = fmt.Fprint(w, `{"message":"429 Too Many Requests"}`)
+			return
+		}
+		_, _ = fmt.Fprint(w, `{"path_with_namespace":"acme/backend","default_branch":"main"}`)
+	}))
+	defer server.Close()
+
+	connector := NewConnectorWithOptions(server.URL, "", server.Client(), ConnectorOptions{AllowInsecureLoopback: true})
+	connector.sleepFn = func(_ context.Context, duration time.Duration) error {
+		slept = append(slept, duration)
+		return nil
+	}
+
+	manifest, err := connector.AcquireRepo(context.Background(), "acme/backend")
+	if err != nil {
+		t.Fatalf("acquire project: %v", err)
+	}
+	if manifest.Source != "gitlab_repo" {
+		t.Fatalf("unexpected source: %s", manifest.Source)
+	}
+	if attempts != 2 {
+		t.Fatalf("expected two attempts, got %d", attempts)
+	}
+	if len(slept) == 0 || slept[0] != 3*time.Second {
+		t.Fatalf("expected retry-after sleep of 3s, got %v", slept)
+	}
+}
+
+func TestConnectorReturnsRateLimitedErrorAfterRetries(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Retry-After", "1")
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = fmt.Fprint(w, `{"message":"429 Too Many Requests"}`)
+	}))
+	defer server.Close()
+
+	connector := NewConnectorWithOptions(server.URL, "", server.Client(), ConnectorOptions{AllowInsecureLoopback: true})
+	connector.MaxRetries = 1
+	connector.sleepFn = func(context.Context, time.Duration) error { return nil }
+
+	_, err := connector.AcquireRepo(context.Background(), "acme/backend")
+	if !IsRateLimitedError(err) {
+		t.Fatalf("expected rate limited error, got %v", err)
+	}
+}
+
+func TestNormalizeProjectRequiresNamespace(t *testing.T) {
+	t.Parallel()
+
+	for _, value := range []string{"", "backend", "acme//backend", `acme\backend`, "acme/../backend"} {
+		if _, err := NormalizeProject(value); err == nil {
+			t.Fatalf("expected %q to be rejected", value)
+		}
+	}
+	if got, err := NormalizeProject("/acme/platform/backend/"); err != nil || got != "acme/platform/backend" {
+		t.Fatalf("unexpected normalized project %q err=%v", got, err)
+	}
+}
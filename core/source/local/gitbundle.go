@@ -0,0 +1,333 @@
+package local
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"context"
+	"crypto/sha1" // #nosec G505 -- git object ids are sha1 digests, not a security boundary.
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Clyra-AI/wrkr/core/source"
+)
+
+// SourceGitBundle marks repos materialized from a git bundle file.
+const SourceGitBundle = "local_git_bundle"
+
+const (
+	maxGitBundleHeaderBytes = 1 << 20
+	maxGitBundleObjects     = 1 << 21
+)
+
+// IsGitBundle reports whether header starts with a v2 or v3 git bundle
+// signature.
+func IsGitBundle(header []byte) bool {
+	return bytes.HasPrefix(header, []byte("# v2 git bundle\n")) || bytes.HasPrefix(header, []byte("# v3 git bundle\n"))
+}
+
+// AcquireBundle materializes the detector-relevant files of one commit from a
+// git bundle. The bundle's HEAD is preferred, then main, then master, then
+// the lexically first branch or tag. Bundles with prerequisites (thin
+// bundles) are rejected because their history is incomplete.
+func AcquireBundle(ctx context.Context, bundlePath, repoName string, opts RefOptions) (source.RepoManifest, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if strings.TrimSpace(opts.MaterializedRoot) == "" {
+		return source.RepoManifest{}, errors.New("materialized source root is required for git bundle acquisition")
+	}
+	repo, refs, err := openGitBundle(bundlePath)
+	if err != nil {
+		return source.RepoManifest{}, err
+	}
+	defer func() { _ = repo.Close() }()
+	ref, id := defaultBundleRef(refs)
+	if ref == "" {
+		return source.RepoManifest{}, fmt.Errorf("git bundle %s has no refs", filepath.Base(bundlePath))
+	}
+	commit, err := repo.peelToCommit(id, ref)
+	if err != nil {
+		return source.RepoManifest{}, err
+	}
+	repoRoot, files, err := materializeGitCommit(ctx, repo, commit, opts.MaterializedRoot, repoName, opts.AllowSourceMaterialization)
+	if err != nil {
+		return source.RepoManifest{}, err
+	}
+
+	manifest := source.RepoManifest{
+		Repo:          repoName,
+		Location:      filepath.ToSlash(bundlePath),
+		ScanRoot:      filepath.ToSlash(repoRoot),
+		Source:        SourceGitBundle,
+		Ref:           ref,
+		Commit:        commit,
+		ContentStatus: source.RepoContentStatusAvailable,
+	}
+	if files == 0 {
+		manifest.ContentStatus = source.RepoContentStatusEmpty
+	}
+	return manifest, nil
+}
+
+func defaultBundleRef(refs map[string]string) (string, string) {
+	for _, name := range []string{"HEAD", "refs/heads/main", "refs/heads/master"} {
+		if id, ok := refs[name]; ok {
+			return name, id
+		}
+	}
+	names := make([]string, 0, len(refs))
+	for name := range refs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if len(names) == 0 {
+		return "", ""
+	}
+	return names[0], refs[names[0]]
+}
+
+// openGitBundle parses the bundle header and indexes its embedded pack in
+// memory, returning a repository backed only by that pack.
+func openGitBundle(bundlePath string) (*gitRepository, map[string]string, error) {
+	file, err := os.Open(bundlePath) // #nosec G304 -- bundle path is an explicit user-selected scan input.
+	if err != nil {
+		return nil, nil, fmt.Errorf("open git bundle: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return nil, nil, fmt.Errorf("stat git bundle: %w", err)
+	}
+	refs, packStart, err := readGitBundleHeader(file, filepath.Base(bundlePath))
+	if err != nil {
+		_ = file.Close()
+		return nil, nil, err
+	}
+
+	pack := &gitPack{
+		path:   bundlePath,
+		reader: io.NewSectionReader(file, packStart, info.Size()-packStart),
+		closer: file,
+		size:   info.Size() - packStart,
+		cache:  map[int64]gitObject{},
+	}
+	repo := &gitRepository{packs: []*gitPack{pack}}
+	if err := indexBundlePack(repo, pack); err != nil {
+		_ = repo.Close()
+		return nil, nil, err
+	}
+	return repo, refs, nil
+}
+
+func readGitBundleHeader(file *os.File, name string) (map[string]string, int64, error) {
+	reader := bufio.NewReader(io.LimitReader(file, maxGitBundleHeaderBytes))
+	offset := int64(0)
+	readLine := func() (string, error) {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return "", fmt.Errorf("git bundle %s has a truncated header", name)
+		}
+		offset += int64(len(line))
+		return strings.TrimSuffix(line, "\n"), nil
+	}
+
+	signature, err := readLine()
+	if err != nil {
+		return nil, 0, err
+	}
+	if !IsGitBundle([]byte(signature + "\n")) {
+		return nil, 0, fmt.Errorf("%s is not a v2 or v3 git bundle", name)
+	}
+	refs := map[string]string{}
+	for {
+		line, err := readLine()
+		if err != nil {
+			return nil, 0, err
+		}
+		if line == "" {
+			return refs, offset, nil
+		}
+		switch {
+		case strings.HasPrefix(line, "@"):
+			key, value, _ := strings.Cut(strings.TrimPrefix(line, "@"), "=")
+			if key == "object-format" && value != "sha1" {
+				return nil, 0, fmt.Errorf("git bundle %s uses unsupported object format %q", name, value)
+			}
+			if key == "filter" {
+				return nil, 0, fmt.Errorf("git bundle %s is a partial-clone bundle; full bundles are required", name)
+			}
+		case strings.HasPrefix(line, "-"):
+			return nil, 0, fmt.Errorf("git bundle %s has prerequisite commits; create it with --all or a full history range", name)
+		default:
+			id, ref, ok := strings.Cut(line, " ")
+			if !ok || !isHexObjectID(id) || len(id) != gitObjectIDHexLength || strings.TrimSpace(ref) == "" {
+				return nil, 0, fmt.Errorf("git bundle %s has a malformed ref line", name)
+			}
+			refs[strings.TrimSpace(ref)] = strings.ToLower(id)
+		}
+	}
+}
+
+// indexBundlePack walks the pack sequentially to find object offsets, then
+// hashes each object to build the sorted id index that pack lookups expect.
+// Reference deltas are resolved in later passes once their bases are indexed.
+func indexBundlePack(repo *gitRepository, pack *gitPack) error {
+	offsets, err := scanPackOffsets(pack)
+	if err != nil {
+		return err
+	}
+	type indexEntry struct {
+		id     []byte
+		offset int64
+	}
+	entries := make([]indexEntry, 0, len(offsets))
+	pending := offsets
+	for len(pending) > 0 {
+		unresolved := pending[:0:0]
+		for _, offset := range pending {
+			object, objErr := pack.objectAt(repo, offset, 0)
+			if objErr != nil {
+				unresolved = append(unresolved, offset)
+				continue
+			}
+			hash := sha1.New() // #nosec G401 -- git object ids are sha1 digests.
+			_, _ = hash.Write([]byte(object.kind + " " + strconv.Itoa(len(object.data)) + "\x00"))
+			_, _ = hash.Write(object.data)
+			entries = append(entries, indexEntry{id: hash.Sum(nil), offset: offset})
+		}
+		if len(unresolved) == len(pending) {
+			return fmt.Errorf("git bundle %s references %d object(s) it does not contain", filepath.Base(pack.path), len(unresolved))
+		}
+		sort.Slice(entries, func(i, j int) bool { return bytes.Compare(entries[i].id, entries[j].id) < 0 })
+		pack.fanout = [256]uint32{}
+		pack.names = make([]byte, 0, len(entries)*gitObjectIDLength)
+		pack.offsets32 = make([]byte, 0, len(entries)*4)
+		pack.offsets64 = nil
+		for _, entry := range entries {
+			pack.names = append(pack.names, entry.id...)
+			if entry.offset < 0x80000000 {
+				pack.offsets32 = binary.BigEndian.AppendUint32(pack.offsets32, uint32(entry.offset))
+			} else {
+				pack.offsets32 = binary.BigEndian.AppendUint32(pack.offsets32, 0x80000000|uint32(len(pack.offsets64)/8))
+				pack.offsets64 = binary.BigEndian.AppendUint64(pack.offsets64, uint64(entry.offset))
+			}
+			for i := int(entry.id[0]); i < len(pack.fanout); i++ {
+				pack.fanout[i]++
+			}
+		}
+		pending = unresolved
+	}
+	return nil
+}
+
+// scanPackOffsets returns the offset of every object in pack by parsing each
+// object header and inflating its body to find where the next one starts.
+func scanPackOffsets(pack *gitPack) ([]int64, error) {
+	name := filepath.Base(pack.path)
+	header := make([]byte, 12)
+	if _, err := pack.reader.ReadAt(header, 0); err != nil || !bytes.Equal(header[:4], []byte("PACK")) {
+		return nil, fmt.Errorf("git bundle %s has a corrupt pack header", name)
+	}
+	if version := binary.BigEndian.Uint32(header[4:8]); version != 2 && version != 3 {
+		return nil, fmt.Errorf("git bundle %s uses unsupported pack version %d", name, version)
+	}
+	count := binary.BigEndian.Uint32(header[8:12])
+	if count > maxGitBundleObjects {
+		return nil, fmt.Errorf("git bundle %s exceeds %d objects", name, maxGitBundleObjects)
+	}
+
+	reader := &countingByteReader{reader: bufio.NewReader(io.NewSectionReader(pack.reader, 12, pack.size-12)), offset: 12}
+	offsets := make([]int64, 0, count)
+	for i := uint32(0); i < count; i++ {
+		offsets = append(offsets, reader.offset)
+		b, err := reader.ReadByte()
+		if err != nil {
+			return nil, fmt.Errorf("git bundle %s pack is truncated", name)
+		}
+		kind := int(b>>4) & 0x7
+		size := int64(b & 0x0f)
+		for shift := uint(4); b&0x80 != 0; shift += 7 {
+			if shift > 56 {
+				return nil, fmt.Errorf("git bundle %s has a corrupt object header", name)
+			}
+			if b, err = reader.ReadByte(); err != nil {
+				return nil, fmt.Errorf("git bundle %s pack is truncated", name)
+			}
+			size |= int64(b&0x7f) << shift
+		}
+		if size > maxGitObjectBytes {
+			return nil, fmt.Errorf("git bundle %s object exceeds the %d-byte limit", name, maxGitObjectBytes)
+		}
+		switch kind {
+		case gitPackOfsDelta:
+			for {
+				if b, err = reader.ReadByte(); err != nil {
+					return nil, fmt.Errorf("git bundle %s pack is truncated", name)
+				}
+				if b&0x80 == 0 {
+					break
+				}
+			}
+		case gitPackRefDelta:
+			if _, err := io.CopyN(io.Discard, reader, gitObjectIDLength); err != nil {
+				return nil, fmt.Errorf("git bundle %s pack is truncated", name)
+			}
+		case gitPackCommit, gitPackTree, gitPackBlob, gitPackTag:
+		default:
+			return nil, fmt.Errorf("git bundle %s has unknown object type %d", name, kind)
+		}
+		if err := skipZlibStream(reader, size); err != nil {
+			return nil, fmt.Errorf("git bundle %s: %w", name, err)
+		}
+	}
+	return offsets, nil
+}
+
+// skipZlibStream consumes exactly one zlib stream of size inflated bytes.
+// reader must implement io.ByteReader so zlib does not read ahead.
+func skipZlibStream(reader *countingByteReader, size int64) error {
+	stream, err := zlib.NewReader(reader)
+	if err != nil {
+		return fmt.Errorf("inflate pack object: %w", err)
+	}
+	defer func() { _ = stream.Close() }()
+	n, err := io.Copy(io.Discard, io.LimitReader(stream, size+1))
+	if err != nil {
+		return fmt.Errorf("inflate pack object: %w", err)
+	}
+	if n != size {
+		return errors.New("pack object size mismatch")
+	}
+	// Reading past the end verifies the checksum and consumes its trailer.
+	if _, err := stream.Read(make([]byte, 1)); !errors.Is(err, io.EOF) {
+		return errors.New("pack object size mismatch")
+	}
+	return nil
+}
+
+type countingByteReader struct {
+	reader *bufio.Reader
+	offset int64
+}
+
+func (r *countingByteReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.offset += int64(n)
+	return n, err
+}
+
+func (r *countingByteReader) ReadByte() (byte, error) {
+	b, err := r.reader.ReadByte()
+	if err == nil {
+		r.offset++
+	}
+	return b, err
+}
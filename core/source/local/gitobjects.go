@@ -0,0 +1,777 @@
+package local
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// This file implements the read-only subset of the git object store that ref
+// scans need: ref resolution, loose objects, and v2 pack indexes with offset
+// and reference deltas. It never writes to the repository and never shells
+// out to git, so a scan cannot trigger hooks, filters, or fsmonitor commands.
+
+const (
+	gitObjectIDLength    = 20
+	gitObjectIDHexLength = 2 * gitObjectIDLength
+	gitMinShortIDLength  = 4
+	maxGitObjectBytes    = 64 << 20
+	maxGitDeltaDepth     = 1024
+	maxGitRefDepth       = 8
+	maxGitTagPeelDepth   = 16
+	maxGitPackCacheSize  = 256
+)
+
+const (
+	gitPackCommit   = 1
+	gitPackTree     = 2
+	gitPackBlob     = 3
+	gitPackTag      = 4
+	gitPackOfsDelta = 6
+	gitPackRefDelta = 7
+)
+
+type gitObject struct {
+	kind string
+	data []byte
+}
+
+type gitRepository struct {
+	gitDir     string
+	commonDir  string
+	objectDirs []string
+	packs      []*gitPack
+}
+
+type gitPack struct {
+	path      string
+	reader    io.ReaderAt
+	closer    io.Closer
+	size      int64
+	fanout    [256]uint32
+	names     []byte
+	offsets32 []byte
+	offsets64 []byte
+	cache     map[int64]gitObject
+}
+
+type gitTreeEntry struct {
+	mode string
+	name string
+	id   string
+}
+
+// openGitRepository opens the object store for root/.git, following gitdir
+// files used by worktrees and submodules and commondir files used by linked
+// worktrees.
+func openGitRepository(root string) (*gitRepository, error) {
+	dotGit := filepath.Join(root, ".git")
+	info, err := os.Stat(dotGit)
+	if err != nil {
+		return nil, fmt.Errorf("%s is not a git repository: %w", root, err)
+	}
+	gitDir := dotGit
+	if !info.IsDir() {
+		payload, readErr := os.ReadFile(dotGit) // #nosec G304 -- .git pointer file inside the selected local repo.
+		if readErr != nil {
+			return nil, fmt.Errorf("read .git file: %w", readErr)
+		}
+		target, ok := strings.CutPrefix(strings.TrimSpace(string(payload)), "gitdir:")
+		if !ok {
+			return nil, fmt.Errorf("%s is not a gitdir pointer", dotGit)
+		}
+		gitDir = resolveGitPath(root, strings.TrimSpace(target))
+	}
+	commonDir := gitDir
+	if payload, readErr := os.ReadFile(filepath.Join(gitDir, "commondir")); readErr == nil { // #nosec G304 -- git metadata inside the selected local repo.
+		commonDir = resolveGitPath(gitDir, strings.TrimSpace(string(payload)))
+	}
+	if err := rejectUnsupportedObjectFormat(commonDir); err != nil {
+		return nil, err
+	}
+
+	repo := &gitRepository{gitDir: gitDir, commonDir: commonDir}
+	objectsDir := filepath.Join(commonDir, "objects")
+	repo.objectDirs = append(repo.objectDirs, objectsDir)
+	if payload, readErr := os.ReadFile(filepath.Join(objectsDir, "info", "alternates")); readErr == nil { // #nosec G304 -- git metadata inside the selected local repo.
+		for _, line := range strings.Split(string(payload), "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			repo.objectDirs = append(repo.objectDirs, resolveGitPath(objectsDir, line))
+		}
+	}
+	for _, dir := range repo.objectDirs {
+		if err := repo.openPacks(filepath.Join(dir, "pack")); err != nil {
+			_ = repo.Close()
+			return nil, err
+		}
+	}
+	return repo, nil
+}
+
+func resolveGitPath(base, target string) string {
+	if filepath.IsAbs(target) {
+		return filepath.Clean(target)
+	}
+	return filepath.Join(base, filepath.FromSlash(target))
+}
+
+func rejectUnsupportedObjectFormat(commonDir string) error {
+	payload, err := os.ReadFile(filepath.Join(commonDir, "config")) // #nosec G304 -- git metadata inside the selected local repo.
+	if err != nil {
+		return nil
+	}
+	for _, line := range strings.Split(string(payload), "\n") {
+		key, value, ok := strings.Cut(line, "=")
+		if !ok || !strings.EqualFold(strings.TrimSpace(key), "objectformat") {
+			continue
+		}
+		if format := strings.ToLower(strings.TrimSpace(value)); format != "" && format != "sha1" {
+			return fmt.Errorf("git object format %q is not supported for ref scans", format)
+		}
+	}
+	return nil
+}
+
+func (r *gitRepository) Close() error {
+	var closeErr error
+	for _, pack := range r.packs {
+		if pack.closer == nil {
+			continue
+		}
+		if err := pack.closer.Close(); err != nil && closeErr == nil {
+			closeErr = err
+		}
+	}
+	r.packs = nil
+	return closeErr
+}
+
+func (r *gitRepository) openPacks(packDir string) error {
+	entries, err := os.ReadDir(packDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("read git pack directory: %w", err)
+	}
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".idx") {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		pack, err := openGitPack(filepath.Join(packDir, name))
+		if err != nil {
+			return err
+		}
+		r.packs = append(r.packs, pack)
+	}
+	return nil
+}
+
+func openGitPack(idxPath string) (*gitPack, error) {
+	idx, err := os.ReadFile(idxPath) // #nosec G304 -- git pack index inside the selected local repo.
+	if err != nil {
+		return nil, fmt.Errorf("read git pack index: %w", err)
+	}
+	const headerSize = 8 + 256*4
+	if len(idx) < headerSize || !bytes.Equal(idx[:4], []byte{0xff, 't', 'O', 'c'}) || binary.BigEndian.Uint32(idx[4:8]) != 2 {
+		return nil, fmt.Errorf("unsupported git pack index %s: only version 2 indexes are supported", filepath.Base(idxPath))
+	}
+	pack := &gitPack{path: strings.TrimSuffix(idxPath, ".idx") + ".pack", cache: map[int64]gitObject{}}
+	for i := range pack.fanout {
+		pack.fanout[i] = binary.BigEndian.Uint32(idx[8+4*i:])
+	}
+	count := int64(pack.fanout[255])
+	namesStart := int64(headerSize)
+	crcStart := namesStart + count*gitObjectIDLength
+	offsetStart := crcStart + count*4
+	largeStart := offsetStart + count*4
+	trailerStart := int64(len(idx)) - 2*gitObjectIDLength
+	if largeStart > trailerStart || (trailerStart-largeStart)%8 != 0 {
+		return nil, fmt.Errorf("corrupt git pack index %s", filepath.Base(idxPath))
+	}
+	pack.names = idx[namesStart:crcStart]
+	pack.offsets32 = idx[offsetStart:largeStart]
+	pack.offsets64 = idx[largeStart:trailerStart]
+
+	file, err := os.Open(pack.path) // #nosec G304 -- git pack file paired with the index above.
+	if err != nil {
+		return nil, fmt.Errorf("open git pack: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("stat git pack: %w", err)
+	}
+	header := make([]byte, 12)
+	if _, err := file.ReadAt(header, 0); err != nil || !bytes.Equal(header[:4], []byte("PACK")) {
+		_ = file.Close()
+		return nil, fmt.Errorf("corrupt git pack %s", filepath.Base(pack.path))
+	}
+	pack.reader = file
+	pack.closer = file
+	pack.size = info.Size()
+	return pack, nil
+}
+
+// resolveRevision resolves a full or abbreviated commit id, a tag, a branch,
+// a remote-tracking branch, or HEAD to the commit id it names, peeling
+// annotated tags.
+func (r *gitRepository) resolveRevision(revision string) (string, error) {
+	revision = strings.TrimSpace(revision)
+	if revision == "" {
+		return "", errors.New("git ref is required")
+	}
+	if strings.ContainsAny(revision, " \t\n~^:?*[\\") || strings.Contains(revision, "..") || strings.Contains(revision, "@{") {
+		return "", fmt.Errorf("git ref %q must be a commit id, tag, or branch name; revision expressions are not supported", revision)
+	}
+
+	id := ""
+	if isHexObjectID(revision) && len(revision) == gitObjectIDHexLength {
+		id = strings.ToLower(revision)
+	}
+	if id == "" {
+		for _, candidate := range []string{revision, "refs/" + revision, "refs/tags/" + revision, "refs/heads/" + revision, "refs/remotes/" + revision, "refs/remotes/" + revision + "/HEAD"} {
+			resolved, err := r.readRef(candidate, 0)
+			if err != nil {
+				return "", err
+			}
+			if resolved != "" {
+				id = resolved
+				break
+			}
+		}
+	}
+	if id == "" && isHexObjectID(revision) && len(revision) >= gitMinShortIDLength {
+		resolved, err := r.resolveShortID(strings.ToLower(revision))
+		if err != nil {
+			return "", err
+		}
+		id = resolved
+	}
+	if id == "" {
+		return "", fmt.Errorf("git ref %q was not found", revision)
+	}
+	return r.peelToCommit(id, revision)
+}
+
+func isHexObjectID(value string) bool {
+	if len(value) > gitObjectIDHexLength {
+		return false
+	}
+	for _, ch := range value {
+		if (ch < '0' || ch > '9') && (ch < 'a' || ch > 'f') && (ch < 'A' || ch > 'F') {
+			return false
+		}
+	}
+	return value != ""
+}
+
+// readRef returns the object id for a loose or packed ref, following symbolic
+// refs. A missing ref returns an empty id and no error.
+func (r *gitRepository) readRef(name string, depth int) (string, error) {
+	if depth > maxGitRefDepth {
+		return "", fmt.Errorf("git ref %q exceeds the symbolic ref depth limit", name)
+	}
+	if !filepath.IsLocal(filepath.FromSlash(name)) {
+		return "", nil
+	}
+	for _, dir := range []string{r.gitDir, r.commonDir} {
+		payload, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name))) // #nosec G304 -- ref name is validated as a local path under the git directory.
+		if err != nil {
+			continue
+		}
+		value := strings.TrimSpace(string(payload))
+		if target, ok := strings.CutPrefix(value, "ref:"); ok {
+			return r.readRef(strings.TrimSpace(target), depth+1)
+		}
+		if isHexObjectID(value) && len(value) == gitObjectIDHexLength {
+			return strings.ToLower(value), nil
+		}
+	}
+	return r.readPackedRef(name)
+}
+
+func (r *gitRepository) readPackedRef(name string) (string, error) {
+	file, err := os.Open(filepath.Join(r.commonDir, "packed-refs")) // #nosec G304 -- git metadata inside the selected local repo.
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("read packed refs: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1<<20)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "^") {
+			continue
+		}
+		id, refName, ok := strings.Cut(line, " ")
+		if ok && refName == name && isHexObjectID(id) && len(id) == gitObjectIDHexLength {
+			return strings.ToLower(id), nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("read packed refs: %w", err)
+	}
+	return "", nil
+}
+
+func (r *gitRepository) resolveShortID(prefix string) (string, error) {
+	matches := map[string]struct{}{}
+	for _, dir := range r.objectDirs {
+		entries, err := os.ReadDir(filepath.Join(dir, prefix[:2]))
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			id := prefix[:2] + entry.Name()
+			if len(id) == gitObjectIDHexLength && strings.HasPrefix(id, prefix) {
+				matches[id] = struct{}{}
+			}
+		}
+	}
+	for _, pack := range r.packs {
+		for _, id := range pack.idsWithPrefix(prefix) {
+			matches[id] = struct{}{}
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return "", nil
+	case 1:
+		for id := range matches {
+			return id, nil
+		}
+	}
+	return "", fmt.Errorf("git ref %q is an ambiguous abbreviated object id", prefix)
+}
+
+func (r *gitRepository) peelToCommit(id, revision string) (string, error) {
+	for range maxGitTagPeelDepth {
+		object, err := r.readObject(id)
+		if err != nil {
+			return "", fmt.Errorf("resolve git ref %q: %w", revision, err)
+		}
+		switch object.kind {
+		case "commit":
+			return id, nil
+		case "tag":
+			target := objectHeaderValue(object.data, "object")
+			if !isHexObjectID(target) || len(target) != gitObjectIDHexLength {
+				return "", fmt.Errorf("resolve git ref %q: tag %s has no target object", revision, id)
+			}
+			id = strings.ToLower(target)
+		default:
+			return "", fmt.Errorf("git ref %q names a %s, not a commit", revision, object.kind)
+		}
+	}
+	return "", fmt.Errorf("git ref %q exceeds the tag peel depth limit", revision)
+}
+
+func (r *gitRepository) commitTree(commitID string) (string, error) {
+	object, err := r.readObject(commitID)
+	if err != nil {
+		return "", err
+	}
+	if object.kind != "commit" {
+		return "", fmt.Errorf("git object %s is a %s, not a commit", commitID, object.kind)
+	}
+	tree := objectHeaderValue(object.data, "tree")
+	if !isHexObjectID(tree) || len(tree) != gitObjectIDHexLength {
+		return "", fmt.Errorf("git commit %s has no tree", commitID)
+	}
+	return strings.ToLower(tree), nil
+}
+
+// objectHeaderValue reads one header line from a commit or tag object.
+func objectHeaderValue(data []byte, key string) string {
+	for _, line := range strings.Split(string(data), "\n") {
+		if line == "" {
+			return ""
+		}
+		if value, ok := strings.CutPrefix(line, key+" "); ok {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
+
+func (r *gitRepository) readTree(id string) ([]gitTreeEntry, error) {
+	object, err := r.readObject(id)
+	if err != nil {
+		return nil, err
+	}
+	if object.kind != "tree" {
+		return nil, fmt.Errorf("git object %s is a %s, not a tree", id, object.kind)
+	}
+	data := object.data
+	entries := make([]gitTreeEntry, 0, 16)
+	for len(data) > 0 {
+		space := bytes.IndexByte(data, ' ')
+		if space <= 0 {
+			return nil, fmt.Errorf("corrupt git tree %s", id)
+		}
+		mode := string(data[:space])
+		data = data[space+1:]
+		nul := bytes.IndexByte(data, 0)
+		if nul <= 0 || len(data) < nul+1+gitObjectIDLength {
+			return nil, fmt.Errorf("corrupt git tree %s", id)
+		}
+		name := string(data[:nul])
+		entryID := hex.EncodeToString(data[nul+1 : nul+1+gitObjectIDLength])
+		data = data[nul+1+gitObjectIDLength:]
+		entries = append(entries, gitTreeEntry{mode: mode, name: name, id: entryID})
+	}
+	return entries, nil
+}
+
+func (r *gitRepository) readObject(id string) (gitObject, error) {
+	return r.readObjectDepth(id, 0)
+}
+
+func (r *gitRepository) readObjectDepth(id string, depth int) (gitObject, error) {
+	if depth > maxGitDeltaDepth {
+		return gitObject{}, fmt.Errorf("git object %s exceeds the delta depth limit", id)
+	}
+	raw, err := hex.DecodeString(id)
+	if err != nil || len(raw) != gitObjectIDLength {
+		return gitObject{}, fmt.Errorf("invalid git object id %q", id)
+	}
+	for _, pack := range r.packs {
+		if offset, ok := pack.offsetFor(raw); ok {
+			return pack.objectAt(r, offset, depth)
+		}
+	}
+	for _, dir := range r.objectDirs {
+		object, found, err := readLooseObject(filepath.Join(dir, id[:2], id[2:]))
+		if err != nil {
+			return gitObject{}, fmt.Errorf("read git object %s: %w", id, err)
+		}
+		if found {
+			return object, nil
+		}
+	}
+	return gitObject{}, fmt.Errorf("git object %s was not found", id)
+}
+
+func readLooseObject(path string) (gitObject, bool, error) {
+	file, err := os.Open(path) // #nosec G304 -- loose object path is derived from a validated hex object id.
+	if err != nil {
+		if os.IsNotExist(err) {
+			return gitObject{}, false, nil
+		}
+		return gitObject{}, false, err
+	}
+	defer func() { _ = file.Close() }()
+	reader, err := zlib.NewReader(file)
+	if err != nil {
+		return gitObject{}, false, err
+	}
+	defer func() { _ = reader.Close() }()
+	buffered := bufio.NewReader(reader)
+	header, err := buffered.ReadString(0)
+	if err != nil {
+		return gitObject{}, false, fmt.Errorf("corrupt loose object header: %w", err)
+	}
+	kind, sizeRaw, ok := strings.Cut(strings.TrimSuffix(header, "\x00"), " ")
+	size, sizeErr := strconv.ParseInt(sizeRaw, 10, 64)
+	if !ok || sizeErr != nil || size < 0 {
+		return gitObject{}, false, errors.New("corrupt loose object header")
+	}
+	if size > maxGitObjectBytes {
+		return gitObject{}, false, fmt.Errorf("object exceeds the %d-byte limit", maxGitObjectBytes)
+	}
+	data, err := io.ReadAll(io.LimitReader(buffered, size+1))
+	if err != nil {
+		return gitObject{}, false, err
+	}
+	if int64(len(data)) != size {
+		return gitObject{}, false, errors.New("loose object size mismatch")
+	}
+	return gitObject{kind: kind, data: data}, true, nil
+}
+
+func (p *gitPack) offsetFor(id []byte) (int64, bool) {
+	lo, hi := p.fanoutRange(id[0])
+	idx := lo + sort.Search(hi-lo, func(i int) bool {
+		return bytes.Compare(p.nameAt(lo+i), id) >= 0
+	})
+	if idx >= hi || !bytes.Equal(p.nameAt(idx), id) {
+		return 0, false
+	}
+	offset := binary.BigEndian.Uint32(p.offsets32[4*idx:])
+	if offset&0x80000000 == 0 {
+		return int64(offset), true
+	}
+	large := int(offset & 0x7fffffff)
+	if 8*large+8 > len(p.offsets64) {
+		return 0, false
+	}
+	return int64(binary.BigEndian.Uint64(p.offsets64[8*large:])), true
+}
+
+func (p *gitPack) idsWithPrefix(prefix string) []string {
+	first, err := hex.DecodeString(prefix[:2])
+	if err != nil {
+		return nil
+	}
+	lo, hi := p.fanoutRange(first[0])
+	out := []string{}
+	for idx := lo; idx < hi; idx++ {
+		if id := hex.EncodeToString(p.nameAt(idx)); strings.HasPrefix(id, prefix) {
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
+func (p *gitPack) fanoutRange(first byte) (int, int) {
+	lo := 0
+	if first > 0 {
+		lo = int(p.fanout[first-1])
+	}
+	return lo, int(p.fanout[first])
+}
+
+func (p *gitPack) nameAt(idx int) []byte {
+	return p.names[idx*gitObjectIDLength : (idx+1)*gitObjectIDLength]
+}
+
+func (p *gitPack) objectAt(repo *gitRepository, offset int64, depth int) (gitObject, error) {
+	if depth > maxGitDeltaDepth {
+		return gitObject{}, fmt.Errorf("git pack %s exceeds the delta depth limit", filepath.Base(p.path))
+	}
+	if cached, ok := p.cache[offset]; ok {
+		return cached, nil
+	}
+	if offset < 12 || offset >= p.size {
+		return gitObject{}, fmt.Errorf("corrupt git pack %s: offset %d out of range", filepath.Base(p.path), offset)
+	}
+	header := make([]byte, 64)
+	n, err := p.reader.ReadAt(header, offset)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return gitObject{}, fmt.Errorf("read git pack %s: %w", filepath.Base(p.path), err)
+	}
+	header = header[:n]
+
+	pos := 0
+	if len(header) == 0 {
+		return gitObject{}, fmt.Errorf("corrupt git pack %s", filepath.Base(p.path))
+	}
+	b := header[pos]
+	pos++
+	kind := int(b>>4) & 0x7
+	size := int64(b & 0x0f)
+	shift := uint(4)
+	for b&0x80 != 0 {
+		if pos >= len(header) || shift > 56 {
+			return gitObject{}, fmt.Errorf("corrupt git pack %s object header", filepath.Base(p.path))
+		}
+		b = header[pos]
+		pos++
+		size |= int64(b&0x7f) << shift
+		shift += 7
+	}
+	if size > maxGitObjectBytes {
+		return gitObject{}, fmt.Errorf("git pack object exceeds the %d-byte limit", maxGitObjectBytes)
+	}
+
+	var object gitObject
+	switch kind {
+	case gitPackCommit, gitPackTree, gitPackBlob, gitPackTag:
+		data, err := p.inflate(offset+int64(pos), size)
+		if err != nil {
+			return gitObject{}, err
+		}
+		object = gitObject{kind: gitPackKindName(kind), data: data}
+	case gitPackOfsDelta:
+		if pos >= len(header) {
+			return gitObject{}, fmt.Errorf("corrupt git pack %s delta header", filepath.Base(p.path))
+		}
+		b = header[pos]
+		pos++
+		relative := int64(b & 0x7f)
+		for b&0x80 != 0 {
+			if pos >= len(header) {
+				return gitObject{}, fmt.Errorf("corrupt git pack %s delta header", filepath.Base(p.path))
+			}
+			b = header[pos]
+			pos++
+			relative = ((relative + 1) << 7) | int64(b&0x7f)
+		}
+		base, err := p.objectAt(repo, offset-relative, depth+1)
+		if err != nil {
+			return gitObject{}, err
+		}
+		delta, err := p.inflate(offset+int64(pos), size)
+		if err != nil {
+			return gitObject{}, err
+		}
+		data, err := applyGitDelta(base.data, delta)
+		if err != nil {
+			return gitObject{}, fmt.Errorf("git pack %s: %w", filepath.Base(p.path), err)
+		}
+		object = gitObject{kind: base.kind, data: data}
+	case gitPackRefDelta:
+		if pos+gitObjectIDLength > len(header) {
+			return gitObject{}, fmt.Errorf("corrupt git pack %s delta header", filepath.Base(p.path))
+		}
+		base, err := repo.readObjectDepth(hex.EncodeToString(header[pos:pos+gitObjectIDLength]), depth+1)
+		if err != nil {
+			return gitObject{}, err
+		}
+		pos += gitObjectIDLength
+		delta, err := p.inflate(offset+int64(pos), size)
+		if err != nil {
+			return gitObject{}, err
+		}
+		data, err := applyGitDelta(base.data, delta)
+		if err != nil {
+			return gitObject{}, fmt.Errorf("git pack %s: %w", filepath.Base(p.path), err)
+		}
+		object = gitObject{kind: base.kind, data: data}
+	default:
+		return gitObject{}, fmt.Errorf("corrupt git pack %s: unknown object type %d", filepath.Base(p.path), kind)
+	}
+
+	if len(p.cache) >= maxGitPackCacheSize {
+		p.cache = map[int64]gitObject{}
+	}
+	p.cache[offset] = object
+	return object, nil
+}
+
+func (p *gitPack) inflate(offset, size int64) ([]byte, error) {
+	reader, err := zlib.NewReader(io.NewSectionReader(p.reader, offset, p.size-offset))
+	if err != nil {
+		return nil, fmt.Errorf("inflate git pack %s object: %w", filepath.Base(p.path), err)
+	}
+	defer func() { _ = reader.Close() }()
+	data, err := io.ReadAll(io.LimitReader(reader, size+1))
+	if err != nil {
+		return nil, fmt.Errorf("inflate git pack %s object: %w", filepath.Base(p.path), err)
+	}
+	if int64(len(data)) != size {
+		return nil, fmt.Errorf("git pack %s object size mismatch", filepath.Base(p.path))
+	}
+	return data, nil
+}
+
+func gitPackKindName(kind int) string {
+	switch kind {
+	case gitPackCommit:
+		return "commit"
+	case gitPackTree:
+		return "tree"
+	case gitPackBlob:
+		return "blob"
+	default:
+		return "tag"
+	}
+}
+
+// applyGitDelta rebuilds an object from its base and a git delta stream of
+// copy and insert instructions.
+func applyGitDelta(base, delta []byte) ([]byte, error) {
+	pos := 0
+	readSize := func() (int64, error) {
+		var value int64
+		shift := uint(0)
+		for {
+			if pos >= len(delta) || shift > 56 {
+				return 0, errors.New("corrupt delta header")
+			}
+			b := delta[pos]
+			pos++
+			value |= int64(b&0x7f) << shift
+			shift += 7
+			if b&0x80 == 0 {
+				return value, nil
+			}
+		}
+	}
+	baseSize, err := readSize()
+	if err != nil {
+		return nil, err
+	}
+	if baseSize != int64(len(base)) {
+		return nil, errors.New("delta base size mismatch")
+	}
+	resultSize, err := readSize()
+	if err != nil {
+		return nil, err
+	}
+	if resultSize > maxGitObjectBytes {
+		return nil, fmt.Errorf("delta result exceeds the %d-byte limit", maxGitObjectBytes)
+	}
+
+	out := make([]byte, 0, resultSize)
+	for pos < len(delta) {
+		cmd := delta[pos]
+		pos++
+		switch {
+		case cmd&0x80 != 0:
+			var copyOffset, copySize int64
+			for bit := uint(0); bit < 4; bit++ {
+				if cmd&(1<<bit) != 0 {
+					if pos >= len(delta) {
+						return nil, errors.New("corrupt delta copy instruction")
+					}
+					copyOffset |= int64(delta[pos]) << (8 * bit)
+					pos++
+				}
+			}
+			for bit := uint(0); bit < 3; bit++ {
+				if cmd&(1<<(4+bit)) != 0 {
+					if pos >= len(delta) {
+						return nil, errors.New("corrupt delta copy instruction")
+					}
+					copySize |= int64(delta[pos]) << (8 * bit)
+					pos++
+				}
+			}
+			if copySize == 0 {
+				copySize = 0x10000
+			}
+			if copyOffset+copySize > int64(len(base)) {
+				return nil, errors.New("delta copy exceeds base object")
+			}
+			out = append(out, base[copyOffset:copyOffset+copySize]...)
+		case cmd != 0:
+			end := pos + int(cmd)
+			if end > len(delta) {
+				return nil, errors.New("corrupt delta insert instruction")
+			}
+			out = append(out, delta[pos:end]...)
+			pos = end
+		default:
+			return nil, errors.New("corrupt delta: reserved instruction")
+		}
+		if int64(len(out)) > resultSize {
+			return nil, errors.New("delta result size mismatch")
+		}
+	}
+	if int64(len(out)) != resultSize {
+		return nil, errors.New("delta result size mismatch")
+	}
+	return out, nil
+}
@@ -0,0 +1,199 @@
+package local
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Clyra-AI/wrkr/core/source"
+	"github.com/Clyra-AI/wrkr/core/source/github"
+	"github.com/Clyra-AI/wrkr/core/workflowloc"
+)
+
+const (
+	// SourceGitRef marks repos materialized from a git ref instead of the
+	// working tree.
+	SourceGitRef = "local_git_ref"
+
+	maxGitRefBlobBytes = 10 << 20
+	maxGitTreeDepth    = 64
+)
+
+// RefOptions controls git ref acquisition for one local repository.
+type RefOptions struct {
+	// MaterializedRoot receives the sparse tree; it must be a scan-managed
+	// directory because the repo subdirectory is replaced on every call.
+	MaterializedRoot string
+	// AllowSourceMaterialization permits broad source-code extension
+	// materialization for explicit deep scans, matching hosted acquisition.
+	AllowSourceMaterialization bool
+	Progress                   ProgressReporter
+}
+
+// AcquireRef resolves ref in the repository at root and materializes the
+// detector-relevant files of that commit under opts.MaterializedRoot. Objects
+// are read directly from .git, so the working tree, index, and HEAD are never
+// touched and uncommitted changes are ignored.
+func AcquireRef(ctx context.Context, root, ref string, opts RefOptions) (source.RepoManifest, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	root = strings.TrimSpace(root)
+	if root == "" {
+		return source.RepoManifest{}, fmt.Errorf("path target is required")
+	}
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return source.RepoManifest{}, errors.New("git ref is required")
+	}
+	if strings.TrimSpace(opts.MaterializedRoot) == "" {
+		return source.RepoManifest{}, errors.New("materialized source root is required for git ref acquisition")
+	}
+	root = filepath.Clean(root)
+	info, err := os.Stat(root)
+	if err != nil {
+		return source.RepoManifest{}, fmt.Errorf("read path target: %w", err)
+	}
+	if !info.IsDir() {
+		return source.RepoManifest{}, fmt.Errorf("path target must be a directory: %s", root)
+	}
+
+	repo, err := openGitRepository(root)
+	if err != nil {
+		return source.RepoManifest{}, fmt.Errorf("--ref requires --path to name a git repository: %w", err)
+	}
+	defer func() { _ = repo.Close() }()
+	commit, err := repo.resolveRevision(ref)
+	if err != nil {
+		return source.RepoManifest{}, err
+	}
+	manifest := repoManifestForRoot(root)
+	repoRoot, files, err := materializeGitCommit(ctx, repo, commit, opts.MaterializedRoot, manifest.Repo, opts.AllowSourceMaterialization)
+	if err != nil {
+		return source.RepoManifest{}, err
+	}
+
+	manifest.ScanRoot = filepath.ToSlash(repoRoot)
+	manifest.Source = SourceGitRef
+	manifest.Ref = ref
+	manifest.Commit = commit
+	manifest.ContentStatus = source.RepoContentStatusAvailable
+	if files == 0 {
+		manifest.ContentStatus = source.RepoContentStatusEmpty
+	}
+	emitPathProgress(opts.Progress, root, []source.RepoManifest{manifest})
+	return manifest, nil
+}
+
+// materializeGitCommit replaces materializedRoot/repoName with the
+// detector-relevant files of commit and returns the repo root and the number
+// of regular files in the commit tree.
+func materializeGitCommit(ctx context.Context, repo *gitRepository, commit, materializedRoot, repoName string, allowSourceMaterialization bool) (string, int, error) {
+	tree, err := repo.commitTree(commit)
+	if err != nil {
+		return "", 0, err
+	}
+	repoRoot, err := safeMaterializedJoin(materializedRoot, repoName)
+	if err != nil {
+		return "", 0, fmt.Errorf("materialize repo root: %w", err)
+	}
+	if err := os.RemoveAll(repoRoot); err != nil {
+		return "", 0, fmt.Errorf("clean materialized repo root: %w", err)
+	}
+	if err := os.MkdirAll(repoRoot, 0o750); err != nil {
+		return "", 0, fmt.Errorf("create materialized repo root: %w", err)
+	}
+
+	files := 0
+	err = walkGitTree(ctx, repo, tree, "", 0, func(rel, id string) error {
+		files++
+		if !shouldMaterializeGitRefPath(rel, allowSourceMaterialization) {
+			return nil
+		}
+		dest, pathErr := safeMaterializedJoin(repoRoot, rel)
+		if pathErr != nil {
+			return pathErr
+		}
+		object, readErr := repo.readObject(id)
+		if readErr != nil {
+			return fmt.Errorf("read %s at %s: %w", rel, commit, readErr)
+		}
+		if object.kind != "blob" {
+			return fmt.Errorf("read %s at %s: object is a %s, not a blob", rel, commit, object.kind)
+		}
+		if len(object.data) > maxGitRefBlobBytes {
+			return fmt.Errorf("read %s at %s: blob exceeds the %d-byte limit", rel, commit, maxGitRefBlobBytes)
+		}
+		if err := os.MkdirAll(filepath.Dir(dest), 0o750); err != nil {
+			return fmt.Errorf("create materialized parent: %w", err)
+		}
+		if err := os.WriteFile(dest, object.data, 0o600); err != nil {
+			return fmt.Errorf("write materialized file %s: %w", rel, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", 0, err
+	}
+	return repoRoot, files, nil
+}
+
+// shouldMaterializeGitRefPath reuses the hosted sparse predicates and adds
+// every supported CI definition, since a local repo may target any CI host.
+func shouldMaterializeGitRefPath(rel string, allowSourceMaterialization bool) bool {
+	if workflowloc.IsCIWorkflow(rel) {
+		return true
+	}
+	return github.ShouldMaterializePath(rel, allowSourceMaterialization)
+}
+
+// walkGitTree visits every regular file in tree order. Symlinks and
+// submodules are skipped, matching hosted sparse acquisition.
+func walkGitTree(ctx context.Context, repo *gitRepository, treeID, prefix string, depth int, visit func(rel, id string) error) error {
+	if depth > maxGitTreeDepth {
+		return fmt.Errorf("git tree %s exceeds the %d-level depth limit", prefix, maxGitTreeDepth)
+	}
+	entries, err := repo.readTree(treeID)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		if entry.name == "." || entry.name == ".." || strings.ContainsAny(entry.name, `/\`) {
+			return fmt.Errorf("refusing unsafe git tree entry %q", prefix+entry.name)
+		}
+		if strings.EqualFold(entry.name, ".git") {
+			continue
+		}
+		rel := prefix + entry.name
+		switch entry.mode {
+		case "40000", "040000":
+			if err := walkGitTree(ctx, repo, entry.id, rel+"/", depth+1, visit); err != nil {
+				return err
+			}
+		case "100644", "100755", "100664":
+			if err := visit(rel, entry.id); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func safeMaterializedJoin(root, rel string) (string, error) {
+	cleanRoot := filepath.Clean(root)
+	cleanRel := filepath.Clean(filepath.FromSlash(rel))
+	if cleanRel == "." || !filepath.IsLocal(cleanRel) {
+		return "", fmt.Errorf("refusing to materialize path outside root: %s", rel)
+	}
+	target := filepath.Join(cleanRoot, cleanRel)
+	if !strings.HasPrefix(target, cleanRoot+string(os.PathSeparator)) {
+		return "", fmt.Errorf("refusing to materialize path outside root: %s", rel)
+	}
+	return target, nil
+}
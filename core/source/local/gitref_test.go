@@ -0,0 +1,202 @@
+package local
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Clyra-AI/wrkr/core/source"
+)
+
+func TestAcquireRefReadsLooseObjectsAtTaggedCommit(t *testing.T) {
+	t.Parallel()
+
+	repoRoot, v1, _ := newGitRefFixture(t)
+	materialized := t.TempDir()
+
+	manifest, err := AcquireRef(context.Background(), repoRoot, "v1", RefOptions{MaterializedRoot: materialized})
+	if err != nil {
+		t.Fatalf("acquire ref: %v", err)
+	}
+	if manifest.Commit != v1 || manifest.Ref != "v1" {
+		t.Fatalf("expected annotated tag to resolve to %s, got %+v", v1, manifest)
+	}
+	if manifest.Source != SourceGitRef || manifest.Location != filepath.ToSlash(repoRoot) {
+		t.Fatalf("unexpected manifest identity: %+v", manifest)
+	}
+	if manifest.ContentStatus != source.RepoContentStatusAvailable {
+		t.Fatalf("expected available content status, got %q", manifest.ContentStatus)
+	}
+
+	scanRoot := filepath.FromSlash(manifest.ScanRoot)
+	assertFileContent(t, filepath.Join(scanRoot, "AGENTS.md"), agentsContent("v1"))
+	assertFileContent(t, filepath.Join(scanRoot, ".codex", "config.toml"), "sandbox_mode = \"read-only\"\n")
+	assertFileContent(t, filepath.Join(scanRoot, ".gitlab-ci.yml"), "stages: [test]\n")
+	for _, rel := range []string{".mcp.json", "src/main.py", "docs/notes.txt", "LOCAL_ONLY.md"} {
+		if _, err := os.Stat(filepath.Join(scanRoot, filepath.FromSlash(rel))); !os.IsNotExist(err) {
+			t.Fatalf("expected %s to be absent from the v1 sparse tree, got %v", rel, err)
+		}
+	}
+}
+
+func TestAcquireRefReadsPackedObjectsAndRefs(t *testing.T) {
+	t.Parallel()
+
+	repoRoot, v1, v2 := newGitRefFixture(t)
+	runGitFixture(t, repoRoot, "gc", "--quiet", "--aggressive", "--prune=now")
+	runGitFixture(t, repoRoot, "pack-refs", "--all", "--prune")
+	if entries, err := os.ReadDir(filepath.Join(repoRoot, ".git", "objects", "pack")); err != nil || len(entries) == 0 {
+		t.Fatalf("expected packed objects after gc, got %v", err)
+	}
+
+	for _, tc := range []struct {
+		ref    string
+		commit string
+		agents string
+	}{
+		{ref: "main", commit: v2, agents: agentsContent("v2")},
+		{ref: "refs/tags/v1", commit: v1, agents: agentsContent("v1")},
+		{ref: v1[:10], commit: v1, agents: agentsContent("v1")},
+		{ref: strings.ToUpper(v2), commit: v2, agents: agentsContent("v2")},
+	} {
+		manifest, err := AcquireRef(context.Background(), repoRoot, tc.ref, RefOptions{MaterializedRoot: t.TempDir()})
+		if err != nil {
+			t.Fatalf("acquire packed ref %s: %v", tc.ref, err)
+		}
+		if manifest.Commit != tc.commit {
+			t.Fatalf("expected %s to resolve to %s, got %s", tc.ref, tc.commit, manifest.Commit)
+		}
+		assertFileContent(t, filepath.Join(filepath.FromSlash(manifest.ScanRoot), "AGENTS.md"), tc.agents)
+	}
+}
+
+func TestAcquireRefHonorsSourceMaterializationOption(t *testing.T) {
+	t.Parallel()
+
+	repoRoot, _, _ := newGitRefFixture(t)
+	manifest, err := AcquireRef(context.Background(), repoRoot, "main", RefOptions{MaterializedRoot: t.TempDir(), AllowSourceMaterialization: true})
+	if err != nil {
+		t.Fatalf("acquire ref: %v", err)
+	}
+	assertFileContent(t, filepath.Join(filepath.FromSlash(manifest.ScanRoot), "src", "main.py"), "print('hi')\n")
+	assertFileContent(t, filepath.Join(filepath.FromSlash(manifest.ScanRoot), ".mcp.json"), "{\"mcpServers\":{}}\n")
+}
+
+func TestAcquireRefRejectsUnsupportedInputs(t *testing.T) {
+	t.Parallel()
+
+	repoRoot, _, _ := newGitRefFixture(t)
+	for _, ref := range []string{"main~1", "HEAD^{tree}", "main..v1", "does-not-exist"} {
+		if _, err := AcquireRef(context.Background(), repoRoot, ref, RefOptions{MaterializedRoot: t.TempDir()}); err == nil {
+			t.Fatalf("expected ref %q to be rejected", ref)
+		}
+	}
+	if _, err := AcquireRef(context.Background(), t.TempDir(), "main", RefOptions{MaterializedRoot: t.TempDir()}); err == nil || !strings.Contains(err.Error(), "git repository") {
+		t.Fatalf("expected non-git path to be rejected, got %v", err)
+	}
+	if _, err := AcquireRef(context.Background(), repoRoot, "main", RefOptions{}); err == nil {
+		t.Fatal("expected missing materialized root to be rejected")
+	}
+}
+
+func TestApplyGitDeltaRejectsOutOfRangeCopy(t *testing.T) {
+	t.Parallel()
+
+	// base size 3, result size 4, copy offset 0 size 4.
+	if _, err := applyGitDelta([]byte("abc"), []byte{0x03, 0x04, 0x90, 0x04}); err == nil {
+		t.Fatal("expected copy beyond base to fail")
+	}
+	got, err := applyGitDelta([]byte("abc"), []byte{0x03, 0x05, 0x90, 0x02, 0x03, 'x', 'y', 'z'})
+	if err != nil || string(got) != "abxyz" {
+		t.Fatalf("unexpected delta result %q err=%v", got, err)
+	}
+}
+
+// newGitRefFixture builds a repo whose v1 tag and main branch differ, with an
+// uncommitted working-tree file that ref scans must ignore.
+func newGitRefFixture(t *testing.T) (string, string, string) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is required to build ref scan fixtures")
+	}
+
+	repoRoot := filepath.Join(t.TempDir(), "incident-repo")
+	if err := os.MkdirAll(repoRoot, 0o755); err != nil {
+		t.Fatalf("mkdir repo: %v", err)
+	}
+	runGitFixture(t, repoRoot, "init", "--quiet", "--initial-branch=main")
+	writeFixtureFile(t, repoRoot, "AGENTS.md", agentsContent("v1"))
+	writeFixtureFile(t, repoRoot, ".codex/config.toml", "sandbox_mode = \"read-only\"\n")
+	writeFixtureFile(t, repoRoot, ".gitlab-ci.yml", "stages: [test]\n")
+	writeFixtureFile(t, repoRoot, "src/main.py", "print('hi')\n")
+	writeFixtureFile(t, repoRoot, "docs/notes.txt", "notes\n")
+	runGitFixture(t, repoRoot, "add", "-A")
+	runGitFixture(t, repoRoot, "commit", "--quiet", "-m", "v1")
+	runGitFixture(t, repoRoot, "tag", "-a", "v1", "-m", "release v1")
+	v1 := gitFixtureOutput(t, repoRoot, "rev-parse", "HEAD")
+
+	writeFixtureFile(t, repoRoot, "AGENTS.md", agentsContent("v2"))
+	writeFixtureFile(t, repoRoot, ".mcp.json", "{\"mcpServers\":{}}\n")
+	runGitFixture(t, repoRoot, "add", "-A")
+	runGitFixture(t, repoRoot, "commit", "--quiet", "-m", "v2")
+	v2 := gitFixtureOutput(t, repoRoot, "rev-parse", "HEAD")
+
+	writeFixtureFile(t, repoRoot, "LOCAL_ONLY.md", "uncommitted\n")
+	return repoRoot, v1, v2
+}
+
+// agentsContent is long enough for git gc to store the second revision as a
+// delta against the first.
+func agentsContent(version string) string {
+	return strings.Repeat("Agents must use the approved MCP gateway for every tool call.\n", 40) + "version: " + version + "\n"
+}
+
+func writeFixtureFile(t *testing.T, root, rel, content string) {
+	t.Helper()
+	path := filepath.Join(root, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir %s: %v", rel, err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write %s: %v", rel, err)
+	}
+}
+
+func assertFileContent(t *testing.T, path, expected string) {
+	t.Helper()
+	payload, err := os.ReadFile(path) // #nosec G304 -- test fixture path.
+	if err != nil {
+		t.Fatalf("read %s: %v", path, err)
+	}
+	if string(payload) != expected {
+		t.Fatalf("unexpected content for %s: %q", path, payload)
+	}
+}
+
+func runGitFixture(t *testing.T, repoRoot string, args ...string) {
+	t.Helper()
+	gitFixtureOutput(t, repoRoot, args...)
+}
+
+func gitFixtureOutput(t *testing.T, repoRoot string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-C", repoRoot, "-c", "commit.gpgsign=false", "-c", "tag.gpgsign=false"}, args...)...) // #nosec G204 -- deterministic test fixture setup.
+	cmd.Env = append(os.Environ(),
+		"GIT_CONFIG_NOSYSTEM=1",
+		"GIT_CONFIG_GLOBAL="+os.DevNull,
+		"GIT_AUTHOR_NAME=wrkr",
+		"GIT_AUTHOR_EMAIL=wrkr@example.com",
+		"GIT_COMMITTER_NAME=wrkr",
+		"GIT_COMMITTER_EMAIL=wrkr@example.com",
+		"GIT_AUTHOR_DATE=2026-01-02T03:04:05Z",
+		"GIT_COMMITTER_DATE=2026-01-02T03:04:05Z",
+	)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v failed: %v\n%s", args, err, output)
+	}
+	return strings.TrimSpace(string(output))
+}
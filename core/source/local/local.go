@@ -17,17 +17,29 @@ import (
 var repoRootSignals = []string{
 	".agents",
 	".claude",
+	".claude-plugin",
 	".codex",
 	".cursor",
+	".gemini",
 	".git",
 	".github",
 	".mcp.json",
 	".vscode/mcp.json",
+	".windsurf",
+	".roo",
+	".continue",
+	".amazonq",
+	".kiro",
 	".cursorrules",
+	".windsurfrules",
+	".clinerules",
+	".roomodes",
+	".aider.conf.yml",
 	"AGENTS.md",
 	"AGENTS.override.md",
 	"CLAUDE.md",
 	"Cargo.toml",
+	"GEMINI.md",
 	"Gemfile",
 	"Jenkinsfile",
 	"build.gradle",
@@ -409,6 +409,40 @@ func TestAcquireMaterializedResumeReusesCompletedRepos(t *testing.T) {
 	}
 }
 
+func TestAcquireMaterializedResumeUsesProviderCheckpoint(t *testing.T) {
+	t.Parallel()
+
+	tmp := t.TempDir()
+	statePath := filepath.Join(tmp, "state.json")
+	materializedRoot := filepath.Join(tmp, "materialized-sources")
+	opts := AcquireMaterializedOptions{
+		StatePath:        statePath,
+		MaterializedRoot: materializedRoot,
+		WorkerCount:      1,
+		Provider:         "gitlab",
+	}
+	first := &trackingMaterializer{t: t, root: materializedRoot}
+	if _, _, err := AcquireMaterialized(context.Background(), "acme/platform", fakeLister{repos: []string{"acme/platform/a"}}, first, opts); err != nil {
+		t.Fatalf("initial acquire materialized: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmp, checkpointRootName, "gitlab-acme_platform.json")); err != nil {
+		t.Fatalf("expected provider-scoped checkpoint file: %v", err)
+	}
+
+	opts.Resume = true
+	second := &trackingMaterializer{t: t, root: materializedRoot}
+	repos, _, err := AcquireMaterialized(context.Background(), "acme/platform", fakeLister{repos: []string{"acme/platform/a"}}, second, opts)
+	if err != nil {
+		t.Fatalf("resume acquire materialized: %v", err)
+	}
+	if second.callCount != 0 {
+		t.Fatalf("expected resume to reuse completed repos, got %d materializer calls", second.callCount)
+	}
+	if len(repos) != 1 || repos[0].Location != "gitlab://acme/platform/a" || repos[0].Source != "gitlab_repo_materialized" {
+		t.Fatalf("expected resumed gitlab manifest, got %+v", repos)
+	}
+}
+
 func TestAcquireMaterializedResumeProgressContinuesCompletedCount(t *testing.T) {
 	t.Parallel()
 
@@ -79,6 +79,15 @@ type checkpointState struct {
 	MaterializedRoot string   `json:"materialized_root"`
 	Repos            []string `json:"repos"`
 	CompletedRepos   []string `json:"completed_repos"`
+	// Revisions records the default-branch commit each completed repo was
+	// materialized at, so a later incremental scan can reuse unchanged repos.
+	Revisions map[string]checkpointRevision `json:"revisions,omitempty"`
+}
+
+type checkpointRevision struct {
+	Commit        string `json:"commit"`
+	Source        string `json:"source"`
+	ContentStatus string `json:"content_status,omitempty"`
 }
 
 type checkpointManager struct {
@@ -373,7 +382,17 @@ func (m *checkpointManager) completedSet() map[string]struct{} {
 }
 
 func (m *checkpointManager) markCompleted(repo string) error {
+	return m.markCompletedRevision(repo, checkpointRevision{})
+}
+
+func (m *checkpointManager) markCompletedRevision(repo string, revision checkpointRevision) error {
 	m.mu.Lock()
+	if strings.TrimSpace(revision.Commit) != "" {
+		if m.state.Revisions == nil {
+			m.state.Revisions = map[string]checkpointRevision{}
+		}
+		m.state.Revisions[repo] = revision
+	}
 	for _, existing := range m.state.CompletedRepos {
 		if existing == repo {
 			m.mu.Unlock()
@@ -385,6 +404,41 @@ func (m *checkpointManager) markCompleted(repo string) error {
 	return m.save()
 }
 
+func (m *checkpointManager) revision(repo string) checkpointRevision {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.state.Revisions[repo]
+}
+
+// loadPreviousRevisions returns the commits recorded by the last completed
+// scan of the same target and materialized root. A missing or unreadable
+// checkpoint only means there is no baseline; unsafe checkpoint files still
+// fail closed.
+func loadPreviousRevisions(path string, org string, materializedRoot string) (map[string]checkpointRevision, error) {
+	manager, err := loadCheckpointManager(path)
+	if err != nil {
+		if IsCheckpointInputError(err) {
+			return map[string]checkpointRevision{}, nil
+		}
+		return nil, err
+	}
+	state := manager.state
+	if state.Version != checkpointVersion ||
+		state.Org != strings.TrimSpace(org) ||
+		state.MaterializedRoot != filepath.Clean(strings.TrimSpace(materializedRoot)) {
+		return map[string]checkpointRevision{}, nil
+	}
+	out := make(map[string]checkpointRevision, len(state.CompletedRepos))
+	for _, repo := range state.CompletedRepos {
+		revision, ok := state.Revisions[repo]
+		if !ok || strings.TrimSpace(revision.Commit) == "" {
+			continue
+		}
+		out[repo] = revision
+	}
+	return out, nil
+}
+
 func uniqueSortedStrings(values []string) []string {
 	set := map[string]struct{}{}
 	for _, value := range values {
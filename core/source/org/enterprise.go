@@ -0,0 +1,115 @@
+package org
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Clyra-AI/wrkr/core/source"
+)
+
+// OrgLister enumerates the member organizations of an enterprise account.
+type OrgLister interface {
+	ListEnterpriseOrgs(ctx context.Context, enterprise string) ([]string, error)
+}
+
+// AcquireEnterpriseOptions extends materialized org acquisition with the
+// caller's policy for org listing failures.
+type AcquireEnterpriseOptions struct {
+	AcquireMaterializedOptions
+	// IsFatal reports whether an org listing error must abort the whole
+	// enterprise scan, such as exhausted rate limits. Other listing errors are
+	// recorded on that org's result and the remaining orgs continue.
+	IsFatal func(error) bool
+}
+
+// EnterpriseOrgResult is the acquisition outcome for one member org.
+type EnterpriseOrgResult struct {
+	Org      string
+	Repos    []source.RepoManifest
+	Failures []source.RepoFailure
+	// Err is set when the org's repositories could not be listed.
+	Err error
+}
+
+// AcquireEnterpriseMaterialized enumerates every member org of enterprise,
+// lists each org's repos, checks one request budget for the combined repo
+// set, and materializes all repos under a single enterprise checkpoint.
+// Results are returned per org in lexical order so one failed org never
+// masks the others.
+func AcquireEnterpriseMaterialized(
+	ctx context.Context,
+	enterprise string,
+	orgLister OrgLister,
+	lister RepoLister,
+	materializer RepoMaterializer,
+	opts AcquireEnterpriseOptions,
+) ([]EnterpriseOrgResult, error) {
+	enterprise = strings.TrimSpace(enterprise)
+	if enterprise == "" {
+		return nil, errors.New("enterprise slug is required")
+	}
+	orgs, err := orgLister.ListEnterpriseOrgs(ctx, enterprise)
+	if err != nil {
+		return nil, err
+	}
+	orgs = uniqueSortedStrings(orgs)
+	if len(orgs) == 0 {
+		return nil, fmt.Errorf("enterprise %s has no member organizations visible to this token", enterprise)
+	}
+
+	results := make([]EnterpriseOrgResult, 0, len(orgs))
+	byOwner := map[string]int{}
+	allRepos := make([]string, 0)
+	for _, orgName := range orgs {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		byOwner[strings.ToLower(orgName)] = len(results)
+		result := EnterpriseOrgResult{Org: orgName}
+		repoNames, listErr := lister.ListOrgRepos(ctx, orgName)
+		if listErr != nil {
+			if errors.Is(listErr, context.Canceled) || errors.Is(listErr, context.DeadlineExceeded) {
+				return nil, listErr
+			}
+			if opts.IsFatal != nil && opts.IsFatal(listErr) {
+				return nil, listErr
+			}
+			result.Err = listErr
+		}
+		allRepos = append(allRepos, repoNames...)
+		results = append(results, result)
+	}
+	allRepos = opts.Shard.Filter(uniqueSortedStrings(allRepos))
+	if budgetErr := ensureRequestBudget(lister, len(allRepos)); budgetErr != nil {
+		return nil, budgetErr
+	}
+
+	label := "enterprise:" + enterprise
+	repos, failures, err := materializeCheckpointed(ctx, label, checkpointKey(opts.Provider, "enterprise-"+enterprise), allRepos, materializer, opts.AcquireMaterializedOptions)
+	if err != nil {
+		return nil, err
+	}
+	resultFor := func(repo string) *EnterpriseOrgResult {
+		owner, _, _ := strings.Cut(strings.TrimSpace(repo), "/")
+		idx, ok := byOwner[strings.ToLower(owner)]
+		if !ok {
+			idx = len(results)
+			byOwner[strings.ToLower(owner)] = idx
+			results = append(results, EnterpriseOrgResult{Org: owner})
+		}
+		return &results[idx]
+	}
+	for _, repo := range repos {
+		result := resultFor(repo.Repo)
+		result.Repos = append(result.Repos, repo)
+	}
+	for _, failure := range failures {
+		result := resultFor(failure.Repo)
+		result.Failures = append(result.Failures, failure)
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].Org < results[j].Org })
+	return results, nil
+}
@@ -0,0 +1,155 @@
+package org
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type fakeEnterprise struct {
+	orgs     []string
+	repos    map[string][]string
+	listErrs map[string]error
+	budget   int
+	budgeted []int
+}
+
+func (f *fakeEnterprise) ListEnterpriseOrgs(_ context.Context, _ string) ([]string, error) {
+	return f.orgs, nil
+}
+
+func (f *fakeEnterprise) ListOrgRepos(_ context.Context, org string) ([]string, error) {
+	if err := f.listErrs[org]; err != nil {
+		return nil, err
+	}
+	return f.repos[org], nil
+}
+
+func (f *fakeEnterprise) EnsureRequestBudget(repoCount int) error {
+	f.budgeted = append(f.budgeted, repoCount)
+	if f.budget > 0 && repoCount > f.budget {
+		return errors.New("budget exceeded")
+	}
+	return nil
+}
+
+func TestAcquireEnterpriseMaterializedKeepsPerOrgResultsSeparate(t *testing.T) {
+	t.Parallel()
+
+	tmp := t.TempDir()
+	materializedRoot := filepath.Join(tmp, "materialized-sources")
+	enterprise := &fakeEnterprise{
+		orgs: []string{"zeta", "acme", "broken", "acme"},
+		repos: map[string][]string{
+			"acme": {"acme/b", "acme/a"},
+			"zeta": {"zeta/api"},
+		},
+		listErrs: map[string]error{"broken": errors.New("forbidden")},
+	}
+	materializer := &trackingMaterializer{t: t, root: materializedRoot, failRepo: "zeta/api"}
+
+	results, err := AcquireEnterpriseMaterialized(context.Background(), "big-co", enterprise, enterprise, materializer, AcquireEnterpriseOptions{
+		AcquireMaterializedOptions: AcquireMaterializedOptions{
+			StatePath:        filepath.Join(tmp, "state.json"),
+			MaterializedRoot: materializedRoot,
+			WorkerCount:      2,
+		},
+	})
+	if err != nil {
+		t.Fatalf("acquire enterprise: %v", err)
+	}
+	if len(enterprise.budgeted) != 1 || enterprise.budgeted[0] != 3 {
+		t.Fatalf("expected one shared budget check for 3 repos, got %v", enterprise.budgeted)
+	}
+	if len(results) != 3 || results[0].Org != "acme" || results[1].Org != "broken" || results[2].Org != "zeta" {
+		t.Fatalf("expected sorted per-org results, got %+v", results)
+	}
+	if len(results[0].Repos) != 2 || results[0].Repos[0].Repo != "acme/a" || len(results[0].Failures) != 0 || results[0].Err != nil {
+		t.Fatalf("unexpected acme result %+v", results[0])
+	}
+	if results[1].Err == nil || len(results[1].Repos) != 0 {
+		t.Fatalf("expected broken org listing error to stay on its own result, got %+v", results[1])
+	}
+	if len(results[2].Repos) != 0 || len(results[2].Failures) != 1 || results[2].Failures[0].Repo != "zeta/api" {
+		t.Fatalf("expected zeta repo failure, got %+v", results[2])
+	}
+	if _, err := os.Stat(filepath.Join(tmp, checkpointRootName, "enterprise-big-co.json")); err != nil {
+		t.Fatalf("expected single enterprise checkpoint file: %v", err)
+	}
+}
+
+func TestAcquireEnterpriseMaterializedResumeUsesSharedCheckpoint(t *testing.T) {
+	t.Parallel()
+
+	tmp := t.TempDir()
+	materializedRoot := filepath.Join(tmp, "materialized-sources")
+	enterprise := &fakeEnterprise{
+		orgs:  []string{"acme", "zeta"},
+		repos: map[string][]string{"acme": {"acme/a"}, "zeta": {"zeta/api"}},
+	}
+	opts := AcquireEnterpriseOptions{AcquireMaterializedOptions: AcquireMaterializedOptions{
+		StatePath:        filepath.Join(tmp, "state.json"),
+		MaterializedRoot: materializedRoot,
+		WorkerCount:      1,
+	}}
+	first := &trackingMaterializer{t: t, root: materializedRoot}
+	if _, err := AcquireEnterpriseMaterialized(context.Background(), "big-co", enterprise, enterprise, first, opts); err != nil {
+		t.Fatalf("initial acquire enterprise: %v", err)
+	}
+
+	opts.Resume = true
+	second := &trackingMaterializer{t: t, root: materializedRoot}
+	results, err := AcquireEnterpriseMaterialized(context.Background(), "big-co", enterprise, enterprise, second, opts)
+	if err != nil {
+		t.Fatalf("resume acquire enterprise: %v", err)
+	}
+	if second.callCount != 0 {
+		t.Fatalf("expected resume to reuse completed repos, got %d materializer calls", second.callCount)
+	}
+	if len(results) != 2 || len(results[0].Repos) != 1 || len(results[1].Repos) != 1 {
+		t.Fatalf("unexpected resumed results %+v", results)
+	}
+}
+
+func TestAcquireEnterpriseMaterializedFailsClosed(t *testing.T) {
+	t.Parallel()
+
+	tmp := t.TempDir()
+	opts := AcquireEnterpriseOptions{
+		AcquireMaterializedOptions: AcquireMaterializedOptions{
+			StatePath:        filepath.Join(tmp, "state.json"),
+			MaterializedRoot: filepath.Join(tmp, "materialized-sources"),
+		},
+		IsFatal: func(err error) bool { return strings.Contains(err.Error(), "rate limit") },
+	}
+	materializer := &trackingMaterializer{t: t, root: opts.MaterializedRoot}
+
+	rateLimited := &fakeEnterprise{
+		orgs:     []string{"acme", "zeta"},
+		repos:    map[string][]string{"acme": {"acme/a"}},
+		listErrs: map[string]error{"zeta": errors.New("rate limit exhausted")},
+	}
+	if _, err := AcquireEnterpriseMaterialized(context.Background(), "big-co", rateLimited, rateLimited, materializer, opts); err == nil || !strings.Contains(err.Error(), "rate limit") {
+		t.Fatalf("expected fatal org listing error, got %v", err)
+	}
+
+	overBudget := &fakeEnterprise{
+		orgs:   []string{"acme", "zeta"},
+		repos:  map[string][]string{"acme": {"acme/a", "acme/b"}, "zeta": {"zeta/api"}},
+		budget: 2,
+	}
+	if _, err := AcquireEnterpriseMaterialized(context.Background(), "big-co", overBudget, overBudget, materializer, opts); err == nil || !strings.Contains(err.Error(), "budget") {
+		t.Fatalf("expected shared budget error, got %v", err)
+	}
+
+	empty := &fakeEnterprise{}
+	if _, err := AcquireEnterpriseMaterialized(context.Background(), "big-co", empty, empty, materializer, opts); err == nil || !strings.Contains(err.Error(), "no member organizations") {
+		t.Fatalf("expected enterprise without orgs to fail, got %v", err)
+	}
+	if materializer.callCount != 0 {
+		t.Fatalf("expected no materialization after fail-closed errors, got %d calls", materializer.callCount)
+	}
+}
@@ -0,0 +1,131 @@
+package org
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Clyra-AI/wrkr/core/source"
+)
+
+type fakeIncrementalMaterializer struct {
+	t        *testing.T
+	commits  map[string]string
+	statuses map[string]string
+
+	mu       sync.Mutex
+	previous map[string]source.RepoRevision
+	reused   []string
+}
+
+func (m *fakeIncrementalMaterializer) MaterializeRepo(context.Context, string, string) (source.RepoManifest, error) {
+	m.t.Fatalf("incremental acquisition must not call MaterializeRepo")
+	return source.RepoManifest{}, nil
+}
+
+func (m *fakeIncrementalMaterializer) MaterializeRepoIncremental(_ context.Context, repo, materializedRoot string, previous source.RepoRevision) (source.RepoManifest, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.previous == nil {
+		m.previous = map[string]source.RepoRevision{}
+	}
+	m.previous[repo] = previous
+	location := filepath.Join(materializedRoot, filepath.FromSlash(repo))
+	if err := os.MkdirAll(location, 0o750); err != nil {
+		m.t.Fatalf("mkdir materialized repo %s: %v", repo, err)
+	}
+	commit := m.commits[repo]
+	reused := commit != "" && commit == previous.Commit && previous.Source == "github_repo_materialized"
+	if reused {
+		m.reused = append(m.reused, repo)
+	}
+	return source.RepoManifest{
+		Repo:          repo,
+		Location:      "github://" + repo,
+		ScanRoot:      filepath.ToSlash(location),
+		Source:        "github_repo_materialized",
+		ContentStatus: m.statuses[repo],
+		Commit:        commit,
+	}, reused, nil
+}
+
+func TestAcquireMaterializedIncrementalReusesUnchangedCommits(t *testing.T) {
+	t.Parallel()
+
+	tmp := t.TempDir()
+	materializedRoot := filepath.Join(tmp, "materialized-sources")
+	lister := fakeLister{repos: []string{"acme/a", "acme/b"}}
+	opts := AcquireMaterializedOptions{
+		StatePath:        filepath.Join(tmp, "state.json"),
+		MaterializedRoot: materializedRoot,
+		WorkerCount:      1,
+		Incremental:      true,
+	}
+
+	first := &fakeIncrementalMaterializer{
+		t:        t,
+		commits:  map[string]string{"acme/a": "aaa", "acme/b": "bbb"},
+		statuses: map[string]string{"acme/a": source.RepoContentStatusEmpty, "acme/b": source.RepoContentStatusAvailable},
+	}
+	repos, _, err := AcquireMaterialized(context.Background(), "acme", lister, first, opts)
+	if err != nil {
+		t.Fatalf("initial incremental acquire: %v", err)
+	}
+	if len(first.reused) != 0 || first.previous["acme/a"].Commit != "" {
+		t.Fatalf("expected no baseline on first scan, got reused=%v previous=%v", first.reused, first.previous)
+	}
+	if len(repos) != 2 || repos[0].Commit != "aaa" || repos[1].Commit != "bbb" {
+		t.Fatalf("expected commits on manifests, got %+v", repos)
+	}
+
+	progress := &recordingProgress{}
+	opts.Progress = progress
+	second := &fakeIncrementalMaterializer{t: t, commits: map[string]string{"acme/a": "aaa", "acme/b": "ccc"}}
+	if _, _, err := AcquireMaterialized(context.Background(), "acme", lister, second, opts); err != nil {
+		t.Fatalf("second incremental acquire: %v", err)
+	}
+	if second.previous["acme/a"].Commit != "aaa" || second.previous["acme/b"].Commit != "bbb" {
+		t.Fatalf("expected previous commits from checkpoint, got %v", second.previous)
+	}
+	if second.previous["acme/a"].ContentStatus != source.RepoContentStatusEmpty {
+		t.Fatalf("expected previous content status from checkpoint, got %v", second.previous)
+	}
+	if len(second.reused) != 1 || second.reused[0] != "acme/a" {
+		t.Fatalf("expected only acme/a to be reused, got %v", second.reused)
+	}
+	events := progress.joined()
+	if !strings.Contains(events, "repo=acme/a status=unchanged") || !strings.Contains(events, "repo=acme/b status=ok") {
+		t.Fatalf("expected unchanged and ok progress statuses, got %s", events)
+	}
+
+	third := &fakeIncrementalMaterializer{t: t, commits: map[string]string{"acme/a": "aaa", "acme/b": "ccc"}}
+	opts.Progress = nil
+	opts.MaterializedRoot = filepath.Join(tmp, "other-root")
+	if _, _, err := AcquireMaterialized(context.Background(), "acme", lister, third, opts); err != nil {
+		t.Fatalf("incremental acquire with new root: %v", err)
+	}
+	if third.previous["acme/a"].Commit != "" {
+		t.Fatalf("expected a changed materialized root to discard the baseline, got %v", third.previous)
+	}
+}
+
+func TestAcquireMaterializedIncrementalRequiresIncrementalMaterializer(t *testing.T) {
+	t.Parallel()
+
+	tmp := t.TempDir()
+	materializer := &trackingMaterializer{t: t, root: filepath.Join(tmp, "materialized-sources")}
+	_, _, err := AcquireMaterialized(context.Background(), "acme", fakeLister{repos: []string{"acme/a"}}, materializer, AcquireMaterializedOptions{
+		StatePath:        filepath.Join(tmp, "state.json"),
+		MaterializedRoot: filepath.Join(tmp, "materialized-sources"),
+		Incremental:      true,
+	})
+	if err == nil {
+		t.Fatal("expected incremental acquisition to fail for a materializer without commit pinning")
+	}
+	if materializer.callCount != 0 {
+		t.Fatalf("expected no materialization, got %d calls", materializer.callCount)
+	}
+}
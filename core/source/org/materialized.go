@@ -13,10 +13,19 @@ import (
 	"github.com/Clyra-AI/wrkr/core/source"
 )
 
+const defaultProvider = "github"
+
 type RepoMaterializer interface {
 	MaterializeRepo(ctx context.Context, repo string, materializedRoot string) (source.RepoManifest, error)
 }
 
+// IncrementalMaterializer materializes a repo pinned to its default-branch
+// commit and reuses the previously materialized tree when that commit and
+// source are unchanged. The returned bool reports whether the tree was reused.
+type IncrementalMaterializer interface {
+	MaterializeRepoIncremental(ctx context.Context, repo, materializedRoot string, previous source.RepoRevision) (source.RepoManifest, bool, error)
+}
+
 type RequestBudgeter interface {
 	EnsureRequestBudget(repoCount int) error
 }
@@ -35,6 +44,16 @@ type AcquireMaterializedOptions struct {
 	Resume           bool
 	WorkerCount      int
 	Progress         ProgressReporter
+	// Provider names the hosted source for checkpoint files and resumed repo
+	// manifests. Empty means GitHub, which keeps existing checkpoints valid.
+	Provider string
+	// Incremental reuses retained repos whose default-branch commit matches
+	// the previous completed scan. The materializer must implement
+	// IncrementalMaterializer.
+	Incremental bool
+	// Shard limits acquisition to one deterministic partition of the listed
+	// repos. The zero value acquires every repo.
+	Shard Shard
 }
 
 type materializeJob struct {
@@ -48,6 +67,7 @@ type materializeResult struct {
 	failure  source.RepoFailure
 	fatalErr error
 	repo     string
+	reused   bool
 }
 
 func AcquireMaterialized(
@@ -61,22 +81,56 @@ func AcquireMaterialized(
 	if err != nil {
 		return nil, nil, err
 	}
-	repoNames = uniqueSortedStrings(repoNames)
-	totalRepos := len(repoNames)
+	repoNames = opts.Shard.Filter(uniqueSortedStrings(repoNames))
+	if budgetErr := ensureRequestBudget(lister, len(repoNames)); budgetErr != nil {
+		return nil, nil, budgetErr
+	}
+	return materializeCheckpointed(ctx, org, checkpointKey(opts.Provider, org), repoNames, materializer, opts)
+}
+
+func ensureRequestBudget(lister RepoLister, repoCount int) error {
 	if budgeter, ok := lister.(RequestBudgeter); ok {
-		if budgetErr := budgeter.EnsureRequestBudget(totalRepos); budgetErr != nil {
-			return nil, nil, budgetErr
-		}
+		return budgeter.EnsureRequestBudget(repoCount)
 	}
+	return nil
+}
+
+// materializeCheckpointed materializes repoNames with one checkpoint file
+// identified by key; org labels progress events and the checkpoint target.
+func materializeCheckpointed(
+	ctx context.Context,
+	org string,
+	key string,
+	repoNames []string,
+	materializer RepoMaterializer,
+	opts AcquireMaterializedOptions,
+) (repos []source.RepoManifest, failures []source.RepoFailure, err error) {
+	totalRepos := len(repoNames)
 	if opts.Progress != nil {
 		opts.Progress.RepoDiscovery(org, totalRepos)
 	}
 
-	checkpointFile, err := checkpointPath(opts.StatePath, org)
+	checkpointFile, err := checkpointPath(opts.StatePath, key)
 	if err != nil {
 		return nil, nil, err
 	}
 
+	var incremental IncrementalMaterializer
+	previous := map[string]checkpointRevision{}
+	if opts.Incremental {
+		var ok bool
+		incremental, ok = materializer.(IncrementalMaterializer)
+		if !ok {
+			return nil, nil, fmt.Errorf("incremental scans are not supported for %s repositories", normalizedProvider(opts.Provider))
+		}
+		if !opts.Resume {
+			previous, err = loadPreviousRevisions(checkpointFile, org, opts.MaterializedRoot)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
 	manager := newCheckpointManager(checkpointFile, org, repoNames, opts.MaterializedRoot)
 	if opts.Resume {
 		manager, err = loadCheckpointManager(checkpointFile)
@@ -96,10 +150,13 @@ func AcquireMaterialized(
 	pendingJobs := make([]materializeJob, 0, totalRepos)
 	for idx, repo := range repoNames {
 		if _, ok := completedSet[repo]; ok {
-			manifest, manifestErr := manifestFromCheckpoint(repo, opts.MaterializedRoot)
+			manifest, manifestErr := manifestFromCheckpoint(opts.Provider, repo, opts.MaterializedRoot)
 			if manifestErr != nil {
 				return nil, nil, manifestErr
 			}
+			if revision := manager.revision(repo); revision.Commit != "" && revision.Source == manifest.Source {
+				manifest.Commit = revision.Commit
+			}
 			repos = append(repos, manifest)
 			continue
 		}
@@ -151,7 +208,21 @@ func AcquireMaterialized(
 					continue
 				case <-job.start:
 				}
-				manifest, materializeErr := materializer.MaterializeRepo(ctx, job.repo, opts.MaterializedRoot)
+				var (
+					manifest       source.RepoManifest
+					reused         bool
+					materializeErr error
+				)
+				if incremental != nil {
+					prior := previous[job.repo]
+					manifest, reused, materializeErr = incremental.MaterializeRepoIncremental(ctx, job.repo, opts.MaterializedRoot, source.RepoRevision{
+						Commit:        prior.Commit,
+						Source:        prior.Source,
+						ContentStatus: prior.ContentStatus,
+					})
+				} else {
+					manifest, materializeErr = materializer.MaterializeRepo(ctx, job.repo, opts.MaterializedRoot)
+				}
 				if materializeErr != nil {
 					if errors.Is(materializeErr, context.Canceled) || errors.Is(materializeErr, context.DeadlineExceeded) {
 						cancel()
@@ -164,12 +235,13 @@ func AcquireMaterialized(
 					}
 					continue
 				}
-				if checkpointErr := manager.markCompleted(job.repo); checkpointErr != nil {
+				revision := checkpointRevision{Commit: manifest.Commit, Source: manifest.Source, ContentStatus: manifest.ContentStatus}
+				if checkpointErr := manager.markCompletedRevision(job.repo, revision); checkpointErr != nil {
 					cancel()
 					results <- materializeResult{fatalErr: checkpointErr, repo: job.repo}
 					continue
 				}
-				results <- materializeResult{manifest: manifest, repo: job.repo}
+				results <- materializeResult{manifest: manifest, repo: job.repo, reused: reused}
 			}
 		}()
 	}
@@ -222,7 +294,11 @@ func AcquireMaterialized(
 			repos = append(repos, result.manifest)
 			succeededResults++
 			if opts.Progress != nil {
-				opts.Progress.RepoMaterializeDone(org, succeededResults, failedResults, totalRepos, result.manifest.Repo, "ok")
+				status := "ok"
+				if result.reused {
+					status = "unchanged"
+				}
+				opts.Progress.RepoMaterializeDone(org, succeededResults, failedResults, totalRepos, result.manifest.Repo, status)
 			}
 		}
 	}
@@ -239,7 +315,7 @@ func AcquireMaterialized(
 	return repos, failures, nil
 }
 
-func manifestFromCheckpoint(repo string, materializedRoot string) (source.RepoManifest, error) {
+func manifestFromCheckpoint(provider string, repo string, materializedRoot string) (source.RepoManifest, error) {
 	location := filepath.Join(materializedRoot, filepath.FromSlash(strings.TrimSpace(repo)))
 	info, err := os.Lstat(location)
 	if err != nil {
@@ -265,14 +341,31 @@ func manifestFromCheckpoint(repo string, materializedRoot string) (source.RepoMa
 	if !materializedLocationWithinRoot(canonicalRoot, resolvedLocation) {
 		return source.RepoManifest{}, newCheckpointSafetyError("resume checkpoint repo materialization escapes managed root: %s", location)
 	}
+	provider = normalizedProvider(provider)
 	return source.RepoManifest{
 		Repo:     strings.TrimSpace(repo),
-		Location: "github://" + strings.TrimSpace(repo),
+		Location: provider + "://" + strings.TrimSpace(repo),
 		ScanRoot: filepath.ToSlash(location),
-		Source:   "github_repo_materialized",
+		Source:   provider + "_repo_materialized",
 	}, nil
 }
 
+func normalizedProvider(provider string) string {
+	provider = strings.ToLower(strings.TrimSpace(provider))
+	if provider == "" {
+		return defaultProvider
+	}
+	return provider
+}
+
+func checkpointKey(provider string, org string) string {
+	provider = normalizedProvider(provider)
+	if provider == defaultProvider {
+		return org
+	}
+	return provider + "-" + org
+}
+
 func materializedLocationWithinRoot(root string, candidate string) bool {
 	rel, err := filepath.Rel(root, candidate)
 	if err != nil {
@@ -0,0 +1,86 @@
+package org
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Shard selects a deterministic partition of listed repositories so one org
+// scan can be split across runners. Index is 1-based. The zero value selects
+// every repository.
+type Shard struct {
+	Index int
+	Count int
+}
+
+// ParseShard parses an "i/n" shard spec such as "3/8". An empty value
+// disables sharding.
+func ParseShard(raw string) (Shard, error) {
+	trimmed := strings.TrimSpace(raw)
+	if trimmed == "" {
+		return Shard{}, nil
+	}
+	indexRaw, countRaw, ok := strings.Cut(trimmed, "/")
+	if !ok {
+		return Shard{}, fmt.Errorf("--shard must use <index>/<count>, for example 1/8")
+	}
+	index, indexErr := strconv.Atoi(strings.TrimSpace(indexRaw))
+	count, countErr := strconv.Atoi(strings.TrimSpace(countRaw))
+	if indexErr != nil || countErr != nil {
+		return Shard{}, fmt.Errorf("--shard must use <index>/<count>, for example 1/8")
+	}
+	if count < 1 || index < 1 || index > count {
+		return Shard{}, fmt.Errorf("--shard index must be between 1 and the shard count, got %d/%d", index, count)
+	}
+	return Shard{Index: index, Count: count}, nil
+}
+
+// Enabled reports whether a shard was requested. A 1/1 shard is enabled and
+// selects every repository.
+func (s Shard) Enabled() bool {
+	return s.Count > 0
+}
+
+func (s Shard) String() string {
+	if s.Count < 1 {
+		return ""
+	}
+	return fmt.Sprintf("%d/%d", s.Index, s.Count)
+}
+
+// Includes reports whether repo belongs to this shard. Assignment hashes the
+// case-folded owner/repo name, so it does not depend on listing order, API
+// mode, or which other repos exist.
+func (s Shard) Includes(repo string) bool {
+	if s.Count <= 1 {
+		return true
+	}
+	return ShardIndex(repo, s.Count) == s.Index
+}
+
+// Filter returns the repositories assigned to this shard, preserving order.
+func (s Shard) Filter(repos []string) []string {
+	if s.Count <= 1 {
+		return repos
+	}
+	out := make([]string, 0, len(repos)/s.Count+1)
+	for _, repo := range repos {
+		if s.Includes(repo) {
+			out = append(out, repo)
+		}
+	}
+	return out
+}
+
+// ShardIndex returns the 1-based shard that owns repo when a scan is split
+// into count shards.
+func ShardIndex(repo string, count int) int {
+	if count <= 1 {
+		return 1
+	}
+	sum := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(repo))))
+	return int(binary.BigEndian.Uint64(sum[:8])%uint64(count)) + 1 // #nosec G115 -- the remainder is below count, which is a positive int.
+}
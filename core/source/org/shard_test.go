@@ -0,0 +1,100 @@
+package org
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseShard(t *testing.T) {
+	t.Parallel()
+
+	for input, want := range map[string]Shard{"": {}, "1/8": {Index: 1, Count: 8}, " 8/8 ": {Index: 8, Count: 8}, "1/1": {Index: 1, Count: 1}} {
+		got, err := ParseShard(input)
+		if err != nil || got != want {
+			t.Fatalf("ParseShard(%q) = %+v, %v; want %+v", input, got, err, want)
+		}
+	}
+	for _, input := range []string{"3", "0/4", "5/4", "a/b", "1/0", "-1/2"} {
+		if _, err := ParseShard(input); err == nil {
+			t.Fatalf("expected ParseShard(%q) to fail", input)
+		}
+	}
+}
+
+func TestShardFilterPartitionsReposDeterministically(t *testing.T) {
+	t.Parallel()
+
+	repos := make([]string, 0, 200)
+	for i := 0; i < 200; i++ {
+		repos = append(repos, fmt.Sprintf("acme/repo-%03d", i))
+	}
+	const count = 8
+	seen := map[string]int{}
+	for index := 1; index <= count; index++ {
+		shard := Shard{Index: index, Count: count}
+		selected := shard.Filter(repos)
+		if len(selected) == 0 {
+			t.Fatalf("expected shard %s to receive repos", shard)
+		}
+		reversed := append([]string(nil), repos...)
+		for i, j := 0, len(reversed)-1; i < j; i, j = i+1, j-1 {
+			reversed[i], reversed[j] = reversed[j], reversed[i]
+		}
+		again := shard.Filter(reversed)
+		if len(again) != len(selected) {
+			t.Fatalf("expected shard membership to ignore listing order, got %d and %d", len(selected), len(again))
+		}
+		for _, repo := range selected {
+			seen[repo]++
+			if !shard.Includes(strings.ToUpper(repo)) {
+				t.Fatalf("expected shard assignment to ignore case for %s", repo)
+			}
+		}
+	}
+	if len(seen) != len(repos) {
+		t.Fatalf("expected every repo in exactly one shard, got %d of %d", len(seen), len(repos))
+	}
+	for repo, hits := range seen {
+		if hits != 1 {
+			t.Fatalf("expected %s in exactly one shard, got %d", repo, hits)
+		}
+	}
+}
+
+func TestAcquireMaterializedAppliesShard(t *testing.T) {
+	t.Parallel()
+
+	tmp := t.TempDir()
+	materializedRoot := filepath.Join(tmp, "materialized-sources")
+	listed := []string{"acme/a", "acme/b", "acme/c", "acme/d", "acme/e", "acme/f"}
+	union := map[string]struct{}{}
+	for index := 1; index <= 2; index++ {
+		shard := Shard{Index: index, Count: 2}
+		repos, failures, err := AcquireMaterialized(
+			context.Background(),
+			"acme",
+			fakeLister{repos: listed},
+			&trackingMaterializer{t: t, root: materializedRoot},
+			AcquireMaterializedOptions{
+				StatePath:        filepath.Join(tmp, fmt.Sprintf("shard-%d", index), "state.json"),
+				MaterializedRoot: materializedRoot,
+				Shard:            shard,
+			},
+		)
+		if err != nil || len(failures) != 0 {
+			t.Fatalf("acquire shard %s: err=%v failures=%+v", shard, err, failures)
+		}
+		for _, repo := range repos {
+			if !shard.Includes(repo.Repo) {
+				t.Fatalf("shard %s acquired foreign repo %s", shard, repo.Repo)
+			}
+			union[repo.Repo] = struct{}{}
+		}
+	}
+	if len(union) != len(listed) {
+		t.Fatalf("expected shards to cover every listed repo, got %v", union)
+	}
+}
@@ -58,6 +58,19 @@ type RepoManifest struct {
 	Source            string                 `json:"source"`
 	ContentStatus     string                 `json:"content_status,omitempty"`
 	OwnershipMetadata *RepoOwnershipMetadata `json:"ownership_metadata,omitempty"`
+	// Ref and Commit record the requested git ref and the commit it resolved
+	// to when a local repo is scanned at a ref instead of its working tree.
+	// Org scans record the default-branch commit in Commit.
+	Ref    string `json:"ref,omitempty"`
+	Commit string `json:"commit,omitempty"`
+}
+
+// RepoRevision is what an earlier org scan recorded about a repository, so
+// an incremental scan can reuse the tree when the commit is unchanged.
+type RepoRevision struct {
+	Commit        string
+	Source        string
+	ContentStatus string
 }
 
 type RepoOwnershipMetadata struct {
@@ -84,13 +97,24 @@ type Manifest struct {
 }
 
 type AcquisitionTelemetry struct {
-	Mode               string   `json:"mode"`
-	Requests           int      `json:"requests"`
-	RateLimitLimit     int      `json:"rate_limit_limit,omitempty"`
-	RateLimitRemaining int      `json:"rate_limit_remaining,omitempty"`
-	RateLimitReset     string   `json:"rate_limit_reset,omitempty"`
-	EstimatedRequests  int      `json:"estimated_requests,omitempty"`
-	Warnings           []string `json:"warnings,omitempty"`
+	Mode               string `json:"mode"`
+	Requests           int    `json:"requests"`
+	RateLimitLimit     int    `json:"rate_limit_limit,omitempty"`
+	RateLimitRemaining int    `json:"rate_limit_remaining,omitempty"`
+	RateLimitReset     string `json:"rate_limit_reset,omitempty"`
+	EstimatedRequests  int    `json:"estimated_requests,omitempty"`
+	// NotModified counts conditional requests answered with 304 from a
+	// response cache; CacheHits counts responses served without a request.
+	NotModified int `json:"not_modified,omitempty"`
+	CacheHits   int `json:"cache_hits,omitempty"`
+	// APIMode is "graphql" when metadata and detector files were fetched with
+	// batched GraphQL queries. GraphQL point usage is tracked apart from the
+	// REST request budget.
+	APIMode                   string   `json:"api_mode,omitempty"`
+	GraphQLPoints             int      `json:"graphql_points,omitempty"`
+	GraphQLRateLimitRemaining int      `json:"graphql_rate_limit_remaining,omitempty"`
+	EstimatedGraphQLPoints    int      `json:"estimated_graphql_points,omitempty"`
+	Warnings                  []string `json:"warnings,omitempty"`
 }
 
 // Finding is the canonical scan record used by diff/state.
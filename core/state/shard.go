@@ -0,0 +1,60 @@
+package state
+
+import "github.com/Clyra-AI/wrkr/core/source"
+
+// ShardRecord marks a snapshot written by `wrkr scan --shard i/n`. It keeps
+// the shard's source manifest, whose repo locations point at the retained
+// materialized trees, so `wrkr state merge` can rerun detection and analysis
+// over the full repo set.
+//
+// Digests cover the inputs that must match across shards: ConfigDigest hashes
+// ShardScanConfig without local file paths, PolicyDigest hashes the --policy
+// file and TopologyDigest is the --execution-topology digest.
+type ShardRecord struct {
+	Index          int             `json:"index"`
+	Count          int             `json:"count"`
+	ConfigDigest   string          `json:"config_digest"`
+	PolicyDigest   string          `json:"policy_digest"`
+	TopologyDigest string          `json:"topology_digest,omitempty"`
+	Scan           ShardScanConfig `json:"scan"`
+	StartedAt      string          `json:"started_at"`
+	Manifest       source.Manifest `json:"source_manifest"`
+}
+
+// ShardScanConfig records the scan settings a merge replays. Tokens are never
+// recorded; Authenticated only notes whether the shard ran with one.
+type ShardScanConfig struct {
+	WrkrVersion                string   `json:"wrkr_version"`
+	Targets                    []string `json:"targets"`
+	Mode                       string   `json:"mode"`
+	Profile                    string   `json:"profile"`
+	GitHubAPI                  string   `json:"github_api,omitempty"`
+	GitHubAPIMode              string   `json:"github_api_mode,omitempty"`
+	GitLabAPI                  string   `json:"gitlab_api,omitempty"`
+	BitbucketAPI               string   `json:"bitbucket_api,omitempty"`
+	AzureDevOpsAPI             string   `json:"azdo_api,omitempty"`
+	Authenticated              bool     `json:"authenticated"`
+	AllowPublicOnly            bool     `json:"allow_public_only,omitempty"`
+	AllowSourceMaterialization bool     `json:"allow_source_materialization,omitempty"`
+	Enrich                     bool     `json:"enrich,omitempty"`
+	SourceRetention            string   `json:"source_retention"`
+	DeploymentMode             string   `json:"deployment_mode"`
+	ApprovedToolsDigest        string   `json:"approved_tools_digest,omitempty"`
+	ProductionTargetsDigest    string   `json:"production_targets_digest,omitempty"`
+	ProductionTargetsStrict    bool     `json:"production_targets_strict,omitempty"`
+	PolicyPath                 string   `json:"policy_path,omitempty"`
+	ApprovedToolsPath          string   `json:"approved_tools_path,omitempty"`
+	ProductionTargetsPath      string   `json:"production_targets_path,omitempty"`
+	ExecutionTopologyPath      string   `json:"execution_topology_path,omitempty"`
+}
+
+// Digestable returns the config with local file paths cleared so runners that
+// check policy files out to different directories still agree.
+func (c ShardScanConfig) Digestable() ShardScanConfig {
+	c.Targets = append([]string(nil), c.Targets...)
+	c.PolicyPath = ""
+	c.ApprovedToolsPath = ""
+	c.ProductionTargetsPath = ""
+	c.ExecutionTopologyPath = ""
+	return c
+}
@@ -67,6 +67,7 @@ type Snapshot struct {
 	SourcePrivacy              *sourceprivacy.Contract        `json:"source_privacy,omitempty"`
 	PublicEvidenceManifestName string                         `json:"public_evidence_manifest_name,omitempty"`
 	PublicEvidence             []source.PublicEvidence        `json:"public_evidence,omitempty"`
+	Shard                      *ShardRecord                   `json:"shard,omitempty"`
 }
 
 type ScoreView struct {
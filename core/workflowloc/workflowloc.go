@@ -41,6 +41,11 @@ func IsAzurePipelinePath(path string) bool {
 		(strings.HasSuffix(normalized, ".yml") || strings.HasSuffix(normalized, ".yaml"))
 }
 
+func IsBitbucketPipeline(path string) bool {
+	normalized := Normalize(path)
+	return normalized == "bitbucket-pipelines.yml" || normalized == "bitbucket-pipelines.yaml"
+}
+
 func IsCIWorkflow(path string) bool {
-	return IsGitHubWorkflow(path) || IsJenkinsfile(path) || IsGitLabCIPath(path) || IsAzurePipelinePath(path)
+	return IsGitHubWorkflow(path) || IsJenkinsfile(path) || IsGitLabCIPath(path) || IsAzurePipelinePath(path) || IsBitbucketPipeline(path)
 }
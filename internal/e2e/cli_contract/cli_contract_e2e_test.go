@@ -288,6 +288,8 @@ func TestE2EOrgJSONProgressStaysOnStderr(t *testing.T) {
 			_, _ = fmt.Fprint(w, `[{"full_name":"acme/a"}]`)
 		case "/repos/acme/a":
 			_, _ = fmt.Fprint(w, `{"full_name":"acme/a","default_branch":"main"}`)
+		case "/repos/acme/a/git/ref/heads/main":
+			_, _ = fmt.Fprint(w, `{"object":{"sha":"0123456789abcdef0123456789abcdef01234567"}}`)
 		case "/repos/acme/a/git/trees/main":
 			_, _ = fmt.Fprint(w, `{"tree":[]}`)
 		default:
@@ -53,3 +53,27 @@ func normalizeSegment(value string, label string) (string, error) {
 	}
 	return trimmed, nil
 }
+
+// NormalizeNamespacePath validates and canonicalizes a slash-separated nested
+// namespace path such as a GitLab group/subgroup/project identifier.
+func NormalizeNamespacePath(value string, label string, minSegments int) (string, error) {
+	trimmed := strings.Trim(strings.TrimSpace(value), "/")
+	if trimmed == "" {
+		return "", fmt.Errorf("%s is required", label)
+	}
+	if strings.Contains(trimmed, "\\") {
+		return "", fmt.Errorf("%s must not contain backslashes: %q", label, value)
+	}
+	parts := strings.Split(trimmed, "/")
+	if len(parts) < minSegments {
+		return "", fmt.Errorf("%s must have at least %d path segment(s), got %q", label, minSegments, value)
+	}
+	for idx, part := range parts {
+		segment, err := normalizeSegment(part, label+" segment")
+		if err != nil {
+			return "", err
+		}
+		parts[idx] = segment
+	}
+	return strings.Join(parts, "/"), nil
+}
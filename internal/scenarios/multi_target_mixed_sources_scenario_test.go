@@ -25,6 +25,8 @@ func TestMultiTargetMixedSourcesScenario(t *testing.T) {
 			_, _ = fmt.Fprint(w, `[{"full_name":"acme/api"}]`)
 		case "/repos/acme/api":
 			_, _ = fmt.Fprint(w, `{"full_name":"acme/api","default_branch":"main"}`)
+		case "/repos/acme/api/git/ref/heads/main":
+			_, _ = fmt.Fprint(w, `{"object":{"sha":"0123456789abcdef0123456789abcdef01234567"}}`)
 		case "/repos/acme/api/git/trees/main":
 			_, _ = fmt.Fprint(w, `{"tree":[]}`)
 		default:
@@ -32,8 +32,12 @@ func TestOrgResumeMatchesCleanRun(t *testing.T) {
 			_, _ = fmt.Fprint(w, `{"full_name":"acme/a","default_branch":"main"}`)
 		case "/repos/acme/b":
 			_, _ = fmt.Fprint(w, `{"full_name":"acme/b","default_branch":"main"}`)
+		case "/repos/acme/a/git/ref/heads/main":
+			_, _ = fmt.Fprint(w, `{"object":{"sha":"0123456789abcdef0123456789abcdef01234567"}}`)
 		case "/repos/acme/a/git/trees/main":
 			_, _ = fmt.Fprint(w, `{"tree":[]}`)
+		case "/repos/acme/b/git/ref/heads/main":
+			_, _ = fmt.Fprint(w, `{"object":{"sha":"0123456789abcdef0123456789abcdef01234567"}}`)
 		case "/repos/acme/b/git/trees/main":
 			if !resumePhase.Load() {
 				time.Sleep(250 * time.Millisecond)
@@ -0,0 +1,222 @@
+// Package sourceconn holds the request retry and path-safety helpers shared
+// by the hosted source connectors.
+package sourceconn
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Clyra-AI/wrkr/internal/githubendpoint"
+)
+
+const (
+	defaultBackoff    = 25 * time.Millisecond
+	defaultMaxBackoff = 2 * time.Second
+	maxMessageLength  = 240
+)
+
+// NewHTTPClient copies source, or a client with a 10 second timeout when it
+// is nil, and restricts redirects to the validated API origin.
+func NewHTTPClient(baseURL string, options githubendpoint.Options, source *http.Client) *http.Client {
+	client := http.Client{Timeout: 10 * time.Second}
+	if source != nil {
+		client = *source
+	}
+	if endpoint, err := githubendpoint.Parse(baseURL, options); err == nil {
+		client.CheckRedirect = githubendpoint.RedirectPolicy(endpoint)
+	}
+	return &client
+}
+
+// SafeJoin joins a repository-relative path onto root and refuses paths that
+// would land outside it.
+func SafeJoin(root, rel string) (string, error) {
+	cleanRoot := filepath.Clean(root)
+	cleanRel := filepath.Clean(filepath.FromSlash(rel))
+	if cleanRel == "." || cleanRel == string(os.PathSeparator) || !filepath.IsLocal(cleanRel) {
+		return "", fmt.Errorf("refusing to materialize path outside root: %s", rel)
+	}
+	target := filepath.Join(cleanRoot, cleanRel)
+	if target != cleanRoot && !strings.HasPrefix(target, cleanRoot+string(os.PathSeparator)) {
+		return "", fmt.Errorf("refusing to materialize path outside root: %s", rel)
+	}
+	return target, nil
+}
+
+// SleepWithContext waits for duration or until ctx is done.
+func SleepWithContext(ctx context.Context, duration time.Duration) error {
+	if duration <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// JitteredBackoff doubles backoff per attempt up to maxBackoff and applies a
+// deterministic jitter so retries are reproducible across runs.
+func JitteredBackoff(backoff, maxBackoff time.Duration, attempt int) time.Duration {
+	if backoff <= 0 {
+		backoff = defaultBackoff
+	}
+	if maxBackoff <= 0 {
+		maxBackoff = defaultMaxBackoff
+	}
+
+	shift := attempt
+	if shift > 8 {
+		shift = 8
+	}
+	delay := time.Duration(float64(backoff) * math.Pow(2, float64(shift)))
+	if delay > maxBackoff {
+		delay = maxBackoff
+	}
+
+	// Deterministic bounded jitter in [-20%, +20%].
+	jitterPct := (attempt*37)%41 - 20
+	delay += delay * time.Duration(jitterPct) / 100
+
+	minDelay := backoff / 2
+	if minDelay <= 0 {
+		minDelay = time.Millisecond
+	}
+	if delay < minDelay {
+		delay = minDelay
+	}
+	if delay > maxBackoff {
+		delay = maxBackoff
+	}
+	return delay
+}
+
+// RetryAfter reads the wait a throttled response asks for: Retry-After as
+// seconds or an HTTP date, then resetHeader as a Unix epoch when set.
+func RetryAfter(header http.Header, resetHeader string, now time.Time) (time.Duration, bool) {
+	if wait, ok := parseRetryAfter(header.Get("Retry-After"), now); ok {
+		return wait, true
+	}
+	if resetHeader == "" {
+		return 0, false
+	}
+	return parseRateLimitReset(header.Get(resetHeader), now)
+}
+
+func parseRetryAfter(raw string, now time.Time) (time.Duration, bool) {
+	value := strings.TrimSpace(raw)
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	when, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	wait := when.Sub(now)
+	if wait < 0 {
+		return 0, false
+	}
+	return wait, true
+}
+
+func parseRateLimitReset(raw string, now time.Time) (time.Duration, bool) {
+	value := strings.TrimSpace(raw)
+	if value == "" {
+		return 0, false
+	}
+	epoch, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || epoch <= 0 {
+		return 0, false
+	}
+	wait := time.Unix(epoch, 0).Sub(now)
+	if wait < 0 {
+		return 0, false
+	}
+	return wait, true
+}
+
+// APIMessage extracts a short error message from an API error body. It reads
+// {"message":...} (GitHub, GitLab, Azure DevOps), {"error":"..."} and
+// {"error":{"message":...}} (GitLab, Bitbucket Cloud), and
+// {"errors":[{"message":...}]} (Bitbucket Data Center), and falls back to the
+// raw body.
+func APIMessage(body []byte) string {
+	trimmed := strings.TrimSpace(string(body))
+	if trimmed == "" {
+		return ""
+	}
+	var payload struct {
+		Message json.RawMessage `json:"message"`
+		Error   json.RawMessage `json:"error"`
+		Errors  []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &payload); err == nil {
+		if message := rawMessage(payload.Message); message != "" {
+			return SanitizeMessage(message)
+		}
+		if message := rawMessage(payload.Error); message != "" {
+			return SanitizeMessage(message)
+		}
+		for _, item := range payload.Errors {
+			if strings.TrimSpace(item.Message) != "" {
+				return SanitizeMessage(item.Message)
+			}
+		}
+	}
+	return SanitizeMessage(trimmed)
+}
+
+// rawMessage returns a JSON string, the message field of a JSON object, or
+// any other non-null value as compact JSON (GitLab validation errors).
+func rawMessage(raw json.RawMessage) string {
+	if len(raw) == 0 || string(raw) == "null" {
+		return ""
+	}
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		return strings.TrimSpace(text)
+	}
+	var object struct {
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(raw, &object); err == nil && strings.TrimSpace(object.Message) != "" {
+		return object.Message
+	}
+	var value any
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return ""
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return ""
+	}
+	return string(encoded)
+}
+
+// SanitizeMessage collapses whitespace and truncates long messages.
+func SanitizeMessage(raw string) string {
+	message := strings.Join(strings.Fields(strings.TrimSpace(raw)), " ")
+	if len(message) > maxMessageLength {
+		return message[:maxMessageLength] + "..."
+	}
+	return message
+}
@@ -0,0 +1,89 @@
+package sourceconn
+
+import (
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSafeJoinRejectsPathsOutsideRoot(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	got, err := SafeJoin(root, ".github/workflows/ci.yml")
+	if err != nil {
+		t.Fatalf("SafeJoin() error = %v", err)
+	}
+	if want := filepath.Join(root, ".github", "workflows", "ci.yml"); got != want {
+		t.Fatalf("SafeJoin() = %q, want %q", got, want)
+	}
+	for _, rel := range []string{"", ".", "/", "../escape", "a/../../escape", "/etc/passwd"} {
+		if _, err := SafeJoin(root, rel); err == nil {
+			t.Fatalf("SafeJoin(%q) expected error", rel)
+		}
+	}
+}
+
+func TestJitteredBackoffIsDeterministicAndBounded(t *testing.T) {
+	t.Parallel()
+
+	backoff, maxBackoff := 100*time.Millisecond, time.Second
+	for attempt := 0; attempt < 12; attempt++ {
+		first := JitteredBackoff(backoff, maxBackoff, attempt)
+		if second := JitteredBackoff(backoff, maxBackoff, attempt); first != second {
+			t.Fatalf("attempt %d: backoff not deterministic: %s != %s", attempt, first, second)
+		}
+		if first < backoff/2 || first > maxBackoff {
+			t.Fatalf("attempt %d: backoff %s outside [%s, %s]", attempt, first, backoff/2, maxBackoff)
+		}
+	}
+	if got := JitteredBackoff(0, 0, 20); got < defaultBackoff/2 || got > defaultMaxBackoff {
+		t.Fatalf("default backoff %s outside [%s, %s]", got, defaultBackoff/2, defaultMaxBackoff)
+	}
+}
+
+func TestRetryAfterPrefersRetryAfterThenResetHeader(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1_700_000_000, 0)
+	header := http.Header{}
+	header.Set("Retry-After", "7")
+	header.Set("RateLimit-Reset", "1700000030")
+	if wait, ok := RetryAfter(header, "RateLimit-Reset", now); !ok || wait != 7*time.Second {
+		t.Fatalf("RetryAfter() = %s, %v; want 7s", wait, ok)
+	}
+
+	header.Del("Retry-After")
+	if wait, ok := RetryAfter(header, "RateLimit-Reset", now); !ok || wait != 30*time.Second {
+		t.Fatalf("RetryAfter() reset = %s, %v; want 30s", wait, ok)
+	}
+	if _, ok := RetryAfter(header, "", now); ok {
+		t.Fatal("RetryAfter() without a reset header should not read RateLimit-Reset")
+	}
+
+	header.Set("Retry-After", now.Add(-time.Minute).UTC().Format(http.TimeFormat))
+	header.Del("RateLimit-Reset")
+	if _, ok := RetryAfter(header, "RateLimit-Reset", now); ok {
+		t.Fatal("RetryAfter() should ignore a date in the past")
+	}
+}
+
+func TestAPIMessageReadsProviderEnvelopes(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]string{
+		`{"message":"Not Found"}`:                              "Not Found",
+		`{"message":{"name":["has already been taken"]}}`:      `{"name":["has already been taken"]}`,
+		`{"error":"insufficient_scope"}`:                       "insufficient_scope",
+		`{"type":"error","error":{"message":"No access"}}`:     "No access",
+		`{"errors":[{"message":"Repository does not exist"}]}`: "Repository does not exist",
+		"  plain\n text  ":                                     "plain text",
+		"":                                                     "",
+	}
+	for body, want := range cases {
+		if got := APIMessage([]byte(body)); got != want {
+			t.Fatalf("APIMessage(%q) = %q, want %q", body, got, want)
+		}
+	}
+}